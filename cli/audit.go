@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+func (r *RootCmd) audit() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:   "audit",
+		Short: "List and export audit logs",
+		Long: "Times are RFC 3339 timestamps, dates such as 2023-01-01 (midnight in your local time zone), or durations before now such as 24h.\n" + formatExamples(
+			example{
+				Description: "List the audit logs of the last day",
+				Command:     "coder audit list --since 24h",
+			},
+			example{
+				Description: "Export the audit logs of a quarter as CSV",
+				Command:     "coder audit export --since 2023-01-01 --until 2023-04-01 -O audit-2023-q1.csv",
+			},
+			example{
+				Description: "Export the workspace deletions of a user as JSON lines",
+				Command:     `coder audit export --search "username:alice resource_type:workspace action:delete" --format jsonl`,
+			},
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.auditList(),
+			r.auditExport(),
+		},
+	}
+	return cmd
+}
+
+// auditFilterOptions returns the options shared by the audit commands.
+func auditFilterOptions(search, since, until *string) clibase.OptionSet {
+	return clibase.OptionSet{
+		{
+			Flag:          "search",
+			FlagShorthand: "s",
+			Description:   "Filter audit logs with the same query syntax as the audit page, e.g. \"username:alice action:delete\".",
+			Value:         clibase.StringOf(search),
+		},
+		{
+			Flag:        "since",
+			Description: "Only include audit logs at or after this time.",
+			Value:       clibase.StringOf(since),
+		},
+		{
+			Flag:        "until",
+			Description: "Only include audit logs at or before this time.",
+			Value:       clibase.StringOf(until),
+		},
+	}
+}
+
+func auditExportRequest(search, since, until string, format codersdk.AuditLogExportFormat) (codersdk.AuditLogExportRequest, error) {
+	now := time.Now()
+	req := codersdk.AuditLogExportRequest{
+		SearchQuery: search,
+		Format:      format,
+	}
+	var err error
+	if since != "" {
+		req.Since, err = parseAuditTime(since, now)
+		if err != nil {
+			return req, xerrors.Errorf("invalid --since: %w", err)
+		}
+	}
+	if until != "" {
+		req.Until, err = parseAuditTime(until, now)
+		if err != nil {
+			return req, xerrors.Errorf("invalid --until: %w", err)
+		}
+	}
+	if !req.Since.IsZero() && !req.Until.IsZero() && req.Until.Before(req.Since) {
+		return req, xerrors.New("--until must not be before --since")
+	}
+	return req, nil
+}
+
+// parseAuditTime parses an RFC 3339 timestamp, a date in the local time zone,
+// or a duration before now.
+func parseAuditTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, xerrors.Errorf("%q is not a timestamp, date or duration", s)
+}
+
+type auditLogRow struct {
+	// For JSON format:
+	codersdk.AuditLog `table:"-"`
+
+	// For table format:
+	Time           string `json:"-" table:"time,default_sort"`
+	User           string `json:"-" table:"user"`
+	Action         string `json:"-" table:"action"`
+	ResourceType   string `json:"-" table:"resource type"`
+	ResourceTarget string `json:"-" table:"resource target"`
+	StatusCode     int32  `json:"-" table:"status code"`
+}
+
+func (r *RootCmd) auditList() *clibase.Cmd {
+	var (
+		search, since, until string
+		limit                int64
+		formatter            = cliui.NewOutputFormatter(
+			cliui.TableFormat([]auditLogRow{}, nil),
+			cliui.JSONFormat(),
+		)
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the most recent audit logs",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			if limit <= 0 {
+				return xerrors.New("--limit must be positive")
+			}
+			req, err := auditExportRequest(search, since, until, codersdk.AuditLogExportFormatJSONL)
+			if err != nil {
+				return err
+			}
+			body, err := client.ExportAuditLogs(inv.Context(), req)
+			if err != nil {
+				return xerrors.Errorf("get audit logs: %w", err)
+			}
+			defer body.Close()
+
+			// The export is newest first, so stop reading once the limit
+			// is reached.
+			var rows []auditLogRow
+			dec := json.NewDecoder(bufio.NewReader(body))
+			for int64(len(rows)) < limit && dec.More() {
+				var alog codersdk.AuditLog
+				err = dec.Decode(&alog)
+				if err != nil {
+					return xerrors.Errorf("decode audit log: %w", err)
+				}
+				row := auditLogRow{
+					AuditLog:       alog,
+					Time:           alog.Time.Local().Format("2006-01-02 15:04:05"),
+					Action:         string(alog.Action),
+					ResourceType:   string(alog.ResourceType),
+					ResourceTarget: alog.ResourceTarget,
+					StatusCode:     alog.StatusCode,
+				}
+				if alog.User != nil {
+					row.User = alog.User.Username
+				}
+				rows = append(rows, row)
+			}
+
+			if len(rows) == 0 {
+				cliui.Infof(inv.Stderr, "No audit logs found.")
+				return nil
+			}
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	cmd.Options = append(auditFilterOptions(&search, &since, &until), clibase.Option{
+		Flag:          "limit",
+		FlagShorthand: "n",
+		Description:   "The maximum number of audit logs to list.",
+		Default:       "25",
+		Value:         clibase.Int64Of(&limit),
+	})
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) auditExport() *clibase.Cmd {
+	var (
+		search, since, until string
+		format               string
+		outputPath           string
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "export",
+		Short: "Export audit logs as CSV or JSON lines, newest first",
+		Long:  "Audit logs are streamed from the server, so exports of any size use little memory.",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			req, err := auditExportRequest(search, since, until, codersdk.AuditLogExportFormat(format))
+			if err != nil {
+				return err
+			}
+			body, err := client.ExportAuditLogs(inv.Context(), req)
+			if err != nil {
+				return xerrors.Errorf("export audit logs: %w", err)
+			}
+			defer body.Close()
+
+			if outputPath == "" || outputPath == "-" {
+				_, err = io.Copy(inv.Stdout, body)
+				if err != nil {
+					return xerrors.Errorf("write audit logs: %w", err)
+				}
+				return nil
+			}
+
+			f, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+			if err != nil {
+				return xerrors.Errorf("create %q: %w", outputPath, err)
+			}
+			_, err = io.Copy(f, body)
+			if err != nil {
+				_ = f.Close()
+				return xerrors.Errorf("write audit logs to %q: %w", outputPath, err)
+			}
+			err = f.Close()
+			if err != nil {
+				return xerrors.Errorf("close %q: %w", outputPath, err)
+			}
+			_, _ = fmt.Fprintf(inv.Stderr, "Exported audit logs to %s\n", cliui.DefaultStyles.Code.Render(outputPath))
+			return nil
+		},
+	}
+
+	cmd.Options = append(auditFilterOptions(&search, &since, &until),
+		clibase.Option{
+			Flag:        "format",
+			Description: "The format to export audit logs in.",
+			Default:     string(codersdk.AuditLogExportFormatCSV),
+			Value:       clibase.EnumOf(&format, string(codersdk.AuditLogExportFormatCSV), string(codersdk.AuditLogExportFormatJSONL)),
+		},
+		clibase.Option{
+			Flag:          "output-file",
+			FlagShorthand: "O",
+			Description:   "Path to write the export to. Defaults to stdout.",
+			Value:         clibase.StringOf(&outputPath),
+		},
+	)
+	return cmd
+}
@@ -0,0 +1,100 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/cli/clitest"
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/testutil"
+)
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, nil)
+	owner := coderdtest.CreateFirstUser(t, client)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	now := time.Now()
+	for i, action := range []codersdk.AuditAction{codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete} {
+		err := client.CreateTestAuditLog(ctx, codersdk.CreateTestAuditLogRequest{
+			Action:     action,
+			ResourceID: owner.UserID,
+			Time:       now.Add(time.Duration(i-3) * time.Hour),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "audit", "list", "--since", "150m", "--output", "json")
+		clitest.SetupConfig(t, client, root)
+		buf := new(bytes.Buffer)
+		inv.Stdout = buf
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		var alogs []codersdk.AuditLog
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &alogs))
+		require.Len(t, alogs, 2)
+		for _, alog := range alogs {
+			require.NotEqual(t, codersdk.AuditActionCreate, alog.Action)
+		}
+	})
+
+	t.Run("ListLimit", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "audit", "list", "-n", "1", "--output", "json")
+		clitest.SetupConfig(t, client, root)
+		buf := new(bytes.Buffer)
+		inv.Stdout = buf
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		var alogs []codersdk.AuditLog
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &alogs))
+		require.Len(t, alogs, 1)
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		path := filepath.Join(t.TempDir(), "audit.csv")
+		inv, root := clitest.New(t, "audit", "export", "--search", "action:delete", "-O", path)
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+		records, err := csv.NewReader(f).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Contains(t, records[1], "delete")
+	})
+
+	t.Run("InvalidTime", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "audit", "export", "--since", "yesterday")
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "invalid --since")
+	})
+}
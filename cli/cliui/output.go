@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"text/template"
 
 	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
 
 	"github.com/coder/coder/cli/clibase"
 )
@@ -23,8 +25,17 @@ type OutputFormatter struct {
 	formatID string
 }
 
+// argumentFormat is implemented by formats that take an argument from the
+// --output flag, e.g. "-o template={{.Name}}".
+type argumentFormat interface {
+	FormatArgument(ctx context.Context, data any, arg string) (string, error)
+}
+
 // NewOutputFormatter creates a new OutputFormatter with the given formats. The
 // first format is the default format. At least two formats must be provided.
+//
+// A csv format is added for the table format, and yaml and template formats
+// are added for the json format, unless formats with those IDs are given.
 func NewOutputFormatter(formats ...OutputFormat) *OutputFormatter {
 	if len(formats) < 2 {
 		panic("at least two output formats must be provided")
@@ -41,6 +52,18 @@ func NewOutputFormatter(formats ...OutputFormat) *OutputFormatter {
 		formatIDs[format.ID()] = struct{}{}
 	}
 
+	var derived []OutputFormat
+	for _, format := range formats {
+		derived = append(derived, derivedFormats(format)...)
+	}
+	for _, format := range derived {
+		if _, ok := formatIDs[format.ID()]; ok {
+			continue
+		}
+		formatIDs[format.ID()] = struct{}{}
+		formats = append(formats, format)
+	}
+
 	return &OutputFormatter{
 		formats:  formats,
 		formatID: formats[0].ID(),
@@ -55,8 +78,14 @@ func (f *OutputFormatter) AttachOptions(opts *clibase.OptionSet) {
 	}
 
 	formatNames := make([]string, 0, len(f.formats))
+	hasTemplate := false
 	for _, format := range f.formats {
 		formatNames = append(formatNames, format.ID())
+		hasTemplate = hasTemplate || format.ID() == "template"
+	}
+	description := "Output format. Available formats: " + strings.Join(formatNames, ", ") + "."
+	if hasTemplate {
+		description += " The template format takes a Go template, e.g. -o template='{{.Name}}'."
 	}
 
 	*opts = append(*opts,
@@ -65,7 +94,7 @@ func (f *OutputFormatter) AttachOptions(opts *clibase.OptionSet) {
 			FlagShorthand: "o",
 			Default:       f.formats[0].ID(),
 			Value:         clibase.StringOf(&f.formatID),
-			Description:   "Output format. Available formats: " + strings.Join(formatNames, ", ") + ".",
+			Description:   description,
 		},
 	)
 }
@@ -73,15 +102,43 @@ func (f *OutputFormatter) AttachOptions(opts *clibase.OptionSet) {
 // Format formats the given data using the format specified by the --output
 // flag. If the flag is not set, the default format is used.
 func (f *OutputFormatter) Format(ctx context.Context, data any) (string, error) {
+	id, arg, hasArg := strings.Cut(f.formatID, "=")
 	for _, format := range f.formats {
-		if format.ID() == f.formatID {
+		if format.ID() != id {
+			continue
+		}
+		if !hasArg {
 			return format.Format(ctx, data)
 		}
+		af, ok := format.(argumentFormat)
+		if !ok {
+			return "", xerrors.Errorf("output format %q does not take an argument", id)
+		}
+		return af.FormatArgument(ctx, data, arg)
 	}
 
 	return "", xerrors.Errorf("unknown output format %q", f.formatID)
 }
 
+// derivedFormats returns the formats that can be built from the given format.
+// They format the same data, so they are available wherever it is.
+func derivedFormats(format OutputFormat) []OutputFormat {
+	switch f := format.(type) {
+	case *tableFormat:
+		return []OutputFormat{&csvFormat{table: f}}
+	case jsonFormat:
+		return []OutputFormat{YAMLFormat(), TemplateFormat()}
+	case *DataChangeFormat:
+		inner := derivedFormats(f.format)
+		for i, d := range inner {
+			inner[i] = ChangeFormatterData(d, f.change)
+		}
+		return inner
+	default:
+		return nil
+	}
+}
+
 type tableFormat struct {
 	defaultColumns []string
 	allColumns     []string
@@ -136,7 +193,7 @@ func (f *tableFormat) AttachOptions(opts *clibase.OptionSet) {
 			FlagShorthand: "c",
 			Default:       strings.Join(f.defaultColumns, ","),
 			Value:         clibase.StringArrayOf(&f.columns),
-			Description:   "Columns to display in table output. Available columns: " + strings.Join(f.allColumns, ", ") + ".",
+			Description:   "Columns to display in table and csv output. Available columns: " + strings.Join(f.allColumns, ", ") + ".",
 		},
 	)
 }
@@ -146,6 +203,27 @@ func (f *tableFormat) Format(_ context.Context, data any) (string, error) {
 	return DisplayTable(data, f.sort, f.columns)
 }
 
+// csvFormat renders the columns of a table format as CSV. The columns are
+// selected with the --column flag of the table format.
+type csvFormat struct {
+	table *tableFormat
+}
+
+var _ OutputFormat = &csvFormat{}
+
+// ID implements OutputFormat.
+func (*csvFormat) ID() string {
+	return "csv"
+}
+
+// AttachOptions implements OutputFormat.
+func (*csvFormat) AttachOptions(_ *clibase.OptionSet) {}
+
+// Format implements OutputFormat.
+func (f *csvFormat) Format(_ context.Context, data any) (string, error) {
+	return DisplayCSV(data, f.table.sort, f.table.columns)
+}
+
 type jsonFormat struct{}
 
 var _ OutputFormat = jsonFormat{}
@@ -173,6 +251,108 @@ func (jsonFormat) Format(_ context.Context, data any) (string, error) {
 	return string(outBytes), nil
 }
 
+type yamlFormat struct{}
+
+var _ OutputFormat = yamlFormat{}
+
+// YAMLFormat creates a YAML formatter. The data is encoded as JSON first, so
+// the output uses the same field names and ordering as the JSON format.
+func YAMLFormat() OutputFormat {
+	return yamlFormat{}
+}
+
+// ID implements OutputFormat.
+func (yamlFormat) ID() string {
+	return "yaml"
+}
+
+// AttachOptions implements OutputFormat.
+func (yamlFormat) AttachOptions(_ *clibase.OptionSet) {}
+
+// Format implements OutputFormat.
+func (yamlFormat) Format(_ context.Context, data any) (string, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return "", xerrors.Errorf("marshal output to JSON: %w", err)
+	}
+
+	// JSON is valid YAML, so decoding it into a node keeps the key order.
+	var node yaml.Node
+	err = yaml.Unmarshal(jsonBytes, &node)
+	if err != nil {
+		return "", xerrors.Errorf("decode JSON output: %w", err)
+	}
+	resetYAMLStyle(&node)
+
+	outBytes, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", xerrors.Errorf("marshal output to YAML: %w", err)
+	}
+
+	return strings.TrimSuffix(string(outBytes), "\n"), nil
+}
+
+// resetYAMLStyle drops the flow style and quoting decoded from JSON so the
+// node is encoded as block style YAML.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+type templateFormat struct{}
+
+var _ OutputFormat = templateFormat{}
+
+// TemplateFormat creates a formatter that executes a Go template given as
+// "-o template=<template>". Slices are formatted one element per line.
+func TemplateFormat() OutputFormat {
+	return templateFormat{}
+}
+
+// ID implements OutputFormat.
+func (templateFormat) ID() string {
+	return "template"
+}
+
+// AttachOptions implements OutputFormat.
+func (templateFormat) AttachOptions(_ *clibase.OptionSet) {}
+
+// Format implements OutputFormat.
+func (templateFormat) Format(_ context.Context, _ any) (string, error) {
+	return "", xerrors.New(`template output requires a template, e.g. -o template='{{.Name}}'`)
+}
+
+// FormatArgument executes the given template for the data.
+func (templateFormat) FormatArgument(_ context.Context, data any, arg string) (string, error) {
+	tmpl, err := template.New("output").Parse(arg)
+	if err != nil {
+		return "", xerrors.Errorf("parse template: %w", err)
+	}
+
+	items := []any{data}
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice {
+		items = make([]any, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).Interface()
+		}
+	}
+
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		var sb strings.Builder
+		err = tmpl.Execute(&sb, item)
+		if err != nil {
+			return "", xerrors.Errorf("execute template: %w", err)
+		}
+		lines = append(lines, sb.String())
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
 type textFormat struct{}
 
 var _ OutputFormat = textFormat{}
@@ -224,3 +404,15 @@ func (d *DataChangeFormat) Format(ctx context.Context, data any) (string, error)
 	}
 	return d.format.Format(ctx, newData)
 }
+
+func (d *DataChangeFormat) FormatArgument(ctx context.Context, data any, arg string) (string, error) {
+	af, ok := d.format.(argumentFormat)
+	if !ok {
+		return "", xerrors.Errorf("output format %q does not take an argument", d.ID())
+	}
+	newData, err := d.change(data)
+	if err != nil {
+		return "", err
+	}
+	return af.FormatArgument(ctx, newData, arg)
+}
@@ -136,4 +136,48 @@ func Test_OutputFormatter(t *testing.T) {
 		require.Equal(t, "", out)
 		require.EqualValues(t, 1, atomic.LoadInt64(&called))
 	})
+	t.Run("DerivedFormats", func(t *testing.T) {
+		t.Parallel()
+
+		type row struct {
+			Name string `json:"name" table:"name,default_sort"`
+			Age  int    `json:"age" table:"age"`
+		}
+		f := cliui.NewOutputFormatter(
+			cliui.TableFormat([]row{}, nil),
+			cliui.JSONFormat(),
+		)
+
+		cmd := &clibase.Cmd{}
+		f.AttachOptions(&cmd.Options)
+		fs := cmd.Options.FlagSet()
+		require.Contains(t, fs.FlagUsages(), "Available formats: table, json, csv, yaml, template.")
+
+		ctx := context.Background()
+		data := []row{{Name: "foo", Age: 10}, {Name: "bar", Age: 20}}
+
+		require.NoError(t, fs.Set("output", "csv"))
+		require.NoError(t, fs.Set("column", "name"))
+		out, err := f.Format(ctx, data)
+		require.NoError(t, err)
+		require.Equal(t, "name\nbar\nfoo", out)
+
+		require.NoError(t, fs.Set("output", "yaml"))
+		out, err = f.Format(ctx, data)
+		require.NoError(t, err)
+		require.Equal(t, "- name: foo\n  age: 10\n- name: bar\n  age: 20", out)
+
+		require.NoError(t, fs.Set("output", "template={{.Name}}={{.Age}}"))
+		out, err = f.Format(ctx, data)
+		require.NoError(t, err)
+		require.Equal(t, "foo=10\nbar=20", out)
+
+		require.NoError(t, fs.Set("output", "template"))
+		_, err = f.Format(ctx, data)
+		require.ErrorContains(t, err, "requires a template")
+
+		require.NoError(t, fs.Set("output", "json=foo"))
+		_, err = f.Format(ctx, data)
+		require.ErrorContains(t, err, "does not take an argument")
+	})
 }
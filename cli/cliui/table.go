@@ -1,6 +1,8 @@
 package cliui
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
 	"reflect"
 	"strings"
@@ -8,6 +10,7 @@ import (
 
 	"github.com/fatih/structtag"
 	"github.com/jedib0t/go-pretty/v6/table"
+	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 )
 
@@ -63,19 +66,106 @@ func filterTableColumns(header table.Row, columns []string) []table.ColumnConfig
 // If sort is empty, the input order will be used. If filterColumns is empty or
 // nil, all available columns are included.
 func DisplayTable(out any, sort string, filterColumns []string) (string, error) {
+	headers, rows, sort, err := tableRows(out, sort, filterColumns)
+	if err != nil {
+		return "", err
+	}
+
+	// Setup the table formatter.
+	tw := Table()
+	tw.AppendHeader(headers)
+	tw.SetColumnConfigs(filterTableColumns(headers, filterColumns))
+	if sort != "" {
+		tw.SortBy([]table.SortBy{{
+			Name: sort,
+		}})
+	}
+	for _, row := range rows {
+		tw.AppendRow(row)
+	}
+
+	return tw.Render(), nil
+}
+
+// DisplayCSV renders a table as CSV. It accepts the same input and options as
+// DisplayTable, so the columns and their order match the table output.
+func DisplayCSV(out any, sort string, filterColumns []string) (string, error) {
+	headers, rows, sort, err := tableRows(out, sort, filterColumns)
+	if err != nil {
+		return "", err
+	}
+
+	// Only keep the selected columns, in table order.
+	columns := make([]int, 0, len(headers))
+	sortColumn := -1
+	for i, header := range headers {
+		headerText, _ := header.(string)
+		if headerText == sort {
+			sortColumn = i
+		}
+		if len(filterColumns) == 0 || slices.Contains(filterColumns, headerText) {
+			columns = append(columns, i)
+		}
+	}
+
+	records := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			if cell != nil {
+				record[i] = fmt.Sprint(cell)
+			}
+		}
+		records = append(records, record)
+	}
+	if sortColumn >= 0 {
+		slices.SortStableFunc(records, func(a, b []string) int {
+			return strings.Compare(a[sortColumn], b[sortColumn])
+		})
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	write := func(record []string) {
+		selected := make([]string, 0, len(columns))
+		for _, i := range columns {
+			selected = append(selected, record[i])
+		}
+		_ = w.Write(selected)
+	}
+	header := make([]string, len(headers))
+	for i, h := range headers {
+		header[i], _ = h.(string)
+	}
+	write(header)
+	for _, record := range records {
+		write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", xerrors.Errorf("write csv: %w", err)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// tableRows converts a slice of structs to table headers and rows, as
+// described in DisplayTable. The returned sort column and the given filter
+// columns are corrected to match the case of the headers.
+func tableRows(out any, sort string, filterColumns []string) (table.Row, []table.Row, string, error) {
 	v := reflect.Indirect(reflect.ValueOf(out))
 
 	if v.Kind() != reflect.Slice {
-		return "", xerrors.Errorf("DisplayTable called with a non-slice type")
+		return nil, nil, "", xerrors.Errorf("DisplayTable called with a non-slice type")
 	}
 
 	// Get the list of table column headers.
 	headersRaw, defaultSort, err := typeToTableHeaders(v.Type().Elem())
 	if err != nil {
-		return "", xerrors.Errorf("get table headers recursively for type %q: %w", v.Type().Elem().String(), err)
+		return nil, nil, "", xerrors.Errorf("get table headers recursively for type %q: %w", v.Type().Elem().String(), err)
 	}
 	if len(headersRaw) == 0 {
-		return "", xerrors.New(`no table headers found on the input type, make sure there is at least one "table" struct tag`)
+		return nil, nil, "", xerrors.New(`no table headers found on the input type, make sure there is at least one "table" struct tag`)
 	}
 	if sort == "" {
 		sort = defaultSort
@@ -96,7 +186,7 @@ func DisplayTable(out any, sort string, filterColumns []string) (string, error)
 			sort = strings.ToLower(strings.ReplaceAll(sort, "_", " "))
 			h, ok := headersMap[sort]
 			if !ok {
-				return "", xerrors.Errorf(`specified sort column %q not found in table headers, available columns are "%v"`, sort, strings.Join(headersRaw, `", "`))
+				return nil, nil, "", xerrors.Errorf(`specified sort column %q not found in table headers, available columns are "%v"`, sort, strings.Join(headersRaw, `", "`))
 			}
 
 			// Autocorrect
@@ -107,7 +197,7 @@ func DisplayTable(out any, sort string, filterColumns []string) (string, error)
 			column := strings.ToLower(strings.ReplaceAll(column, "_", " "))
 			h, ok := headersMap[column]
 			if !ok {
-				return "", xerrors.Errorf(`specified filter column %q not found in table headers, available columns are "%v"`, column, strings.Join(headersRaw, `", "`))
+				return nil, nil, "", xerrors.Errorf(`specified filter column %q not found in table headers, available columns are "%v"`, column, strings.Join(headersRaw, `", "`))
 			}
 
 			// Autocorrect
@@ -127,26 +217,17 @@ func DisplayTable(out any, sort string, filterColumns []string) (string, error)
 			}
 		}
 		if !found {
-			return "", xerrors.Errorf("specified sort column %q not found in table headers, available columns are %q", sort, strings.Join(headersRaw, `", "`))
+			return nil, nil, "", xerrors.Errorf("specified sort column %q not found in table headers, available columns are %q", sort, strings.Join(headersRaw, `", "`))
 		}
 	}
 
-	// Setup the table formatter.
-	tw := Table()
-	tw.AppendHeader(headers)
-	tw.SetColumnConfigs(filterTableColumns(headers, filterColumns))
-	if sort != "" {
-		tw.SortBy([]table.SortBy{{
-			Name: sort,
-		}})
-	}
-
-	// Write each struct to the table.
+	// Convert each struct to a row.
+	rows := make([]table.Row, 0, v.Len())
 	for i := 0; i < v.Len(); i++ {
 		// Format the row as a slice.
 		rowMap, err := valueToTableMap(v.Index(i))
 		if err != nil {
-			return "", xerrors.Errorf("get table row map %v: %w", i, err)
+			return nil, nil, "", xerrors.Errorf("get table row map %v: %w", i, err)
 		}
 
 		rowSlice := make([]any, len(headers))
@@ -177,10 +258,10 @@ func DisplayTable(out any, sort string, filterColumns []string) (string, error)
 			rowSlice[i] = v
 		}
 
-		tw.AppendRow(table.Row(rowSlice))
+		rows = append(rows, table.Row(rowSlice))
 	}
 
-	return tw.Render(), nil
+	return headers, rows, sort, nil
 }
 
 // parseTableStructTag returns the name of the field according to the `table`
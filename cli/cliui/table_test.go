@@ -359,6 +359,38 @@ Alice   25
 	})
 }
 
+func Test_DisplayCSV(t *testing.T) {
+	t.Parallel()
+
+	in := []tableTest4{
+		{Inline: tableTest2{Name: stringWrapper{str: "foo"}, Age: 10}, SortField: "b"},
+		{Inline: tableTest2{Name: stringWrapper{str: "bar, baz"}, Age: 20}, SortField: "a"},
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := cliui.DisplayCSV(in, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, "name,age,sort field\n\"bar, baz\",20,a\nfoo,10,b", out)
+	})
+
+	t.Run("FilterColumns", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := cliui.DisplayCSV(in, "age", []string{"sort_field", "name"})
+		require.NoError(t, err)
+		require.Equal(t, "name,sort field\nfoo,b\n\"bar, baz\",a", out)
+	})
+
+	t.Run("InvalidColumn", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := cliui.DisplayCSV(in, "", []string{"nope"})
+		require.Error(t, err)
+	})
+}
+
 // compareTables normalizes the incoming table lines
 func compareTables(t *testing.T, expected, out string) {
 	t.Helper()
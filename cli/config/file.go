@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -46,6 +48,44 @@ func (r Root) Organization() File {
 	return File(filepath.Join(string(r), "organization"))
 }
 
+// CurrentContext stores the name of the deployment context used when
+// none is selected explicitly.
+func (r Root) CurrentContext() File {
+	r.mustNotEmpty()
+	return File(filepath.Join(string(r), "context"))
+}
+
+// Context returns the configuration directory of a named deployment
+// context. Each context stores its own URL and session token.
+func (r Root) Context(name string) Root {
+	r.mustNotEmpty()
+	return Root(filepath.Join(r.contextsPath(), name))
+}
+
+// Contexts returns the names of all saved deployment contexts in
+// lexical order.
+func (r Root) Contexts() ([]string, error) {
+	r.mustNotEmpty()
+	entries, err := os.ReadDir(r.contextsPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+func (r Root) contextsPath() string {
+	return filepath.Join(string(r), "contexts")
+}
+
 func (r Root) DotfilesURL() File {
 	r.mustNotEmpty()
 	return File(filepath.Join(string(r), "dotfilesurl"))
@@ -36,3 +36,24 @@ func TestFile(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestContexts(t *testing.T) {
+	t.Parallel()
+
+	root := config.Root(t.TempDir())
+	names, err := root.Contexts()
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	for _, name := range []string{"staging", "prod"} {
+		err := root.Context(name).URL().Write("https://" + name + ".example.com")
+		require.NoError(t, err)
+	}
+	names, err = root.Contexts()
+	require.NoError(t, err)
+	require.Equal(t, []string{"prod", "staging"}, names)
+
+	url, err := root.Context("prod").URL().Read()
+	require.NoError(t, err)
+	require.Equal(t, "https://prod.example.com", url)
+}
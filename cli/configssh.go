@@ -49,6 +49,18 @@ type sshConfigOptions struct {
 	waitEnum       string
 	userHostPrefix string
 	sshOptions     []string
+	contexts       []string
+}
+
+// sshConfigDeployment is a deployment host entries are written for.
+type sshConfigDeployment struct {
+	client *codersdk.Client
+	// context is the named context of the deployment, if any.
+	context string
+	// hostSuffix is appended to the host prefix to keep the hosts of
+	// multiple deployments apart.
+	hostSuffix string
+	recv       func() ([]sshWorkspaceConfig, error)
 }
 
 // addOptions expects options in the form of "option=value" or "option value".
@@ -106,6 +118,9 @@ func (o sshConfigOptions) equal(other sshConfigOptions) bool {
 	if !slices.Equal(opt1, opt2) {
 		return false
 	}
+	if !slices.Equal(o.contexts, other.contexts) {
+		return false
+	}
 	return o.waitEnum == other.waitEnum && o.userHostPrefix == other.userHostPrefix
 }
 
@@ -119,6 +134,9 @@ func (o sshConfigOptions) asList() (list []string) {
 	for _, opt := range o.sshOptions {
 		list = append(list, fmt.Sprintf("ssh-option: %s", opt))
 	}
+	for _, name := range o.contexts {
+		list = append(list, fmt.Sprintf("context: %s", name))
+	}
 	return list
 }
 
@@ -215,6 +233,10 @@ func (r *RootCmd) configSSH() *clibase.Cmd {
 				Description: "You can use --dry-run (or -n) to see the changes that would be made",
 				Command:     "coder config-ssh --dry-run",
 			},
+			example{
+				Description: "You can use --contexts to add hosts for several deployments, e.g. \"ssh coder.staging.workspace\"",
+				Command:     "coder config-ssh --contexts staging,prod",
+			},
 		),
 		Middleware: clibase.Chain(
 			clibase.RequireNArgs(0),
@@ -344,97 +366,139 @@ func (r *RootCmd) configSSH() *clibase.Cmd {
 			newline := len(before) > 0
 			sshConfigWriteSectionHeader(buf, newline, sshConfigOpts)
 
-			workspaceConfigs, err := recvWorkspaceConfigs()
+			// Every deployment gets its own host entries. By default only
+			// the deployment of the selected context is used.
+			currentContext, err := r.currentContext()
 			if err != nil {
-				return xerrors.Errorf("fetch workspace configs failed: %w", err)
+				return err
 			}
-
-			coderdConfig, err := client.SSHConfiguration(inv.Context())
-			if err != nil {
-				// If the error is 404, this deployment does not support
-				// this endpoint yet. Do not error, just assume defaults.
-				// TODO: Remove this in 2 months (May 31, 2023). Just return the error
-				// 	and remove this 404 check.
-				var sdkErr *codersdk.Error
-				if !(xerrors.As(err, &sdkErr) && sdkErr.StatusCode() == http.StatusNotFound) {
-					return xerrors.Errorf("fetch coderd config failed: %w", err)
+			deployments := []sshConfigDeployment{{
+				client:  client,
+				context: currentContext,
+				recv:    recvWorkspaceConfigs,
+			}}
+			if len(sshConfigOpts.contexts) > 0 {
+				deployments = nil
+				for _, name := range sshConfigOpts.contexts {
+					contextClient, err := r.contextClient(name)
+					if err != nil {
+						return err
+					}
+					deployments = append(deployments, sshConfigDeployment{
+						client:     contextClient,
+						context:    name,
+						hostSuffix: name + ".",
+						recv:       sshPrepareWorkspaceConfigs(inv.Context(), contextClient),
+					})
 				}
-				coderdConfig.HostnamePrefix = "coder."
 			}
 
-			if sshConfigOpts.userHostPrefix != "" {
-				// Override with user flag.
-				coderdConfig.HostnamePrefix = sshConfigOpts.userHostPrefix
-			}
+			// The first host written is used as an example at the end.
+			var exampleHost string
+			for _, deployment := range deployments {
+				workspaceConfigs, err := deployment.recv()
+				if err != nil {
+					return xerrors.Errorf("fetch workspace configs failed: %w", err)
+				}
 
-			// Ensure stable sorting of output.
-			slices.SortFunc(workspaceConfigs, func(a, b sshWorkspaceConfig) int {
-				return slice.Ascending(a.Name, b.Name)
-			})
-			for _, wc := range workspaceConfigs {
-				sort.Strings(wc.Hosts)
-				// Write agent configuration.
-				for _, workspaceHostname := range wc.Hosts {
-					sshHostname := fmt.Sprintf("%s%s", coderdConfig.HostnamePrefix, workspaceHostname)
-					defaultOptions := []string{
-						"HostName " + sshHostname,
-						"ConnectTimeout=0",
-						"StrictHostKeyChecking=no",
-						// Without this, the "REMOTE HOST IDENTITY CHANGED"
-						// message will appear.
-						"UserKnownHostsFile=/dev/null",
-						// This disables the "Warning: Permanently added 'hostname' (RSA) to the list of known hosts."
-						// message from appearing on every SSH. This happens because we ignore the known hosts.
-						"LogLevel ERROR",
+				coderdConfig, err := deployment.client.SSHConfiguration(inv.Context())
+				if err != nil {
+					// If the error is 404, this deployment does not support
+					// this endpoint yet. Do not error, just assume defaults.
+					// TODO: Remove this in 2 months (May 31, 2023). Just return the error
+					// 	and remove this 404 check.
+					var sdkErr *codersdk.Error
+					if !(xerrors.As(err, &sdkErr) && sdkErr.StatusCode() == http.StatusNotFound) {
+						return xerrors.Errorf("fetch coderd config failed: %w", err)
 					}
+					coderdConfig.HostnamePrefix = "coder."
+				}
+
+				if sshConfigOpts.userHostPrefix != "" {
+					// Override with user flag.
+					coderdConfig.HostnamePrefix = sshConfigOpts.userHostPrefix
+				}
+				hostPrefix := coderdConfig.HostnamePrefix + deployment.hostSuffix
+
+				proxyFlags := ""
+				if deployment.context != "" {
+					// Pin the context so switching contexts later does not
+					// point existing hosts at another deployment.
+					proxyFlags += " --context " + deployment.context
+				}
 
-					if !skipProxyCommand {
-						flags := ""
-						if sshConfigOpts.waitEnum != "auto" {
-							flags += " --wait=" + sshConfigOpts.waitEnum
+				// Ensure stable sorting of output.
+				slices.SortFunc(workspaceConfigs, func(a, b sshWorkspaceConfig) int {
+					return slice.Ascending(a.Name, b.Name)
+				})
+				if exampleHost == "" && len(workspaceConfigs) > 0 {
+					exampleHost = hostPrefix + workspaceConfigs[0].Name
+				}
+				for _, wc := range workspaceConfigs {
+					sort.Strings(wc.Hosts)
+					// Write agent configuration.
+					for _, workspaceHostname := range wc.Hosts {
+						sshHostname := fmt.Sprintf("%s%s", hostPrefix, workspaceHostname)
+						defaultOptions := []string{
+							"HostName " + sshHostname,
+							"ConnectTimeout=0",
+							"StrictHostKeyChecking=no",
+							// Without this, the "REMOTE HOST IDENTITY CHANGED"
+							// message will appear.
+							"UserKnownHostsFile=/dev/null",
+							// This disables the "Warning: Permanently added 'hostname' (RSA) to the list of known hosts."
+							// message from appearing on every SSH. This happens because we ignore the known hosts.
+							"LogLevel ERROR",
 						}
-						defaultOptions = append(defaultOptions, fmt.Sprintf(
-							"ProxyCommand %s --global-config %s ssh --stdio%s %s",
-							escapedCoderBinary, escapedGlobalConfig, flags, workspaceHostname,
-						))
-					}
 
-					// Create a copy of the options so we can modify them.
-					configOptions := sshConfigOpts
-					configOptions.sshOptions = nil
+						if !skipProxyCommand {
+							flags := ""
+							if sshConfigOpts.waitEnum != "auto" {
+								flags += " --wait=" + sshConfigOpts.waitEnum
+							}
+							defaultOptions = append(defaultOptions, fmt.Sprintf(
+								"ProxyCommand %s --global-config %s%s ssh --stdio%s %s",
+								escapedCoderBinary, escapedGlobalConfig, proxyFlags, flags, workspaceHostname,
+							))
+						}
 
-					// Add standard options.
-					err := configOptions.addOptions(defaultOptions...)
-					if err != nil {
-						return err
-					}
+						// Create a copy of the options so we can modify them.
+						configOptions := sshConfigOpts
+						configOptions.sshOptions = nil
 
-					// Override with deployment options
-					for k, v := range coderdConfig.SSHConfigOptions {
-						opt := fmt.Sprintf("%s %s", k, v)
-						err := configOptions.addOptions(opt)
+						// Add standard options.
+						err := configOptions.addOptions(defaultOptions...)
 						if err != nil {
-							return xerrors.Errorf("add coderd config option %q: %w", opt, err)
+							return err
 						}
-					}
-					// Override with flag options
-					for _, opt := range sshConfigOpts.sshOptions {
-						err := configOptions.addOptions(opt)
-						if err != nil {
-							return xerrors.Errorf("add flag config option %q: %w", opt, err)
+
+						// Override with deployment options
+						for k, v := range coderdConfig.SSHConfigOptions {
+							opt := fmt.Sprintf("%s %s", k, v)
+							err := configOptions.addOptions(opt)
+							if err != nil {
+								return xerrors.Errorf("add coderd config option %q: %w", opt, err)
+							}
+						}
+						// Override with flag options
+						for _, opt := range sshConfigOpts.sshOptions {
+							err := configOptions.addOptions(opt)
+							if err != nil {
+								return xerrors.Errorf("add flag config option %q: %w", opt, err)
+							}
 						}
-					}
 
-					hostBlock := []string{
-						"Host " + sshHostname,
-					}
-					// Prefix with '\t'
-					for _, v := range configOptions.sshOptions {
-						hostBlock = append(hostBlock, "\t"+v)
-					}
+						hostBlock := []string{
+							"Host " + sshHostname,
+						}
+						// Prefix with '\t'
+						for _, v := range configOptions.sshOptions {
+							hostBlock = append(hostBlock, "\t"+v)
+						}
 
-					_, _ = buf.WriteString(strings.Join(hostBlock, "\n"))
-					_ = buf.WriteByte('\n')
+						_, _ = buf.WriteString(strings.Join(hostBlock, "\n"))
+						_ = buf.WriteByte('\n')
+					}
 				}
 			}
 
@@ -491,9 +555,9 @@ func (r *RootCmd) configSSH() *clibase.Cmd {
 				_, _ = fmt.Fprintf(out, "Updated %q\n", sshConfigFile)
 			}
 
-			if len(workspaceConfigs) > 0 {
+			if exampleHost != "" {
 				_, _ = fmt.Fprintln(out, "You should now be able to ssh into your workspace.")
-				_, _ = fmt.Fprintf(out, "For example, try running:\n\n\t$ ssh %s%s\n", coderdConfig.HostnamePrefix, workspaceConfigs[0].Name)
+				_, _ = fmt.Fprintf(out, "For example, try running:\n\n\t$ ssh %s\n", exampleHost)
 			} else {
 				_, _ = fmt.Fprint(out, "You don't have any workspaces yet, try creating one with:\n\n\t$ coder create <workspace>\n")
 			}
@@ -560,6 +624,13 @@ func (r *RootCmd) configSSH() *clibase.Cmd {
 			Description: "Override the default host prefix.",
 			Value:       clibase.StringOf(&sshConfigOpts.userHostPrefix),
 		},
+		{
+			Flag: "contexts",
+			Env:  "CODER_CONFIGSSH_CONTEXTS",
+			Description: "Write host entries for each of the given saved contexts instead of only the current one. " +
+				"Hosts are named \"<prefix><context>.<workspace>\" to keep deployments apart.",
+			Value: clibase.StringArrayOf(&sshConfigOpts.contexts),
+		},
 		{
 			Flag:        "wait",
 			Env:         "CODER_CONFIGSSH_WAIT", // Not to be mixed with CODER_SSH_WAIT.
@@ -606,6 +677,9 @@ func sshConfigWriteSectionHeader(w io.Writer, addNewline bool, o sshConfigOption
 	for _, opt := range o.sshOptions {
 		_, _ = fmt.Fprintf(&ow, "# :%s=%s\n", "ssh-option", opt)
 	}
+	for _, name := range o.contexts {
+		_, _ = fmt.Fprintf(&ow, "# :%s=%s\n", "context", name)
+	}
 	if ow.Len() > 0 {
 		_, _ = fmt.Fprint(w, sshConfigOptionsHeader)
 		_, _ = fmt.Fprint(w, ow.String())
@@ -635,6 +709,8 @@ func sshConfigParseLastOptions(r io.Reader) (o sshConfigOptions) {
 				o.userHostPrefix = parts[1]
 			case "ssh-option":
 				o.sshOptions = append(o.sshOptions, parts[1])
+			case "context":
+				o.contexts = append(o.contexts, parts[1])
 			default:
 				// Unknown option, ignore.
 			}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/coder/coder/agent"
 	"github.com/coder/coder/cli/clitest"
+	"github.com/coder/coder/cli/config"
 	"github.com/coder/coder/coderd/coderdtest"
 	"github.com/coder/coder/codersdk"
 	"github.com/coder/coder/codersdk/agentsdk"
@@ -800,3 +801,50 @@ func sshConfigFileParseHosts(t *testing.T, name string) []string {
 
 	return result
 }
+
+func TestConfigSSH_Contexts(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Root(t.TempDir())
+	workspaces := map[string]string{}
+	for _, name := range []string{"staging", "prod"} {
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		authToken := uuid.NewString()
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, &echo.Responses{
+			Parse:          echo.ParseComplete,
+			ProvisionPlan:  echo.ProvisionApplyWithAgent(authToken),
+			ProvisionApply: echo.ProvisionApplyWithAgent(authToken),
+		})
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+		coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+		workspaces[name] = workspace.Name
+
+		inv, _ := clitest.New(t, "login", client.URL.String(), "--token", client.SessionToken(), "--context", name, "--global-config", string(cfg))
+		inv.Stdout = &bytes.Buffer{}
+		err := inv.Run()
+		require.NoError(t, err)
+	}
+
+	sshConfigFile := sshConfigFileName(t)
+	inv, _ := clitest.New(t, "config-ssh", "--ssh-config-file", sshConfigFile, "--contexts", "staging,prod", "--yes", "--global-config", string(cfg))
+	inv.Stdout = &bytes.Buffer{}
+	err := inv.Run()
+	require.NoError(t, err)
+
+	hosts := sshConfigFileParseHosts(t, sshConfigFile)
+	require.ElementsMatch(t, []string{
+		"coder.staging." + workspaces["staging"],
+		"coder.staging." + workspaces["staging"] + ".example",
+		"coder.prod." + workspaces["prod"],
+		"coder.prod." + workspaces["prod"] + ".example",
+	}, hosts)
+
+	got := sshConfigFileRead(t, sshConfigFile)
+	require.Contains(t, got, "# :context=staging")
+	require.Contains(t, got, "# :context=prod")
+	require.Contains(t, got, "--context staging ssh --stdio "+workspaces["staging"])
+	require.Contains(t, got, "--context prod ssh --stdio "+workspaces["prod"])
+}
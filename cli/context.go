@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/cli/config"
+	"github.com/coder/coder/codersdk"
+)
+
+var (
+	contextNameRegex        = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+	contextNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+)
+
+// validateContextName ensures a context name is safe to use as a directory
+// name and as part of an SSH host name.
+func validateContextName(name string) error {
+	if !contextNameRegex.MatchString(name) {
+		return xerrors.Errorf("invalid context name %q: must start with a letter or number and only contain letters, numbers, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// contextNameFromURL derives a context name from the host of a deployment
+// URL, e.g. "coder.example.com" or "localhost-3000".
+func contextNameFromURL(u *url.URL) string {
+	name := contextNameInvalidChars.ReplaceAllString(u.Host, "-")
+	return strings.Trim(name, "-._")
+}
+
+// savedContext returns the config directory of an existing named context.
+func (r *RootCmd) savedContext(name string) (config.Root, error) {
+	err := validateContextName(name)
+	if err != nil {
+		return "", err
+	}
+	conf := r.createConfig().Context(name)
+	_, err = os.Stat(string(conf))
+	if os.IsNotExist(err) {
+		return "", xerrors.Errorf("context %q does not exist, run 'coder context list' to see saved contexts", name)
+	}
+	if err != nil {
+		return "", xerrors.Errorf("stat context %q: %w", name, err)
+	}
+	return conf, nil
+}
+
+// contextClient returns a client authenticated with the URL and session
+// token of a saved context.
+func (r *RootCmd) contextClient(name string) (*codersdk.Client, error) {
+	conf, err := r.savedContext(name)
+	if err != nil {
+		return nil, err
+	}
+	rawURL, err := conf.URL().Read()
+	if err != nil {
+		return nil, xerrors.Errorf("read url of context %q: %w", name, err)
+	}
+	serverURL, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, xerrors.Errorf("parse url of context %q: %w", name, err)
+	}
+	token, err := conf.Session().Read()
+	if err != nil {
+		return nil, xerrors.Errorf("read session of context %q: %w", name, err)
+	}
+	client, err := r.createUnauthenticatedClient(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	client.SetSessionToken(token)
+	return client, nil
+}
+
+func (r *RootCmd) contexts() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:   "context",
+		Short: "Switch between deployments you are logged in to",
+		Long: "Every 'coder login' saves the deployment URL and session token as a named context. " +
+			"Commands use the current context unless --context or CODER_CONTEXT is set.\n\n" + formatExamples(
+			example{
+				Description: "List saved contexts",
+				Command:     "coder context list",
+			},
+			example{
+				Description: "Make \"staging\" the current context",
+				Command:     "coder context use staging",
+			},
+			example{
+				Description: "Run a single command against another context",
+				Command:     "coder list --context prod",
+			},
+		),
+		Aliases: []string{"contexts"},
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.contextList(),
+			r.contextUse(),
+			r.contextDelete(),
+		},
+	}
+	return cmd
+}
+
+type contextRow struct {
+	Name    string `json:"name" table:"name,default_sort"`
+	URL     string `json:"url" table:"url"`
+	Current bool   `json:"current" table:"current"`
+}
+
+func (r *RootCmd) contextList() *clibase.Cmd {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]contextRow{}, nil),
+		cliui.JSONFormat(),
+	)
+
+	cmd := &clibase.Cmd{
+		Use:        "list",
+		Short:      "List saved contexts",
+		Aliases:    []string{"ls"},
+		Middleware: clibase.RequireNArgs(0),
+		Handler: func(inv *clibase.Invocation) error {
+			current, err := r.currentContext()
+			if err != nil {
+				return err
+			}
+
+			root := r.createConfig()
+			names, err := root.Contexts()
+			if err != nil {
+				return xerrors.Errorf("list contexts: %w", err)
+			}
+			if len(names) == 0 {
+				_, _ = fmt.Fprintf(inv.Stderr, "%s No contexts found! Log in to save one:\n\n", Caret)
+				_, _ = fmt.Fprintln(inv.Stderr, cliui.DefaultStyles.Code.Render("  $ coder login <url> --context <name>"))
+				return nil
+			}
+
+			rows := make([]contextRow, 0, len(names))
+			for _, name := range names {
+				rawURL, err := root.Context(name).URL().Read()
+				if err != nil && !os.IsNotExist(err) {
+					return xerrors.Errorf("read url of context %q: %w", name, err)
+				}
+				rows = append(rows, contextRow{
+					Name:    name,
+					URL:     strings.TrimSpace(rawURL),
+					Current: name == current,
+				})
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) contextUse() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:        "use <name>",
+		Short:      "Change the current context",
+		Middleware: clibase.RequireNArgs(1),
+		Handler: func(inv *clibase.Invocation) error {
+			name := inv.Args[0]
+			_, err := r.savedContext(name)
+			if err != nil {
+				return err
+			}
+
+			err = r.createConfig().CurrentContext().Write(name)
+			if err != nil {
+				return xerrors.Errorf("write current context: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Switched to context %s.\n", cliui.DefaultStyles.Keyword.Render(name))
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) contextDelete() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:   "delete <name>",
+		Short: "Delete a saved context",
+		Long: "Only the locally stored URL and session token are removed. " +
+			"Run 'coder logout --context <name>' first to also revoke the session.",
+		Middleware: clibase.RequireNArgs(1),
+		Options: clibase.OptionSet{
+			cliui.SkipPromptOption(),
+		},
+		Handler: func(inv *clibase.Invocation) error {
+			name := inv.Args[0]
+			conf, err := r.savedContext(name)
+			if err != nil {
+				return err
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Delete context %s?", cliui.DefaultStyles.Code.Render(name)),
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = os.RemoveAll(string(conf))
+			if err != nil {
+				return xerrors.Errorf("remove context %q: %w", name, err)
+			}
+
+			root := r.createConfig()
+			current, err := root.CurrentContext().Read()
+			if err == nil && strings.TrimSpace(current) == name {
+				err = root.CurrentContext().Delete()
+				if err != nil {
+					return xerrors.Errorf("unset current context: %w", err)
+				}
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Deleted context %s.\n", cliui.DefaultStyles.Keyword.Render(name))
+			return nil
+		},
+	}
+	return cmd
+}
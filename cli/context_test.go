@@ -0,0 +1,127 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/cli/clitest"
+	"github.com/coder/coder/cli/config"
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/testutil"
+)
+
+func TestContext(t *testing.T) {
+	t.Parallel()
+
+	// loginContext logs in to a new deployment and saves it as the named
+	// context.
+	loginContext := func(t *testing.T, cfg config.Root, name string) {
+		t.Helper()
+
+		client := coderdtest.New(t, nil)
+		coderdtest.CreateFirstUser(t, client)
+		inv, _ := clitest.New(t, "login", client.URL.String(), "--token", client.SessionToken(), "--context", name, "--global-config", string(cfg))
+		inv.Stdout = &bytes.Buffer{}
+		err := inv.Run()
+		require.NoError(t, err)
+	}
+
+	type contextRow struct {
+		Name    string `json:"name"`
+		URL     string `json:"url"`
+		Current bool   `json:"current"`
+	}
+	listContexts := func(t *testing.T, cfg config.Root) []contextRow {
+		t.Helper()
+
+		inv, _ := clitest.New(t, "context", "list", "-o", "json", "--global-config", string(cfg))
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err := inv.Run()
+		require.NoError(t, err)
+		var rows []contextRow
+		if out.Len() == 0 {
+			return rows
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &rows))
+		return rows
+	}
+
+	t.Run("LoginAndUse", func(t *testing.T) {
+		t.Parallel()
+		cfg := config.Root(t.TempDir())
+		loginContext(t, cfg, "staging")
+		loginContext(t, cfg, "prod")
+
+		// The most recent login becomes the current context.
+		rows := listContexts(t, cfg)
+		require.Len(t, rows, 2)
+		require.Equal(t, "prod", rows[0].Name)
+		require.True(t, rows[0].Current)
+		require.Equal(t, "staging", rows[1].Name)
+		require.False(t, rows[1].Current)
+
+		inv, _ := clitest.New(t, "context", "use", "staging", "--global-config", string(cfg))
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err := inv.Run()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "Switched to context")
+
+		rows = listContexts(t, cfg)
+		require.False(t, rows[0].Current)
+		require.True(t, rows[1].Current)
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+		cfg := config.Root(t.TempDir())
+		loginContext(t, cfg, "staging")
+		loginContext(t, cfg, "prod")
+
+		// Logging out of a context other than the current one must
+		// leave the current one alone.
+		inv, _ := clitest.New(t, "logout", "--yes", "--context", "staging", "--global-config", string(cfg))
+		inv.Stdout = &bytes.Buffer{}
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.NoFileExists(t, string(cfg.Context("staging").Session()))
+		require.FileExists(t, string(cfg.Context("prod").Session()))
+
+		inv, _ = clitest.New(t, "list", "--context", "nope", "--global-config", string(cfg))
+		inv.Stdout = &bytes.Buffer{}
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, `context "nope" does not exist`)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		t.Parallel()
+		cfg := config.Root(t.TempDir())
+		loginContext(t, cfg, "staging")
+
+		inv, _ := clitest.New(t, "context", "delete", "staging", "--yes", "--global-config", string(cfg))
+		inv.Stdout = &bytes.Buffer{}
+		err := inv.Run()
+		require.NoError(t, err)
+
+		require.NoDirExists(t, string(cfg.Context("staging")))
+		require.NoFileExists(t, string(cfg.CurrentContext()))
+		require.Empty(t, listContexts(t, cfg))
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		t.Parallel()
+		cfg := config.Root(t.TempDir())
+		client := coderdtest.New(t, nil)
+		coderdtest.CreateFirstUser(t, client)
+
+		inv, _ := clitest.New(t, "login", client.URL.String(), "--token", client.SessionToken(), "--context", "../escape", "--global-config", string(cfg))
+		inv.Stdout = &bytes.Buffer{}
+		err := inv.Run()
+		require.ErrorContains(t, err, "invalid context name")
+	})
+}
@@ -0,0 +1,584 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/sloghuman"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/coderd/httpapi"
+	"github.com/coder/coder/codersdk"
+)
+
+func (r *RootCmd) cp() *clibase.Cmd {
+	var (
+		recursive bool
+		resume    bool
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Annotations: workspaceCommand,
+		Use:         "cp <source>... <destination>",
+		Short:       "Copy files between your machine and a workspace",
+		Long: "Workspace paths are written as [<owner>/]<workspace>[.<agent>]:<path>, relative " +
+			"paths are relative to the home directory in the workspace. Sources " +
+			"may contain glob patterns. Symlinks in copied directories are copied " +
+			"as symlinks.\n\n" + formatExamples(
+			example{
+				Description: "Copy a file to the home directory of a workspace",
+				Command:     "coder cp ./notes.txt my-workspace:",
+			},
+			example{
+				Description: "Copy a directory from a workspace",
+				Command:     "coder cp -r my-workspace:project/dist ./dist",
+			},
+			example{
+				Description: "Copy a file from the workspace of another user",
+				Command:     "coder cp alice/dev:notes.txt ./",
+			},
+			example{
+				Description: "Copy all logs, continuing transfers that were interrupted",
+				Command:     "coder cp --resume 'my-workspace:/var/log/app/*.log' ./logs",
+			},
+		),
+		Middleware: clibase.Chain(
+			clibase.RequireRangeArgs(2, -1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			sources := make([]copyPath, 0, len(inv.Args)-1)
+			for _, arg := range inv.Args[:len(inv.Args)-1] {
+				sources = append(sources, parseCopyPath(arg))
+			}
+			destination := parseCopyPath(inv.Args[len(inv.Args)-1])
+
+			// Exactly one side of the copy is in the workspace.
+			workspaceName := destination.workspace
+			for _, source := range sources {
+				if (source.workspace == "") == (destination.workspace == "") {
+					return xerrors.New("either all sources or the destination must be a workspace path, e.g. my-workspace:path")
+				}
+				if source.workspace != "" {
+					if workspaceName != "" && workspaceName != source.workspace {
+						return xerrors.New("all sources must be in the same workspace")
+					}
+					workspaceName = source.workspace
+				}
+			}
+
+			workspace, workspaceAgent, err := getWorkspaceAndAgent(ctx, inv, client, codersdk.Me, workspaceName)
+			if err != nil {
+				return err
+			}
+			if workspace.LatestBuild.Transition != codersdk.WorkspaceTransitionStart {
+				return xerrors.New("workspace must be in start transition to copy files")
+			}
+
+			err = cliui.Agent(ctx, inv.Stderr, workspaceAgent.ID, cliui.AgentOptions{
+				Fetch: client.WorkspaceAgent,
+				Wait:  false,
+			})
+			if err != nil {
+				return xerrors.Errorf("await agent: %w", err)
+			}
+
+			var logger slog.Logger
+			if r.verbose {
+				logger = slog.Make(sloghuman.Sink(inv.Stderr)).Leveled(slog.LevelDebug)
+			}
+			if r.disableDirect {
+				_, _ = fmt.Fprintln(inv.Stderr, "Direct connections disabled.")
+			}
+			conn, err := r.dialWorkspaceAgent(ctx, client, workspaceAgent.ID, logger)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			sshClient, err := conn.SSHClient(ctx)
+			if err != nil {
+				return xerrors.Errorf("ssh client: %w", err)
+			}
+			defer sshClient.Close()
+			sftpClient, err := sftp.NewClient(sshClient)
+			if err != nil {
+				return xerrors.Errorf("sftp client: %w", err)
+			}
+			defer sftpClient.Close()
+
+			var remote copyFS = remoteCopyFS{client: sftpClient}
+			c := &copier{
+				recursive: recursive,
+				resume:    resume,
+				srcFS:     localCopyFS{},
+				dstFS:     remote,
+			}
+			if destination.workspace == "" {
+				c.srcFS, c.dstFS = remote, localCopyFS{}
+			}
+			if isTTYErr(inv) {
+				c.progress = inv.Stderr
+			}
+
+			var paths []string
+			for _, source := range sources {
+				matches, err := expandCopyPath(c.srcFS, source.path)
+				if err != nil {
+					return err
+				}
+				paths = append(paths, matches...)
+			}
+
+			start := time.Now()
+			err = c.copyAll(paths, destination.path)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(inv.Stderr, "Copied %d file(s), %s in %s.\n",
+				c.files, formatCopyBytes(c.bytes), time.Since(start).Truncate(time.Millisecond))
+			return nil
+		},
+	}
+
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:          "recursive",
+			FlagShorthand: "r",
+			Env:           "CODER_CP_RECURSIVE",
+			Description:   "Copy directories and their contents.",
+			Value:         clibase.BoolOf(&recursive),
+		},
+		{
+			Flag: "resume",
+			Env:  "CODER_CP_RESUME",
+			Description: "Continue interrupted transfers by appending to destination files that are smaller " +
+				"than their source, and skip files that were copied completely.",
+			Value: clibase.BoolOf(&resume),
+		},
+	}
+	return cmd
+}
+
+// copyPath is a source or destination of "coder cp".
+type copyPath struct {
+	// workspace is "[<owner>/]<workspace>[.<agent>]", or empty for local
+	// paths.
+	workspace string
+	path      string
+}
+
+// parseCopyPath splits a "[<owner>/]<workspace>:<path>" argument. Arguments
+// without a workspace are local paths.
+func parseCopyPath(arg string) copyPath {
+	workspace, p, ok := strings.Cut(arg, ":")
+	// Local paths may contain colons too, e.g. "./a:b" or "C:\a" on Windows.
+	if !ok || workspace == "" || strings.Contains(workspace, `\`) ||
+		(runtime.GOOS == "windows" && len(workspace) == 1) {
+		return copyPath{path: arg}
+	}
+	// A slash separates the owner, e.g. "alice/dev:". Local paths such as
+	// "./a:b" don't start with a valid owner name.
+	if owner, name, ok := strings.Cut(workspace, "/"); ok {
+		if httpapi.NameValid(owner) != nil || name == "" || strings.Contains(name, "/") {
+			return copyPath{path: arg}
+		}
+	}
+	if p == "" {
+		// The home directory.
+		p = "."
+	}
+	return copyPath{workspace: workspace, path: p}
+}
+
+// expandCopyPath returns the paths matching a source, which may be a glob
+// pattern.
+func expandCopyPath(fsys copyFS, p string) ([]string, error) {
+	if !strings.ContainsAny(p, "*?[") {
+		return []string{p}, nil
+	}
+	matches, err := fsys.Glob(p)
+	if err != nil {
+		return nil, xerrors.Errorf("match %q: %w", p, err)
+	}
+	if len(matches) == 0 {
+		return nil, xerrors.Errorf("no files match %q", p)
+	}
+	return matches, nil
+}
+
+// copyFile is an open file on either side of the copy.
+type copyFile interface {
+	io.ReadWriteSeeker
+	io.Closer
+}
+
+// copyFS abstracts the local and the workspace file system.
+type copyFS interface {
+	Stat(name string) (fs.FileInfo, error)
+	Lstat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.FileInfo, error)
+	Glob(pattern string) ([]string, error)
+	Open(name string) (copyFile, error)
+	// Create opens a file for writing, creating it with the given mode if
+	// it doesn't exist. Existing content is kept if truncate is false.
+	Create(name string, mode fs.FileMode, truncate bool) (copyFile, error)
+	MkdirAll(name string) error
+	Readlink(name string) (string, error)
+	Symlink(oldname, newname string) error
+	Join(elem ...string) string
+	Base(name string) string
+}
+
+type localCopyFS struct{}
+
+func (localCopyFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (localCopyFS) Lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(name)
+}
+
+func (localCopyFS) ReadDir(name string) ([]fs.FileInfo, error) {
+	entries, err := os.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]fs.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (localCopyFS) Glob(pattern string) ([]string, error) {
+	return filepath.Glob(pattern)
+}
+
+func (localCopyFS) Open(name string) (copyFile, error) {
+	return os.Open(name)
+}
+
+func (localCopyFS) Create(name string, mode fs.FileMode, truncate bool) (copyFile, error) {
+	flag := os.O_WRONLY | os.O_CREATE
+	if truncate {
+		flag |= os.O_TRUNC
+	}
+	return os.OpenFile(name, flag, mode)
+}
+
+func (localCopyFS) MkdirAll(name string) error {
+	return os.MkdirAll(name, 0o755)
+}
+
+func (localCopyFS) Readlink(name string) (string, error) {
+	return os.Readlink(name)
+}
+
+func (localCopyFS) Symlink(oldname, newname string) error {
+	return os.Symlink(oldname, newname)
+}
+
+func (localCopyFS) Join(elem ...string) string {
+	return filepath.Join(elem...)
+}
+
+func (localCopyFS) Base(name string) string {
+	return filepath.Base(name)
+}
+
+type remoteCopyFS struct {
+	client *sftp.Client
+}
+
+func (f remoteCopyFS) Stat(name string) (fs.FileInfo, error) {
+	return f.client.Stat(name)
+}
+
+func (f remoteCopyFS) Lstat(name string) (fs.FileInfo, error) {
+	return f.client.Lstat(name)
+}
+
+func (f remoteCopyFS) ReadDir(name string) ([]fs.FileInfo, error) {
+	return f.client.ReadDir(name)
+}
+
+func (f remoteCopyFS) Glob(pattern string) ([]string, error) {
+	return f.client.Glob(pattern)
+}
+
+func (f remoteCopyFS) Open(name string) (copyFile, error) {
+	return f.client.Open(name)
+}
+
+func (f remoteCopyFS) Create(name string, mode fs.FileMode, truncate bool) (copyFile, error) {
+	flag := os.O_WRONLY | os.O_CREATE
+	if truncate {
+		flag |= os.O_TRUNC
+	}
+	file, err := f.client.OpenFile(name, flag)
+	if err != nil {
+		return nil, err
+	}
+	// SFTP doesn't take a mode when opening files.
+	err = file.Chmod(mode)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+func (f remoteCopyFS) MkdirAll(name string) error {
+	return f.client.MkdirAll(name)
+}
+
+func (f remoteCopyFS) Readlink(name string) (string, error) {
+	return f.client.ReadLink(name)
+}
+
+func (f remoteCopyFS) Symlink(oldname, newname string) error {
+	return f.client.Symlink(oldname, newname)
+}
+
+func (remoteCopyFS) Join(elem ...string) string {
+	return path.Join(elem...)
+}
+
+func (remoteCopyFS) Base(name string) string {
+	return path.Base(name)
+}
+
+// copier copies files and directories from srcFS to dstFS.
+type copier struct {
+	recursive bool
+	resume    bool
+	srcFS     copyFS
+	dstFS     copyFS
+	// progress receives progress bars, nil disables them.
+	progress io.Writer
+
+	files int
+	bytes int64
+}
+
+// copyAll copies the sources to the destination the way cp does: into the
+// destination if it's a directory, otherwise to the destination itself.
+func (c *copier) copyAll(sources []string, destination string) error {
+	info, err := c.dstFS.Stat(destination)
+	destinationIsDir := err == nil && info.IsDir()
+	if len(sources) > 1 && !destinationIsDir {
+		return xerrors.Errorf("target %q is not a directory", destination)
+	}
+
+	for _, source := range sources {
+		target := destination
+		if destinationIsDir {
+			target = c.dstFS.Join(destination, c.srcFS.Base(source))
+		}
+		err := c.copy(source, target)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copy copies source to target. A symlink given as source is followed, but
+// symlinks in the directories being copied are recreated instead, so that
+// symlink cycles can't make the copy recurse forever.
+func (c *copier) copy(source, target string) error {
+	info, err := c.srcFS.Stat(source)
+	if err != nil {
+		return xerrors.Errorf("stat %q: %w", source, err)
+	}
+	return c.copyEntry(source, target, info)
+}
+
+func (c *copier) copyEntry(source, target string, info fs.FileInfo) error {
+	if info.Mode()&fs.ModeSymlink != 0 {
+		return c.copySymlink(source, target)
+	}
+	if !info.IsDir() {
+		return c.copyFile(source, target, info)
+	}
+	if !c.recursive {
+		return xerrors.Errorf("%q is a directory, use --recursive to copy it", source)
+	}
+
+	err := c.dstFS.MkdirAll(target)
+	if err != nil {
+		return xerrors.Errorf("create directory %q: %w", target, err)
+	}
+	entries, err := c.srcFS.ReadDir(source)
+	if err != nil {
+		return xerrors.Errorf("read directory %q: %w", source, err)
+	}
+	for _, entry := range entries {
+		entrySource := c.srcFS.Join(source, entry.Name())
+		info, err := c.srcFS.Lstat(entrySource)
+		if err != nil {
+			return xerrors.Errorf("stat %q: %w", entrySource, err)
+		}
+		err = c.copyEntry(entrySource, c.dstFS.Join(target, entry.Name()), info)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *copier) copySymlink(source, target string) error {
+	link, err := c.srcFS.Readlink(source)
+	if err != nil {
+		return xerrors.Errorf("read symlink %q: %w", source, err)
+	}
+	// Copying again, e.g. to resume, finds the symlink already in place.
+	if existing, err := c.dstFS.Readlink(target); err == nil && existing == link {
+		return nil
+	}
+	err = c.dstFS.Symlink(link, target)
+	if err != nil {
+		return xerrors.Errorf("create symlink %q: %w", target, err)
+	}
+	return nil
+}
+
+func (c *copier) copyFile(source, target string, info fs.FileInfo) error {
+	if !info.Mode().IsRegular() {
+		return xerrors.Errorf("%q is not a regular file", source)
+	}
+
+	// Continue where an interrupted transfer stopped. A destination that's
+	// larger than the source can't be a partial copy, so it's overwritten.
+	var offset int64
+	if c.resume {
+		existing, err := c.dstFS.Stat(target)
+		if err == nil && existing.Mode().IsRegular() && existing.Size() <= info.Size() {
+			offset = existing.Size()
+		}
+	}
+
+	in, err := c.srcFS.Open(source)
+	if err != nil {
+		return xerrors.Errorf("open %q: %w", source, err)
+	}
+	defer in.Close()
+	out, err := c.dstFS.Create(target, info.Mode().Perm(), offset == 0)
+	if err != nil {
+		return xerrors.Errorf("create %q: %w", target, err)
+	}
+	defer out.Close()
+
+	if offset > 0 {
+		_, err = in.Seek(offset, io.SeekStart)
+		if err != nil {
+			return xerrors.Errorf("seek %q: %w", source, err)
+		}
+		_, err = out.Seek(offset, io.SeekStart)
+		if err != nil {
+			return xerrors.Errorf("seek %q: %w", target, err)
+		}
+	}
+
+	progress := &copyProgress{
+		w:       c.progress,
+		name:    c.srcFS.Base(source),
+		total:   info.Size(),
+		written: offset,
+	}
+	n, err := io.Copy(out, io.TeeReader(in, progress))
+	progress.done()
+	if err != nil {
+		return xerrors.Errorf("copy %q to %q: %w", source, target, err)
+	}
+	err = out.Close()
+	if err != nil {
+		return xerrors.Errorf("close %q: %w", target, err)
+	}
+
+	c.files++
+	c.bytes += n
+	return nil
+}
+
+// copyProgress draws a progress bar for a single file as it's written to.
+type copyProgress struct {
+	w        io.Writer
+	name     string
+	total    int64
+	written  int64
+	lastDraw time.Time
+}
+
+func (p *copyProgress) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+	if time.Since(p.lastDraw) > 100*time.Millisecond {
+		p.draw()
+	}
+	return len(b), nil
+}
+
+func (p *copyProgress) draw() {
+	if p.w == nil {
+		return
+	}
+	p.lastDraw = time.Now()
+
+	const width = 30
+	percent := 100
+	if p.total > 0 {
+		percent = int(p.written * 100 / p.total)
+	}
+	filled := percent * width / 100
+	_, _ = fmt.Fprintf(p.w, "\r%s [%s%s] %3d%% %s/%s",
+		p.name,
+		strings.Repeat("=", filled), strings.Repeat(" ", width-filled),
+		percent,
+		formatCopyBytes(p.written), formatCopyBytes(p.total),
+	)
+}
+
+func (p *copyProgress) done() {
+	if p.w == nil {
+		return
+	}
+	p.draw()
+	_, _ = fmt.Fprintln(p.w)
+}
+
+// formatCopyBytes formats a size in bytes with binary units, e.g. "1.5 MiB".
+func formatCopyBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
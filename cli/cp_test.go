@@ -0,0 +1,166 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/agent"
+	"github.com/coder/coder/cli/clitest"
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/coderd/rbac"
+	"github.com/coder/coder/codersdk/agentsdk"
+	"github.com/coder/coder/testutil"
+)
+
+func TestCp(t *testing.T) {
+	t.Parallel()
+
+	// The agent runs on this machine, so workspace paths are local paths.
+	client, workspace, agentToken := setupWorkspaceForAgent(t, nil)
+	agentClient := agentsdk.New(client.URL)
+	agentClient.SetSessionToken(agentToken)
+	agentCloser := agent.New(agent.Options{
+		Client: agentClient,
+		Logger: slogtest.Make(t, nil).Named("agent"),
+	})
+	t.Cleanup(func() {
+		_ = agentCloser.Close()
+	})
+
+	runCp := func(t *testing.T, args ...string) error {
+		t.Helper()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		inv, root := clitest.New(t, append([]string{"cp"}, args...)...)
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &bytes.Buffer{}
+		inv.Stderr = &bytes.Buffer{}
+		return inv.WithContext(ctx).Run()
+	}
+
+	t.Run("Recursive", func(t *testing.T) {
+		t.Parallel()
+
+		src := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("b"), 0o600))
+		dst := filepath.Join(t.TempDir(), "copy")
+
+		err := runCp(t, src, workspace.Name+":"+filepath.ToSlash(dst))
+		require.ErrorContains(t, err, "use --recursive")
+
+		err = runCp(t, "-r", src, workspace.Name+":"+filepath.ToSlash(dst))
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "a", string(b))
+		b, err = os.ReadFile(filepath.Join(dst, "sub", "b.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "b", string(b))
+	})
+
+	t.Run("SymlinkCycle", func(t *testing.T) {
+		t.Parallel()
+		if runtime.GOOS == "windows" {
+			t.Skip("creating symlinks requires privileges on Windows")
+		}
+
+		src := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0o600))
+		require.NoError(t, os.Symlink("..", filepath.Join(src, "parent")))
+		dst := filepath.Join(t.TempDir(), "copy")
+
+		err := runCp(t, "-r", workspace.Name+":"+filepath.ToSlash(src), dst)
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "a", string(b))
+		link, err := os.Readlink(filepath.Join(dst, "parent"))
+		require.NoError(t, err)
+		require.Equal(t, "..", link)
+	})
+
+	t.Run("GlobFromWorkspace", func(t *testing.T) {
+		t.Parallel()
+
+		src := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(src, "a.log"), []byte("a"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "b.log"), []byte("b"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "c.txt"), []byte("c"), 0o600))
+		dst := t.TempDir()
+
+		err := runCp(t, workspace.Name+":"+filepath.ToSlash(src)+"/*.log", dst)
+		require.NoError(t, err)
+
+		entries, err := os.ReadDir(dst)
+		require.NoError(t, err)
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		require.ElementsMatch(t, []string{"a.log", "b.log"}, names)
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		t.Parallel()
+
+		src := filepath.Join(t.TempDir(), "big")
+		content := bytes.Repeat([]byte("0123456789"), 10000)
+		require.NoError(t, os.WriteFile(src, content, 0o600))
+		// A partial copy, with content that differs from the source to
+		// prove that it isn't copied again.
+		dst := filepath.Join(t.TempDir(), "big")
+		partial := bytes.Repeat([]byte("x"), 5000)
+		require.NoError(t, os.WriteFile(dst, partial, 0o600))
+
+		err := runCp(t, "--resume", src, workspace.Name+":"+filepath.ToSlash(dst))
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		require.Equal(t, append(partial, content[len(partial):]...), b)
+	})
+
+	t.Run("OtherUser", func(t *testing.T) {
+		t.Parallel()
+
+		src := filepath.Join(t.TempDir(), "a.txt")
+		require.NoError(t, os.WriteFile(src, []byte("a"), 0o600))
+		dst := filepath.Join(t.TempDir(), "a.txt")
+
+		// Owners can copy from the workspaces of other users by prefixing
+		// the workspace with its owner.
+		ownerClient, _ := coderdtest.CreateAnotherUser(t, client, workspace.OrganizationID, rbac.RoleOwner())
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "cp", workspace.OwnerName+"/"+workspace.Name+":"+filepath.ToSlash(src), dst)
+		clitest.SetupConfig(t, ownerClient, root)
+		inv.Stdout = &bytes.Buffer{}
+		inv.Stderr = &bytes.Buffer{}
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(dst)
+		require.NoError(t, err)
+		require.Equal(t, "a", string(b))
+	})
+
+	t.Run("BothLocal", func(t *testing.T) {
+		t.Parallel()
+
+		err := runCp(t, t.TempDir(), t.TempDir())
+		require.ErrorContains(t, err, "must be a workspace path")
+	})
+}
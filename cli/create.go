@@ -23,6 +23,7 @@ func (r *RootCmd) create() *clibase.Cmd {
 		startAt       string
 		stopAfter     time.Duration
 		workspaceName string
+		fromWorkspace string
 
 		parameterFlags workspaceParameterFlags
 	)
@@ -36,6 +37,10 @@ func (r *RootCmd) create() *clibase.Cmd {
 				Description: "Create a workspace for another user (if you have permission)",
 				Command:     "coder create <username>/<workspace_name>",
 			},
+			example{
+				Description: "Create a workspace with the same template version, parameters and schedule as another workspace",
+				Command:     "coder create <workspace_name> --from <username>/<workspace_name>",
+			},
 		),
 		Middleware: clibase.Chain(r.InitClient(client)),
 		Handler: func(inv *clibase.Invocation) error {
@@ -73,8 +78,37 @@ func (r *RootCmd) create() *clibase.Cmd {
 				return xerrors.Errorf("A workspace already exists named %q!", workspaceName)
 			}
 
-			var template codersdk.Template
-			if templateName == "" {
+			var (
+				template          codersdk.Template
+				templateVersionID uuid.UUID
+				sourceWorkspace   *codersdk.Workspace
+				sourceParameters  []codersdk.WorkspaceBuildParameter
+			)
+			if fromWorkspace != "" {
+				source, err := NamedWorkspace(inv.Context(), client, fromWorkspace)
+				if err != nil {
+					return xerrors.Errorf("get source workspace: %w", err)
+				}
+				template, err = client.Template(inv.Context(), source.TemplateID)
+				if err != nil {
+					return xerrors.Errorf("get template of source workspace: %w", err)
+				}
+				if templateName != "" && templateName != template.Name {
+					return xerrors.Errorf("the source workspace uses the %q template, not %q", template.Name, templateName)
+				}
+				if template.Deprecated {
+					return xerrors.New(templateDeprecationMessage(inv.Context(), client, template))
+				}
+				sourceParameters, err = client.WorkspaceBuildParameters(inv.Context(), source.LatestBuild.ID)
+				if err != nil {
+					return xerrors.Errorf("get source workspace parameters: %w", err)
+				}
+				templateVersionID = source.LatestBuild.TemplateVersionID
+				if template.RequireActiveVersion {
+					templateVersionID = template.ActiveVersionID
+				}
+				sourceWorkspace = &source
+			} else if templateName == "" {
 				_, _ = fmt.Fprintln(inv.Stdout, cliui.DefaultStyles.Wrap.Render("Select a template below to preview the provisioned infrastructure:"))
 
 				templates, err := client.TemplatesByOrganization(inv.Context(), organization.ID)
@@ -90,6 +124,10 @@ func (r *RootCmd) create() *clibase.Cmd {
 				templateByName := make(map[string]codersdk.Template, len(templates))
 
 				for _, template := range templates {
+					// Deprecated templates can't be used for new workspaces.
+					if template.Deprecated {
+						continue
+					}
 					templateName := template.Name
 
 					if template.ActiveUserCount > 0 {
@@ -120,6 +158,9 @@ func (r *RootCmd) create() *clibase.Cmd {
 				if err != nil {
 					return xerrors.Errorf("get template by name: %w", err)
 				}
+				if template.Deprecated {
+					return xerrors.New(templateDeprecationMessage(inv.Context(), client, template))
+				}
 			}
 
 			var schedSpec *string
@@ -137,9 +178,12 @@ func (r *RootCmd) create() *clibase.Cmd {
 			}
 
 			richParameters, err := prepWorkspaceBuild(inv, client, prepWorkspaceBuildArgs{
-				Action:           WorkspaceCreate,
-				Template:         template,
-				NewWorkspaceName: workspaceName,
+				Action:            WorkspaceCreate,
+				Template:          template,
+				TemplateVersionID: templateVersionID,
+				NewWorkspaceName:  workspaceName,
+
+				SourceWorkspaceParameters: sourceParameters,
 
 				RichParameterFile: parameterFlags.richParameterFile,
 				RichParameters:    cliRichParameters,
@@ -161,13 +205,17 @@ func (r *RootCmd) create() *clibase.Cmd {
 				ttlMillis = ptr.Ref(stopAfter.Milliseconds())
 			}
 
-			workspace, err := client.CreateWorkspace(inv.Context(), organization.ID, workspaceOwner, codersdk.CreateWorkspaceRequest{
+			req := codersdk.CreateWorkspaceRequest{
 				TemplateID:          template.ID,
 				Name:                workspaceName,
 				AutostartSchedule:   schedSpec,
 				TTLMillis:           ttlMillis,
 				RichParameterValues: richParameters,
-			})
+			}
+			if sourceWorkspace != nil {
+				req.SourceWorkspaceID = &sourceWorkspace.ID
+			}
+			workspace, err := client.CreateWorkspace(inv.Context(), organization.ID, workspaceOwner, req)
 			if err != nil {
 				return xerrors.Errorf("create workspace: %w", err)
 			}
@@ -195,6 +243,11 @@ func (r *RootCmd) create() *clibase.Cmd {
 			Description: "Specify the workspace autostart schedule. Check coder schedule start --help for the syntax.",
 			Value:       clibase.StringOf(&startAt),
 		},
+		clibase.Option{
+			Flag:        "from",
+			Description: "Clone an existing workspace, specified as <username>/<workspace_name>. The new workspace uses the template version, mutable parameter values and schedule of the source workspace. Immutable parameters are prompted for again. Parameter values can be overridden with --parameter or --rich-parameter-file.",
+			Value:       clibase.StringOf(&fromWorkspace),
+		},
 		clibase.Option{
 			Flag:        "stop-after",
 			Env:         "CODER_WORKSPACE_STOP_AFTER",
@@ -208,12 +261,15 @@ func (r *RootCmd) create() *clibase.Cmd {
 }
 
 type prepWorkspaceBuildArgs struct {
-	Action           WorkspaceCLIAction
-	Template         codersdk.Template
-	NewWorkspaceName string
-	WorkspaceID      uuid.UUID
+	Action   WorkspaceCLIAction
+	Template codersdk.Template
+	// TemplateVersionID defaults to the active version of the template.
+	TemplateVersionID uuid.UUID
+	NewWorkspaceName  string
+	WorkspaceID       uuid.UUID
 
-	LastBuildParameters []codersdk.WorkspaceBuildParameter
+	LastBuildParameters       []codersdk.WorkspaceBuildParameter
+	SourceWorkspaceParameters []codersdk.WorkspaceBuildParameter
 
 	PromptBuildOptions bool
 	BuildOptions       []codersdk.WorkspaceBuildParameter
@@ -223,12 +279,16 @@ type prepWorkspaceBuildArgs struct {
 	RichParameterFile    string
 }
 
-// prepWorkspaceBuild will ensure a workspace build will succeed on the latest template version.
+// prepWorkspaceBuild will ensure a workspace build will succeed on the given template version, or the latest one.
 // Any missing params will be prompted to the user. It supports rich parameters.
 func prepWorkspaceBuild(inv *clibase.Invocation, client *codersdk.Client, args prepWorkspaceBuildArgs) ([]codersdk.WorkspaceBuildParameter, error) {
 	ctx := inv.Context()
 
-	templateVersion, err := client.TemplateVersion(ctx, args.Template.ActiveVersionID)
+	templateVersionID := args.TemplateVersionID
+	if templateVersionID == uuid.Nil {
+		templateVersionID = args.Template.ActiveVersionID
+	}
+	templateVersion, err := client.TemplateVersion(ctx, templateVersionID)
 	if err != nil {
 		return nil, xerrors.Errorf("get template version: %w", err)
 	}
@@ -248,6 +308,7 @@ func prepWorkspaceBuild(inv *clibase.Invocation, client *codersdk.Client, args p
 
 	resolver := new(ParameterResolver).
 		WithLastBuildParameters(args.LastBuildParameters).
+		WithSourceWorkspaceParameters(args.SourceWorkspaceParameters).
 		WithPromptBuildOptions(args.PromptBuildOptions).
 		WithBuildOptions(args.BuildOptions).
 		WithPromptRichParameters(args.PromptRichParameters).
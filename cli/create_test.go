@@ -393,6 +393,57 @@ func TestCreateWithRichParameters(t *testing.T) {
 		}
 		<-doneChan
 	})
+
+	t.Run("CloneWorkspace", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, echoResponses)
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		source := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref("CRON_TZ=US/Central 30 9 * * Mon-Fri")
+			cwr.RichParameterValues = []codersdk.WorkspaceBuildParameter{
+				{Name: firstParameterName, Value: firstParameterValue},
+				{Name: secondParameterName, Value: secondParameterValue},
+				{Name: immutableParameterName, Value: immutableParameterValue},
+			}
+		})
+		coderdtest.AwaitWorkspaceBuildJob(t, client, source.LatestBuild.ID)
+
+		// Mutable parameters are copied from the source workspace unless
+		// overridden, and only the immutable parameter is prompted.
+		inv, root := clitest.New(t, "create", "my-clone", "--from", source.OwnerName+"/"+source.Name,
+			"--parameter", fmt.Sprintf("%s=%s", firstParameterName, "9"), "-y")
+		clitest.SetupConfig(t, client, root)
+		ctx := testutil.Context(t, testutil.WaitLong)
+		doneChan := make(chan struct{})
+		pty := ptytest.New(t).Attach(inv)
+		go func() {
+			defer close(doneChan)
+			err := inv.WithContext(ctx).Run()
+			assert.NoError(t, err)
+		}()
+		pty.ExpectMatch(immutableParameterDescription)
+		pty.WriteLine("5")
+		<-doneChan
+
+		clone, err := client.WorkspaceByOwnerAndName(ctx, codersdk.Me, "my-clone", codersdk.WorkspaceOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, source.TemplateID, clone.TemplateID)
+		assert.Equal(t, source.LatestBuild.TemplateVersionID, clone.LatestBuild.TemplateVersionID)
+		assert.Equal(t, source.AutostartSchedule, clone.AutostartSchedule)
+
+		parameters, err := client.WorkspaceBuildParameters(ctx, clone.LatestBuild.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []codersdk.WorkspaceBuildParameter{
+			{Name: firstParameterName, Value: "9"},
+			{Name: secondParameterName, Value: secondParameterValue},
+			{Name: immutableParameterName, Value: "5"},
+		}, parameters)
+	})
 }
 
 func TestCreateValidateRichParameters(t *testing.T) {
@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/sloghuman"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+// execResultRow is a row of the summary table printed by "coder exec".
+type execResultRow struct {
+	Workspace string `table:"workspace,default_sort"`
+	ExitCode  string `table:"exit code"`
+	Duration  string `table:"duration"`
+	Error     string `table:"error"`
+}
+
+func (r *RootCmd) exec() *clibase.Cmd {
+	var (
+		parallel int64
+		agent    string
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Annotations: workspaceCommand,
+		Use:         "exec <workspace|filter> -- <command>",
+		Short:       "Run a command in one or more workspaces",
+		Long: "The first argument is a workspace name or, if it contains a colon, a " +
+			"search query in the syntax of 'coder list --search'. The command runs " +
+			"in every matching workspace and its output is prefixed with the " +
+			"workspace name.\n\n" + formatExamples(
+			example{
+				Description: "Pull the latest changes in all workspaces of a template",
+				Command:     "coder exec 'template:backend' -- git -C ~/backend pull",
+			},
+			example{
+				Description: "Clean up caches in at most 10 workspaces at a time",
+				Command:     "coder exec 'owner:me status:running' --parallel 10 -- rm -rf ~/.cache/go-build",
+			},
+		),
+		Middleware: clibase.Chain(
+			clibase.RequireRangeArgs(2, -1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			if parallel < 1 {
+				return xerrors.New("--parallel must be at least 1")
+			}
+			command := strings.Join(inv.Args[1:], " ")
+
+			var workspaces []codersdk.Workspace
+			if strings.Contains(inv.Args[0], ":") {
+				res, err := client.Workspaces(ctx, codersdk.WorkspaceFilter{
+					FilterQuery: inv.Args[0],
+				})
+				if err != nil {
+					return xerrors.Errorf("search workspaces: %w", err)
+				}
+				workspaces = res.Workspaces
+			} else {
+				workspace, err := NamedWorkspace(ctx, client, inv.Args[0])
+				if err != nil {
+					return err
+				}
+				workspaces = []codersdk.Workspace{workspace}
+			}
+			if len(workspaces) == 0 {
+				return xerrors.Errorf("no workspaces match %q", inv.Args[0])
+			}
+
+			var logger slog.Logger
+			if r.verbose {
+				logger = slog.Make(sloghuman.Sink(inv.Stderr)).Leveled(slog.LevelDebug)
+			}
+			if r.disableDirect {
+				_, _ = fmt.Fprintln(inv.Stderr, "Direct connections disabled.")
+			}
+
+			var (
+				outputMu sync.Mutex
+				results  = make([]execResultRow, len(workspaces))
+				eg       errgroup.Group
+			)
+			eg.SetLimit(int(parallel))
+			for i, workspace := range workspaces {
+				i, workspace := i, workspace
+				eg.Go(func() error {
+					name := workspace.OwnerName + "/" + workspace.Name
+					stdout := &prefixWriter{mu: &outputMu, w: inv.Stdout, prefix: name + ": "}
+					stderr := &prefixWriter{mu: &outputMu, w: inv.Stderr, prefix: name + ": "}
+
+					start := time.Now()
+					exitCode, err := r.execInWorkspace(ctx, client, logger, workspace, agent, command, stdout, stderr)
+					_ = stdout.Flush()
+					_ = stderr.Flush()
+
+					results[i] = execResultRow{
+						Workspace: name,
+						ExitCode:  "-",
+						Duration:  time.Since(start).Truncate(time.Millisecond).String(),
+					}
+					if exitCode >= 0 {
+						results[i].ExitCode = fmt.Sprint(exitCode)
+					}
+					if err != nil {
+						results[i].Error = err.Error()
+					}
+					// Failures are reported in the summary, they don't stop
+					// the other workspaces.
+					return nil
+				})
+			}
+			_ = eg.Wait()
+
+			summary, err := cliui.DisplayTable(results, "", nil)
+			if err != nil {
+				return xerrors.Errorf("render summary: %w", err)
+			}
+			_, _ = fmt.Fprintln(inv.Stdout)
+			_, _ = fmt.Fprintln(inv.Stdout, summary)
+
+			failed := 0
+			for _, result := range results {
+				if result.ExitCode != "0" {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return xerrors.Errorf("command failed in %d of %d workspace(s)", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:          "parallel",
+			FlagShorthand: "p",
+			Env:           "CODER_EXEC_PARALLEL",
+			Description:   "Maximum number of workspaces to run the command in at the same time.",
+			Default:       "5",
+			Value:         clibase.Int64Of(&parallel),
+		},
+		{
+			Flag:        "agent",
+			Env:         "CODER_EXEC_AGENT",
+			Description: "Name of the agent to run the command on in workspaces with multiple agents. By default a random agent is used.",
+			Value:       clibase.StringOf(&agent),
+		},
+	}
+	return cmd
+}
+
+// execInWorkspace runs a command in a workspace over the agent SSH server and
+// returns its exit code. The exit code is -1 if the command didn't run to
+// completion.
+func (r *RootCmd) execInWorkspace(ctx context.Context, client *codersdk.Client, logger slog.Logger, workspace codersdk.Workspace, agentName, command string, stdout, stderr io.Writer) (int, error) {
+	if workspace.LatestBuild.Transition != codersdk.WorkspaceTransitionStart ||
+		workspace.LatestBuild.Job.Status != codersdk.ProvisionerJobSucceeded {
+		return -1, xerrors.Errorf("workspace is not running")
+	}
+	workspaceAgent, err := selectWorkspaceAgent(workspace, agentName)
+	if err != nil {
+		return -1, err
+	}
+	if workspaceAgent.Status != codersdk.WorkspaceAgentConnected {
+		return -1, xerrors.Errorf("agent is %s", workspaceAgent.Status)
+	}
+
+	conn, err := r.dialWorkspaceAgent(ctx, client, workspaceAgent.ID, logger)
+	if err != nil {
+		return -1, err
+	}
+	defer conn.Close()
+
+	sshClient, err := conn.SSHClient(ctx)
+	if err != nil {
+		return -1, xerrors.Errorf("ssh client: %w", err)
+	}
+	defer sshClient.Close()
+	sshSession, err := sshClient.NewSession()
+	if err != nil {
+		return -1, xerrors.Errorf("ssh session: %w", err)
+	}
+	defer sshSession.Close()
+
+	sshSession.Stdout = stdout
+	sshSession.Stderr = stderr
+	err = sshSession.Run(command)
+	var exitErr *gossh.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus(), nil
+	}
+	if err != nil {
+		return -1, xerrors.Errorf("run command: %w", err)
+	}
+	return 0, nil
+}
+
+// prefixWriter prefixes every line written to it. Complete lines are written
+// while holding mu so output of concurrent writers isn't interleaved.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		err := p.writeLine(p.buf[:i+1])
+		p.buf = p.buf[i+1:]
+		if err != nil {
+			return len(b), err
+		}
+	}
+}
+
+// Flush writes a trailing partial line.
+func (p *prefixWriter) Flush() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+	line := append(p.buf, '\n')
+	p.buf = nil
+	return p.writeLine(line)
+}
+
+func (p *prefixWriter) writeLine(line []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err := fmt.Fprintf(p.w, "%s%s", cliui.DefaultStyles.Placeholder.Render(p.prefix), line)
+	return err
+}
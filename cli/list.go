@@ -1,16 +1,20 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/cli/clibase"
 	"github.com/coder/coder/cli/cliui"
 	"github.com/coder/coder/coderd/schedule"
 	"github.com/coder/coder/coderd/util/ptr"
+	"github.com/coder/coder/coderd/util/slice"
 	"github.com/coder/coder/codersdk"
 )
 
@@ -76,6 +80,7 @@ func (r *RootCmd) list() *clibase.Cmd {
 		all               bool
 		defaultQuery      = "owner:me"
 		searchQuery       string
+		watch             bool
 		displayWorkspaces []workspaceListRow
 		formatter         = cliui.NewOutputFormatter(
 			cliui.TableFormat([]workspaceListRow{}, nil),
@@ -100,6 +105,10 @@ func (r *RootCmd) list() *clibase.Cmd {
 				filter.FilterQuery = ""
 			}
 
+			if watch {
+				return watchWorkspaceList(inv, client, filter, formatter)
+			}
+
 			res, err := client.Workspaces(inv.Context(), filter)
 			if err != nil {
 				return err
@@ -112,16 +121,11 @@ func (r *RootCmd) list() *clibase.Cmd {
 				return nil
 			}
 
-			userRes, err := client.Users(inv.Context(), codersdk.UsersRequest{})
+			usersByID, err := listUsersByID(inv.Context(), client)
 			if err != nil {
 				return err
 			}
 
-			usersByID := map[uuid.UUID]codersdk.User{}
-			for _, user := range userRes.Users {
-				usersByID[user.ID] = user
-			}
-
 			now := time.Now()
 			displayWorkspaces = make([]workspaceListRow, len(res.Workspaces))
 			for i, workspace := range res.Workspaces {
@@ -151,8 +155,173 @@ func (r *RootCmd) list() *clibase.Cmd {
 			Default:     defaultQuery,
 			Value:       clibase.StringOf(&searchQuery),
 		},
+		{
+			Flag:          "watch",
+			FlagShorthand: "w",
+			Description:   "Keep the list on screen and update it as workspaces change.",
+			Value:         clibase.BoolOf(&watch),
+		},
 	}
 
 	formatter.AttachOptions(&cmd.Options)
 	return cmd
 }
+
+// listWatchRefreshInterval is how often the workspace list is fetched again
+// in watch mode to pick up created and deleted workspaces. Changes to listed
+// workspaces are streamed as they happen.
+const listWatchRefreshInterval = 30 * time.Second
+
+func listUsersByID(ctx context.Context, client *codersdk.Client) (map[uuid.UUID]codersdk.User, error) {
+	userRes, err := client.Users(ctx, codersdk.UsersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	usersByID := map[uuid.UUID]codersdk.User{}
+	for _, user := range userRes.Users {
+		usersByID[user.ID] = user
+	}
+	return usersByID, nil
+}
+
+// watchWorkspaceList renders the workspaces matching the filter and renders
+// them again whenever one of them changes, until the context is canceled.
+func watchWorkspaceList(inv *clibase.Invocation, client *codersdk.Client, filter codersdk.WorkspaceFilter, formatter *cliui.OutputFormatter) error {
+	ctx, cancel := context.WithCancel(inv.Context())
+	defer cancel()
+
+	var (
+		updates    = make(chan codersdk.Workspace)
+		closed     = make(chan uuid.UUID)
+		watching   = map[uuid.UUID]context.CancelFunc{}
+		workspaces = map[uuid.UUID]codersdk.Workspace{}
+		usersByID  map[uuid.UUID]codersdk.User
+	)
+	watchWorkspace := func(id uuid.UUID) error {
+		watchCtx, watchCancel := context.WithCancel(ctx)
+		wc, err := client.WatchWorkspace(watchCtx, id)
+		if err != nil {
+			watchCancel()
+			return err
+		}
+		watching[id] = watchCancel
+		go func() {
+			for workspace := range wc {
+				select {
+				case updates <- workspace:
+				case <-watchCtx.Done():
+					return
+				}
+			}
+			// The stream ended, let the next refresh start a new one.
+			select {
+			case closed <- id:
+			case <-watchCtx.Done():
+			}
+		}()
+		return nil
+	}
+	refresh := func() error {
+		res, err := client.Workspaces(ctx, filter)
+		if err != nil {
+			return err
+		}
+		usersByID, err = listUsersByID(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		listed := make(map[uuid.UUID]struct{}, len(res.Workspaces))
+		for _, workspace := range res.Workspaces {
+			listed[workspace.ID] = struct{}{}
+			workspaces[workspace.ID] = workspace
+			if _, ok := watching[workspace.ID]; ok {
+				continue
+			}
+			err = watchWorkspace(workspace.ID)
+			if err != nil {
+				return xerrors.Errorf("watch workspace %q: %w", workspace.Name, err)
+			}
+		}
+		for id, watchCancel := range watching {
+			if _, ok := listed[id]; ok {
+				continue
+			}
+			watchCancel()
+			delete(watching, id)
+			delete(workspaces, id)
+		}
+		return nil
+	}
+	render := func() error {
+		now := time.Now()
+		rows := make([]workspaceListRow, 0, len(workspaces))
+		for _, workspace := range workspaces {
+			rows = append(rows, workspaceListRowFromWorkspace(now, usersByID, workspace))
+		}
+		// Keep the order stable between renders for formats that
+		// don't sort.
+		slices.SortFunc(rows, func(a, b workspaceListRow) int {
+			return slice.Ascending(a.WorkspaceName, b.WorkspaceName)
+		})
+
+		out, err := formatter.Format(ctx, rows)
+		if err != nil {
+			return err
+		}
+		if isTTYOut(inv) {
+			// Move the cursor home and clear the screen to redraw in place.
+			_, _ = fmt.Fprint(inv.Stdout, "\033[H\033[2J")
+			_, _ = fmt.Fprintln(inv.Stdout, cliui.DefaultStyles.Placeholder.Render(
+				fmt.Sprintf("Watching workspaces, press Ctrl+C to stop. Last update: %s", now.Format(time.Kitchen)),
+			))
+		}
+		_, err = fmt.Fprintln(inv.Stdout, out)
+		return err
+	}
+
+	err := refresh()
+	if err != nil {
+		return err
+	}
+	err = render()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(listWatchRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// Stopping the watch with Ctrl+C is the expected way out.
+			return nil
+		case workspace := <-updates:
+			if _, ok := watching[workspace.ID]; !ok {
+				// An update that raced with the workspace being removed
+				// from the list.
+				continue
+			}
+			workspaces[workspace.ID] = workspace
+		case id := <-closed:
+			if watchCancel, ok := watching[id]; ok {
+				watchCancel()
+				delete(watching, id)
+			}
+			continue
+		case <-ticker.C:
+			err = refresh()
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+		}
+		err = render()
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -48,8 +48,15 @@ func (r *RootCmd) login() *clibase.Cmd {
 		useTokenForSession bool
 	)
 	cmd := &clibase.Cmd{
-		Use:        "login <url>",
-		Short:      "Authenticate with Coder deployment",
+		Use:   "login <url>",
+		Short: "Authenticate with Coder deployment",
+		Long: "The URL and session token are saved as a named context, which becomes the current context. " +
+			"The context is named after the deployment's host unless --context is set.\n\n" + formatExamples(
+			example{
+				Description: "Log in to a staging deployment and save it as the \"staging\" context",
+				Command:     "coder login https://staging.example.com --context staging",
+			},
+		),
 		Middleware: clibase.RequireRangeArgs(0, 1),
 		Handler: func(inv *clibase.Invocation) error {
 			ctx := inv.Context()
@@ -202,18 +209,15 @@ func (r *RootCmd) login() *clibase.Cmd {
 				}
 
 				sessionToken := resp.SessionToken
-				config := r.createConfig()
-				err = config.Session().Write(sessionToken)
+				contextName, err := r.saveLoginContext(serverURL, sessionToken)
 				if err != nil {
-					return xerrors.Errorf("write session token: %w", err)
-				}
-				err = config.URL().Write(serverURL.String())
-				if err != nil {
-					return xerrors.Errorf("write server url: %w", err)
+					return err
 				}
 
 				_, _ = fmt.Fprintf(inv.Stdout,
 					cliui.DefaultStyles.Paragraph.Render(fmt.Sprintf("Welcome to Coder, %s! You're authenticated.", cliui.DefaultStyles.Keyword.Render(username)))+"\n")
+				_, _ = fmt.Fprintf(inv.Stdout,
+					cliui.DefaultStyles.Paragraph.Render(fmt.Sprintf("Saved as context %s.", cliui.DefaultStyles.Keyword.Render(contextName)))+"\n")
 
 				_, _ = fmt.Fprintf(inv.Stdout,
 					cliui.DefaultStyles.Paragraph.Render("Get started by creating a template: "+cliui.DefaultStyles.Code.Render("coder templates init"))+"\n")
@@ -272,17 +276,13 @@ func (r *RootCmd) login() *clibase.Cmd {
 				return xerrors.Errorf("get user: %w", err)
 			}
 
-			config := r.createConfig()
-			err = config.Session().Write(sessionToken)
-			if err != nil {
-				return xerrors.Errorf("write session token: %w", err)
-			}
-			err = config.URL().Write(serverURL.String())
+			contextName, err := r.saveLoginContext(serverURL, sessionToken)
 			if err != nil {
-				return xerrors.Errorf("write server url: %w", err)
+				return err
 			}
 
 			_, _ = fmt.Fprintf(inv.Stdout, Caret+"Welcome to Coder, %s! You're authenticated.\n", cliui.DefaultStyles.Keyword.Render(resp.Username))
+			_, _ = fmt.Fprintf(inv.Stdout, Caret+"Saved as context %s.\n", cliui.DefaultStyles.Keyword.Render(contextName))
 			return nil
 		},
 	}
@@ -320,6 +320,35 @@ func (r *RootCmd) login() *clibase.Cmd {
 	return cmd
 }
 
+// saveLoginContext stores the URL and session token of a deployment as a
+// named context and makes it the current context.
+func (r *RootCmd) saveLoginContext(serverURL *url.URL, sessionToken string) (string, error) {
+	name := r.context
+	if name == "" {
+		name = contextNameFromURL(serverURL)
+	}
+	err := validateContextName(name)
+	if err != nil {
+		return "", err
+	}
+
+	root := r.createConfig()
+	config := root.Context(name)
+	err = config.Session().Write(sessionToken)
+	if err != nil {
+		return "", xerrors.Errorf("write session token: %w", err)
+	}
+	err = config.URL().Write(serverURL.String())
+	if err != nil {
+		return "", xerrors.Errorf("write server url: %w", err)
+	}
+	err = root.CurrentContext().Write(name)
+	if err != nil {
+		return "", xerrors.Errorf("write current context: %w", err)
+	}
+	return name, nil
+}
+
 // isWSL determines if coder-cli is running within Windows Subsystem for Linux
 func isWSL() (bool, error) {
 	if runtime.GOOS == goosDarwin || runtime.GOOS == goosWindows {
@@ -205,7 +205,7 @@ func TestLogin(t *testing.T) {
 		root, cfg := clitest.New(t, "login", client.URL.String(), "--token", client.SessionToken())
 		err := root.Run()
 		require.NoError(t, err)
-		sessionFile, err := cfg.Session().Read()
+		sessionFile, err := currentContext(t, cfg).Session().Read()
 		require.NoError(t, err)
 		// This **should not be equal** to the token we passed in.
 		require.NotEqual(t, client.SessionToken(), sessionFile)
@@ -23,9 +23,11 @@ func (r *RootCmd) logout() *clibase.Cmd {
 		Handler: func(inv *clibase.Invocation) error {
 			var errors []error
 
-			config := r.createConfig()
+			config, err := r.contextConfig()
+			if err != nil {
+				return err
+			}
 
-			var err error
 			_, err = cliui.Prompt(inv, cliui.PromptOptions{
 				Text:      "Are you sure you want to log out?",
 				IsConfirm: true,
@@ -3,6 +3,7 @@ package cli_test
 import (
 	"os"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,14 +21,14 @@ func TestLogout(t *testing.T) {
 		t.Parallel()
 
 		pty := ptytest.New(t)
-		config := login(t, pty)
+		global, config := login(t, pty)
 
 		// Ensure session files exist.
 		require.FileExists(t, string(config.URL()))
 		require.FileExists(t, string(config.Session()))
 
 		logoutChan := make(chan struct{})
-		logout, _ := clitest.New(t, "logout", "--global-config", string(config))
+		logout, _ := clitest.New(t, "logout", "--global-config", string(global))
 		logout.Stdin = pty.Input()
 		logout.Stdout = pty.Output()
 
@@ -48,14 +49,14 @@ func TestLogout(t *testing.T) {
 		t.Parallel()
 
 		pty := ptytest.New(t)
-		config := login(t, pty)
+		global, config := login(t, pty)
 
 		// Ensure session files exist.
 		require.FileExists(t, string(config.URL()))
 		require.FileExists(t, string(config.Session()))
 
 		logoutChan := make(chan struct{})
-		logout, _ := clitest.New(t, "logout", "--global-config", string(config), "-y")
+		logout, _ := clitest.New(t, "logout", "--global-config", string(global), "-y")
 		logout.Stdin = pty.Input()
 		logout.Stdout = pty.Output()
 
@@ -74,7 +75,7 @@ func TestLogout(t *testing.T) {
 		t.Parallel()
 
 		pty := ptytest.New(t)
-		config := login(t, pty)
+		global, config := login(t, pty)
 
 		// Ensure session files exist.
 		require.FileExists(t, string(config.URL()))
@@ -84,7 +85,7 @@ func TestLogout(t *testing.T) {
 		require.NoError(t, err)
 
 		logoutChan := make(chan struct{})
-		logout, _ := clitest.New(t, "logout", "--global-config", string(config))
+		logout, _ := clitest.New(t, "logout", "--global-config", string(global))
 
 		logout.Stdin = pty.Input()
 		logout.Stdout = pty.Output()
@@ -101,7 +102,7 @@ func TestLogout(t *testing.T) {
 		t.Parallel()
 
 		pty := ptytest.New(t)
-		config := login(t, pty)
+		global, config := login(t, pty)
 
 		// Ensure session files exist.
 		require.FileExists(t, string(config.URL()))
@@ -111,7 +112,7 @@ func TestLogout(t *testing.T) {
 		require.NoError(t, err)
 
 		logoutChan := make(chan struct{})
-		logout, _ := clitest.New(t, "logout", "--global-config", string(config))
+		logout, _ := clitest.New(t, "logout", "--global-config", string(global))
 
 		logout.Stdin = pty.Input()
 		logout.Stdout = pty.Output()
@@ -128,7 +129,7 @@ func TestLogout(t *testing.T) {
 		t.Parallel()
 
 		pty := ptytest.New(t)
-		config := login(t, pty)
+		global, config := login(t, pty)
 
 		// Ensure session files exist.
 		require.FileExists(t, string(config.URL()))
@@ -164,7 +165,7 @@ func TestLogout(t *testing.T) {
 			}
 		}()
 
-		logout, _ := clitest.New(t, "logout", "--global-config", string(config))
+		logout, _ := clitest.New(t, "logout", "--global-config", string(global))
 
 		logout.Stdin = pty.Input()
 		logout.Stdout = pty.Output()
@@ -188,7 +189,9 @@ func TestLogout(t *testing.T) {
 	})
 }
 
-func login(t *testing.T, pty *ptytest.PTY) config.Root {
+// login logs in to a new deployment and returns the global config directory
+// along with the directory of the context the login was saved to.
+func login(t *testing.T, pty *ptytest.PTY) (config.Root, config.Root) {
 	t.Helper()
 
 	client := coderdtest.New(t, nil)
@@ -209,5 +212,14 @@ func login(t *testing.T, pty *ptytest.PTY) config.Root {
 	pty.ExpectMatch("Welcome to Coder")
 	<-doneChan
 
-	return cfg
+	return cfg, currentContext(t, cfg)
+}
+
+// currentContext returns the directory of the current context.
+func currentContext(t *testing.T, cfg config.Root) config.Root {
+	t.Helper()
+
+	name, err := cfg.CurrentContext().Read()
+	require.NoError(t, err)
+	return cfg.Context(strings.TrimSpace(name))
 }
@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+const (
+	workspaceLogSourceBuild = "build"
+	workspaceLogSourceAgent = "agent"
+)
+
+// workspaceLogLine is a single line of output from either a workspace build
+// or a workspace agent. When using JSON output, one is written per line.
+type workspaceLogLine struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Source    string            `json:"source"`
+	Agent     string            `json:"agent,omitempty"`
+	Stage     string            `json:"stage,omitempty"`
+	Level     codersdk.LogLevel `json:"level"`
+	Output    string            `json:"output"`
+}
+
+func (r *RootCmd) logs() *clibase.Cmd {
+	var (
+		buildNumber  int64
+		agentName    string
+		follow       bool
+		since        time.Duration
+		outputFormat string
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Annotations: workspaceCommand,
+		Use:         "logs <workspace>",
+		Short:       "Show the build and agent logs of a workspace",
+		Long: "Logs written so far are printed once. With --follow, build logs are streamed until " +
+			"the build completes and agent logs until interrupted.\n\n" + formatExamples(
+			example{
+				Description: "Print the logs of the latest build and all agents",
+				Command:     "coder logs my-workspace",
+			},
+			example{
+				Description: "Follow the logs of a single agent",
+				Command:     "coder logs my-workspace --agent main --follow",
+			},
+			example{
+				Description: "Print the last hour of logs from a previous build as JSON",
+				Command:     "coder logs my-workspace --build 3 --since 1h -o json",
+			},
+		),
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			owner, workspaceName, err := splitNamedWorkspace(inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			var build codersdk.WorkspaceBuild
+			if buildNumber == 0 {
+				workspace, err := client.WorkspaceByOwnerAndName(ctx, owner, workspaceName, codersdk.WorkspaceOptions{})
+				if err != nil {
+					return xerrors.Errorf("get workspace: %w", err)
+				}
+				build = workspace.LatestBuild
+			} else {
+				build, err = client.WorkspaceBuildByUsernameAndWorkspaceNameAndBuildNumber(ctx, owner, workspaceName, strconv.FormatInt(buildNumber, 10))
+				if err != nil {
+					return xerrors.Errorf("get workspace build: %w", err)
+				}
+			}
+
+			lw := &workspaceLogWriter{
+				w:       inv.Stdout,
+				json:    outputFormat == "json",
+				verbose: r.verbose,
+			}
+			if since > 0 {
+				lw.cutoff = time.Now().Add(-since)
+			}
+
+			writeBuildLog := func(log codersdk.ProvisionerJobLog) error {
+				return lw.Write(workspaceLogLine{
+					CreatedAt: log.CreatedAt,
+					Source:    workspaceLogSourceBuild,
+					Stage:     log.Stage,
+					Level:     log.Level,
+					Output:    log.Output,
+				})
+			}
+			if follow {
+				buildLogs, closer, err := client.WorkspaceBuildLogsAfter(ctx, build.ID, 0)
+				if err != nil {
+					return xerrors.Errorf("get build logs: %w", err)
+				}
+				for log := range buildLogs {
+					err = writeBuildLog(log)
+					if err != nil {
+						_ = closer.Close()
+						return err
+					}
+				}
+				_ = closer.Close()
+
+				// Resources are only available once the build has completed,
+				// so the build is fetched again after its logs have ended.
+				build, err = client.WorkspaceBuild(ctx, build.ID)
+				if err != nil {
+					return xerrors.Errorf("get workspace build: %w", err)
+				}
+			} else {
+				buildLogs, err := client.WorkspaceBuildLogs(ctx, build.ID)
+				if err != nil {
+					return xerrors.Errorf("get build logs: %w", err)
+				}
+				for _, log := range buildLogs {
+					err = writeBuildLog(log)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			var agents []codersdk.WorkspaceAgent
+			for _, resource := range build.Resources {
+				for _, agent := range resource.Agents {
+					if agentName != "" && agent.Name != agentName {
+						continue
+					}
+					agents = append(agents, agent)
+				}
+			}
+			if agentName != "" && len(agents) == 0 {
+				return xerrors.Errorf("agent %q not found in build #%d of workspace %q", agentName, build.BuildNumber, inv.Args[0])
+			}
+
+			eg, egCtx := errgroup.WithContext(ctx)
+			for _, agent := range agents {
+				agent := agent
+				eg.Go(func() error {
+					agentLogs, closer, err := client.WorkspaceAgentLogsAfter(egCtx, agent.ID, 0, follow)
+					if err != nil {
+						return xerrors.Errorf("get logs for agent %q: %w", agent.Name, err)
+					}
+					defer closer.Close()
+					for {
+						select {
+						case <-egCtx.Done():
+							return nil
+						case logs, ok := <-agentLogs:
+							if !ok {
+								return nil
+							}
+							for _, log := range logs {
+								err = lw.Write(workspaceLogLine{
+									CreatedAt: log.CreatedAt,
+									Source:    workspaceLogSourceAgent,
+									Agent:     agent.Name,
+									Level:     log.Level,
+									Output:    log.Output,
+								})
+								if err != nil {
+									return err
+								}
+							}
+						}
+					}
+				})
+			}
+			return eg.Wait()
+		},
+	}
+	cmd.Options = clibase.OptionSet{
+		buildNumberOption(&buildNumber),
+		{
+			Flag:        "agent",
+			Description: "Only show logs from the agent with the given name.",
+			Value:       clibase.StringOf(&agentName),
+		},
+		{
+			Flag:          "follow",
+			FlagShorthand: "f",
+			Description:   "Keep streaming build and agent logs as they are written.",
+			Value:         clibase.BoolOf(&follow),
+		},
+		{
+			Flag:        "since",
+			Description: "Only show logs written within the given duration, e.g. 30m or 2h.",
+			Value:       clibase.DurationOf(&since),
+		},
+		{
+			Flag:          "output",
+			FlagShorthand: "o",
+			Description:   "Output format. JSON output writes one object per line.",
+			Default:       "text",
+			Value:         clibase.EnumOf(&outputFormat, "text", "json"),
+		},
+	}
+	return cmd
+}
+
+// workspaceLogWriter writes log lines from concurrent streams.
+type workspaceLogWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	json    bool
+	verbose bool
+	cutoff  time.Time
+}
+
+func (lw *workspaceLogWriter) Write(line workspaceLogLine) error {
+	if line.CreatedAt.Before(lw.cutoff) {
+		return nil
+	}
+
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	if lw.json {
+		return json.NewEncoder(lw.w).Encode(line)
+	}
+
+	render := func(s ...string) string { return strings.Join(s, " ") }
+	switch line.Level {
+	case codersdk.LogLevelTrace, codersdk.LogLevelDebug:
+		if !lw.verbose {
+			return nil
+		}
+		render = cliui.DefaultStyles.Placeholder.Render
+	case codersdk.LogLevelError:
+		render = cliui.DefaultStyles.Error.Render
+	case codersdk.LogLevelWarn:
+		render = cliui.DefaultStyles.Warn.Render
+	}
+
+	source := line.Source
+	if line.Agent != "" {
+		source += "/" + line.Agent
+	}
+	_, err := fmt.Fprintf(lw.w, "%s\n", render(
+		line.CreatedAt.Local().Format("2006-01-02 15:04:05.000Z07:00"),
+		"["+source+"]",
+		line.Output,
+	))
+	return err
+}
@@ -0,0 +1,136 @@
+package cli_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/cli/clitest"
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/codersdk/agentsdk"
+	"github.com/coder/coder/provisioner/echo"
+	"github.com/coder/coder/provisionersdk/proto"
+	"github.com/coder/coder/testutil"
+)
+
+func TestLogs(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*codersdk.Client, codersdk.Workspace, string) {
+		t.Helper()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		authToken := uuid.NewString()
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, &echo.Responses{
+			Parse:         echo.ParseComplete,
+			ProvisionPlan: echo.ProvisionComplete,
+			ProvisionApply: append([]*proto.Provision_Response{{
+				Type: &proto.Provision_Response_Log{
+					Log: &proto.Log{
+						Level:  proto.LogLevel_INFO,
+						Output: "build-output",
+					},
+				},
+			}}, echo.ProvisionApplyWithAgent(authToken)...),
+		})
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+		coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+		return client, workspace, authToken
+	}
+
+	t.Run("BuildAndAgent", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client, workspace, authToken := setup(t)
+
+		agentClient := agentsdk.New(client.URL)
+		agentClient.SetSessionToken(authToken)
+		err := agentClient.PatchLogs(ctx, agentsdk.PatchLogs{
+			Logs: []agentsdk.Log{{
+				CreatedAt: database.Now(),
+				Output:    "agent-output",
+				Level:     codersdk.LogLevelInfo,
+			}},
+		})
+		require.NoError(t, err)
+
+		inv, root := clitest.New(t, "logs", workspace.Name)
+		clitest.SetupConfig(t, client, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "[build] build-output")
+		require.Contains(t, out.String(), "[agent/example] agent-output")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client, workspace, _ := setup(t)
+
+		inv, root := clitest.New(t, "logs", workspace.Name, "-o", "json")
+		clitest.SetupConfig(t, client, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		var found bool
+		scanner := bufio.NewScanner(&out)
+		for scanner.Scan() {
+			var line map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			if line["output"] == "build-output" {
+				require.Equal(t, "build", line["source"])
+				found = true
+			}
+		}
+		require.True(t, found, "build log not found in JSON output")
+	})
+
+	t.Run("PendingBuild", func(t *testing.T) {
+		t.Parallel()
+
+		client, closer := coderdtest.NewWithProvisionerCloser(t, nil)
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		// Without a provisioner daemon, the build stays pending.
+		require.NoError(t, closer.Close())
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitMedium)
+		defer cancel()
+
+		inv, root := clitest.New(t, "logs", workspace.Name)
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &bytes.Buffer{}
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		// The build logs aren't followed until the build completes.
+		require.NoError(t, ctx.Err())
+	})
+
+	t.Run("AgentNotFound", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client, workspace, _ := setup(t)
+
+		inv, root := clitest.New(t, "logs", workspace.Name, "--agent", "nope")
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &bytes.Buffer{}
+		err := inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, `agent "nope" not found`)
+	})
+}
@@ -17,7 +17,7 @@ func TestNetcheck(t *testing.T) {
 	t.Parallel()
 
 	pty := ptytest.New(t)
-	config := login(t, pty)
+	config, _ := login(t, pty)
 
 	var out bytes.Buffer
 	inv, _ := clitest.New(t, "netcheck", "--global-config", string(config))
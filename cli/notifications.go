@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+func (r *RootCmd) notifications() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:   "notifications",
+		Short: "Manage your notifications",
+		Long: "Coder notifies you of events such as failed workspace builds in your inbox and, if configured, by email.\n" + formatExamples(
+			example{
+				Description: "List your unread notifications",
+				Command:     "coder notifications list --unread",
+			},
+			example{
+				Description: "Mark all notifications as read",
+				Command:     "coder notifications read --all",
+			},
+			example{
+				Description: "Stop emails about upcoming autostops",
+				Command:     "coder notifications preferences set workspace_autostop --email=false",
+			},
+		),
+		Aliases: []string{"notification"},
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.listNotifications(),
+			r.readNotifications(),
+			r.notificationPreferences(),
+		},
+	}
+	return cmd
+}
+
+type notificationListRow struct {
+	// For JSON format:
+	codersdk.Notification `table:"-"`
+
+	// For table format:
+	ID        string `json:"-" table:"id"`
+	Event     string `json:"-" table:"event"`
+	Title     string `json:"-" table:"title"`
+	CreatedAt string `json:"-" table:"created at,default_sort"`
+	Read      bool   `json:"-" table:"read"`
+}
+
+func (r *RootCmd) listNotifications() *clibase.Cmd {
+	var (
+		unread    bool
+		formatter = cliui.NewOutputFormatter(
+			cliui.TableFormat([]notificationListRow{}, nil),
+			cliui.JSONFormat(),
+		)
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List your notifications",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			notifications, err := client.Notifications(inv.Context(), codersdk.Me, codersdk.NotificationsRequest{
+				Unread: unread,
+			})
+			if err != nil {
+				return xerrors.Errorf("list notifications: %w", err)
+			}
+
+			if len(notifications) == 0 {
+				cliui.Infof(
+					inv.Stdout,
+					"No notifications found.\n",
+				)
+			}
+
+			rows := make([]notificationListRow, 0, len(notifications))
+			for _, notification := range notifications {
+				rows = append(rows, notificationListRow{
+					Notification: notification,
+					ID:           notification.ID.String(),
+					Event:        string(notification.Event),
+					Title:        notification.Title,
+					CreatedAt:    notification.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+					Read:         notification.ReadAt != nil,
+				})
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:        "unread",
+			Description: "Only list notifications that were not read yet.",
+			Value:       clibase.BoolOf(&unread),
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) readNotifications() *clibase.Cmd {
+	var all bool
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "read [id...]",
+		Short: "Mark notifications as read",
+		Middleware: clibase.Chain(
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			if all {
+				if len(inv.Args) > 0 {
+					return xerrors.New("notification IDs cannot be combined with --all")
+				}
+				err := client.MarkAllNotificationsRead(inv.Context(), codersdk.Me)
+				if err != nil {
+					return xerrors.Errorf("mark all notifications as read: %w", err)
+				}
+				cliui.Infof(inv.Stdout, "All notifications have been marked as read.")
+				return nil
+			}
+			if len(inv.Args) == 0 {
+				return xerrors.New("specify the IDs of the notifications to mark as read, or --all")
+			}
+
+			for _, arg := range inv.Args {
+				id, err := uuid.Parse(arg)
+				if err != nil {
+					return xerrors.Errorf("invalid notification ID %q: %w", arg, err)
+				}
+				_, err = client.UpdateNotification(inv.Context(), codersdk.Me, id, codersdk.UpdateNotificationRequest{Read: true})
+				if err != nil {
+					return xerrors.Errorf("mark notification %s as read: %w", id, err)
+				}
+			}
+			cliui.Infof(inv.Stdout, "Marked %d notification(s) as read.", len(inv.Args))
+			return nil
+		},
+	}
+
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:          "all",
+			FlagShorthand: "a",
+			Description:   "Mark all notifications as read.",
+			Value:         clibase.BoolOf(&all),
+		},
+	}
+	return cmd
+}
+
+type notificationPreferenceRow struct {
+	// For JSON format:
+	codersdk.NotificationPreference `table:"-"`
+
+	// For table format:
+	Event string `json:"-" table:"event,default_sort"`
+	Inbox bool   `json:"-" table:"inbox"`
+	Email bool   `json:"-" table:"email"`
+}
+
+func notificationPreferenceRows(preferences []codersdk.NotificationPreference) []notificationPreferenceRow {
+	rows := make([]notificationPreferenceRow, 0, len(preferences))
+	for _, preference := range preferences {
+		rows = append(rows, notificationPreferenceRow{
+			NotificationPreference: preference,
+			Event:                  string(preference.Event),
+			Inbox:                  preference.Inbox,
+			Email:                  preference.Email,
+		})
+	}
+	return rows
+}
+
+func (r *RootCmd) notificationPreferences() *clibase.Cmd {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]notificationPreferenceRow{}, nil),
+		cliui.JSONFormat(),
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "preferences",
+		Short: "Show how you are notified of each event",
+		Long:  "You are notified of events without a preference by email and in your inbox.",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			preferences, err := client.NotificationPreferences(inv.Context(), codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get notification preferences: %w", err)
+			}
+
+			out, err := formatter.Format(inv.Context(), notificationPreferenceRows(preferences))
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+		Children: []*clibase.Cmd{
+			r.setNotificationPreference(),
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) setNotificationPreference() *clibase.Cmd {
+	var email, inbox bool
+	events := make([]string, 0, len(codersdk.NotificationEvents))
+	for _, event := range codersdk.NotificationEvents {
+		events = append(events, string(event))
+	}
+
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "set <event>",
+		Short: "Change how you are notified of an event",
+		Long:  "The event is one of " + strings.Join(events, ", ") + ".",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			event := codersdk.NotificationEvent(inv.Args[0])
+			if !slices.Contains(codersdk.NotificationEvents, event) {
+				return xerrors.Errorf("unknown event %q, must be one of %s", event, strings.Join(events, ", "))
+			}
+			if !inv.ParsedFlags().Changed("email") && !inv.ParsedFlags().Changed("inbox") {
+				return xerrors.New("specify --email and/or --inbox")
+			}
+
+			preferences, err := client.NotificationPreferences(inv.Context(), codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get notification preferences: %w", err)
+			}
+			preference := codersdk.NotificationPreference{Event: event, Email: true, Inbox: true}
+			for _, p := range preferences {
+				if p.Event == event {
+					preference = p
+				}
+			}
+			if inv.ParsedFlags().Changed("email") {
+				preference.Email = email
+			}
+			if inv.ParsedFlags().Changed("inbox") {
+				preference.Inbox = inbox
+			}
+
+			_, err = client.UpdateNotificationPreferences(inv.Context(), codersdk.Me, codersdk.UpdateNotificationPreferencesRequest{
+				Preferences: []codersdk.NotificationPreference{preference},
+			})
+			if err != nil {
+				return xerrors.Errorf("update notification preferences: %w", err)
+			}
+			cliui.Infof(inv.Stdout, "Updated the notification preference for %s.", event)
+			return nil
+		},
+	}
+
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:        "email",
+			Description: "Whether to be notified of the event by email.",
+			Value:       clibase.BoolOf(&email),
+		},
+		{
+			Flag:        "inbox",
+			Description: "Whether to be notified of the event in your inbox.",
+			Value:       clibase.BoolOf(&inbox),
+		},
+	}
+	return cmd
+}
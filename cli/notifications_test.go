@@ -0,0 +1,106 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/cli/clitest"
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/testutil"
+)
+
+func TestNotifications(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ListAndRead", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		memberClient, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "notifications", "ls")
+		clitest.SetupConfig(t, memberClient, root)
+		buf := new(bytes.Buffer)
+		inv.Stdout = buf
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "No notifications found")
+
+		// Suspending the member notifies them.
+		_, err = client.UpdateUserStatus(ctx, member.ID.String(), codersdk.UserStatusSuspended)
+		require.NoError(t, err)
+		_, err = client.UpdateUserStatus(ctx, member.ID.String(), codersdk.UserStatusActive)
+		require.NoError(t, err)
+
+		inv, root = clitest.New(t, "notifications", "ls", "--unread", "--output=json")
+		clitest.SetupConfig(t, memberClient, root)
+		buf = new(bytes.Buffer)
+		inv.Stdout = buf
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		var notifications []codersdk.Notification
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &notifications))
+		require.Len(t, notifications, 1)
+		require.Equal(t, codersdk.NotificationEventUserSuspended, notifications[0].Event)
+
+		inv, root = clitest.New(t, "notifications", "read", notifications[0].ID.String())
+		clitest.SetupConfig(t, memberClient, root)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		unread, err := memberClient.Notifications(ctx, codersdk.Me, codersdk.NotificationsRequest{Unread: true})
+		require.NoError(t, err)
+		require.Empty(t, unread)
+
+		inv, root = clitest.New(t, "notifications", "read")
+		clitest.SetupConfig(t, memberClient, root)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "--all")
+
+		inv, root = clitest.New(t, "notifications", "read", "--all")
+		clitest.SetupConfig(t, memberClient, root)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+	})
+
+	t.Run("Preferences", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "notifications", "preferences", "set", "workspace_autostop", "--email=false")
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		inv, root = clitest.New(t, "notifications", "preferences", "--output=json")
+		clitest.SetupConfig(t, client, root)
+		buf := new(bytes.Buffer)
+		inv.Stdout = buf
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		var preferences []codersdk.NotificationPreference
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &preferences))
+		require.Len(t, preferences, len(codersdk.NotificationEvents))
+		for _, preference := range preferences {
+			require.Equal(t, preference.Event != codersdk.NotificationEventWorkspaceAutostop, preference.Email, preference.Event)
+			require.True(t, preference.Inbox, preference.Event)
+		}
+
+		inv, root = clitest.New(t, "notifications", "preferences", "set", "unknown", "--email=false")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "unknown event")
+
+		inv, root = clitest.New(t, "notifications", "preferences", "set", "user_suspended")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "--email")
+	})
+}
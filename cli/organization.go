@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+func (r *RootCmd) organizations() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:     "organizations [subcommand]",
+		Short:   "Manage organizations",
+		Aliases: []string{"organization", "org", "orgs"},
+		Long: "Commands that operate on a single organization use the organization " +
+			"selected with the global --org flag.\n\n" + formatExamples(
+			example{
+				Description: "List the organizations you are a member of",
+				Command:     "coder organizations list",
+			},
+			example{
+				Description: "Add a user to a specific organization",
+				Command:     "coder organizations members add alice --org my-org",
+			},
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.organizationList(),
+			r.organizationShow(),
+			r.organizationCreate(),
+			r.organizationMembers(),
+		},
+	}
+	return cmd
+}
+
+type organizationRow struct {
+	// For json format:
+	Organization codersdk.Organization `table:"-"`
+
+	// For table format:
+	Name      string    `json:"-" table:"name,default_sort"`
+	ID        uuid.UUID `json:"-" table:"id"`
+	CreatedAt time.Time `json:"-" table:"created at"`
+	Current   string    `json:"-" table:"current"`
+}
+
+// organizationsToRows converts a list of organizations to a list of rows for
+// outputting, marking the organization with the given ID as current.
+func organizationsToRows(currentID uuid.UUID, organizations ...codersdk.Organization) []organizationRow {
+	rows := make([]organizationRow, len(organizations))
+	for i, organization := range organizations {
+		current := ""
+		if organization.ID == currentID {
+			current = cliui.DefaultStyles.Keyword.Render("*")
+		}
+		rows[i] = organizationRow{
+			Organization: organization,
+			Name:         organization.Name,
+			ID:           organization.ID,
+			CreatedAt:    organization.CreatedAt,
+			Current:      current,
+		}
+	}
+	return rows
+}
+
+func (r *RootCmd) organizationList() *clibase.Cmd {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]organizationRow{}, []string{"name", "id", "current"}),
+		cliui.JSONFormat(),
+	)
+	client := new(codersdk.Client)
+
+	cmd := &clibase.Cmd{
+		Use:     "list",
+		Short:   "List the organizations you are a member of",
+		Aliases: []string{"ls"},
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			current, err := CurrentOrganization(inv, client)
+			if err != nil {
+				return xerrors.Errorf("get current organization: %w", err)
+			}
+			organizations, err := client.OrganizationsByUser(inv.Context(), codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get organizations: %w", err)
+			}
+
+			out, err := formatter.Format(inv.Context(), organizationsToRows(current.ID, organizations...))
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) organizationShow() *clibase.Cmd {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]organizationRow{}, []string{"name", "id", "created at"}),
+		cliui.JSONFormat(),
+	)
+	client := new(codersdk.Client)
+
+	cmd := &clibase.Cmd{
+		Use:   "show",
+		Short: "Show the selected organization",
+		Long: formatExamples(
+			example{
+				Command: "coder organizations show --org my-org",
+			},
+		),
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			organization, err := CurrentOrganization(inv, client)
+			if err != nil {
+				return xerrors.Errorf("get current organization: %w", err)
+			}
+
+			out, err := formatter.Format(inv.Context(), organizationsToRows(uuid.Nil, organization))
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) organizationCreate() *clibase.Cmd {
+	client := new(codersdk.Client)
+
+	cmd := &clibase.Cmd{
+		Use:   "create <name>",
+		Short: "Create an organization",
+		Long:  "You are added to the new organization as an organization admin.",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			organization, err := client.CreateOrganization(inv.Context(), codersdk.CreateOrganizationRequest{
+				Name: inv.Args[0],
+			})
+			if err != nil {
+				return xerrors.Errorf("create organization: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Created organization %s (%s)!\n",
+				cliui.DefaultStyles.Keyword.Render(organization.Name),
+				cliui.DefaultStyles.Placeholder.Render(organization.ID.String()),
+			)
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,132 @@
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/cli/clitest"
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/coderd/rbac"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/testutil"
+)
+
+func TestOrganizations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ListAndSelect", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		first, err := client.Organization(ctx, owner.OrganizationID)
+		require.NoError(t, err)
+		org, err := client.CreateOrganization(ctx, codersdk.CreateOrganizationRequest{
+			Name: "another",
+		})
+		require.NoError(t, err)
+
+		inv, root := clitest.New(t, "organizations", "list", "--org", "another", "-o", "table", "-c", "name,current")
+		clitest.SetupConfig(t, client, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), first.Name)
+		require.Regexp(t, `another\s+\*`, out.String())
+
+		inv, root = clitest.New(t, "organizations", "show", "--org", org.ID.String())
+		clitest.SetupConfig(t, client, root)
+		out.Reset()
+		inv.Stdout = &out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "another")
+	})
+
+	t.Run("SelectNotFound", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		coderdtest.CreateFirstUser(t, client)
+
+		inv, root := clitest.New(t, "templates", "list", "--org", "nope")
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, `organization "nope" not found`)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		coderdtest.CreateFirstUser(t, client)
+
+		inv, root := clitest.New(t, "organizations", "create", "new-org")
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &bytes.Buffer{}
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		_, err = client.OrganizationByName(ctx, codersdk.Me, "new-org")
+		require.NoError(t, err)
+	})
+
+	t.Run("Members", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		_, user := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		org, err := client.CreateOrganization(ctx, codersdk.CreateOrganizationRequest{
+			Name: "another",
+		})
+		require.NoError(t, err)
+
+		inv, root := clitest.New(t, "organizations", "members", "add", user.Username, "--org", "another")
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &bytes.Buffer{}
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		inv, root = clitest.New(t, "organizations", "members", "edit-roles", user.Username, "organization-admin", "--org", "another")
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &bytes.Buffer{}
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		members, err := client.OrganizationMembers(ctx, org.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		for _, member := range members {
+			if member.UserID != user.ID {
+				continue
+			}
+			roles := make([]string, 0, len(member.Roles))
+			for _, role := range member.Roles {
+				roles = append(roles, role.Name)
+			}
+			require.ElementsMatch(t, []string{rbac.RoleOrgMember(org.ID), rbac.RoleOrgAdmin(org.ID)}, roles)
+		}
+
+		inv, root = clitest.New(t, "organizations", "members", "list", "--org", "another")
+		clitest.SetupConfig(t, client, root)
+		var out bytes.Buffer
+		inv.Stdout = &out
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), user.Username)
+
+		inv, root = clitest.New(t, "organizations", "members", "remove", user.Username, "--org", "another", "--yes")
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &bytes.Buffer{}
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		members, err = client.OrganizationMembers(ctx, org.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+	})
+}
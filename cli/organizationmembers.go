@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/coderd/rbac"
+	"github.com/coder/coder/codersdk"
+)
+
+func (r *RootCmd) organizationMembers() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:     "members",
+		Short:   "Manage the members of an organization",
+		Aliases: []string{"member"},
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.organizationMembersList(),
+			r.organizationMemberAdd(),
+			r.organizationMemberRemove(),
+			r.organizationMemberEditRoles(),
+		},
+	}
+	return cmd
+}
+
+type organizationMemberRow struct {
+	// For json format:
+	Member codersdk.OrganizationMemberWithUserData `table:"-"`
+
+	// For table format:
+	Username  string    `json:"-" table:"username,default_sort"`
+	Email     string    `json:"-" table:"email"`
+	UserID    uuid.UUID `json:"-" table:"user id"`
+	Roles     string    `json:"-" table:"roles"`
+	CreatedAt time.Time `json:"-" table:"created at"`
+}
+
+func organizationMembersToRows(members ...codersdk.OrganizationMemberWithUserData) []organizationMemberRow {
+	rows := make([]organizationMemberRow, len(members))
+	for i, member := range members {
+		roles := make([]string, 0, len(member.Roles))
+		for _, role := range member.Roles {
+			name := role.DisplayName
+			if name == "" {
+				name = role.Name
+			}
+			roles = append(roles, name)
+		}
+		rows[i] = organizationMemberRow{
+			Member:    member,
+			Username:  member.Username,
+			Email:     member.Email,
+			UserID:    member.UserID,
+			Roles:     strings.Join(roles, ", "),
+			CreatedAt: member.CreatedAt,
+		}
+	}
+	return rows
+}
+
+func (r *RootCmd) organizationMembersList() *clibase.Cmd {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]organizationMemberRow{}, []string{"username", "email", "roles"}),
+		cliui.JSONFormat(),
+	)
+	client := new(codersdk.Client)
+
+	cmd := &clibase.Cmd{
+		Use:     "list",
+		Short:   "List the members of the selected organization",
+		Aliases: []string{"ls"},
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			organization, err := CurrentOrganization(inv, client)
+			if err != nil {
+				return xerrors.Errorf("get current organization: %w", err)
+			}
+			members, err := client.OrganizationMembers(inv.Context(), organization.ID)
+			if err != nil {
+				return xerrors.Errorf("get organization members: %w", err)
+			}
+
+			out, err := formatter.Format(inv.Context(), organizationMembersToRows(members...))
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) organizationMemberAdd() *clibase.Cmd {
+	client := new(codersdk.Client)
+
+	cmd := &clibase.Cmd{
+		Use:   "add <username|user_id>",
+		Short: "Add a user to the selected organization",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			organization, err := CurrentOrganization(inv, client)
+			if err != nil {
+				return xerrors.Errorf("get current organization: %w", err)
+			}
+			_, err = client.PostOrganizationMember(inv.Context(), organization.ID, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("add organization member: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Added %s to organization %s!\n",
+				cliui.DefaultStyles.Keyword.Render(inv.Args[0]),
+				cliui.DefaultStyles.Keyword.Render(organization.Name),
+			)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) organizationMemberRemove() *clibase.Cmd {
+	client := new(codersdk.Client)
+
+	cmd := &clibase.Cmd{
+		Use:   "remove <username|user_id>",
+		Short: "Remove a user from the selected organization",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Options: clibase.OptionSet{
+			cliui.SkipPromptOption(),
+		},
+		Handler: func(inv *clibase.Invocation) error {
+			organization, err := CurrentOrganization(inv, client)
+			if err != nil {
+				return xerrors.Errorf("get current organization: %w", err)
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Remove %s from organization %s?", cliui.DefaultStyles.Code.Render(inv.Args[0]), cliui.DefaultStyles.Code.Render(organization.Name)),
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = client.DeleteOrganizationMember(inv.Context(), organization.ID, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("remove organization member: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Removed %s from organization %s!\n",
+				cliui.DefaultStyles.Keyword.Render(inv.Args[0]),
+				cliui.DefaultStyles.Keyword.Render(organization.Name),
+			)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) organizationMemberEditRoles() *clibase.Cmd {
+	client := new(codersdk.Client)
+
+	cmd := &clibase.Cmd{
+		Use:   "edit-roles <username|user_id> [roles...]",
+		Short: "Replace the organization roles of a member",
+		Long: "Roles can be given with or without the organization ID suffix. Every member " +
+			"keeps the organization-member role. Passing no roles removes all other roles.\n\n" + formatExamples(
+			example{
+				Description: "Make a member an organization admin",
+				Command:     "coder organizations members edit-roles alice organization-admin",
+			},
+		),
+		Middleware: clibase.Chain(
+			clibase.RequireRangeArgs(1, -1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			organization, err := CurrentOrganization(inv, client)
+			if err != nil {
+				return xerrors.Errorf("get current organization: %w", err)
+			}
+
+			roles := []string{rbac.RoleOrgMember(organization.ID)}
+			for _, role := range inv.Args[1:] {
+				if _, ok := rbac.IsOrgRole(role); !ok {
+					role = role + ":" + organization.ID.String()
+				}
+				roles = append(roles, role)
+			}
+
+			member, err := client.UpdateOrganizationMemberRoles(inv.Context(), organization.ID, inv.Args[0], codersdk.UpdateRoles{
+				Roles: roles,
+			})
+			if err != nil {
+				return xerrors.Errorf("update organization member roles: %w", err)
+			}
+
+			names := make([]string, 0, len(member.Roles))
+			for _, role := range member.Roles {
+				names = append(names, role.Name)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Updated roles of %s in organization %s: %s\n",
+				cliui.DefaultStyles.Keyword.Render(inv.Args[0]),
+				cliui.DefaultStyles.Keyword.Render(organization.Name),
+				strings.Join(names, ", "),
+			)
+			return nil
+		},
+	}
+	return cmd
+}
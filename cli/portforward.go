@@ -26,8 +26,11 @@ import (
 
 func (r *RootCmd) portForward() *clibase.Cmd {
 	var (
-		tcpForwards []string // <port>:<port>
-		udpForwards []string // <port>:<port>
+		tcpForwards   []string // <port>:<port>
+		udpForwards   []string // <port>:<port>
+		socks5Address string   // <ip>:<port>
+		httpAddress   string   // <ip>:<port>
+		auto          bool
 	)
 	client := new(codersdk.Client)
 	cmd := &clibase.Cmd{
@@ -55,6 +58,14 @@ func (r *RootCmd) portForward() *clibase.Cmd {
 				Description: "Port forward specifying the local address to bind to",
 				Command:     "coder port-forward <workspace> --tcp 1.2.3.4:8080:8080",
 			},
+			example{
+				Description: "Reach any address from the workspace through a local SOCKS5 proxy",
+				Command:     "coder port-forward <workspace> --socks5 127.0.0.1:1080",
+			},
+			example{
+				Description: "Forward every port that starts listening in the workspace to the same local port",
+				Command:     "coder port-forward <workspace> --auto",
+			},
 		),
 		Middleware: clibase.Chain(
 			clibase.RequireNArgs(1),
@@ -68,7 +79,7 @@ func (r *RootCmd) portForward() *clibase.Cmd {
 			if err != nil {
 				return xerrors.Errorf("parse port-forward specs: %w", err)
 			}
-			if len(specs) == 0 {
+			if len(specs) == 0 && socks5Address == "" && httpAddress == "" && !auto {
 				err = inv.Command.HelpHandler(inv)
 				if err != nil {
 					return xerrors.Errorf("generate help output: %w", err)
@@ -138,6 +149,23 @@ func (r *RootCmd) portForward() *clibase.Cmd {
 				listeners[i] = l
 			}
 
+			// Proxies dial the requested address from the workspace.
+			dial := workspaceProxyDialer(conn)
+			if socks5Address != "" {
+				l, err := listenAndProxySOCKS5(ctx, inv, wg, socks5Address, dial)
+				if err != nil {
+					return err
+				}
+				listeners = append(listeners, l)
+			}
+			if httpAddress != "" {
+				l, err := listenAndProxyHTTP(inv, wg, httpAddress, dial)
+				if err != nil {
+					return err
+				}
+				listeners = append(listeners, l)
+			}
+
 			// Wait for the context to be canceled or for a signal and close
 			// all listeners.
 			var closeErr error
@@ -161,6 +189,14 @@ func (r *RootCmd) portForward() *clibase.Cmd {
 
 			conn.AwaitReachable(ctx)
 			_, _ = fmt.Fprintln(inv.Stderr, "Ready!")
+			if auto {
+				forwarder := newAutoPortForwarder(ctx, inv, conn, wg, specs)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					forwarder.run(ctx, inv)
+				}()
+			}
 			wg.Wait()
 			return closeErr
 		},
@@ -180,6 +216,24 @@ func (r *RootCmd) portForward() *clibase.Cmd {
 			Description: "Forward UDP port(s) from the workspace to the local machine. The UDP connection has TCP-like semantics to support stateful UDP protocols.",
 			Value:       clibase.StringArrayOf(&udpForwards),
 		},
+		{
+			Flag:        "socks5",
+			Env:         "CODER_PORT_FORWARD_SOCKS5",
+			Description: "Serve a SOCKS5 proxy on the given local address that connects to any host and port from within the workspace.",
+			Value:       clibase.StringOf(&socks5Address),
+		},
+		{
+			Flag:        "http-proxy",
+			Env:         "CODER_PORT_FORWARD_HTTP_PROXY",
+			Description: "Serve an HTTP proxy, supporting CONNECT, on the given local address that connects to any host and port from within the workspace.",
+			Value:       clibase.StringOf(&httpAddress),
+		},
+		{
+			Flag:        "auto",
+			Env:         "CODER_PORT_FORWARD_AUTO",
+			Description: "Forward every TCP port listening in the workspace to the same port on the local machine, following ports as servers start and stop. Ports ignored by the agent are not forwarded.",
+			Value:       clibase.BoolOf(&auto),
+		},
 	}
 
 	return cmd
@@ -219,6 +273,13 @@ func listenAndPortForward(ctx context.Context, inv *clibase.Invocation, conn *co
 		return nil, xerrors.Errorf("listen '%v://%v': %w", spec.listenNetwork, spec.listenAddress, err)
 	}
 
+	forwardListener(ctx, inv, conn, wg, l, spec)
+	return l, nil
+}
+
+// forwardListener accepts connections on l and forwards them to the
+// workspace until l is closed.
+func forwardListener(ctx context.Context, inv *clibase.Invocation, conn *codersdk.WorkspaceAgentConn, wg *sync.WaitGroup, l net.Listener, spec portForwardSpec) {
 	wg.Add(1)
 	go func(spec portForwardSpec) {
 		defer wg.Done()
@@ -247,8 +308,6 @@ func listenAndPortForward(ctx context.Context, inv *clibase.Invocation, conn *co
 			}(netConn)
 		}
 	}(spec)
-
-	return l, nil
 }
 
 type portForwardSpec struct {
@@ -1,11 +1,27 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net"
+	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/agent/agentssh"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/codersdk/agentsdk"
+	"github.com/coder/coder/testutil"
 )
 
 func Test_parsePortForwards(t *testing.T) {
@@ -88,3 +104,153 @@ func Test_parsePortForwards(t *testing.T) {
 		})
 	}
 }
+
+func Test_autoPortForwarder(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx       = context.Background()
+		ports     []codersdk.WorkspaceAgentListeningPort
+		listening = map[string]net.Listener{}
+	)
+	forwarder := &autoPortForwarder{
+		listPorts: func(context.Context) ([]codersdk.WorkspaceAgentListeningPort, error) {
+			return ports, nil
+		},
+		listen: func(spec portForwardSpec) (net.Listener, error) {
+			require.Equal(t, spec.listenAddress, spec.dialAddress)
+			if spec.listenAddress == "127.0.0.1:9000" {
+				return nil, xerrors.New("address already in use")
+			}
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			listening[spec.listenAddress] = l
+			return l, nil
+		},
+		skip:     map[uint16]struct{}{8081: {}},
+		forwards: map[uint16]*autoPortForward{},
+	}
+	t.Cleanup(forwarder.close)
+
+	ports = []codersdk.WorkspaceAgentListeningPort{
+		{Network: "tcp", Port: 8080, ProcessName: "node"},
+		{Network: "tcp", Port: 8081},
+		{Network: "tcp", Port: 9000},
+	}
+	changed, err := forwarder.sync(ctx)
+	require.NoError(t, err)
+	require.True(t, changed)
+	rows := forwarder.rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, uint16(8080), rows[0].Port)
+	require.Equal(t, "node", rows[0].Process)
+	require.Equal(t, "forwarding", rows[0].Status)
+	require.Equal(t, uint16(9000), rows[1].Port)
+	require.Equal(t, "address already in use", rows[1].Status)
+
+	// Nothing changed, failed ports aren't retried.
+	changed, err = forwarder.sync(ctx)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// The server on 8080 stopped.
+	ports = ports[1:]
+	changed, err = forwarder.sync(ctx)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Len(t, forwarder.rows(), 1)
+	_, err = listening["127.0.0.1:8080"].Accept()
+	require.ErrorIs(t, err, net.ErrClosed)
+}
+
+func Test_sshProxyDialer(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows can't connect to the unspecified address")
+	}
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	server, err := agentssh.NewServer(ctx, slogtest.Make(t, nil), prometheus.NewRegistry(), afero.NewMemMapFs(), 0, "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+	server.AgentToken = func() string { return "" }
+	server.Manifest = atomic.NewPointer(&agentsdk.Manifest{})
+	sshListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve(sshListener)
+	}()
+
+	// The destination echoes what it receives.
+	destination, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = destination.Close()
+	})
+	go func() {
+		for {
+			conn, err := destination.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	// Loopback addresses are dialed on the agent directly, but the
+	// unspecified address also reaches the destination through SSH.
+	_, port, err := net.SplitHostPort(destination.Addr().String())
+	require.NoError(t, err)
+	addr := net.JoinHostPort("0.0.0.0", port)
+
+	var clients []*gossh.Client
+	dial := sshProxyDialer(func(context.Context, string, string) (net.Conn, error) {
+		return nil, xerrors.New("unexpected dial to the agent")
+	}, func(ctx context.Context) (*gossh.Client, error) {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", sshListener.Addr().String())
+		if err != nil {
+			return nil, err
+		}
+		sshConn, channels, requests, err := gossh.NewClientConn(conn, "localhost:22", &gossh.ClientConfig{
+			HostKeyCallback: gossh.InsecureIgnoreHostKey(), //nolint:gosec // This is a test.
+		})
+		if err != nil {
+			return nil, err
+		}
+		client := gossh.NewClient(sshConn, channels, requests)
+		clients = append(clients, client)
+		return client, nil
+	})
+	t.Cleanup(func() {
+		for _, client := range clients {
+			_ = client.Close()
+		}
+	})
+
+	echo := func() {
+		conn, err := dial(ctx, addr)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.Write([]byte("hello"))
+		require.NoError(t, err)
+		b := make([]byte, 5)
+		_, err = io.ReadFull(conn, b)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(b))
+	}
+
+	echo()
+	echo()
+	require.Len(t, clients, 1, "the SSH client is reused")
+
+	// Once the SSH client is closed, e.g. because the agent restarted, the
+	// next dial connects again.
+	require.NoError(t, clients[0].Close())
+	echo()
+	require.Len(t, clients, 2)
+}
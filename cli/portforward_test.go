@@ -1,10 +1,12 @@
 package cli_test
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"sync"
 	"testing"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/pion/udp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/proxy"
 
 	"github.com/coder/coder/cli/clitest"
 	"github.com/coder/coder/coderd/coderdtest"
@@ -288,6 +291,81 @@ func TestPortForward(t *testing.T) {
 	})
 }
 
+//nolint:paralleltest // Subtests share the running port-forward.
+func TestPortForward_Proxy(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+	user := coderdtest.CreateFirstUser(t, client)
+	workspace := runAgent(t, client, user.UserID)
+
+	remotePort := setupTestListener(t, func() net.Listener {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err, "create TCP listener")
+		return l
+	}())
+	remoteAddress := net.JoinHostPort("127.0.0.1", remotePort)
+
+	freeAddress := func(t *testing.T) string {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err, "create TCP listener to generate random port")
+		defer l.Close()
+		return l.Addr().String()
+	}
+	socks5Address := freeAddress(t)
+	httpAddress := freeAddress(t)
+
+	inv, root := clitest.New(t, "-v", "port-forward", workspace.Name, "--socks5", socks5Address, "--http-proxy", httpAddress)
+	clitest.SetupConfig(t, client, root)
+	pty := ptytest.New(t).Attach(inv)
+	inv.Stderr = pty.Output()
+	ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+	defer cancel()
+	errC := make(chan error)
+	go func() {
+		errC <- inv.WithContext(ctx).Run()
+	}()
+	pty.ExpectMatchContext(ctx, "Ready!")
+
+	t.Run("SOCKS5", func(t *testing.T) {
+		dialer, err := proxy.SOCKS5("tcp", socks5Address, nil, &net.Dialer{Timeout: testutil.WaitShort})
+		require.NoError(t, err)
+		c, err := dialer.(proxy.ContextDialer).DialContext(ctx, "tcp", remoteAddress)
+		require.NoError(t, err, "dial through SOCKS5 proxy")
+		defer c.Close()
+		testDial(t, c)
+	})
+
+	t.Run("HTTPConnect", func(t *testing.T) {
+		d := net.Dialer{Timeout: testutil.WaitShort}
+		c, err := d.DialContext(ctx, "tcp", httpAddress)
+		require.NoError(t, err, "open connection to HTTP proxy")
+		defer c.Close()
+
+		_, err = fmt.Fprintf(c, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", remoteAddress, remoteAddress)
+		require.NoError(t, err)
+		br := bufio.NewReader(c)
+		res, err := http.ReadResponse(br, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		testDial(t, &bufferedConn{Conn: c, r: br})
+	})
+
+	cancel()
+	err := <-errC
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// bufferedConn reads from r, which may hold data read ahead from Conn.
+type bufferedConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
 // runAgent creates a fake workspace and starts an agent locally for that
 // workspace. The agent will be cleaned up on test completion.
 // nolint:unused
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+// autoPortForwardInterval is how often the listening ports of the workspace
+// are polled with --auto. The agent caches the list for a second.
+const autoPortForwardInterval = 2 * time.Second
+
+// autoPortForwardRow is a row of the live table printed with --auto.
+type autoPortForwardRow struct {
+	Port         uint16 `table:"port,default_sort"`
+	Process      string `table:"process"`
+	LocalAddress string `table:"local address"`
+	Status       string `table:"status"`
+}
+
+type autoPortForward struct {
+	port     codersdk.WorkspaceAgentListeningPort
+	listener net.Listener
+	err      error
+}
+
+// autoPortForwarder forwards every TCP port that is listening in the
+// workspace to the same port on the local machine. Ports the agent was told
+// to ignore are never reported by it, so they aren't forwarded either.
+type autoPortForwarder struct {
+	// listPorts returns the ports listening in the workspace.
+	listPorts func(ctx context.Context) ([]codersdk.WorkspaceAgentListeningPort, error)
+	// listen starts forwarding a local address to the workspace.
+	listen func(spec portForwardSpec) (net.Listener, error)
+	// skip contains remote ports that are already forwarded explicitly.
+	skip map[uint16]struct{}
+
+	mu       sync.Mutex
+	forwards map[uint16]*autoPortForward
+}
+
+func newAutoPortForwarder(ctx context.Context, inv *clibase.Invocation, conn *codersdk.WorkspaceAgentConn, wg *sync.WaitGroup, specs []portForwardSpec) *autoPortForwarder {
+	skip := map[uint16]struct{}{}
+	for _, spec := range specs {
+		if spec.dialNetwork != "tcp" {
+			continue
+		}
+		_, port, err := net.SplitHostPort(spec.dialAddress)
+		if err != nil {
+			continue
+		}
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			continue
+		}
+		skip[uint16(p)] = struct{}{}
+	}
+
+	return &autoPortForwarder{
+		listPorts: func(ctx context.Context) ([]codersdk.WorkspaceAgentListeningPort, error) {
+			res, err := conn.ListeningPorts(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return res.Ports, nil
+		},
+		listen: func(spec portForwardSpec) (net.Listener, error) {
+			l, err := net.Listen(spec.listenNetwork, spec.listenAddress)
+			if err != nil {
+				return nil, err
+			}
+			forwardListener(ctx, inv, conn, wg, l, spec)
+			return l, nil
+		},
+		skip:     skip,
+		forwards: map[uint16]*autoPortForward{},
+	}
+}
+
+// sync starts forwarding ports that appeared in the workspace and stops
+// forwarding ports that went away. It reports whether anything changed.
+func (a *autoPortForwarder) sync(ctx context.Context) (bool, error) {
+	ports, err := a.listPorts(ctx)
+	if err != nil {
+		return false, xerrors.Errorf("list listening ports: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	changed := false
+	seen := make(map[uint16]struct{}, len(ports))
+	for _, port := range ports {
+		if port.Network != "tcp" || port.Port < codersdk.WorkspaceAgentMinimumListeningPort {
+			continue
+		}
+		if _, ok := a.skip[port.Port]; ok {
+			continue
+		}
+		seen[port.Port] = struct{}{}
+		if _, ok := a.forwards[port.Port]; ok {
+			continue
+		}
+
+		address := net.JoinHostPort("127.0.0.1", strconv.Itoa(int(port.Port)))
+		forward := &autoPortForward{port: port}
+		// A failed listen isn't retried until the port goes away, so
+		// a local service using the same port doesn't cause a loop.
+		forward.listener, forward.err = a.listen(portForwardSpec{
+			listenNetwork: "tcp",
+			listenAddress: address,
+			dialNetwork:   "tcp",
+			dialAddress:   address,
+		})
+		a.forwards[port.Port] = forward
+		changed = true
+	}
+
+	for port, forward := range a.forwards {
+		if _, ok := seen[port]; ok {
+			continue
+		}
+		if forward.listener != nil {
+			_ = forward.listener.Close()
+		}
+		delete(a.forwards, port)
+		changed = true
+	}
+	return changed, nil
+}
+
+// close stops all automatic forwards.
+func (a *autoPortForwarder) close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for port, forward := range a.forwards {
+		if forward.listener != nil {
+			_ = forward.listener.Close()
+		}
+		delete(a.forwards, port)
+	}
+}
+
+func (a *autoPortForwarder) rows() []autoPortForwardRow {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	rows := make([]autoPortForwardRow, 0, len(a.forwards))
+	for _, forward := range a.forwards {
+		row := autoPortForwardRow{
+			Port:    forward.port.Port,
+			Process: forward.port.ProcessName,
+			Status:  "forwarding",
+		}
+		if forward.listener != nil {
+			row.LocalAddress = forward.listener.Addr().String()
+		}
+		if forward.err != nil {
+			row.Status = forward.err.Error()
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Port < rows[j].Port
+	})
+	return rows
+}
+
+// run polls the workspace until ctx is canceled and prints the active
+// forwards whenever they change.
+func (a *autoPortForwarder) run(ctx context.Context, inv *clibase.Invocation) {
+	defer a.close()
+
+	render := func() {
+		rows := a.rows()
+		if isTTYOut(inv) {
+			// Move the cursor home and clear the screen to redraw in place.
+			_, _ = fmt.Fprint(inv.Stdout, "\033[H\033[2J")
+			_, _ = fmt.Fprintln(inv.Stdout, cliui.DefaultStyles.Placeholder.Render(
+				fmt.Sprintf("Forwarding listening workspace ports, press Ctrl+C to stop. Last update: %s", time.Now().Format(time.Kitchen)),
+			))
+		}
+		if len(rows) == 0 {
+			_, _ = fmt.Fprintln(inv.Stdout, "Waiting for ports to listen in the workspace...")
+			return
+		}
+		out, err := cliui.DisplayTable(rows, "", nil)
+		if err != nil {
+			_, _ = fmt.Fprintf(inv.Stderr, "Render port-forwards: %s\n", err)
+			return
+		}
+		_, _ = fmt.Fprintln(inv.Stdout, out)
+	}
+
+	first := true
+	ticker := time.NewTicker(autoPortForwardInterval)
+	defer ticker.Stop()
+	for {
+		changed, err := a.sync(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// The agent may be restarting, keep the current forwards
+			// and try again.
+			_, _ = fmt.Fprintf(inv.Stderr, "Failed to refresh listening ports: %s\n", err)
+		}
+		if changed || first {
+			render()
+			first = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
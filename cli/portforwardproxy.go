@@ -0,0 +1,339 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"sync"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/agent/agentssh"
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/codersdk"
+)
+
+// proxyDialer dials a destination from within the workspace.
+type proxyDialer func(ctx context.Context, addr string) (net.Conn, error)
+
+// workspaceProxyDialer returns a dialer that connects to services on the
+// agent itself over tailnet, and to any other host through the SSH server of
+// the agent, the same way "ssh -L" does.
+func workspaceProxyDialer(conn *codersdk.WorkspaceAgentConn) proxyDialer {
+	return sshProxyDialer(conn.DialContext, conn.SSHClient)
+}
+
+// sshProxyDialer returns a dialer that uses dialAgent for loopback addresses
+// and forwards all others over an SSH client from newSSHClient. The SSH client
+// is shared between dials, and replaced once it stops working, e.g. after the
+// agent restarted.
+func sshProxyDialer(
+	dialAgent func(ctx context.Context, network, addr string) (net.Conn, error),
+	newSSHClient func(ctx context.Context) (*gossh.Client, error),
+) proxyDialer {
+	var (
+		mu        sync.Mutex
+		sshClient *gossh.Client
+	)
+	getSSHClient := func(ctx context.Context) (*gossh.Client, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if sshClient == nil {
+			client, err := newSSHClient(ctx)
+			if err != nil {
+				return nil, xerrors.Errorf("ssh client: %w", err)
+			}
+			sshClient = client
+		}
+		return sshClient, nil
+	}
+	dropSSHClient := func(client *gossh.Client) {
+		mu.Lock()
+		if sshClient == client {
+			sshClient = nil
+		}
+		mu.Unlock()
+		_ = client.Close()
+	}
+
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, xerrors.Errorf("split %q: %w", addr, err)
+		}
+		if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return dialAgent(ctx, "tcp", addr)
+		}
+
+		for attempt := 0; ; attempt++ {
+			client, err := getSSHClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+			netConn, err := client.Dial("tcp", addr)
+			if err == nil {
+				return netConn, nil
+			}
+			// The agent refusing to connect to the address doesn't mean the
+			// SSH client is broken.
+			var openErr *gossh.OpenChannelError
+			if xerrors.As(err, &openErr) {
+				return nil, err
+			}
+			dropSSHClient(client)
+			if attempt > 0 {
+				return nil, err
+			}
+		}
+	}
+}
+
+// listenAndProxySOCKS5 accepts SOCKS5 proxy clients on address until the listener
+// is closed.
+func listenAndProxySOCKS5(ctx context.Context, inv *clibase.Invocation, wg *sync.WaitGroup, address string, dial proxyDialer) (net.Listener, error) {
+	const name = "SOCKS5"
+	serve := serveSOCKS5(dial)
+	_, _ = fmt.Fprintf(inv.Stderr, "Serving a %s proxy on '%s' to reach any address from the workspace\n", name, address)
+
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, xerrors.Errorf("listen '%s': %w", address, err)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			netConn, err := l.Accept()
+			if err != nil {
+				// Silently ignore net.ErrClosed errors.
+				if xerrors.Is(err, net.ErrClosed) {
+					return
+				}
+				_, _ = fmt.Fprintf(inv.Stderr, "Error accepting %s proxy connection on '%s': %v\n", name, address, err)
+				_, _ = fmt.Fprintln(inv.Stderr, "Killing listener")
+				return
+			}
+
+			go func() {
+				defer netConn.Close()
+				err := serve(ctx, netConn)
+				if err != nil {
+					_, _ = fmt.Fprintf(inv.Stderr, "%s proxy: %s\n", name, err)
+				}
+			}()
+		}
+	}()
+
+	return l, nil
+}
+
+// SOCKS5 protocol constants, see RFC 1928.
+const (
+	socks5Version         = 0x05
+	socks5NoAuth          = 0x00
+	socks5NoAcceptable    = 0xff
+	socks5CmdConnect      = 0x01
+	socks5AddrIPv4        = 0x01
+	socks5AddrDomain      = 0x03
+	socks5AddrIPv6        = 0x04
+	socks5Succeeded       = 0x00
+	socks5HostUnreachable = 0x04
+	socks5CmdUnsupported  = 0x07
+	socks5AddrUnsupported = 0x08
+)
+
+// serveSOCKS5 handles a SOCKS5 client that doesn't authenticate and uses
+// the CONNECT command.
+func serveSOCKS5(dial proxyDialer) func(ctx context.Context, netConn net.Conn) error {
+	return func(ctx context.Context, netConn net.Conn) error {
+		r := bufio.NewReader(netConn)
+
+		// Greeting: version, number of methods, methods.
+		header := make([]byte, 2)
+		_, err := io.ReadFull(r, header)
+		if err != nil {
+			return xerrors.Errorf("read greeting: %w", err)
+		}
+		if header[0] != socks5Version {
+			return xerrors.Errorf("unsupported SOCKS version %d", header[0])
+		}
+		methods := make([]byte, header[1])
+		_, err = io.ReadFull(r, methods)
+		if err != nil {
+			return xerrors.Errorf("read auth methods: %w", err)
+		}
+		method := byte(socks5NoAcceptable)
+		for _, m := range methods {
+			if m == socks5NoAuth {
+				method = socks5NoAuth
+			}
+		}
+		_, err = netConn.Write([]byte{socks5Version, method})
+		if err != nil {
+			return xerrors.Errorf("write auth method: %w", err)
+		}
+		if method == socks5NoAcceptable {
+			return xerrors.New("client requires authentication")
+		}
+
+		// Request: version, command, reserved, address type, address, port.
+		request := make([]byte, 4)
+		_, err = io.ReadFull(r, request)
+		if err != nil {
+			return xerrors.Errorf("read request: %w", err)
+		}
+		var host string
+		switch request[3] {
+		case socks5AddrIPv4, socks5AddrIPv6:
+			ip := make(net.IP, net.IPv4len)
+			if request[3] == socks5AddrIPv6 {
+				ip = make(net.IP, net.IPv6len)
+			}
+			_, err = io.ReadFull(r, ip)
+			host = ip.String()
+		case socks5AddrDomain:
+			var length byte
+			length, err = r.ReadByte()
+			if err == nil {
+				domain := make([]byte, length)
+				_, err = io.ReadFull(r, domain)
+				host = string(domain)
+			}
+		default:
+			_ = writeSOCKS5Reply(netConn, socks5AddrUnsupported)
+			return xerrors.Errorf("unsupported address type %d", request[3])
+		}
+		if err != nil {
+			return xerrors.Errorf("read address: %w", err)
+		}
+		port := make([]byte, 2)
+		_, err = io.ReadFull(r, port)
+		if err != nil {
+			return xerrors.Errorf("read port: %w", err)
+		}
+		if request[1] != socks5CmdConnect {
+			_ = writeSOCKS5Reply(netConn, socks5CmdUnsupported)
+			return xerrors.Errorf("unsupported command %d", request[1])
+		}
+
+		addr := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+		remoteConn, err := dial(ctx, addr)
+		if err != nil {
+			_ = writeSOCKS5Reply(netConn, socks5HostUnreachable)
+			return xerrors.Errorf("dial %q in workspace: %w", addr, err)
+		}
+		defer remoteConn.Close()
+		err = writeSOCKS5Reply(netConn, socks5Succeeded)
+		if err != nil {
+			return xerrors.Errorf("write reply: %w", err)
+		}
+
+		// The client may have sent data along with the request.
+		if r.Buffered() > 0 {
+			buffered, _ := r.Peek(r.Buffered())
+			_, err = remoteConn.Write(buffered)
+			if err != nil {
+				return xerrors.Errorf("write buffered data: %w", err)
+			}
+		}
+		agentssh.Bicopy(ctx, netConn, remoteConn)
+		return nil
+	}
+}
+
+// writeSOCKS5Reply writes a reply with an unspecified bound address, which
+// clients don't need for CONNECT.
+func writeSOCKS5Reply(w io.Writer, status byte) error {
+	_, err := w.Write([]byte{socks5Version, status, 0x00, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
+
+// listenAndProxyHTTP serves an HTTP proxy on address until the listener is
+// closed.
+func listenAndProxyHTTP(inv *clibase.Invocation, wg *sync.WaitGroup, address string, dial proxyDialer) (net.Listener, error) {
+	_, _ = fmt.Fprintf(inv.Stderr, "Serving an HTTP proxy on '%s' to reach any address from the workspace\n", address)
+
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, xerrors.Errorf("listen '%s': %w", address, err)
+	}
+
+	srv := &http.Server{
+		Handler:           httpProxyHandler(dial),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := srv.Serve(l)
+		if err != nil && !xerrors.Is(err, net.ErrClosed) {
+			_, _ = fmt.Fprintf(inv.Stderr, "Error serving HTTP proxy on '%s': %v\n", address, err)
+		}
+	}()
+
+	return l, nil
+}
+
+// httpProxyHandler handles HTTP proxy clients. CONNECT requests are
+// tunneled and plain HTTP requests are forwarded.
+func httpProxyHandler(dial proxyDialer) http.Handler {
+	forward := &httputil.ReverseProxy{
+		Director: func(*http.Request) {},
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+				return dial(ctx, addr)
+			},
+		},
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			if !r.URL.IsAbs() {
+				http.Error(rw, "Only proxy requests are supported.", http.StatusBadRequest)
+				return
+			}
+			forward.ServeHTTP(rw, r)
+			return
+		}
+
+		remoteConn, err := dial(r.Context(), r.Host)
+		if err != nil {
+			http.Error(rw, fmt.Sprintf("Failed to dial %q in workspace: %s", r.Host, err), http.StatusBadGateway)
+			return
+		}
+		defer remoteConn.Close()
+
+		hijacker, ok := rw.(http.Hijacker)
+		if !ok {
+			http.Error(rw, "Hijacking is not supported.", http.StatusInternalServerError)
+			return
+		}
+		netConn, buf, err := hijacker.Hijack()
+		if err != nil {
+			return
+		}
+		defer netConn.Close()
+		_, err = io.WriteString(netConn, "HTTP/1.1 200 Connection Established\r\n\r\n")
+		if err != nil {
+			return
+		}
+		// The client may have sent data right after the request.
+		if buf.Reader.Buffered() > 0 {
+			buffered, _ := buf.Reader.Peek(buf.Reader.Buffered())
+			_, err = remoteConn.Write(buffered)
+			if err != nil {
+				return
+			}
+		}
+		agentssh.Bicopy(r.Context(), netConn, remoteConn)
+	})
+}
@@ -28,7 +28,7 @@ func (r *RootCmd) restart() *clibase.Cmd {
 			ctx := inv.Context()
 			out := inv.Stdout
 
-			workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return err
 			}
@@ -49,8 +49,9 @@ func (r *RootCmd) restart() *clibase.Cmd {
 			}
 
 			buildParameters, err := prepStartWorkspace(inv, client, prepStartWorkspaceArgs{
-				Action:   WorkspaceRestart,
-				Template: template,
+				Action:    WorkspaceRestart,
+				Template:  template,
+				Workspace: workspace,
 
 				LastBuildParameters: lastBuildParameters,
 
@@ -61,14 +61,18 @@ const (
 	varForceTty         = "force-tty"
 	varVerbose          = "verbose"
 	varDisableDirect    = "disable-direct-connections"
+	varOrganization     = "org"
+	varContext          = "context"
 	notLoggedInMessage  = "You are not logged in. Try logging in using 'coder login <url>'."
 
 	envNoVersionCheck   = "CODER_NO_VERSION_WARNING"
 	envNoFeatureWarning = "CODER_NO_FEATURE_WARNING"
 	envSessionToken     = "CODER_SESSION_TOKEN"
 	//nolint:gosec
-	envAgentToken = "CODER_AGENT_TOKEN"
-	envURL        = "CODER_URL"
+	envAgentToken   = "CODER_AGENT_TOKEN"
+	envURL          = "CODER_URL"
+	envOrganization = "CODER_ORGANIZATION"
+	envContext      = "CODER_CONTEXT"
 )
 
 var errUnauthenticated = xerrors.New(notLoggedInMessage)
@@ -76,14 +80,19 @@ var errUnauthenticated = xerrors.New(notLoggedInMessage)
 func (r *RootCmd) Core() []*clibase.Cmd {
 	// Please re-sort this list alphabetically if you change it!
 	return []*clibase.Cmd{
+		r.audit(),
+		r.contexts(),
 		r.dotfiles(),
 		r.login(),
 		r.logout(),
 		r.netcheck(),
+		r.notifications(),
+		r.organizations(),
 		r.portForward(),
 		r.publickey(),
 		r.resetPassword(),
 		r.state(),
+		r.support(),
 		r.templates(),
 		r.tokens(),
 		r.users(),
@@ -91,9 +100,12 @@ func (r *RootCmd) Core() []*clibase.Cmd {
 
 		// Workspace Commands
 		r.configSSH(),
+		r.cp(),
 		r.create(),
 		r.deleteWorkspace(),
+		r.exec(),
 		r.list(),
+		r.logs(),
 		r.ping(),
 		r.rename(),
 		r.schedules(),
@@ -102,7 +114,9 @@ func (r *RootCmd) Core() []*clibase.Cmd {
 		r.ssh(),
 		r.start(),
 		r.stop(),
+		r.transfer(),
 		r.update(),
+		r.workspaces(),
 		r.restart(),
 		r.stat(),
 
@@ -387,6 +401,20 @@ func (r *RootCmd) Command(subcommands []*clibase.Cmd) (*clibase.Cmd, error) {
 			Value:       clibase.BoolOf(&r.disableDirect),
 			Group:       globalGroup,
 		},
+		{
+			Flag:        varContext,
+			Env:         envContext,
+			Description: "Select which saved deployment context to use instead of the current one. See 'coder context --help'.",
+			Value:       clibase.StringOf(&r.context),
+			Group:       globalGroup,
+		},
+		{
+			Flag:        varOrganization,
+			Env:         envOrganization,
+			Description: "Select which organization (name or ID) commands operate on. Defaults to the first organization you are a member of.",
+			Value:       clibase.StringOf(&r.organization),
+			Group:       globalGroup,
+		},
 		{
 			Flag:        "debug-http",
 			Description: "Debug codersdk HTTP requests.",
@@ -444,6 +472,8 @@ type RootCmd struct {
 	verbose       bool
 	disableDirect bool
 	debugHTTP     bool
+	organization  string
+	context       string
 
 	noVersionCheck   bool
 	noFeatureWarning bool
@@ -511,8 +541,10 @@ func (r *RootCmd) initClientInternal(client *codersdk.Client, allowTokenMissing
 	}
 	return func(next clibase.HandlerFunc) clibase.HandlerFunc {
 		return func(inv *clibase.Invocation) error {
-			conf := r.createConfig()
-			var err error
+			conf, err := r.contextConfig()
+			if err != nil {
+				return err
+			}
 			if r.clientURL == nil || r.clientURL.String() == "" {
 				rawURL, err := conf.URL().Read()
 				// If the configuration files are absent, the user is logged out
@@ -626,14 +658,27 @@ func (r *RootCmd) createAgentClient() (*agentsdk.Client, error) {
 }
 
 // CurrentOrganization returns the currently active organization for the authenticated user.
+// The organization is selected with the global --org flag, falling back to
+// the first organization the user is a member of.
 func CurrentOrganization(inv *clibase.Invocation, client *codersdk.Client) (codersdk.Organization, error) {
 	orgs, err := client.OrganizationsByUser(inv.Context(), codersdk.Me)
 	if err != nil {
-		return codersdk.Organization{}, nil
+		return codersdk.Organization{}, xerrors.Errorf("get organizations: %w", err)
+	}
+
+	selected, _ := inv.ParsedFlags().GetString(varOrganization)
+	if selected == "" {
+		if len(orgs) == 0 {
+			return codersdk.Organization{}, xerrors.New("you are not a member of any organizations")
+		}
+		return orgs[0], nil
 	}
-	// For now, we won't use the config to set this.
-	// Eventually, we will support changing using "coder switch <org>"
-	return orgs[0], nil
+	for _, org := range orgs {
+		if org.Name == selected || org.ID.String() == selected {
+			return org, nil
+		}
+	}
+	return codersdk.Organization{}, xerrors.Errorf("organization %q not found, run 'coder organizations list' to see the organizations you are a member of", selected)
 }
 
 func splitNamedWorkspace(identifier string) (owner string, workspaceName string, err error) {
@@ -652,10 +697,10 @@ func splitNamedWorkspace(identifier string) (owner string, workspaceName string,
 	return owner, workspaceName, nil
 }
 
-// namedWorkspace fetches and returns a workspace by an identifier, which may be either
+// NamedWorkspace fetches and returns a workspace by an identifier, which may be either
 // a bare name (for a workspace owned by the current user) or a "user/workspace" combination,
 // where user is either a username or UUID.
-func namedWorkspace(ctx context.Context, client *codersdk.Client, identifier string) (codersdk.Workspace, error) {
+func NamedWorkspace(ctx context.Context, client *codersdk.Client, identifier string) (codersdk.Workspace, error) {
 	owner, name, err := splitNamedWorkspace(identifier)
 	if err != nil {
 		return codersdk.Workspace{}, err
@@ -668,6 +713,37 @@ func (r *RootCmd) createConfig() config.Root {
 	return config.Root(r.globalConfig)
 }
 
+// currentContext returns the name of the deployment context selected with
+// --context, falling back to the saved current context. An empty name means
+// no context is selected and the URL and session token at the root of the
+// config directory are used.
+func (r *RootCmd) currentContext() (string, error) {
+	if r.context != "" {
+		return r.context, nil
+	}
+	name, err := r.createConfig().CurrentContext().Read()
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", xerrors.Errorf("read current context: %w", err)
+	}
+	return strings.TrimSpace(name), nil
+}
+
+// contextConfig returns the config directory holding the URL and session
+// token of the selected deployment context.
+func (r *RootCmd) contextConfig() (config.Root, error) {
+	name, err := r.currentContext()
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return r.createConfig(), nil
+	}
+	return r.savedContext(name)
+}
+
 // isTTY returns whether the passed reader is a TTY or not.
 func isTTY(inv *clibase.Invocation) bool {
 	// If the `--force-tty` command is available, and set,
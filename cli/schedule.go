@@ -19,7 +19,7 @@ import (
 const (
 	scheduleShowDescriptionLong = `Shows the following information for the given workspace:
   * The automatic start schedule
-  * The next scheduled start time
+  * The next scheduled start time, taking autostart exceptions and skipped starts into account
   * The duration after which it will stop
   * The next scheduled stop time
 `
@@ -50,13 +50,18 @@ When enabling scheduled stop, enter a duration in one of the following formats:
   * The new stop time is calculated from *now*.
   * The new stop time must be at least 30 minutes in the future.
   * The workspace template may restrict the maximum workspace runtime.
+`
+	scheduleOverrideStartDescriptionLong = `
+  * Skips the next scheduled start that is not already skipped or covered by an autostart exception.
+  * Run the command again to also skip the start after that.
+  * Changing the start schedule of the workspace undoes all skips.
 `
 )
 
 func (r *RootCmd) schedules() *clibase.Cmd {
 	scheduleCmd := &clibase.Cmd{
 		Annotations: workspaceCommand,
-		Use:         "schedule { show | start | stop | override } <workspace>",
+		Use:         "schedule { show | start | stop | override-stop | override-start | calendar } <workspace>",
 		Short:       "Schedule automated start and stop times for workspaces",
 		Handler: func(inv *clibase.Invocation) error {
 			return inv.Command.HelpHandler(inv)
@@ -66,6 +71,8 @@ func (r *RootCmd) schedules() *clibase.Cmd {
 			r.scheduleStart(),
 			r.scheduleStop(),
 			r.scheduleOverride(),
+			r.scheduleOverrideStart(),
+			r.scheduleCalendar(),
 		},
 	}
 
@@ -83,12 +90,12 @@ func (r *RootCmd) scheduleShow() *clibase.Cmd {
 			r.InitClient(client),
 		),
 		Handler: func(inv *clibase.Invocation) error {
-			workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return err
 			}
 
-			return displaySchedule(workspace, inv.Stdout)
+			return displaySchedule(workspace, workspaceAutostartExceptions(inv.Context(), client, workspace), inv.Stdout)
 		},
 	}
 	return showCmd
@@ -110,7 +117,7 @@ func (r *RootCmd) scheduleStart() *clibase.Cmd {
 			r.InitClient(client),
 		),
 		Handler: func(inv *clibase.Invocation) error {
-			workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return err
 			}
@@ -132,11 +139,11 @@ func (r *RootCmd) scheduleStart() *clibase.Cmd {
 				return err
 			}
 
-			updated, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			updated, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return err
 			}
-			return displaySchedule(updated, inv.Stdout)
+			return displaySchedule(updated, workspaceAutostartExceptions(inv.Context(), client, updated), inv.Stdout)
 		},
 	}
 
@@ -158,7 +165,7 @@ func (r *RootCmd) scheduleStop() *clibase.Cmd {
 			r.InitClient(client),
 		),
 		Handler: func(inv *clibase.Invocation) error {
-			workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return err
 			}
@@ -178,11 +185,11 @@ func (r *RootCmd) scheduleStop() *clibase.Cmd {
 				return err
 			}
 
-			updated, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			updated, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return err
 			}
-			return displaySchedule(updated, inv.Stdout)
+			return displaySchedule(updated, workspaceAutostartExceptions(inv.Context(), client, updated), inv.Stdout)
 		},
 	}
 }
@@ -207,7 +214,7 @@ func (r *RootCmd) scheduleOverride() *clibase.Cmd {
 				return err
 			}
 
-			workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return xerrors.Errorf("get workspace: %w", err)
 			}
@@ -232,17 +239,66 @@ func (r *RootCmd) scheduleOverride() *clibase.Cmd {
 				return err
 			}
 
-			updated, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			updated, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return err
 			}
-			return displaySchedule(updated, inv.Stdout)
+			return displaySchedule(updated, workspaceAutostartExceptions(inv.Context(), client, updated), inv.Stdout)
 		},
 	}
 	return overrideCmd
 }
 
-func displaySchedule(workspace codersdk.Workspace, out io.Writer) error {
+func (r *RootCmd) scheduleOverrideStart() *clibase.Cmd {
+	var clearSkips bool
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "override-start <workspace-name>",
+		Short: "Skip the next scheduled start of a workspace.",
+		Long: scheduleOverrideStartDescriptionLong + "\n" + formatExamples(
+			example{
+				Description: "Skip tomorrow's start of a workspace that starts every day",
+				Command:     "coder schedule override-start my-workspace",
+			},
+			example{
+				Description: "Undo all skipped starts",
+				Command:     "coder schedule override-start my-workspace --clear",
+			},
+		),
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+
+			if err := client.SkipWorkspaceAutostart(inv.Context(), workspace.ID, codersdk.SkipWorkspaceAutostartRequest{
+				Skip: !clearSkips,
+			}); err != nil {
+				return err
+			}
+
+			updated, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			return displaySchedule(updated, workspaceAutostartExceptions(inv.Context(), client, updated), inv.Stdout)
+		},
+	}
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:        "clear",
+			Description: "Start the workspace on schedule again.",
+			Value:       clibase.BoolOf(&clearSkips),
+		},
+	}
+	return cmd
+}
+
+func displaySchedule(workspace codersdk.Workspace, exceptions []schedule.AutostartException, out io.Writer) error {
 	loc, err := tz.TimezoneIANA()
 	if err != nil {
 		loc = time.UTC // best effort
@@ -261,9 +317,18 @@ func displaySchedule(workspace codersdk.Workspace, out io.Writer) error {
 			_, _ = fmt.Fprintf(out, "Invalid autostart schedule %q for workspace %s: %s\n", *workspace.AutostartSchedule, workspace.Name, err.Error())
 			return nil
 		}
-		schedNext := sched.Next(time.Now()).In(sched.Location())
+		var skipUntil time.Time
+		if workspace.AutostartSkipUntil != nil {
+			skipUntil = *workspace.AutostartSkipUntil
+		}
 		schedStart = fmt.Sprintf("%s %s (%s)", sched.Time(), sched.DaysOfWeek(), sched.Location())
-		schedNextStart = schedNext.Format(timeFormat + " on " + dateFormat)
+		schedNext := schedule.NextAutostart(sched, time.Now(), skipUntil, exceptions)
+		if !schedNext.IsZero() {
+			schedNextStart = schedNext.In(sched.Location()).Format(timeFormat + " on " + dateFormat)
+		}
+		if skipUntil.After(time.Now()) {
+			schedNextStart = fmt.Sprintf("%s (skipped until %s)", schedNextStart, skipUntil.In(sched.Location()).Format(timeFormat+" on "+dateFormat))
+		}
 	}
 
 	if !ptr.NilOrZero(workspace.TTLMillis) {
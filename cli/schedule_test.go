@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -356,6 +358,105 @@ func TestScheduleOverride(t *testing.T) {
 }
 
 //nolint:paralleltest // t.Setenv
+func TestScheduleOverrideStart(t *testing.T) {
+	t.Parallel()
+
+	// Given: we have a workspace that starts every day
+	var (
+		ctx       = context.Background()
+		client    = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user      = coderdtest.CreateFirstUser(t, client)
+		version   = coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_         = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		project   = coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace = coderdtest.CreateWorkspace(t, client, user.OrganizationID, project.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref("CRON_TZ=UTC 0 9 * * *")
+		})
+		stdoutBuf = &bytes.Buffer{}
+	)
+
+	// When: we skip the next start
+	inv, root := clitest.New(t, "schedule", "override-start", workspace.Name)
+	clitest.SetupConfig(t, client, root)
+	inv.Stdout = stdoutBuf
+	err := inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+
+	// Then: the workspace starts the day after
+	updated, err := client.Workspace(ctx, workspace.ID)
+	require.NoError(t, err)
+	require.NotNil(t, updated.AutostartSkipUntil)
+	require.Contains(t, stdoutBuf.String(), "skipped until")
+
+	// When: we undo the skip
+	inv, root = clitest.New(t, "schedule", "override-start", workspace.Name, "--clear")
+	clitest.SetupConfig(t, client, root)
+	err = inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+
+	// Then: the workspace starts on schedule again
+	updated, err = client.Workspace(ctx, workspace.ID)
+	require.NoError(t, err)
+	require.Nil(t, updated.AutostartSkipUntil)
+}
+
+func TestScheduleCalendar(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		client   = coderdtest.New(t, nil)
+		user     = coderdtest.CreateFirstUser(t, client)
+		version  = coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		template = coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		calendar = filepath.Join(t.TempDir(), "holidays.ics")
+	)
+	err := os.WriteFile(calendar, []byte("BEGIN:VCALENDAR\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"DTSTART;VALUE=DATE:20231225\r\n"+
+		"DTEND;VALUE=DATE:20231227\r\n"+
+		"SUMMARY:Christmas\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n"), 0o600)
+	require.NoError(t, err)
+
+	// When: we import a calendar for the template
+	inv, root := clitest.New(t, "schedule", "calendar", "import", "--template", template.Name, calendar)
+	clitest.SetupConfig(t, client, root)
+	err = inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+
+	// Then: only the template has the exception
+	exceptions, err := client.TemplateAutostartExceptions(ctx, template.ID)
+	require.NoError(t, err)
+	require.Len(t, exceptions, 1)
+	require.True(t, exceptions[0].AllDay)
+	exceptions, err = client.DeploymentAutostartExceptions(ctx)
+	require.NoError(t, err)
+	require.Empty(t, exceptions)
+
+	// And: it is listed
+	stdoutBuf := &bytes.Buffer{}
+	inv, root = clitest.New(t, "schedule", "calendar", "list", "-t", template.Name)
+	clitest.SetupConfig(t, client, root)
+	inv.Stdout = stdoutBuf
+	err = inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+	require.Contains(t, stdoutBuf.String(), "Christmas")
+	require.Contains(t, stdoutBuf.String(), "2023-12-25")
+
+	// When: we clear the template's calendar
+	inv, root = clitest.New(t, "schedule", "calendar", "clear", "--template", template.Name)
+	clitest.SetupConfig(t, client, root)
+	err = inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+
+	// Then: the exception is gone
+	exceptions, err = client.TemplateAutostartExceptions(ctx, template.ID)
+	require.NoError(t, err)
+	require.Empty(t, exceptions)
+}
+
 func TestScheduleStartDefaults(t *testing.T) {
 	t.Setenv("TZ", "Pacific/Tongatapu")
 	var (
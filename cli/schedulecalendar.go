@@ -0,0 +1,263 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/coderd/schedule"
+	"github.com/coder/coder/codersdk"
+)
+
+const scheduleCalendarDescriptionLong = `Exceptions are periods in which no workspace is started automatically, e.g.
+public holidays. Deployment exceptions apply to every workspace, template
+exceptions to the workspaces of the template. Exceptions do not stop running
+workspaces.
+`
+
+func (r *RootCmd) scheduleCalendar() *clibase.Cmd {
+	return &clibase.Cmd{
+		Use:   "calendar",
+		Short: "Manage the periods in which workspaces are not started automatically",
+		Long:  scheduleCalendarDescriptionLong,
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.scheduleCalendarList(),
+			r.scheduleCalendarImport(),
+			r.scheduleCalendarClear(),
+		},
+	}
+}
+
+type scheduleCalendarRow struct {
+	// For JSON format:
+	codersdk.AutostartException `table:"-"`
+
+	// For table format:
+	Name     string `json:"-" table:"name"`
+	StartsAt string `json:"-" table:"starts at,default_sort"`
+	EndsAt   string `json:"-" table:"ends at"`
+	AllDay   bool   `json:"-" table:"all day"`
+}
+
+func (r *RootCmd) scheduleCalendarList() *clibase.Cmd {
+	var templateName string
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]scheduleCalendarRow{}, nil),
+		cliui.JSONFormat(),
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "list",
+		Short: "List autostart exceptions",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			templateID, err := calendarTemplateID(inv, client, templateName)
+			if err != nil {
+				return err
+			}
+
+			var exceptions []codersdk.AutostartException
+			if templateID == uuid.Nil {
+				exceptions, err = client.DeploymentAutostartExceptions(inv.Context())
+			} else {
+				exceptions, err = client.TemplateAutostartExceptions(inv.Context(), templateID)
+			}
+			if err != nil {
+				return xerrors.Errorf("get autostart exceptions: %w", err)
+			}
+
+			rows := make([]scheduleCalendarRow, 0, len(exceptions))
+			for _, exception := range exceptions {
+				layout := "2006-01-02 15:04 MST"
+				startsAt, endsAt := exception.StartsAt.Local(), exception.EndsAt.Local()
+				if exception.AllDay {
+					layout = "2006-01-02"
+					startsAt, endsAt = exception.StartsAt.UTC(), exception.EndsAt.UTC()
+				}
+				rows = append(rows, scheduleCalendarRow{
+					AutostartException: exception,
+					Name:               exception.Name,
+					StartsAt:           startsAt.Format(layout),
+					EndsAt:             endsAt.Format(layout),
+					AllDay:             exception.AllDay,
+				})
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	cmd.Options = clibase.OptionSet{calendarTemplateOption(&templateName)}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) scheduleCalendarImport() *clibase.Cmd {
+	var templateName string
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "import <file.ics>",
+		Short: "Replace the autostart exceptions with the events of an iCalendar file",
+		Long: "Events with a start date and no start time are all-day exceptions. Recurring events " +
+			"are not expanded; only their first occurrence is imported.\n\n" + formatExamples(
+			example{
+				Description: "Import public holidays for the whole deployment",
+				Command:     "coder schedule calendar import holidays.ics",
+			},
+			example{
+				Description: "Import the offsite days of the team using a template",
+				Command:     "coder schedule calendar import --template my-template offsite.ics",
+			},
+		),
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			templateID, err := calendarTemplateID(inv, client, templateName)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("open calendar: %w", err)
+			}
+			defer f.Close()
+			events, err := schedule.ParseICalendar(f)
+			if err != nil {
+				return xerrors.Errorf("parse calendar %q: %w", inv.Args[0], err)
+			}
+
+			req := codersdk.UpdateAutostartExceptionsRequest{
+				Exceptions: make([]codersdk.CreateAutostartExceptionRequest, 0, len(events)),
+			}
+			for _, event := range events {
+				req.Exceptions = append(req.Exceptions, codersdk.CreateAutostartExceptionRequest{
+					Name:     event.Name,
+					StartsAt: event.StartsAt,
+					EndsAt:   event.EndsAt,
+					AllDay:   event.AllDay,
+				})
+			}
+			exceptions, err := updateAutostartExceptions(inv.Context(), client, templateID, req)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Imported %d autostart exceptions.\n", len(exceptions))
+			return nil
+		},
+	}
+	cmd.Options = clibase.OptionSet{calendarTemplateOption(&templateName)}
+	return cmd
+}
+
+func (r *RootCmd) scheduleCalendarClear() *clibase.Cmd {
+	var templateName string
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "clear",
+		Short: "Remove all autostart exceptions",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			templateID, err := calendarTemplateID(inv, client, templateName)
+			if err != nil {
+				return err
+			}
+
+			_, err = updateAutostartExceptions(inv.Context(), client, templateID, codersdk.UpdateAutostartExceptionsRequest{})
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintln(inv.Stdout, "Removed all autostart exceptions.")
+			return nil
+		},
+	}
+	cmd.Options = clibase.OptionSet{calendarTemplateOption(&templateName)}
+	return cmd
+}
+
+func calendarTemplateOption(templateName *string) clibase.Option {
+	return clibase.Option{
+		Flag:          "template",
+		FlagShorthand: "t",
+		Description:   "Manage the exceptions of this template instead of the exceptions of the deployment.",
+		Value:         clibase.StringOf(templateName),
+	}
+}
+
+// calendarTemplateID returns the ID of the named template, or uuid.Nil if no
+// template is named.
+func calendarTemplateID(inv *clibase.Invocation, client *codersdk.Client, templateName string) (uuid.UUID, error) {
+	if templateName == "" {
+		return uuid.Nil, nil
+	}
+	organization, err := CurrentOrganization(inv, client)
+	if err != nil {
+		return uuid.Nil, xerrors.Errorf("get current organization: %w", err)
+	}
+	template, err := client.TemplateByName(inv.Context(), organization.ID, templateName)
+	if err != nil {
+		return uuid.Nil, xerrors.Errorf("get template by name: %w", err)
+	}
+	return template.ID, nil
+}
+
+func updateAutostartExceptions(ctx context.Context, client *codersdk.Client, templateID uuid.UUID, req codersdk.UpdateAutostartExceptionsRequest) ([]codersdk.AutostartException, error) {
+	var (
+		exceptions []codersdk.AutostartException
+		err        error
+	)
+	if templateID == uuid.Nil {
+		exceptions, err = client.UpdateDeploymentAutostartExceptions(ctx, req)
+	} else {
+		exceptions, err = client.UpdateTemplateAutostartExceptions(ctx, templateID, req)
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("update autostart exceptions: %w", err)
+	}
+	return exceptions, nil
+}
+
+// workspaceAutostartExceptions returns the exceptions that apply to the
+// workspace. Exceptions that cannot be read are ignored.
+func workspaceAutostartExceptions(ctx context.Context, client *codersdk.Client, workspace codersdk.Workspace) []schedule.AutostartException {
+	var exceptions []codersdk.AutostartException
+	if templateExceptions, err := client.TemplateAutostartExceptions(ctx, workspace.TemplateID); err == nil {
+		exceptions = append(exceptions, templateExceptions...)
+	}
+	if deploymentExceptions, err := client.DeploymentAutostartExceptions(ctx); err == nil {
+		exceptions = append(exceptions, deploymentExceptions...)
+	}
+
+	converted := make([]schedule.AutostartException, 0, len(exceptions))
+	for _, exception := range exceptions {
+		converted = append(converted, schedule.AutostartException{
+			Name:     exception.Name,
+			StartsAt: exception.StartsAt,
+			EndsAt:   exception.EndsAt,
+			AllDay:   exception.AllDay,
+		})
+	}
+	return converted
+}
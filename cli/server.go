@@ -76,6 +76,7 @@ import (
 	"github.com/coder/coder/coderd/gitsshkey"
 	"github.com/coder/coder/coderd/httpapi"
 	"github.com/coder/coder/coderd/httpmw"
+	"github.com/coder/coder/coderd/notifications"
 	"github.com/coder/coder/coderd/prometheusmetrics"
 	"github.com/coder/coder/coderd/schedule"
 	"github.com/coder/coder/coderd/telemetry"
@@ -826,6 +827,21 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 			options.StatsBatcher = batcher
 			defer closeBatcher()
 
+			// Notification emails are only queued if they can be sent.
+			var notificationSender notifications.Sender
+			if cfg.Notifications.EmailFrom != "" || cfg.Notifications.EmailSmarthost != "" {
+				if cfg.Notifications.EmailFrom == "" || cfg.Notifications.EmailSmarthost == "" {
+					return xerrors.New("--notifications-email-from and --notifications-email-smarthost must be set together")
+				}
+				notificationSender = &notifications.SMTPSender{
+					From:      cfg.Notifications.EmailFrom.String(),
+					Smarthost: cfg.Notifications.EmailSmarthost.String(),
+					Username:  cfg.Notifications.EmailUsername.String(),
+					Password:  cfg.Notifications.EmailPassword.String(),
+				}
+			}
+			options.Notifications = notifications.NewStoreEnqueuer(options.Database, logger.Named("notifications"), notificationSender != nil)
+
 			closeCheckInactiveUsersFunc := dormancy.CheckInactiveUsers(ctx, logger, options.Database)
 			defer closeCheckInactiveUsersFunc()
 
@@ -901,7 +917,7 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 			defer shutdownConns()
 
 			// Ensures that old database entries are cleaned up over time!
-			purger := dbpurge.New(ctx, logger, options.Database)
+			purger := dbpurge.New(ctx, logger, options.Database, options.PrometheusRegistry, cfg.Retention)
 			defer purger.Close()
 
 			// Wrap the server in middleware that redirects to the access URL if
@@ -967,9 +983,17 @@ func (r *RootCmd) Server(newAPI func(context.Context, *coderd.Options) (*coderd.
 
 			autobuildTicker := time.NewTicker(cfg.AutobuildPollInterval.Value())
 			defer autobuildTicker.Stop()
-			autobuildExecutor := autobuild.NewExecutor(ctx, options.Database, coderAPI.TemplateScheduleStore, logger, autobuildTicker.C)
+			autobuildExecutor := autobuild.NewExecutor(ctx, options.Database, coderAPI.TemplateScheduleStore, options.Notifications, logger, autobuildTicker.C)
 			autobuildExecutor.Run()
 
+			if notificationSender != nil {
+				notificationTicker := time.NewTicker(notifications.DispatchInterval)
+				defer notificationTicker.Stop()
+				notificationDispatcher := notifications.NewDispatcher(ctx, options.Database, notificationSender, logger.Named("notifications.dispatcher"), notificationTicker.C, int(cfg.Notifications.MaxSendAttempts.Value()))
+				notificationDispatcher.Start()
+				defer notificationDispatcher.Close()
+			}
+
 			hangDetectorTicker := time.NewTicker(cfg.JobHangDetectorInterval.Value())
 			defer hangDetectorTicker.Stop()
 			hangDetector := unhanger.New(ctx, options.Database, options.Pubsub, logger, hangDetectorTicker.C)
@@ -182,6 +182,15 @@ func (r *RootCmd) ssh() *clibase.Cmd {
 				_, _ = fmt.Fprintln(inv.Stderr, updateWorkspaceBanner)
 			}
 
+			if isTTYErr(inv) {
+				// The warning is best-effort, so failing to fetch the template
+				// shouldn't prevent connecting.
+				template, err := client.Template(ctx, workspace.TemplateID)
+				if err == nil && template.Deprecated {
+					_, _ = fmt.Fprintln(inv.Stderr, cliui.DefaultStyles.Warn.Render(templateDeprecationMessage(ctx, client, template)))
+				}
+			}
+
 			// OpenSSH passes stderr directly to the calling TTY.
 			// This is required in "stdio" mode so a connecting indicator can be displayed.
 			err = cliui.Agent(ctx, inv.Stderr, workspaceAgent.ID, cliui.AgentOptions{
@@ -198,15 +207,11 @@ func (r *RootCmd) ssh() *clibase.Cmd {
 			if r.disableDirect {
 				_, _ = fmt.Fprintln(inv.Stderr, "Direct connections disabled.")
 			}
-			conn, err := client.DialWorkspaceAgent(ctx, workspaceAgent.ID, &codersdk.DialWorkspaceAgentOptions{
-				Logger:         logger,
-				BlockEndpoints: r.disableDirect,
-			})
+			conn, err := r.dialWorkspaceAgent(ctx, client, workspaceAgent.ID, logger)
 			if err != nil {
-				return xerrors.Errorf("dial agent: %w", err)
+				return err
 			}
 			defer conn.Close()
-			conn.AwaitReachable(ctx)
 
 			stopPolling := tryPollWorkspaceAutostop(ctx, client, workspace)
 			defer stopPolling()
@@ -534,7 +539,7 @@ func getWorkspaceAndAgent(ctx context.Context, inv *clibase.Invocation, client *
 		err            error
 	)
 
-	workspace, err = namedWorkspace(ctx, client, workspaceParts[0])
+	workspace, err = NamedWorkspace(ctx, client, workspaceParts[0])
 	if err != nil {
 		return codersdk.Workspace{}, codersdk.WorkspaceAgent{}, err
 	}
@@ -557,40 +562,54 @@ func getWorkspaceAndAgent(ctx context.Context, inv *clibase.Invocation, client *
 		return codersdk.Workspace{}, codersdk.WorkspaceAgent{}, xerrors.Errorf("workspace %q is being deleted", workspace.Name)
 	}
 
-	resources := workspace.LatestBuild.Resources
+	agentName := ""
+	if len(workspaceParts) >= 2 {
+		agentName = workspaceParts[1]
+	}
+	workspaceAgent, err := selectWorkspaceAgent(workspace, agentName)
+	if err != nil {
+		return codersdk.Workspace{}, codersdk.WorkspaceAgent{}, err
+	}
+
+	return workspace, workspaceAgent, nil
+}
 
+// selectWorkspaceAgent returns the agent of the workspace with the given name.
+// If the name is empty, the only agent or a random one is returned.
+func selectWorkspaceAgent(workspace codersdk.Workspace, agentName string) (codersdk.WorkspaceAgent, error) {
 	agents := make([]codersdk.WorkspaceAgent, 0)
-	for _, resource := range resources {
+	for _, resource := range workspace.LatestBuild.Resources {
 		agents = append(agents, resource.Agents...)
 	}
 	if len(agents) == 0 {
-		return codersdk.Workspace{}, codersdk.WorkspaceAgent{}, xerrors.Errorf("workspace %q has no agents", workspace.Name)
+		return codersdk.WorkspaceAgent{}, xerrors.Errorf("workspace %q has no agents", workspace.Name)
 	}
-	var workspaceAgent codersdk.WorkspaceAgent
-	if len(workspaceParts) >= 2 {
-		for _, otherAgent := range agents {
-			if otherAgent.Name != workspaceParts[1] {
-				continue
+	if agentName != "" {
+		for _, agent := range agents {
+			if agent.Name == agentName {
+				return agent, nil
 			}
-			workspaceAgent = otherAgent
-			break
-		}
-		if workspaceAgent.ID == uuid.Nil {
-			return codersdk.Workspace{}, codersdk.WorkspaceAgent{}, xerrors.Errorf("agent not found by name %q", workspaceParts[1])
 		}
+		return codersdk.WorkspaceAgent{}, xerrors.Errorf("agent not found by name %q", agentName)
 	}
-	if workspaceAgent.ID == uuid.Nil {
-		if len(agents) > 1 {
-			workspaceAgent, err = cryptorand.Element(agents)
-			if err != nil {
-				return codersdk.Workspace{}, codersdk.WorkspaceAgent{}, err
-			}
-		} else {
-			workspaceAgent = agents[0]
-		}
+	if len(agents) > 1 {
+		return cryptorand.Element(agents)
 	}
+	return agents[0], nil
+}
 
-	return workspace, workspaceAgent, nil
+// dialWorkspaceAgent connects to a workspace agent over tailnet, honoring
+// --disable-direct-connections, and waits until the agent is reachable.
+func (r *RootCmd) dialWorkspaceAgent(ctx context.Context, client *codersdk.Client, agentID uuid.UUID, logger slog.Logger) (*codersdk.WorkspaceAgentConn, error) {
+	conn, err := client.DialWorkspaceAgent(ctx, agentID, &codersdk.DialWorkspaceAgentOptions{
+		Logger:         logger,
+		BlockEndpoints: r.disableDirect,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("dial agent: %w", err)
+	}
+	conn.AwaitReachable(ctx)
+	return conn, nil
 }
 
 // Attempt to poll workspace autostop. We write a per-workspace lockfile to
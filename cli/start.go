@@ -25,7 +25,7 @@ func (r *RootCmd) start() *clibase.Cmd {
 		),
 		Options: append(parameterFlags.cliBuildOptions(), cliui.SkipPromptOption()),
 		Handler: func(inv *clibase.Invocation) error {
-			workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return err
 			}
@@ -39,6 +39,9 @@ func (r *RootCmd) start() *clibase.Cmd {
 			if err != nil {
 				return err
 			}
+			if template.Deprecated {
+				_, _ = fmt.Fprintln(inv.Stderr, cliui.DefaultStyles.Warn.Render(templateDeprecationMessage(inv.Context(), client, template)))
+			}
 
 			buildOptions, err := asWorkspaceBuildParameters(parameterFlags.buildOptions)
 			if err != nil {
@@ -46,8 +49,9 @@ func (r *RootCmd) start() *clibase.Cmd {
 			}
 
 			buildParameters, err := prepStartWorkspace(inv, client, prepStartWorkspaceArgs{
-				Action:   WorkspaceStart,
-				Template: template,
+				Action:    WorkspaceStart,
+				Template:  template,
+				Workspace: workspace,
 
 				LastBuildParameters: lastBuildParameters,
 
@@ -79,8 +83,9 @@ func (r *RootCmd) start() *clibase.Cmd {
 }
 
 type prepStartWorkspaceArgs struct {
-	Action   WorkspaceCLIAction
-	Template codersdk.Template
+	Action    WorkspaceCLIAction
+	Template  codersdk.Template
+	Workspace codersdk.Workspace
 
 	LastBuildParameters []codersdk.WorkspaceBuildParameter
 
@@ -91,7 +96,17 @@ type prepStartWorkspaceArgs struct {
 func prepStartWorkspace(inv *clibase.Invocation, client *codersdk.Client, args prepStartWorkspaceArgs) ([]codersdk.WorkspaceBuildParameter, error) {
 	ctx := inv.Context()
 
-	templateVersion, err := client.TemplateVersion(ctx, args.Template.ActiveVersionID)
+	// Start builds reuse the version of the last build, unless the template
+	// requires the active version.
+	templateVersionID := args.Workspace.LatestBuild.TemplateVersionID
+	if args.Template.RequireActiveVersion {
+		templateVersionID = args.Template.ActiveVersionID
+		if templateVersionID != args.Workspace.LatestBuild.TemplateVersionID {
+			_, _ = fmt.Fprintln(inv.Stdout, cliui.DefaultStyles.Warn.Render("The template requires the active version, so the workspace will be updated."))
+		}
+	}
+
+	templateVersion, err := client.TemplateVersion(ctx, templateVersionID)
 	if err != nil {
 		return nil, xerrors.Errorf("get template version: %w", err)
 	}
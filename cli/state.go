@@ -1,13 +1,19 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/cli/clibase"
 	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/coderd/tfstate"
 	"github.com/coder/coder/codersdk"
 )
 
@@ -19,8 +25,11 @@ func (r *RootCmd) state() *clibase.Cmd {
 			return inv.Command.HelpHandler(inv)
 		},
 		Children: []*clibase.Cmd{
+			r.stateDiff(),
+			r.stateList(),
 			r.statePull(),
 			r.statePush(),
+			r.stateRollback(),
 		},
 	}
 	return cmd
@@ -40,7 +49,7 @@ func (r *RootCmd) statePull() *clibase.Cmd {
 			var err error
 			var build codersdk.WorkspaceBuild
 			if buildNumber == 0 {
-				workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+				workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 				if err != nil {
 					return err
 				}
@@ -91,7 +100,7 @@ func (r *RootCmd) statePush() *clibase.Cmd {
 			r.InitClient(client),
 		),
 		Handler: func(inv *clibase.Invocation) error {
-			workspace, err := namedWorkspace(inv.Context(), client, inv.Args[0])
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
 			if err != nil {
 				return err
 			}
@@ -131,3 +140,240 @@ func (r *RootCmd) statePush() *clibase.Cmd {
 	}
 	return cmd
 }
+
+type stateListRow struct {
+	// For JSON format:
+	codersdk.WorkspaceBuildStateSummary `table:"-"`
+
+	// For table format:
+	Build      int32  `json:"-" table:"build,default_sort"`
+	Transition string `json:"-" table:"transition"`
+	CreatedAt  string `json:"-" table:"created at"`
+	Size       int    `json:"-" table:"size (bytes)"`
+	Count      int    `json:"-" table:"resources"`
+}
+
+// stateListPageSize is the number of build states fetched at once.
+const stateListPageSize = 25
+
+func (r *RootCmd) stateList() *clibase.Cmd {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]stateListRow{}, nil),
+		cliui.JSONFormat(),
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "list <workspace>",
+		Short: "List the Terraform state of every build of a workspace.",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			var rows []stateListRow
+			for {
+				states, err := client.WorkspaceBuildStates(inv.Context(), workspace.ID, codersdk.Pagination{
+					Limit:  stateListPageSize,
+					Offset: len(rows),
+				})
+				if err != nil {
+					return xerrors.Errorf("get workspace build states: %w", err)
+				}
+				for _, state := range states {
+					rows = append(rows, stateListRow{
+						WorkspaceBuildStateSummary: state,
+						Build:                      state.BuildNumber,
+						Transition:                 string(state.Transition),
+						CreatedAt:                  state.CreatedAt.Local().Format(time.Stamp),
+						Size:                       state.SizeBytes,
+						Count:                      state.Resources,
+					})
+				}
+				if len(states) < stateListPageSize {
+					break
+				}
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) stateDiff() *clibase.Cmd {
+	var fromBuild, toBuild int64
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "diff <workspace>",
+		Short: "Compare the Terraform state of two builds at the resource level.",
+		Long: "Resources only in the newer build are prefixed with \"+\", resources only in the " +
+			"older build with \"-\" and resources with changed attributes with \"~\".",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			if fromBuild == 0 {
+				return xerrors.New("--from is required")
+			}
+
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			from, err := workspaceBuildTerraformState(inv.Context(), client, workspace, fromBuild)
+			if err != nil {
+				return err
+			}
+			to, err := workspaceBuildTerraformState(inv.Context(), client, workspace, toBuild)
+			if err != nil {
+				return err
+			}
+
+			changes := tfstate.Diff(from, to)
+			if len(changes) == 0 {
+				_, _ = fmt.Fprintln(inv.Stderr, "No resource changes.")
+				return nil
+			}
+			for _, change := range changes {
+				switch change.Action {
+				case tfstate.ChangeActionAdded:
+					_, _ = fmt.Fprintf(inv.Stdout, "+ %s\n", change.Address)
+				case tfstate.ChangeActionRemoved:
+					_, _ = fmt.Fprintf(inv.Stdout, "- %s\n", change.Address)
+				case tfstate.ChangeActionChanged:
+					_, _ = fmt.Fprintf(inv.Stdout, "~ %s (%s)\n", change.Address, strings.Join(change.Attributes, ", "))
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:        "from",
+			Description: "The build number to compare from.",
+			Value:       clibase.Int64Of(&fromBuild),
+		},
+		{
+			Flag:        "to",
+			Description: "The build number to compare to. Defaults to latest.",
+			Value:       clibase.Int64Of(&toBuild),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) stateRollback() *clibase.Cmd {
+	var buildNumber int64
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "rollback <workspace>",
+		Short: "Start a new build of a workspace from the Terraform state of an older build.",
+		Long: "The new build uses the template version and transition of the latest build. " +
+			"Use this to recover from an apply that orphaned or corrupted resources.",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			if buildNumber == 0 {
+				return xerrors.New("--build is required")
+			}
+
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			build, err := workspaceBuildByNumber(inv.Context(), client, workspace, buildNumber)
+			if err != nil {
+				return err
+			}
+			state, err := client.WorkspaceBuildState(inv.Context(), build.ID)
+			if err != nil {
+				return xerrors.Errorf("get state of build %d: %w", buildNumber, err)
+			}
+			// An empty state would make the new build reuse the latest state.
+			if len(state) == 0 {
+				return xerrors.Errorf("build %d has no state to restore", buildNumber)
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Restore the state of build %d in a new build of %s?", buildNumber, workspace.FullName()),
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			latest := workspace.LatestBuild
+			newBuild, err := client.CreateWorkspaceBuild(inv.Context(), workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+				TemplateVersionID: latest.TemplateVersionID,
+				Transition:        latest.Transition,
+				ProvisionerState:  state,
+			})
+			if err != nil {
+				return err
+			}
+			err = cliui.WorkspaceBuild(inv.Context(), inv.Stderr, client, newBuild.ID)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Build %d restored the state of build %d.\n", newBuild.BuildNumber, buildNumber)
+			return nil
+		},
+	}
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:          "build",
+			FlagShorthand: "b",
+			Description:   "The build number whose state is restored.",
+			Value:         clibase.Int64Of(&buildNumber),
+		},
+		cliui.SkipPromptOption(),
+	}
+	return cmd
+}
+
+// workspaceBuildByNumber returns a build of the workspace. Build number 0 is
+// the latest build.
+func workspaceBuildByNumber(ctx context.Context, client *codersdk.Client, workspace codersdk.Workspace, buildNumber int64) (codersdk.WorkspaceBuild, error) {
+	if buildNumber == 0 {
+		return workspace.LatestBuild, nil
+	}
+	build, err := client.WorkspaceBuildByUsernameAndWorkspaceNameAndBuildNumber(ctx, workspace.OwnerName, workspace.Name, strconv.FormatInt(buildNumber, 10))
+	if err != nil {
+		return codersdk.WorkspaceBuild{}, xerrors.Errorf("get build %d: %w", buildNumber, err)
+	}
+	return build, nil
+}
+
+// workspaceBuildTerraformState fetches and parses the state of a workspace
+// build.
+func workspaceBuildTerraformState(ctx context.Context, client *codersdk.Client, workspace codersdk.Workspace, buildNumber int64) (tfstate.State, error) {
+	build, err := workspaceBuildByNumber(ctx, client, workspace, buildNumber)
+	if err != nil {
+		return tfstate.State{}, err
+	}
+	raw, err := client.WorkspaceBuildState(ctx, build.ID)
+	if err != nil {
+		return tfstate.State{}, xerrors.Errorf("get state of build %d: %w", build.BuildNumber, err)
+	}
+	state, err := tfstate.Parse(raw)
+	if err != nil {
+		return tfstate.State{}, xerrors.Errorf("build %d doesn't have a Terraform state: %w", build.BuildNumber, err)
+	}
+	return state, nil
+}
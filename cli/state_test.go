@@ -2,6 +2,7 @@ package cli_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/coder/coder/cli/clitest"
 	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/codersdk"
 	"github.com/coder/coder/provisioner/echo"
 	"github.com/coder/coder/provisionersdk/proto"
 )
@@ -121,3 +124,93 @@ func TestStatePush(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestStateHistory(t *testing.T) {
+	t.Parallel()
+
+	const (
+		firstState  = `{"version": 4, "resources": [{"mode": "managed", "type": "docker_volume", "name": "home", "instances": [{"attributes": {"name": "home"}}]}]}`
+		secondState = `{"version": 4, "resources": [{"mode": "managed", "type": "docker_container", "name": "dev", "instances": [{"attributes": {"image": "ubuntu"}}]}]}`
+	)
+	stateResponses := func(state string) *echo.Responses {
+		return &echo.Responses{
+			Parse: echo.ParseComplete,
+			ProvisionApply: []*proto.Provision_Response{{
+				Type: &proto.Provision_Response_Complete{
+					Complete: &proto.Provision_Complete{
+						State: []byte(state),
+					},
+				},
+			}},
+		}
+	}
+	// setup creates a workspace with two builds, each on a template version
+	// that stores a different state.
+	setup := func(t *testing.T) (*codersdk.Client, codersdk.Workspace) {
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, stateResponses(firstState))
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+		coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+
+		version = coderdtest.UpdateTemplateVersion(t, client, user.OrganizationID, stateResponses(secondState), template.ID)
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		build := coderdtest.CreateWorkspaceBuild(t, client, workspace, database.WorkspaceTransitionStart, func(req *codersdk.CreateWorkspaceBuildRequest) {
+			req.TemplateVersionID = version.ID
+		})
+		coderdtest.AwaitWorkspaceBuildJob(t, client, build.ID)
+		return client, coderdtest.MustWorkspace(t, client, workspace.ID)
+	}
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+		client, workspace := setup(t)
+
+		inv, root := clitest.New(t, "state", "list", workspace.Name, "-o", "json")
+		var out bytes.Buffer
+		inv.Stdout = &out
+		clitest.SetupConfig(t, client, root)
+		err := inv.Run()
+		require.NoError(t, err)
+
+		var states []codersdk.WorkspaceBuildStateSummary
+		require.NoError(t, json.Unmarshal(out.Bytes(), &states))
+		require.Len(t, states, 2)
+		for _, state := range states {
+			require.Equal(t, 1, state.Resources)
+			require.NotZero(t, state.SizeBytes)
+		}
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		t.Parallel()
+		client, workspace := setup(t)
+
+		inv, root := clitest.New(t, "state", "diff", workspace.Name, "--from", "1")
+		var out bytes.Buffer
+		inv.Stdout = &out
+		clitest.SetupConfig(t, client, root)
+		err := inv.Run()
+		require.NoError(t, err)
+		require.Equal(t, "+ docker_container.dev\n- docker_volume.home\n", out.String())
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		t.Parallel()
+		client, workspace := setup(t)
+
+		inv, root := clitest.New(t, "state", "rollback", workspace.Name, "--build", "1", "--yes")
+		var out bytes.Buffer
+		inv.Stdout = &out
+		clitest.SetupConfig(t, client, root)
+		err := inv.Run()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "Build 3 restored the state of build 1.")
+
+		workspace = coderdtest.MustWorkspace(t, client, workspace.ID)
+		require.EqualValues(t, 3, workspace.LatestBuild.BuildNumber)
+		require.Equal(t, codersdk.WorkspaceTransitionStart, workspace.LatestBuild.Transition)
+	})
+}
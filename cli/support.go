@@ -0,0 +1,359 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/sloghuman"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/coderd/healthcheck"
+	"github.com/coder/coder/codersdk"
+)
+
+func (r *RootCmd) support() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:   "support",
+		Short: "Commands for troubleshooting issues with a Coder deployment",
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.supportBundle(),
+		},
+	}
+	return cmd
+}
+
+// supportBundlePingCount is the number of pings to the workspace agent
+// recorded in a support bundle.
+const supportBundlePingCount = 5
+
+func (r *RootCmd) supportBundle() *clibase.Cmd {
+	var (
+		outputPath        string
+		agentDebugAddress string
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "bundle [workspace[.agent]]",
+		Short: "Generate a zip archive with diagnostic information for a support ticket",
+		Long: "The archive contains the CLI version, the deployment health report, " +
+			"the deployment config with secrets removed, the DERP map and a network " +
+			"report. If a workspace is given, its build logs, agent logs, ping results " +
+			"and the tailnet status of both sides of the connection to it are included too. Information that " +
+			"can't be collected is listed in errors.txt instead of failing the command.\n\n" + formatExamples(
+			example{
+				Description: "Collect information about a workspace that can't be reached",
+				Command:     "coder support bundle my-workspace --output-file bundle.zip",
+			},
+		),
+		Middleware: clibase.Chain(
+			clibase.RequireRangeArgs(0, 1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			ctx, cancel := context.WithTimeout(inv.Context(), 2*time.Minute)
+			defer cancel()
+
+			if outputPath == "" {
+				outputPath = fmt.Sprintf("coder-support-%d.zip", time.Now().Unix())
+			}
+			f, err := os.OpenFile(outputPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+			if err != nil {
+				return xerrors.Errorf("create %q: %w", outputPath, err)
+			}
+			defer f.Close()
+
+			b := &supportBundleWriter{
+				inv: inv,
+				zip: zip.NewWriter(f),
+			}
+			b.collectDeployment(ctx, client)
+			if len(inv.Args) > 0 {
+				r.collectWorkspace(ctx, b, client, inv.Args[0], agentDebugAddress)
+			}
+
+			if len(b.errors) > 0 {
+				b.writeFile("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n"))
+			}
+			err = b.zip.Close()
+			if err != nil {
+				return xerrors.Errorf("write %q: %w", outputPath, err)
+			}
+			err = f.Close()
+			if err != nil {
+				return xerrors.Errorf("close %q: %w", outputPath, err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Wrote support bundle to %s\n", cliui.DefaultStyles.Code.Render(outputPath))
+			if len(b.errors) > 0 {
+				_, _ = fmt.Fprintf(inv.Stderr, "%d item(s) could not be collected, see errors.txt in the bundle.\n", len(b.errors))
+			}
+			return nil
+		},
+	}
+
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:          "output-file",
+			FlagShorthand: "O",
+			Env:           "CODER_SUPPORT_BUNDLE_OUTPUT_FILE",
+			Description:   "Path to write the zip archive to. Defaults to coder-support-<unix time>.zip in the current directory.",
+			Value:         clibase.StringOf(&outputPath),
+		},
+		{
+			Flag:        "agent-debug-address",
+			Env:         "CODER_SUPPORT_BUNDLE_AGENT_DEBUG_ADDRESS",
+			Default:     "127.0.0.1:2113",
+			Description: "Address of the debug server of the workspace agent, as set with --debug-address of \"coder agent\".",
+			Value:       clibase.StringOf(&agentDebugAddress),
+		},
+	}
+	return cmd
+}
+
+// supportBundleWriter writes files to a support bundle and keeps track of
+// everything that couldn't be collected.
+type supportBundleWriter struct {
+	inv    *clibase.Invocation
+	zip    *zip.Writer
+	errors []string
+}
+
+func (b *supportBundleWriter) fail(name string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %s", name, err))
+	_, _ = fmt.Fprintf(b.inv.Stderr, "Could not collect %s: %s\n", name, err)
+}
+
+func (b *supportBundleWriter) writeFile(name string, data []byte) {
+	w, err := b.zip.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err == nil {
+		_, err = w.Write(data)
+	}
+	if err != nil {
+		b.fail(name, xerrors.Errorf("write to archive: %w", err))
+	}
+}
+
+func (b *supportBundleWriter) writeJSON(name string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.fail(name, xerrors.Errorf("marshal: %w", err))
+		return
+	}
+	b.writeFile(name, append(data, '\n'))
+}
+
+func (b *supportBundleWriter) collectDeployment(ctx context.Context, client *codersdk.Client) {
+	_, _ = fmt.Fprintln(b.inv.Stderr, "Collecting deployment information...")
+	b.writeJSON("cli/version.json", defaultVersionInfo())
+
+	buildInfo, err := client.BuildInfo(ctx)
+	if err != nil {
+		b.fail("deployment/buildinfo.json", err)
+	} else {
+		b.writeJSON("deployment/buildinfo.json", buildInfo)
+	}
+
+	// The server already strips secrets, strip them again in case the
+	// server is older.
+	config, err := client.DeploymentConfig(ctx)
+	if err == nil {
+		config.Values, err = config.Values.WithoutSecrets()
+	}
+	if err != nil {
+		b.fail("deployment/config.json", err)
+	} else {
+		b.writeJSON("deployment/config.json", config.Values)
+	}
+
+	health, err := debugHealth(ctx, client)
+	if err != nil {
+		b.fail("deployment/health.json", err)
+	} else {
+		b.writeFile("deployment/health.json", health)
+	}
+
+	connInfo, err := client.WorkspaceAgentConnectionInfoGeneric(ctx)
+	if err != nil {
+		b.fail("network/derp_map.json", err)
+		return
+	}
+	b.writeJSON("network/derp_map.json", connInfo.DERPMap)
+
+	_, _ = fmt.Fprintln(b.inv.Stderr, "Gathering a network report. This may take a few seconds...")
+	var report healthcheck.DERPReport
+	report.Run(ctx, &healthcheck.DERPReportOptions{
+		DERPMap: connInfo.DERPMap,
+	})
+	b.writeJSON("network/netcheck.json", report)
+}
+
+// debugHealth returns the health report of the deployment. Only owners can
+// read it.
+func debugHealth(ctx context.Context, client *codersdk.Client) ([]byte, error) {
+	res, err := client.Request(ctx, http.MethodGet, "/api/v2/debug/health", nil)
+	if err != nil {
+		return nil, xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, codersdk.ReadBodyAsError(res)
+	}
+	return io.ReadAll(res.Body)
+}
+
+func (r *RootCmd) collectWorkspace(ctx context.Context, b *supportBundleWriter, client *codersdk.Client, arg, agentDebugAddress string) {
+	_, _ = fmt.Fprintln(b.inv.Stderr, "Collecting workspace information...")
+	workspaceName, agentName, _ := strings.Cut(arg, ".")
+	workspace, err := NamedWorkspace(ctx, client, workspaceName)
+	if err != nil {
+		b.fail("workspace/workspace.json", err)
+		return
+	}
+	b.writeJSON("workspace/workspace.json", workspace)
+
+	// The latest build may be pending or stuck, so the logs written so far
+	// are collected instead of following them.
+	buildLogs, err := client.WorkspaceBuildLogs(ctx, workspace.LatestBuild.ID)
+	if err != nil {
+		b.fail("workspace/build_logs.txt", err)
+	} else {
+		var buf bytes.Buffer
+		for _, log := range buildLogs {
+			_, _ = fmt.Fprintf(&buf, "%s [%s] %s: %s\n", log.CreatedAt.Format(time.RFC3339), log.Level, log.Stage, log.Output)
+		}
+		b.writeFile("workspace/build_logs.txt", buf.Bytes())
+	}
+
+	workspaceAgent, err := selectWorkspaceAgent(workspace, agentName)
+	if err != nil {
+		b.fail("agent/agent.json", err)
+		return
+	}
+	b.writeJSON("agent/agent.json", workspaceAgent)
+
+	agentLogs, closer, err := client.WorkspaceAgentLogsAfter(ctx, workspaceAgent.ID, 0, false)
+	if err != nil {
+		b.fail("agent/logs.txt", err)
+	} else {
+		var buf bytes.Buffer
+		for logs := range agentLogs {
+			for _, log := range logs {
+				_, _ = fmt.Fprintf(&buf, "%s [%s] %s\n", log.CreatedAt.Format(time.RFC3339), log.Level, log.Output)
+			}
+		}
+		_ = closer.Close()
+		b.writeFile("agent/logs.txt", buf.Bytes())
+	}
+
+	if workspaceAgent.Status != codersdk.WorkspaceAgentConnected {
+		b.fail("network/ping.txt", xerrors.Errorf("agent is %s", workspaceAgent.Status))
+		return
+	}
+
+	_, _ = fmt.Fprintln(b.inv.Stderr, "Connecting to the workspace agent...")
+	// The connection logs are often the most useful part of a bundle, so
+	// they are always collected at debug level.
+	var connLog bytes.Buffer
+	logger := slog.Make(sloghuman.Sink(&connLog)).Leveled(slog.LevelDebug)
+	defer func() {
+		b.writeFile("network/connection.log", connLog.Bytes())
+	}()
+	dialCtx, dialCancel := context.WithTimeout(ctx, 30*time.Second)
+	defer dialCancel()
+	conn, err := r.dialWorkspaceAgent(dialCtx, client, workspaceAgent.ID, logger)
+	if err != nil {
+		b.fail("network/ping.txt", err)
+		return
+	}
+	defer conn.Close()
+
+	var pings bytes.Buffer
+	for i := 0; i < supportBundlePingCount; i++ {
+		if i > 0 {
+			time.Sleep(time.Second)
+		}
+		pingCtx, pingCancel := context.WithTimeout(ctx, 5*time.Second)
+		dur, p2p, pong, err := conn.Ping(pingCtx)
+		pingCancel()
+		if err != nil {
+			_, _ = fmt.Fprintf(&pings, "ping failed: %s\n", err)
+			continue
+		}
+		via := fmt.Sprintf("DERP region %d", pong.DERPRegionID)
+		if p2p {
+			via = "p2p " + pong.Endpoint
+		}
+		_, _ = fmt.Fprintf(&pings, "pong via %s in %s\n", via, dur.Round(time.Millisecond))
+	}
+	b.writeFile("network/ping.txt", pings.Bytes())
+
+	magicsock := httptest.NewRecorder()
+	conn.MagicsockServeHTTPDebug(magicsock, httptest.NewRequest(http.MethodGet, "/debug/magicsock", nil))
+	b.writeFile("network/client_magicsock.html", magicsock.Body.Bytes())
+
+	// The debug server of the agent only listens inside the workspace, so
+	// it's reached through the SSH server of the agent.
+	agentMagicsock, err := agentDebugMagicsock(ctx, conn, agentDebugAddress)
+	if err != nil {
+		b.fail("network/agent_magicsock.html", err)
+	} else {
+		b.writeFile("network/agent_magicsock.html", agentMagicsock)
+	}
+}
+
+// agentDebugMagicsock fetches the magicsock debug page from the debug server
+// of the agent at address, as seen from within the workspace.
+func agentDebugMagicsock(ctx context.Context, conn *codersdk.WorkspaceAgentConn, address string) ([]byte, error) {
+	sshClient, err := conn.SSHClient(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("ssh client: %w", err)
+	}
+	defer sshClient.Close()
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(_ context.Context, network, addr string) (net.Conn, error) {
+				return sshClient.Dial(network, addr)
+			},
+		},
+	}
+	defer httpClient.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/debug/magicsock", address), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("request agent debug server at %s: %w", address, err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, xerrors.Errorf("read response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("agent debug server at %s: %s: %s", address, res.Status, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,145 @@
+package cli_test
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/agent"
+	"github.com/coder/coder/cli/clitest"
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/codersdk/agentsdk"
+	"github.com/coder/coder/testutil"
+)
+
+func TestSupportBundle(t *testing.T) {
+	t.Parallel()
+
+	readBundle := func(t *testing.T, path string) map[string]string {
+		t.Helper()
+		r, err := zip.OpenReader(path)
+		require.NoError(t, err)
+		defer r.Close()
+		files := map[string]string{}
+		for _, f := range r.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(rc)
+			_ = rc.Close()
+			require.NoError(t, err)
+			files[f.Name] = string(data)
+		}
+		return files
+	}
+
+	t.Run("Workspace", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t, nil)
+		agentClient := agentsdk.New(client.URL)
+		agentClient.SetSessionToken(agentToken)
+		agentCloser := agent.New(agent.Options{
+			Client: agentClient,
+			Logger: slogtest.Make(t, nil).Named("agent"),
+		})
+		t.Cleanup(func() {
+			_ = agentCloser.Close()
+		})
+		coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+		// The agent in the workspace serves its debug server on a random
+		// port instead of the default one.
+		debugServer := httptest.NewServer(agentCloser.HTTPDebug())
+		t.Cleanup(debugServer.Close)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitSuperLong)
+		defer cancel()
+
+		path := filepath.Join(t.TempDir(), "bundle.zip")
+		inv, root := clitest.New(t, "support", "bundle", workspace.Name, "--output-file", path,
+			"--agent-debug-address", debugServer.Listener.Addr().String())
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		files := readBundle(t, path)
+		for _, name := range []string{
+			"cli/version.json",
+			"deployment/buildinfo.json",
+			"deployment/config.json",
+			"network/derp_map.json",
+			"network/netcheck.json",
+			"workspace/workspace.json",
+			"workspace/build_logs.txt",
+			"agent/agent.json",
+			"agent/logs.txt",
+			"network/ping.txt",
+			"network/client_magicsock.html",
+			"network/agent_magicsock.html",
+			"network/connection.log",
+		} {
+			require.Contains(t, files, name)
+		}
+		require.Contains(t, files["workspace/workspace.json"], workspace.ID.String())
+		require.Contains(t, files["network/ping.txt"], "pong via")
+		require.Contains(t, files["network/agent_magicsock.html"], "magicsock")
+		require.NotContains(t, files, "errors.txt")
+	})
+
+	t.Run("PendingBuild", func(t *testing.T) {
+		t.Parallel()
+
+		client, closer := coderdtest.NewWithProvisionerCloser(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		// Without a provisioner daemon, the build stays pending.
+		require.NoError(t, closer.Close())
+		workspace := coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitMedium)
+		defer cancel()
+
+		path := filepath.Join(t.TempDir(), "bundle.zip")
+		inv, root := clitest.New(t, "support", "bundle", workspace.Name, "--output-file", path)
+		clitest.SetupConfig(t, client, root)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		// The build logs aren't followed until the build completes.
+		require.NoError(t, ctx.Err())
+
+		files := readBundle(t, path)
+		require.Contains(t, files, "workspace/build_logs.txt")
+		require.Contains(t, files["errors.txt"], "agent/agent.json")
+	})
+
+	t.Run("NoPermission", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitSuperLong)
+		defer cancel()
+
+		path := filepath.Join(t.TempDir(), "bundle.zip")
+		inv, root := clitest.New(t, "support", "bundle", "--output-file", path)
+		clitest.SetupConfig(t, member, root)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		files := readBundle(t, path)
+		require.Contains(t, files, "cli/version.json")
+		require.Contains(t, files, "network/derp_map.json")
+		require.NotContains(t, files, "deployment/health.json")
+		require.Contains(t, files["errors.txt"], "deployment/health.json")
+	})
+}
@@ -229,6 +229,10 @@ type createValidTemplateVersionArgs struct {
 	// values.
 	ReuseParameters bool
 	ProvisionerTags map[string]string
+
+	// GitURL and GitCommitSHA record where the files were taken from.
+	GitURL       string
+	GitCommitSHA string
 }
 
 func createValidTemplateVersion(inv *clibase.Invocation, args createValidTemplateVersionArgs) (*codersdk.TemplateVersion, error) {
@@ -253,6 +257,8 @@ func createValidTemplateVersion(inv *clibase.Invocation, args createValidTemplat
 		Provisioner:        codersdk.ProvisionerType(args.Provisioner),
 		ProvisionerTags:    args.ProvisionerTags,
 		UserVariableValues: variableValues,
+		GitURL:             args.GitURL,
+		GitCommitSHA:       args.GitCommitSHA,
 	}
 	if args.Template != nil {
 		req.TemplateID = args.Template.ID
@@ -275,7 +275,7 @@ func TestTemplateCreate(t *testing.T) {
 		// We expect the cli to return an error, so we have to handle it
 		// ourselves.
 		go func() {
-			cancel()
+			defer cancel()
 			err := inv.Run()
 			assert.Error(t, err)
 		}()
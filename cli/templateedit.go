@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/cli/clibase"
@@ -28,6 +29,9 @@ func (r *RootCmd) templateEdit() *clibase.Cmd {
 		allowUserCancelWorkspaceJobs bool
 		allowUserAutostart           bool
 		allowUserAutostop            bool
+		deprecationMessage           string
+		replacementTemplate          string
+		requireActiveVersion         bool
 	)
 	client := new(codersdk.Client)
 
@@ -108,6 +112,26 @@ func (r *RootCmd) templateEdit() *clibase.Cmd {
 				AllowUserCancelWorkspaceJobs: allowUserCancelWorkspaceJobs,
 				AllowUserAutostart:           allowUserAutostart,
 				AllowUserAutostop:            allowUserAutostop,
+				RequireActiveVersion:         template.RequireActiveVersion,
+			}
+
+			if inv.ParsedFlags().Changed("require-active-version") {
+				req.RequireActiveVersion = requireActiveVersion
+			}
+
+			if inv.ParsedFlags().Changed("deprecated") {
+				req.DeprecationMessage = &deprecationMessage
+			}
+			if inv.ParsedFlags().Changed("replacement-template") {
+				replacementID := uuid.Nil
+				if replacementTemplate != "" {
+					replacement, err := client.TemplateByName(inv.Context(), organization.ID, replacementTemplate)
+					if err != nil {
+						return xerrors.Errorf("get replacement template: %w", err)
+					}
+					replacementID = replacement.ID
+				}
+				req.ReplacementTemplateID = &replacementID
 			}
 
 			_, err = client.UpdateTemplateMeta(inv.Context(), template.ID, req)
@@ -204,6 +228,22 @@ func (r *RootCmd) templateEdit() *clibase.Cmd {
 			Default:     "true",
 			Value:       clibase.BoolOf(&allowUserAutostop),
 		},
+		{
+			Flag:        "deprecated",
+			Description: "Deprecate the template with the given message, which prevents new workspaces from being created from it. Pass an empty message to undeprecate the template.",
+			Value:       clibase.StringOf(&deprecationMessage),
+		},
+		{
+			Flag:        "replacement-template",
+			Description: "The name of the template users should move to from the deprecated template. Pass an empty name to clear it.",
+			Value:       clibase.StringOf(&replacementTemplate),
+		},
+		{
+			Flag:        "require-active-version",
+			Description: "Require workspaces created from this template to be started with the active template version.",
+			Default:     "false",
+			Value:       clibase.BoolOf(&requireActiveVersion),
+		},
 		cliui.SkipPromptOption(),
 	}
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -242,6 +243,95 @@ func TestTemplateEdit(t *testing.T) {
 		assert.Equal(t, "", updated.Icon)
 		assert.Equal(t, "", updated.DisplayName)
 	})
+	t.Run("Deprecate", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		replacementVersion := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, replacementVersion.ID)
+		replacement := coderdtest.CreateTemplate(t, client, user.OrganizationID, replacementVersion.ID)
+
+		inv, root := clitest.New(t, "templates", "edit", template.Name,
+			"--deprecated", "Moved to a bigger image.",
+			"--replacement-template", replacement.Name,
+		)
+		clitest.SetupConfig(t, client, root)
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		updated, err := client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		assert.True(t, updated.Deprecated)
+		assert.Equal(t, "Moved to a bigger image.", updated.DeprecationMessage)
+		require.NotNil(t, updated.ReplacementTemplateID)
+		assert.Equal(t, replacement.ID, *updated.ReplacementTemplateID)
+
+		// Creating a workspace points to the replacement.
+		inv, root = clitest.New(t, "create", "my-workspace", "--template", template.Name, "-y")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.ErrorContains(t, err, "Moved to a bigger image.")
+		require.ErrorContains(t, err, fmt.Sprintf("Use the %q template instead.", replacement.Name))
+
+		// Editing other metadata keeps the template deprecated.
+		inv, root = clitest.New(t, "templates", "edit", template.Name, "--description", "old")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		updated, err = client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		assert.True(t, updated.Deprecated)
+
+		inv, root = clitest.New(t, "templates", "edit", template.Name, "--deprecated", "")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		updated, err = client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		assert.False(t, updated.Deprecated)
+		assert.Nil(t, updated.ReplacementTemplateID)
+	})
+
+	t.Run("RequireActiveVersion", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+
+		inv, root := clitest.New(t, "templates", "edit", template.Name, "--require-active-version")
+		clitest.SetupConfig(t, client, root)
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err := inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+
+		updated, err := client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		assert.True(t, updated.RequireActiveVersion)
+
+		// Editing other metadata keeps the policy.
+		inv, root = clitest.New(t, "templates", "edit", template.Name, "--description", "new")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		updated, err = client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		assert.True(t, updated.RequireActiveVersion)
+
+		inv, root = clitest.New(t, "templates", "edit", template.Name, "--require-active-version=false")
+		clitest.SetupConfig(t, client, root)
+		err = inv.WithContext(ctx).Run()
+		require.NoError(t, err)
+		updated, err = client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		assert.False(t, updated.RequireActiveVersion)
+	})
+
 	t.Run("RestartRequirement", func(t *testing.T) {
 		t.Parallel()
 		t.Run("BlockedAGPL", func(t *testing.T) {
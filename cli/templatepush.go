@@ -2,8 +2,11 @@ package cli
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -23,6 +26,9 @@ type templateUploadFlags struct {
 	directory      string
 	ignoreLockfile bool
 	message        string
+	// source is shown instead of the directory when confirming the
+	// upload, e.g. for a directory cloned from Git.
+	source string
 }
 
 func (pf *templateUploadFlags) options() []clibase.Option {
@@ -66,6 +72,9 @@ func (pf *templateUploadFlags) upload(inv *clibase.Invocation, client *codersdk.
 		content = inv.Stdin
 	} else {
 		prettyDir := prettyDirectoryPath(pf.directory)
+		if pf.source != "" {
+			prettyDir = pf.source
+		}
 		_, err := cliui.Prompt(inv, cliui.PromptOptions{
 			Text:      fmt.Sprintf("Upload %q?", prettyDir),
 			IsConfirm: true,
@@ -164,17 +173,47 @@ func (r *RootCmd) templatePush() *clibase.Cmd {
 		uploadFlags     templateUploadFlags
 		activate        bool
 		create          bool
+		gitURL          string
+		gitRef          string
 	)
 	client := new(codersdk.Client)
 	cmd := &clibase.Cmd{
 		Use:   "push [template]",
 		Short: "Push a new template version from the current directory or as specified by flag",
+		Long: formatExamples(
+			example{
+				Description: "Push the template in the current directory",
+				Command:     "coder templates push my-template",
+			},
+			example{
+				Description: "Push a template from a subdirectory of a tag in a Git repository",
+				Command:     "coder templates push my-template --git-url https://github.com/example/templates.git --git-ref v1.2.0 --directory docker",
+			},
+		),
 		Middleware: clibase.Chain(
 			clibase.RequireRangeArgs(0, 1),
 			r.InitClient(client),
 		),
 		Handler: func(inv *clibase.Invocation) error {
-			uploadFlags.setWorkdir(workdir)
+			var git *templateGitSource
+			if gitURL != "" {
+				var err error
+				git, err = cloneTemplateGitSource(inv, gitURL, gitRef, uploadFlags.directory)
+				if err != nil {
+					return err
+				}
+				defer git.close()
+				uploadFlags.directory = git.directory
+				uploadFlags.source = git.String()
+				if uploadFlags.message == "" {
+					uploadFlags.message = git.subject
+				}
+			} else {
+				if gitRef != "" {
+					return xerrors.New("--git-ref requires --git-url")
+				}
+				uploadFlags.setWorkdir(workdir)
+			}
 
 			organization, err := CurrentOrganization(inv, client)
 			if err != nil {
@@ -185,6 +224,11 @@ func (r *RootCmd) templatePush() *clibase.Cmd {
 			if err != nil {
 				return err
 			}
+			if git != nil && len(inv.Args) == 0 {
+				// The directory is a temporary clone, name the template
+				// after the repository instead.
+				name = git.templateName()
+			}
 
 			var createTemplate bool
 			template, err := client.TemplateByName(inv.Context(), organization.ID, name)
@@ -222,6 +266,10 @@ func (r *RootCmd) templatePush() *clibase.Cmd {
 				VariablesFile:   variablesFile,
 				Variables:       variables,
 			}
+			if git != nil {
+				args.GitURL = git.url
+				args.GitCommitSHA = git.commitSHA
+			}
 
 			if !createTemplate {
 				args.Name = versionName
@@ -323,8 +371,125 @@ func (r *RootCmd) templatePush() *clibase.Cmd {
 			Default:     "false",
 			Value:       clibase.BoolOf(&create),
 		},
+		{
+			Flag:        "git-url",
+			Env:         "CODER_TEMPLATE_PUSH_GIT_URL",
+			Description: "Clone the template from a Git repository instead of uploading a local directory. The commit is recorded on the template version and --directory selects a subdirectory of the repository.",
+			Value:       clibase.StringOf(&gitURL),
+		},
+		{
+			Flag:        "git-ref",
+			Env:         "CODER_TEMPLATE_PUSH_GIT_REF",
+			Description: "Branch, tag or commit SHA to check out with --git-url. Defaults to the default branch of the repository.",
+			Value:       clibase.StringOf(&gitRef),
+		},
 		cliui.SkipPromptOption(),
 	}
 	cmd.Options = append(cmd.Options, uploadFlags.options()...)
 	return cmd
 }
+
+// templateGitSource is a template checked out from a Git repository into a
+// temporary directory.
+type templateGitSource struct {
+	// url has credentials removed so it can be displayed and stored.
+	url       string
+	ref       string
+	subdir    string
+	commitSHA string
+	subject   string
+
+	cloneDir  string
+	directory string
+}
+
+// cloneTemplateGitSource fetches a single commit of the repository with the
+// git binary, so the user's credential helpers and SSH config apply.
+func cloneTemplateGitSource(inv *clibase.Invocation, rawURL, ref, subdir string) (*templateGitSource, error) {
+	if subdir == "-" {
+		return nil, xerrors.New("--git-url can't be combined with reading a tar from stdin")
+	}
+	subdir = filepath.Clean(subdir)
+	if filepath.IsAbs(subdir) || subdir == ".." || strings.HasPrefix(subdir, ".."+string(filepath.Separator)) {
+		return nil, xerrors.Errorf("--directory %q must be relative to the root of the repository", subdir)
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, xerrors.Errorf("--git-url requires git to be installed: %w", err)
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	cloneDir, err := os.MkdirTemp("", "coder-template-git-")
+	if err != nil {
+		return nil, xerrors.Errorf("create temporary directory: %w", err)
+	}
+	src := &templateGitSource{
+		url:       codersdk.RedactGitURL(rawURL),
+		ref:       ref,
+		subdir:    subdir,
+		cloneDir:  cloneDir,
+		directory: filepath.Join(cloneDir, subdir),
+	}
+
+	git := func(args ...string) (string, error) {
+		cmd := exec.CommandContext(inv.Context(), "git", append([]string{"-C", cloneDir}, args...)...)
+		// Never block on a credential prompt.
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return "", xerrors.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	_, _ = fmt.Fprintf(inv.Stderr, "Fetching %s from %s...\n", cliui.DefaultStyles.Code.Render(ref), src.url)
+	_, err = git("init", "--quiet")
+	if err == nil {
+		_, err = git("fetch", "--quiet", "--depth", "1", rawURL, ref)
+	}
+	if err == nil {
+		_, err = git("checkout", "--quiet", "FETCH_HEAD")
+	}
+	if err == nil {
+		src.commitSHA, err = git("rev-parse", "HEAD")
+	}
+	if err == nil {
+		src.subject, err = git("log", "-1", "--format=%s")
+	}
+	if err != nil {
+		src.close()
+		return nil, err
+	}
+
+	stat, err := os.Stat(src.directory)
+	if err != nil || !stat.IsDir() {
+		src.close()
+		return nil, xerrors.Errorf("directory %q does not exist in %s at %s", subdir, src.url, shortCommitSHA(src.commitSHA))
+	}
+	return src, nil
+}
+
+func (s *templateGitSource) close() {
+	_ = os.RemoveAll(s.cloneDir)
+}
+
+// templateName is the name of the subdirectory, or of the repository if the
+// template is at its root.
+func (s *templateGitSource) templateName() string {
+	if s.subdir != "." {
+		return filepath.Base(s.subdir)
+	}
+	name := strings.TrimSuffix(strings.TrimRight(s.url, "/"), ".git")
+	return name[strings.LastIndexAny(name, "/:")+1:]
+}
+
+func (s *templateGitSource) String() string {
+	source := s.url
+	if s.subdir != "." {
+		source += "//" + filepath.ToSlash(s.subdir)
+	}
+	return fmt.Sprintf("%s at %s", source, shortCommitSHA(s.commitSHA))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -372,6 +373,71 @@ func TestTemplatePush(t *testing.T) {
 		assert.NotEqual(t, template.ActiveVersionID, templateVersions[1].ID)
 	})
 
+	t.Run("Git", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git is not installed")
+		}
+
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+
+		// Commit the template to a subdirectory of a local repository.
+		repo := t.TempDir()
+		source := clitest.CreateTemplateVersionSource(t, &echo.Responses{
+			Parse:          echo.ParseComplete,
+			ProvisionApply: echo.ProvisionComplete,
+		})
+		require.NoError(t, os.Rename(source, filepath.Join(repo, "docker")))
+		git := func(args ...string) string {
+			args = append([]string{"-C", repo, "-c", "user.name=Coder", "-c", "user.email=test@coder.com"}, args...)
+			out, err := exec.Command("git", args...).CombinedOutput()
+			require.NoError(t, err, string(out))
+			return strings.TrimSpace(string(out))
+		}
+		git("init", "--quiet")
+		git("add", ".")
+		git("commit", "--quiet", "-m", "Add docker template")
+		sha := git("rev-parse", "HEAD")
+
+		inv, root := clitest.New(t, "templates", "push", template.Name,
+			"--git-url", repo,
+			"--directory", "docker",
+			"--test.provisioner", string(database.ProvisionerTypeEcho),
+			"--yes",
+		)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.Run())
+
+		templateVersions, err := client.TemplateVersionsByTemplate(context.Background(), codersdk.TemplateVersionsByTemplateRequest{
+			TemplateID: template.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, templateVersions, 2)
+		latest := templateVersions[1]
+		assert.Equal(t, repo, latest.GitURL)
+		assert.Equal(t, sha, latest.GitCommitSHA)
+		assert.Equal(t, "Add docker template", latest.Message)
+
+		t.Run("DirectoryOutsideRepository", func(t *testing.T) {
+			t.Parallel()
+
+			inv, root := clitest.New(t, "templates", "push", template.Name,
+				"--git-url", repo,
+				"--directory", "../docker",
+				"--test.provisioner", string(database.ProvisionerTypeEcho),
+				"--yes",
+			)
+			clitest.SetupConfig(t, client, root)
+			err := inv.Run()
+			require.ErrorContains(t, err, "must be relative to the root of the repository")
+		})
+	})
+
 	t.Run("Variables", func(t *testing.T) {
 		t.Parallel()
 
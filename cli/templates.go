@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -61,6 +63,8 @@ type templateTableRow struct {
 	ActiveVersionID uuid.UUID                `json:"-" table:"active version id"`
 	UsedBy          string                   `json:"-" table:"used by"`
 	DefaultTTL      time.Duration            `json:"-" table:"default ttl"`
+	Workspaces      int64                    `json:"-" table:"workspaces"`
+	Deprecated      string                   `json:"-" table:"deprecated"`
 }
 
 // templateToRows converts a list of templates to a list of templateTableRow for
@@ -78,8 +82,23 @@ func templatesToRows(templates ...codersdk.Template) []templateTableRow {
 			ActiveVersionID: template.ActiveVersionID,
 			UsedBy:          cliui.DefaultStyles.Fuchsia.Render(formatActiveDevelopers(template.ActiveUserCount)),
 			DefaultTTL:      (time.Duration(template.DefaultTTLMillis) * time.Millisecond),
+			Workspaces:      template.WorkspaceCount,
+			Deprecated:      template.DeprecationMessage,
 		}
 	}
 
 	return rows
 }
+
+// templateDeprecationMessage explains that the template is deprecated and
+// names its replacement if the user can see it.
+func templateDeprecationMessage(ctx context.Context, client *codersdk.Client, template codersdk.Template) string {
+	message := fmt.Sprintf("Template %q is deprecated: %s", template.Name, template.DeprecationMessage)
+	if template.ReplacementTemplateID != nil {
+		replacement, err := client.Template(ctx, *template.ReplacementTemplateID)
+		if err == nil {
+			message += fmt.Sprintf(" Use the %q template instead.", replacement.Name)
+		}
+	}
+	return message
+}
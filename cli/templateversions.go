@@ -23,12 +23,20 @@ func (r *RootCmd) templateVersions() *clibase.Cmd {
 				Description: "List versions of a specific template",
 				Command:     "coder templates versions list my-template",
 			},
+			example{
+				Description: "Review the changes of a new version before making it the active version",
+				Command:     "coder templates versions diff my-template v1 v2 && coder templates versions promote my-template v2",
+			},
 		),
 		Handler: func(inv *clibase.Invocation) error {
 			return inv.Command.HelpHandler(inv)
 		},
 		Children: []*clibase.Cmd{
 			r.templateVersionsList(),
+			r.templateVersionsPromote(),
+			r.setArchiveTemplateVersions(true),
+			r.setArchiveTemplateVersions(false),
+			r.templateVersionsDiff(),
 		},
 	}
 
@@ -41,6 +49,7 @@ func (r *RootCmd) templateVersionsList() *clibase.Cmd {
 		cliui.JSONFormat(),
 	)
 	client := new(codersdk.Client)
+	var includeArchived clibase.Bool
 
 	cmd := &clibase.Cmd{
 		Use: "list <template>",
@@ -59,7 +68,8 @@ func (r *RootCmd) templateVersionsList() *clibase.Cmd {
 				return xerrors.Errorf("get template by name: %w", err)
 			}
 			req := codersdk.TemplateVersionsByTemplateRequest{
-				TemplateID: template.ID,
+				TemplateID:      template.ID,
+				IncludeArchived: includeArchived.Value(),
 			}
 
 			versions, err := client.TemplateVersionsByTemplate(inv.Context(), req)
@@ -78,10 +88,120 @@ func (r *RootCmd) templateVersionsList() *clibase.Cmd {
 		},
 	}
 
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:        "include-archived",
+			Env:         "CODER_TEMPLATE_VERSIONS_LIST_INCLUDE_ARCHIVED",
+			Description: "Include archived versions in the list.",
+			Value:       &includeArchived,
+		},
+	}
 	formatter.AttachOptions(&cmd.Options)
 	return cmd
 }
 
+func (r *RootCmd) templateVersionsPromote() *clibase.Cmd {
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "promote <template> <version>",
+		Short: "Make a version the active version of the specified template",
+		Long:  "New workspaces are created from the active version and existing workspaces are prompted to update to it.",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			template, version, err := templateVersionByName(inv, client, inv.Args[0], inv.Args[1])
+			if err != nil {
+				return err
+			}
+			if template.ActiveVersionID == version.ID {
+				_, _ = fmt.Fprintf(inv.Stdout, "Version %s is already the active version of template %s\n",
+					cliui.DefaultStyles.Keyword.Render(version.Name), cliui.DefaultStyles.Keyword.Render(template.Name))
+				return nil
+			}
+
+			err = client.UpdateActiveTemplateVersion(inv.Context(), template.ID, codersdk.UpdateActiveTemplateVersion{
+				ID: version.ID,
+			})
+			if err != nil {
+				return xerrors.Errorf("update active template version: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Promoted version %s to the active version of template %s\n",
+				cliui.DefaultStyles.Keyword.Render(version.Name), cliui.DefaultStyles.Keyword.Render(template.Name))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// setArchiveTemplateVersions returns the archive or unarchive command.
+func (r *RootCmd) setArchiveTemplateVersions(archive bool) *clibase.Cmd {
+	var (
+		use    = "archive <template> <version...>"
+		short  = "Archive versions of the specified template"
+		long   = "Archived versions are hidden from version lists and can't be used to start workspaces. Workspaces on an archived version can still be stopped and updated. The active version can't be archived."
+		action = "archive"
+		verb   = "Archived"
+	)
+	if !archive {
+		use = "unarchive <template> <version...>"
+		short = "Unarchive versions of the specified template"
+		long = "Unarchived versions are listed again and can be used to start workspaces."
+		action = "unarchive"
+		verb = "Unarchived"
+	}
+
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   use,
+		Short: short,
+		Long:  long,
+		Middleware: clibase.Chain(
+			clibase.RequireRangeArgs(2, -1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			for _, versionName := range inv.Args[1:] {
+				template, version, err := templateVersionByName(inv, client, inv.Args[0], versionName)
+				if err != nil {
+					return err
+				}
+				if archive {
+					err = client.ArchiveTemplateVersion(inv.Context(), version.ID)
+				} else {
+					err = client.UnarchiveTemplateVersion(inv.Context(), version.ID)
+				}
+				if err != nil {
+					return xerrors.Errorf("%s version %q: %w", action, version.Name, err)
+				}
+				_, _ = fmt.Fprintf(inv.Stdout, "%s version %s of template %s\n", verb,
+					cliui.DefaultStyles.Keyword.Render(version.Name), cliui.DefaultStyles.Keyword.Render(template.Name))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// templateVersionByName returns a template in the current organization and
+// one of its versions.
+func templateVersionByName(inv *clibase.Invocation, client *codersdk.Client, templateName, versionName string) (codersdk.Template, codersdk.TemplateVersion, error) {
+	organization, err := CurrentOrganization(inv, client)
+	if err != nil {
+		return codersdk.Template{}, codersdk.TemplateVersion{}, xerrors.Errorf("get current organization: %w", err)
+	}
+	template, err := client.TemplateByName(inv.Context(), organization.ID, templateName)
+	if err != nil {
+		return codersdk.Template{}, codersdk.TemplateVersion{}, xerrors.Errorf("get template by name: %w", err)
+	}
+	version, err := client.TemplateVersionByName(inv.Context(), template.ID, versionName)
+	if err != nil {
+		return codersdk.Template{}, codersdk.TemplateVersion{}, xerrors.Errorf("get template version %q: %w", versionName, err)
+	}
+	return template, version, nil
+}
+
 type templateVersionRow struct {
 	// For json format:
 	TemplateVersion codersdk.TemplateVersion `table:"-"`
@@ -91,7 +211,9 @@ type templateVersionRow struct {
 	CreatedAt time.Time `json:"-" table:"created at"`
 	CreatedBy string    `json:"-" table:"created by"`
 	Status    string    `json:"-" table:"status"`
+	Commit    string    `json:"-" table:"commit"`
 	Active    string    `json:"-" table:"active"`
+	Archived  string    `json:"-" table:"archived"`
 }
 
 // templateVersionsToRows converts a list of template versions to a list of rows
@@ -104,15 +226,31 @@ func templateVersionsToRows(activeVersionID uuid.UUID, templateVersions ...coder
 			activeStatus = cliui.DefaultStyles.Code.Render(cliui.DefaultStyles.Keyword.Render("Active"))
 		}
 
+		archivedStatus := ""
+		if templateVersion.Archived {
+			archivedStatus = cliui.DefaultStyles.Warn.Render("Archived")
+		}
+
 		rows[i] = templateVersionRow{
 			TemplateVersion: templateVersion,
 			Name:            templateVersion.Name,
 			CreatedAt:       templateVersion.CreatedAt,
 			CreatedBy:       templateVersion.CreatedBy.Username,
 			Status:          strings.Title(string(templateVersion.Job.Status)),
+			Commit:          shortCommitSHA(templateVersion.GitCommitSHA),
 			Active:          activeStatus,
+			Archived:        archivedStatus,
 		}
 	}
 
 	return rows
 }
+
+// shortCommitSHA abbreviates a Git commit SHA to the length Git uses by
+// default.
+func shortCommitSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
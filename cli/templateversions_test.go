@@ -1,13 +1,17 @@
 package cli_test
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/cli/clitest"
 	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/provisionersdk/proto"
 	"github.com/coder/coder/pty/ptytest"
+	"github.com/coder/coder/testutil"
 )
 
 func TestTemplateVersions(t *testing.T) {
@@ -36,4 +40,95 @@ func TestTemplateVersions(t *testing.T) {
 		pty.ExpectMatch(version.CreatedBy.Username)
 		pty.ExpectMatch("Active")
 	})
+	t.Run("PromoteArchiveUnarchive", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		oldVersion := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, oldVersion.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, oldVersion.ID)
+		newVersion := coderdtest.UpdateTemplateVersion(t, client, user.OrganizationID, nil, template.ID)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, newVersion.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		inv, root := clitest.New(t, "templates", "versions", "promote", template.Name, newVersion.Name)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+		template, err := client.Template(ctx, template.ID)
+		require.NoError(t, err)
+		require.Equal(t, newVersion.ID, template.ActiveVersionID)
+
+		inv, root = clitest.New(t, "templates", "versions", "archive", template.Name, oldVersion.Name)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+		version, err := client.TemplateVersion(ctx, oldVersion.ID)
+		require.NoError(t, err)
+		require.True(t, version.Archived)
+
+		// Archived versions are only listed on request.
+		var out bytes.Buffer
+		inv, root = clitest.New(t, "templates", "versions", "list", template.Name)
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &out
+		require.NoError(t, inv.WithContext(ctx).Run())
+		require.NotContains(t, out.String(), oldVersion.Name)
+		out.Reset()
+		inv, root = clitest.New(t, "templates", "versions", "list", template.Name, "--include-archived")
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &out
+		require.NoError(t, inv.WithContext(ctx).Run())
+		require.Contains(t, out.String(), oldVersion.Name)
+		require.Contains(t, out.String(), "Archived")
+
+		// Archived versions can't be promoted.
+		inv, root = clitest.New(t, "templates", "versions", "promote", template.Name, oldVersion.Name)
+		clitest.SetupConfig(t, client, root)
+		require.ErrorContains(t, inv.WithContext(ctx).Run(), "archived")
+
+		inv, root = clitest.New(t, "templates", "versions", "unarchive", template.Name, oldVersion.Name)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+		version, err = client.TemplateVersion(ctx, oldVersion.ID)
+		require.NoError(t, err)
+		require.False(t, version.Archived)
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		oldVersion := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, createEchoResponsesWithTemplateVariables([]*proto.TemplateVariable{
+			{Name: "region", Type: "string", DefaultValue: "us"},
+			{Name: "token", Type: "string", DefaultValue: "old-secret", Sensitive: true},
+		}))
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, oldVersion.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, oldVersion.ID)
+		newVersion := coderdtest.UpdateTemplateVersion(t, client, user.OrganizationID, createEchoResponsesWithTemplateVariables([]*proto.TemplateVariable{
+			{Name: "region", Type: "string", DefaultValue: "eu"},
+			{Name: "size", Type: "number", DefaultValue: "2"},
+			{Name: "token", Type: "string", DefaultValue: "new-secret", Sensitive: true},
+		}), template.ID)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, newVersion.ID)
+
+		var out bytes.Buffer
+		inv, root := clitest.New(t, "templates", "versions", "diff", template.Name, oldVersion.Name, newVersion.Name)
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &out
+		require.NoError(t, inv.Run())
+
+		require.Contains(t, out.String(), "--- a/")
+		require.Contains(t, out.String(), `~ variable "region" default: "us" -> "eu"`)
+		require.Contains(t, out.String(), `+ variable "size" added`)
+		require.NotContains(t, out.String(), `variable "token"`)
+
+		// A version is identical to itself.
+		out.Reset()
+		inv, root = clitest.New(t, "templates", "versions", "diff", template.Name, newVersion.Name, newVersion.Name)
+		clitest.SetupConfig(t, client, root)
+		inv.Stdout = &out
+		require.NoError(t, inv.Run())
+		require.Empty(t, out.String())
+	})
 }
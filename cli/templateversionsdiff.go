@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/diff"
+	"github.com/pkg/diff/write"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+func (r *RootCmd) templateVersionsDiff() *clibase.Cmd {
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "diff <template> <old-version> <new-version>",
+		Short: "Show the changes between two versions of the specified template",
+		Long: "Compares the files, rich parameters and template variables of the " +
+			"versions. File changes are printed as a unified diff.",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(3),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			ctx := inv.Context()
+			template, oldVersion, err := templateVersionByName(inv, client, inv.Args[0], inv.Args[1])
+			if err != nil {
+				return err
+			}
+			newVersion, err := client.TemplateVersionByName(ctx, template.ID, inv.Args[2])
+			if err != nil {
+				return xerrors.Errorf("get template version %q: %w", inv.Args[2], err)
+			}
+
+			oldFiles, err := templateVersionFiles(ctx, client, oldVersion)
+			if err != nil {
+				return err
+			}
+			newFiles, err := templateVersionFiles(ctx, client, newVersion)
+			if err != nil {
+				return err
+			}
+			oldParams, err := client.TemplateVersionRichParameters(ctx, oldVersion.ID)
+			if err != nil {
+				return xerrors.Errorf("get parameters of %q: %w", oldVersion.Name, err)
+			}
+			newParams, err := client.TemplateVersionRichParameters(ctx, newVersion.ID)
+			if err != nil {
+				return xerrors.Errorf("get parameters of %q: %w", newVersion.Name, err)
+			}
+			oldVariables, err := client.TemplateVersionVariables(ctx, oldVersion.ID)
+			if err != nil {
+				return xerrors.Errorf("get variables of %q: %w", oldVersion.Name, err)
+			}
+			newVariables, err := client.TemplateVersionVariables(ctx, newVersion.ID)
+			if err != nil {
+				return xerrors.Errorf("get variables of %q: %w", newVersion.Name, err)
+			}
+
+			var out bytes.Buffer
+			changed, err := diffTemplateVersionFiles(&out, oldFiles, newFiles, isTTYOut(inv))
+			if err != nil {
+				return err
+			}
+			changes := diffTemplateVersionParameters(oldParams, newParams)
+			changes = append(changes, diffTemplateVersionVariables(oldVariables, newVariables)...)
+			if len(changes) > 0 {
+				if changed {
+					_, _ = fmt.Fprintln(&out)
+				}
+				for _, change := range changes {
+					_, _ = fmt.Fprintln(&out, change)
+				}
+				changed = true
+			}
+			if !changed {
+				_, _ = fmt.Fprintf(inv.Stderr, "Versions %s and %s of template %s are identical\n",
+					cliui.DefaultStyles.Keyword.Render(oldVersion.Name), cliui.DefaultStyles.Keyword.Render(newVersion.Name),
+					cliui.DefaultStyles.Keyword.Render(template.Name))
+				return nil
+			}
+			_, err = io.Copy(inv.Stdout, &out)
+			return err
+		},
+	}
+	return cmd
+}
+
+// templateVersionFiles returns the regular files in the source archive of a
+// template version by path.
+func templateVersionFiles(ctx context.Context, client *codersdk.Client, version codersdk.TemplateVersion) (map[string][]byte, error) {
+	raw, contentType, err := client.Download(ctx, version.Job.FileID)
+	if err != nil {
+		return nil, xerrors.Errorf("download files of %q: %w", version.Name, err)
+	}
+	if contentType != codersdk.ContentTypeTar {
+		return nil, xerrors.Errorf("files of %q have unsupported content type %q", version.Name, contentType)
+	}
+
+	files := map[string][]byte{}
+	reader := tar.NewReader(bytes.NewReader(raw))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("read files of %q: %w", version.Name, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, xerrors.Errorf("read %q of %q: %w", header.Name, version.Name, err)
+		}
+		files[path.Clean(header.Name)] = data
+	}
+	return files, nil
+}
+
+// diffTemplateVersionFiles writes a unified diff of the files that differ
+// and reports whether there were any.
+// nolint: revive // Color is an option, not a control coupling.
+func diffTemplateVersionFiles(w io.Writer, oldFiles, newFiles map[string][]byte, color bool) (bool, error) {
+	names := make([]string, 0, len(oldFiles)+len(newFiles))
+	for name := range oldFiles {
+		names = append(names, name)
+	}
+	for name := range newFiles {
+		if _, ok := oldFiles[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var opts []write.Option
+	if color {
+		opts = append(opts, write.TerminalColor())
+	}
+	changed := false
+	for _, name := range names {
+		oldData, inOld := oldFiles[name]
+		newData, inNew := newFiles[name]
+		if inOld && inNew && bytes.Equal(oldData, newData) {
+			continue
+		}
+		changed = true
+
+		oldName, newName := "a/"+name, "b/"+name
+		if !inOld {
+			oldName = "/dev/null"
+		}
+		if !inNew {
+			newName = "/dev/null"
+		}
+		if bytes.IndexByte(oldData, 0) >= 0 || bytes.IndexByte(newData, 0) >= 0 {
+			_, _ = fmt.Fprintf(w, "Binary files %s and %s differ\n", oldName, newName)
+			continue
+		}
+		// Text reads from the file names if the contents are nil.
+		err := diff.Text(oldName, newName, string(oldData), string(newData), w, opts...)
+		if err != nil {
+			return false, xerrors.Errorf("diff %q: %w", name, err)
+		}
+	}
+	return changed, nil
+}
+
+// diffTemplateVersionParameters describes parameters that were added,
+// removed or changed.
+func diffTemplateVersionParameters(oldParams, newParams []codersdk.TemplateVersionParameter) []string {
+	describe := func(p codersdk.TemplateVersionParameter) []templateVersionField {
+		options := make([]string, 0, len(p.Options))
+		for _, option := range p.Options {
+			options = append(options, fmt.Sprintf("%s=%s", option.Name, option.Value))
+		}
+		fields := []templateVersionField{
+			{"display name", p.DisplayName},
+			{"type", p.Type},
+			{"default", p.DefaultValue},
+			{"required", fmt.Sprint(p.Required)},
+			{"mutable", fmt.Sprint(p.Mutable)},
+			{"ephemeral", fmt.Sprint(p.Ephemeral)},
+			{"options", strings.Join(options, ", ")},
+			{"validation regex", p.ValidationRegex},
+			{"validation monotonic", string(p.ValidationMonotonic)},
+			{"description", p.DescriptionPlaintext},
+		}
+		if p.ValidationMin != nil {
+			fields = append(fields, templateVersionField{"validation min", fmt.Sprint(*p.ValidationMin)})
+		}
+		if p.ValidationMax != nil {
+			fields = append(fields, templateVersionField{"validation max", fmt.Sprint(*p.ValidationMax)})
+		}
+		return fields
+	}
+
+	oldByName := map[string][]templateVersionField{}
+	for _, p := range oldParams {
+		oldByName[p.Name] = describe(p)
+	}
+	newByName := map[string][]templateVersionField{}
+	for _, p := range newParams {
+		newByName[p.Name] = describe(p)
+	}
+	return diffTemplateVersionFields("parameter", oldByName, newByName)
+}
+
+// diffTemplateVersionVariables describes template variables that were added,
+// removed or changed. Values of sensitive variables are never printed.
+func diffTemplateVersionVariables(oldVariables, newVariables []codersdk.TemplateVersionVariable) []string {
+	describe := func(v codersdk.TemplateVersionVariable) []templateVersionField {
+		defaultValue := v.DefaultValue
+		if v.Sensitive && defaultValue != "" {
+			defaultValue = "(sensitive)"
+		}
+		return []templateVersionField{
+			{"type", v.Type},
+			{"default", defaultValue},
+			{"required", fmt.Sprint(v.Required)},
+			{"sensitive", fmt.Sprint(v.Sensitive)},
+			{"description", v.Description},
+		}
+	}
+
+	oldByName := map[string][]templateVersionField{}
+	for _, v := range oldVariables {
+		oldByName[v.Name] = describe(v)
+	}
+	newByName := map[string][]templateVersionField{}
+	for _, v := range newVariables {
+		newByName[v.Name] = describe(v)
+	}
+	return diffTemplateVersionFields("variable", oldByName, newByName)
+}
+
+type templateVersionField struct {
+	name  string
+	value string
+}
+
+func diffTemplateVersionFields(kind string, oldByName, newByName map[string][]templateVersionField) []string {
+	names := make([]string, 0, len(oldByName)+len(newByName))
+	for name := range oldByName {
+		names = append(names, name)
+	}
+	for name := range newByName {
+		if _, ok := oldByName[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []string
+	for _, name := range names {
+		oldFields, inOld := oldByName[name]
+		newFields, inNew := newByName[name]
+		switch {
+		case !inOld:
+			changes = append(changes, fmt.Sprintf("+ %s %q added", kind, name))
+		case !inNew:
+			changes = append(changes, fmt.Sprintf("- %s %q removed", kind, name))
+		default:
+			oldValues := map[string]string{}
+			for _, field := range oldFields {
+				oldValues[field.name] = field.value
+			}
+			seen := map[string]bool{}
+			for _, field := range newFields {
+				seen[field.name] = true
+				if oldValue := oldValues[field.name]; oldValue != field.value {
+					changes = append(changes, fmt.Sprintf("~ %s %q %s: %q -> %q", kind, name, field.name, oldValue, field.value))
+				}
+			}
+			for _, field := range oldFields {
+				if !seen[field.name] {
+					changes = append(changes, fmt.Sprintf("~ %s %q %s: %q -> %q", kind, name, field.name, field.value, ""))
+				}
+			}
+		}
+	}
+	return changes
+}
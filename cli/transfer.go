@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+func (r *RootCmd) transfer() *clibase.Cmd {
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Annotations: workspaceCommand,
+		Use:         "transfer <workspace> <new owner>",
+		Short:       "Transfer a stopped workspace to another user",
+		Long: "Only stopped workspaces can be transferred. The workspace stops being shared with anyone, and agents get new tokens on the next build of the workspace.\n\n" + formatExamples(
+			example{
+				Description: "Give a workspace and its persistent resources to another user",
+				Command:     "coder transfer alice/dev bob",
+			},
+		),
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			workspace, err := NamedWorkspace(inv.Context(), client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+			newOwner, err := client.User(inv.Context(), inv.Args[1])
+			if err != nil {
+				return xerrors.Errorf("get user: %w", err)
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      fmt.Sprintf("Transfer %s to %s? The agent token is regenerated on the next build.", workspace.FullName(), newOwner.Username),
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			workspace, err = client.TransferWorkspace(inv.Context(), workspace.ID, codersdk.TransferWorkspaceRequest{
+				OwnerID: newOwner.ID,
+			})
+			if err != nil {
+				return xerrors.Errorf("transfer workspace: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Workspace %s has been transferred to %s\n", cliui.DefaultStyles.Keyword.Render(workspace.Name), cliui.DefaultStyles.Keyword.Render(workspace.OwnerName))
+			return nil
+		},
+	}
+
+	cmd.Options = append(cmd.Options, cliui.SkipPromptOption())
+
+	return cmd
+}
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+// workspaceBulkPollInterval is how often the progress of a bulk operation is
+// fetched.
+const workspaceBulkPollInterval = time.Second
+
+func (r *RootCmd) workspaces() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Annotations: workspaceCommand,
+		Use:         "workspaces",
+		Short:       "Manage many workspaces at once",
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.workspacesBulk(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) workspacesBulk() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:   "bulk",
+		Short: "Build every workspace matching a search query",
+		Long: "The builds run on the server, so the command can be interrupted and the progress " +
+			"followed later with \"coder workspaces bulk status\".",
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.workspacesBulkTransition(codersdk.WorkspaceBulkTransitionDelete, "Delete workspaces matching a search query"),
+			r.workspacesBulkTransition(codersdk.WorkspaceBulkTransitionStart, "Start workspaces matching a search query"),
+			r.workspacesBulkStatus(),
+			r.workspacesBulkTransition(codersdk.WorkspaceBulkTransitionStop, "Stop workspaces matching a search query"),
+			r.workspacesBulkTransition(codersdk.WorkspaceBulkTransitionUpdate, "Update workspaces matching a search query to the active version of their template"),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) workspacesBulkTransition(transition codersdk.WorkspaceBulkTransition, short string) *clibase.Cmd {
+	var (
+		all          bool
+		defaultQuery = "owner:me"
+		searchQuery  string
+		concurrency  int64
+		detach       bool
+		formatter    = workspaceBulkFormatter()
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   string(transition),
+		Short: short,
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			query := searchQuery
+			if all && searchQuery == defaultQuery {
+				query = ""
+			}
+
+			text := fmt.Sprintf("Confirm %s of all workspaces matching %q?", transition, query)
+			if query == "" {
+				text = fmt.Sprintf("Confirm %s of all workspaces?", transition)
+			}
+			_, err := cliui.Prompt(inv, cliui.PromptOptions{
+				Text:      text,
+				IsConfirm: true,
+				Default:   cliui.ConfirmNo,
+			})
+			if err != nil {
+				return err
+			}
+
+			operation, err := client.CreateWorkspaceBulkOperation(inv.Context(), codersdk.CreateWorkspaceBulkOperationRequest{
+				Query:       query,
+				Transition:  transition,
+				Concurrency: int(concurrency),
+			})
+			if err != nil {
+				return xerrors.Errorf("create bulk operation: %w", err)
+			}
+			cliui.Infof(inv.Stderr, "Started bulk operation %s for %d workspaces.", operation.ID, len(operation.Workspaces))
+
+			if detach {
+				cliui.Infof(inv.Stderr, "Follow its progress with %s.", cliui.DefaultStyles.Code.Render("coder workspaces bulk status --watch "+operation.ID.String()))
+				return nil
+			}
+
+			operation, err = watchWorkspaceBulkOperation(inv, client, operation)
+			if err != nil {
+				return err
+			}
+			return displayWorkspaceBulkOperation(inv, formatter, operation)
+		},
+	}
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:          "all",
+			FlagShorthand: "a",
+			Description:   "Select the workspaces of all users instead of only your own.",
+			Value:         clibase.BoolOf(&all),
+		},
+		{
+			Flag:        "search",
+			Description: "Select the workspaces matching a search query.",
+			Default:     defaultQuery,
+			Value:       clibase.StringOf(&searchQuery),
+		},
+		{
+			Flag:        "concurrency",
+			Description: "Maximum number of workspaces built at the same time, up to 50.",
+			Default:     "5",
+			Value:       clibase.Int64Of(&concurrency),
+		},
+		{
+			Flag:        "detach",
+			Description: "Return once the builds are queued instead of waiting for them to finish.",
+			Value:       clibase.BoolOf(&detach),
+		},
+		cliui.SkipPromptOption(),
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) workspacesBulkStatus() *clibase.Cmd {
+	var (
+		watch     bool
+		formatter = workspaceBulkFormatter()
+	)
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "status <operation>",
+		Short: "Show the progress of a bulk operation",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			id, err := uuid.Parse(inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("invalid operation ID %q: %w", inv.Args[0], err)
+			}
+
+			operation, err := client.WorkspaceBulkOperation(inv.Context(), id)
+			if err != nil {
+				return xerrors.Errorf("get bulk operation: %w", err)
+			}
+			if watch {
+				operation, err = watchWorkspaceBulkOperation(inv, client, operation)
+				if err != nil {
+					return err
+				}
+			}
+			return displayWorkspaceBulkOperation(inv, formatter, operation)
+		},
+	}
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:          "watch",
+			FlagShorthand: "w",
+			Description:   "Wait for the operation to complete.",
+			Value:         clibase.BoolOf(&watch),
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+type workspaceBulkRow struct {
+	// For JSON format:
+	codersdk.WorkspaceBulkOperationWorkspace `table:"-"`
+
+	// For table format:
+	Workspace string `json:"-" table:"workspace,default_sort"`
+	State     string `json:"-" table:"status"`
+	Reason    string `json:"-" table:"error"`
+}
+
+func workspaceBulkFormatter() *cliui.OutputFormatter {
+	return cliui.NewOutputFormatter(
+		cliui.TableFormat([]workspaceBulkRow{}, nil),
+		cliui.JSONFormat(),
+	)
+}
+
+// watchWorkspaceBulkOperation polls a bulk operation until it completes,
+// printing the progress whenever it changes.
+func watchWorkspaceBulkOperation(inv *clibase.Invocation, client *codersdk.Client, operation codersdk.WorkspaceBulkOperation) (codersdk.WorkspaceBulkOperation, error) {
+	ticker := time.NewTicker(workspaceBulkPollInterval)
+	defer ticker.Stop()
+
+	lastDone := -1
+	for {
+		done := 0
+		for _, workspace := range operation.Workspaces {
+			if workspace.Status.Done() {
+				done++
+			}
+		}
+		if done != lastDone {
+			_, _ = fmt.Fprintf(inv.Stderr, "%s %d/%d workspaces done\n", Caret, done, len(operation.Workspaces))
+			lastDone = done
+		}
+		if operation.Status == codersdk.WorkspaceBulkOperationStatusCompleted {
+			return operation, nil
+		}
+
+		select {
+		case <-inv.Context().Done():
+			return operation, inv.Context().Err()
+		case <-ticker.C:
+		}
+
+		var err error
+		operation, err = client.WorkspaceBulkOperation(inv.Context(), operation.ID)
+		if err != nil {
+			return operation, xerrors.Errorf("get bulk operation: %w", err)
+		}
+	}
+}
+
+// displayWorkspaceBulkOperation prints the status of each workspace in the
+// operation. It returns an error if any of them failed.
+func displayWorkspaceBulkOperation(inv *clibase.Invocation, formatter *cliui.OutputFormatter, operation codersdk.WorkspaceBulkOperation) error {
+	rows := make([]workspaceBulkRow, 0, len(operation.Workspaces))
+	failed := 0
+	for _, workspace := range operation.Workspaces {
+		if workspace.Status == codersdk.WorkspaceBulkOperationWorkspaceStatusFailed {
+			failed++
+		}
+		rows = append(rows, workspaceBulkRow{
+			WorkspaceBulkOperationWorkspace: workspace,
+			Workspace:                       workspace.OwnerName + "/" + workspace.WorkspaceName,
+			State:                           string(workspace.Status),
+			Reason:                          workspace.Error,
+		})
+	}
+
+	out, err := formatter.Format(inv.Context(), rows)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(inv.Stdout, out)
+
+	if failed > 0 {
+		return xerrors.Errorf("%d of %d workspaces failed", failed, len(operation.Workspaces))
+	}
+	return nil
+}
@@ -3,11 +3,14 @@ package coderd
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"net/netip"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +21,7 @@ import (
 	"github.com/coder/coder/coderd/audit"
 	"github.com/coder/coder/coderd/database"
 	"github.com/coder/coder/coderd/database/db2sdk"
+	"github.com/coder/coder/coderd/database/dbauthz"
 	"github.com/coder/coder/coderd/httpapi"
 	"github.com/coder/coder/coderd/httpmw"
 	"github.com/coder/coder/coderd/rbac"
@@ -83,6 +87,235 @@ func (api *API) auditLogs(rw http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// auditLogExportPageSize is the number of audit logs fetched at once when
+// exporting, so that exports don't load every audit log into memory.
+const auditLogExportPageSize = 1000
+
+// @Summary Export audit logs
+// @ID export-audit-logs
+// @Security CoderSessionToken
+// @Tags Audit
+// @Param q query string false "Search query"
+// @Param since query string false "Only export audit logs at or after this time" format(date-time)
+// @Param until query string false "Only export audit logs at or before this time" format(date-time)
+// @Param format query string false "Export format" Enums(csv,jsonl)
+// @Success 200
+// @Router /audit/export [get]
+func (api *API) auditLogsExport(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	apiKey := httpmw.APIKey(r)
+
+	vals := r.URL.Query()
+	p := httpapi.NewQueryParamParser()
+	queryStr := p.String(vals, "", "q")
+	since := p.Time3339Nano(vals, time.Time{}, "since")
+	until := p.Time3339Nano(vals, time.Time{}, "until")
+	format := codersdk.AuditLogExportFormat(p.String(vals, string(codersdk.AuditLogExportFormatCSV), "format"))
+	p.ErrorExcessParams(vals)
+	if format != codersdk.AuditLogExportFormatCSV && format != codersdk.AuditLogExportFormatJSONL {
+		p.Errors = append(p.Errors, codersdk.ValidationError{
+			Field:  "format",
+			Detail: fmt.Sprintf("Format %q must be one of csv or jsonl.", format),
+		})
+	}
+	if len(p.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Query parameters have invalid values.",
+			Validations: p.Errors,
+		})
+		return
+	}
+
+	filter, errs := searchquery.AuditLogs(queryStr)
+	if len(errs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid audit search query.",
+			Validations: errs,
+		})
+		return
+	}
+	if filter.Username == "me" {
+		filter.UserID = apiKey.UserID
+		filter.Username = ""
+	}
+	if since.After(filter.DateFrom) {
+		filter.DateFrom = since
+	}
+	if !until.IsZero() && (filter.DateTo.IsZero() || until.Before(filter.DateTo)) {
+		filter.DateTo = until
+	}
+	// Audit logs are fetched newest first in pages that continue after the
+	// last audit log of the previous page, so audit logs written during the
+	// export don't shift the pages.
+	params := database.GetAuditLogsForExportParams{
+		ResourceType:   filter.ResourceType,
+		ResourceID:     filter.ResourceID,
+		ResourceTarget: filter.ResourceTarget,
+		Action:         filter.Action,
+		UserID:         filter.UserID,
+		Username:       filter.Username,
+		Email:          filter.Email,
+		DateFrom:       filter.DateFrom,
+		DateTo:         filter.DateTo,
+		BuildReason:    filter.BuildReason,
+		LimitCount:     auditLogExportPageSize,
+	}
+
+	// Fetch the first page before writing the header, so that errors such as
+	// missing permissions are still returned as such.
+	dblogs, err := api.Database.GetAuditLogsForExport(ctx, params)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"audit-logs.%s\"", format))
+	var write func(alog codersdk.AuditLog) error
+	switch format {
+	case codersdk.AuditLogExportFormatJSONL:
+		rw.Header().Set("Content-Type", "application/x-ndjson")
+		rw.WriteHeader(http.StatusOK)
+		enc := json.NewEncoder(rw)
+		write = func(alog codersdk.AuditLog) error {
+			return enc.Encode(alog)
+		}
+	default:
+		rw.Header().Set("Content-Type", "text/csv")
+		rw.WriteHeader(http.StatusOK)
+		w := csv.NewWriter(rw)
+		write = func(alog codersdk.AuditLog) error {
+			err := w.Write(auditLogCSVRecord(alog))
+			if err != nil {
+				return err
+			}
+			w.Flush()
+			return w.Error()
+		}
+		err = w.Write(auditLogCSVHeader)
+		if err != nil {
+			return
+		}
+		// Flush the header now, or an export without audit logs would be
+		// empty.
+		w.Flush()
+		if w.Error() != nil {
+			return
+		}
+	}
+
+	for {
+		for _, dblog := range dblogs {
+			// Looking up whether the resource was deleted and its link takes
+			// several queries per audit log, so exports leave them out.
+			err = write(api.convertAuditLogRow(ctx, database.GetAuditLogsOffsetRow{
+				ID:               dblog.ID,
+				Time:             dblog.Time,
+				UserID:           dblog.UserID,
+				OrganizationID:   dblog.OrganizationID,
+				Ip:               dblog.Ip,
+				UserAgent:        dblog.UserAgent,
+				ResourceType:     dblog.ResourceType,
+				ResourceID:       dblog.ResourceID,
+				ResourceTarget:   dblog.ResourceTarget,
+				Action:           dblog.Action,
+				Diff:             dblog.Diff,
+				StatusCode:       dblog.StatusCode,
+				AdditionalFields: dblog.AdditionalFields,
+				RequestID:        dblog.RequestID,
+				ResourceIcon:     dblog.ResourceIcon,
+				ChainSequence:    dblog.ChainSequence,
+				ChainHash:        dblog.ChainHash,
+				UserUsername:     dblog.UserUsername,
+				UserEmail:        dblog.UserEmail,
+				UserCreatedAt:    dblog.UserCreatedAt,
+				UserStatus:       dblog.UserStatus,
+				UserRoles:        dblog.UserRoles,
+				UserAvatarUrl:    dblog.UserAvatarUrl,
+			}))
+			if err != nil {
+				api.Logger.Debug(ctx, "write audit log export", slog.Error(err))
+				return
+			}
+		}
+		if f, ok := rw.(http.Flusher); ok {
+			f.Flush()
+		}
+		if len(dblogs) < auditLogExportPageSize {
+			return
+		}
+
+		last := dblogs[len(dblogs)-1]
+		params.AfterTime = last.Time
+		params.AfterID = last.ID
+		dblogs, err = api.Database.GetAuditLogsForExport(ctx, params)
+		if err != nil {
+			// The status was already written, so abort the response instead of
+			// ending it as if the export was complete.
+			api.Logger.Warn(ctx, "fetch audit logs to export", slog.Error(err))
+			panic(http.ErrAbortHandler)
+		}
+	}
+}
+
+var auditLogCSVHeader = []string{
+	"id", "time", "organization_id", "user_id", "username", "email", "ip", "user_agent",
+	"resource_type", "resource_id", "resource_target", "action", "status_code",
+	"description", "request_id", "diff", "additional_fields",
+}
+
+func auditLogCSVRecord(alog codersdk.AuditLog) []string {
+	var userID, username, email string
+	if alog.User != nil {
+		userID, username, email = alog.User.ID.String(), alog.User.Username, alog.User.Email
+	}
+	var ip string
+	if alog.IP.IsValid() {
+		ip = alog.IP.String()
+	}
+	diff, _ := json.Marshal(alog.Diff)
+	additionalFields := string(alog.AdditionalFields)
+	if additionalFields == "" {
+		additionalFields = "{}"
+	}
+	record := []string{
+		alog.ID.String(),
+		alog.Time.UTC().Format(time.RFC3339Nano),
+		alog.OrganizationID.String(),
+		userID,
+		username,
+		email,
+		ip,
+		alog.UserAgent,
+		string(alog.ResourceType),
+		alog.ResourceID.String(),
+		alog.ResourceTarget,
+		string(alog.Action),
+		strconv.Itoa(int(alog.StatusCode)),
+		alog.Description,
+		alog.RequestID.String(),
+		string(diff),
+		additionalFields,
+	}
+	for i, cell := range record {
+		record[i] = escapeCSVFormula(cell)
+	}
+	return record
+}
+
+// escapeCSVFormula prefixes cells that spreadsheets would evaluate as a
+// formula with a quote, so user-controlled fields like the resource target
+// can't inject formulas into exports.
+func escapeCSVFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
 // @Summary Generate fake audit log
 // @ID generate-fake-audit-log
 // @Security CoderSessionToken
@@ -174,6 +407,19 @@ func (api *API) convertAuditLogs(ctx context.Context, dblogs []database.GetAudit
 }
 
 func (api *API) convertAuditLog(ctx context.Context, dblog database.GetAuditLogsOffsetRow) codersdk.AuditLog {
+	alog := api.convertAuditLogRow(ctx, dblog)
+	alog.IsDeleted = api.auditLogIsResourceDeleted(ctx, dblog)
+	if !alog.IsDeleted {
+		var additionalFields audit.AdditionalFields
+		_ = json.Unmarshal(dblog.AdditionalFields, &additionalFields)
+		alog.ResourceLink = api.auditLogResourceLink(ctx, dblog, additionalFields)
+	}
+	return alog
+}
+
+// convertAuditLogRow converts an audit log without querying the database, so
+// IsDeleted and ResourceLink are left unset.
+func (api *API) convertAuditLogRow(ctx context.Context, dblog database.GetAuditLogsOffsetRow) codersdk.AuditLog {
 	ip, _ := netip.AddrFromSlice(dblog.Ip.IPNet.IP)
 
 	diff := codersdk.AuditDiff{}
@@ -198,11 +444,8 @@ func (api *API) convertAuditLog(ctx context.Context, dblog database.GetAuditLogs
 		}
 	}
 
-	var (
-		additionalFieldsBytes = []byte(dblog.AdditionalFields)
-		additionalFields      audit.AdditionalFields
-		err                   = json.Unmarshal(additionalFieldsBytes, &additionalFields)
-	)
+	var additionalFields audit.AdditionalFields
+	err := json.Unmarshal(dblog.AdditionalFields, &additionalFields)
 	if err != nil {
 		api.Logger.Error(ctx, "unmarshal additional fields", slog.Error(err))
 		resourceInfo := audit.AdditionalFields{
@@ -216,16 +459,6 @@ func (api *API) convertAuditLog(ctx context.Context, dblog database.GetAuditLogs
 		api.Logger.Error(ctx, "marshal additional fields", slog.Error(err))
 	}
 
-	var (
-		isDeleted    = api.auditLogIsResourceDeleted(ctx, dblog)
-		resourceLink string
-	)
-	if isDeleted {
-		resourceLink = ""
-	} else {
-		resourceLink = api.auditLogResourceLink(ctx, dblog, additionalFields)
-	}
-
 	return codersdk.AuditLog{
 		ID:               dblog.ID,
 		RequestID:        dblog.RequestID,
@@ -243,8 +476,6 @@ func (api *API) convertAuditLog(ctx context.Context, dblog database.GetAuditLogs
 		AdditionalFields: dblog.AdditionalFields,
 		User:             user,
 		Description:      auditLogDescription(dblog),
-		ResourceLink:     resourceLink,
-		IsDeleted:        isDeleted,
 	}
 }
 
@@ -2,18 +2,26 @@ package coderd_test
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"strconv"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/coderd/audit"
 	"github.com/coder/coder/coderd/coderdtest"
 	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbgen"
+	"github.com/coder/coder/coderd/database/dbtestutil"
 	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/testutil"
 )
 
 func TestAuditLogs(t *testing.T) {
@@ -257,3 +265,200 @@ func TestAuditLogsFilter(t *testing.T) {
 		}
 	})
 }
+
+func TestAuditLogsExport(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, nil)
+	user := coderdtest.CreateFirstUser(t, client)
+	memberClient, _ := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	now := time.Now()
+	for i, action := range []codersdk.AuditAction{codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete} {
+		err := client.CreateTestAuditLog(ctx, codersdk.CreateTestAuditLogRequest{
+			Action:     action,
+			ResourceID: user.UserID,
+			Time:       now.Add(time.Duration(i-3) * time.Hour),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		body, err := client.ExportAuditLogs(ctx, codersdk.AuditLogExportRequest{
+			Since: now.Add(-150 * time.Minute),
+		})
+		require.NoError(t, err)
+		defer body.Close()
+		records, err := csv.NewReader(body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		require.Equal(t, "id", records[0][0])
+		actions := []string{records[1][11], records[2][11]}
+		require.ElementsMatch(t, []string{"write", "delete"}, actions)
+		require.Equal(t, user.UserID.String(), records[1][3])
+	})
+
+	t.Run("EmptyRange", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		body, err := client.ExportAuditLogs(ctx, codersdk.AuditLogExportRequest{
+			Since: now.Add(-10 * time.Hour),
+			Until: now.Add(-9 * time.Hour),
+		})
+		require.NoError(t, err)
+		defer body.Close()
+		records, err := csv.NewReader(body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, "id", records[0][0])
+	})
+
+	t.Run("JSONL", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		body, err := client.ExportAuditLogs(ctx, codersdk.AuditLogExportRequest{
+			SearchQuery: "action:create",
+			Format:      codersdk.AuditLogExportFormatJSONL,
+		})
+		require.NoError(t, err)
+		defer body.Close()
+		var alogs []codersdk.AuditLog
+		dec := json.NewDecoder(body)
+		for dec.More() {
+			var alog codersdk.AuditLog
+			require.NoError(t, dec.Decode(&alog))
+			alogs = append(alogs, alog)
+		}
+		require.Len(t, alogs, 1)
+		require.Equal(t, codersdk.AuditActionCreate, alogs[0].Action)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := client.ExportAuditLogs(ctx, codersdk.AuditLogExportRequest{Format: "xml"})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := memberClient.ExportAuditLogs(ctx, codersdk.AuditLogExportRequest{})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+	})
+
+	t.Run("Pages", func(t *testing.T) {
+		t.Parallel()
+
+		db, pubsub := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{
+			Database: db,
+			Pubsub:   pubsub,
+		})
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		// More audit logs than fit in a page, all with the same time, so
+		// that the pages are only told apart by ID.
+		at := database.Now().Add(-time.Hour)
+		want := make(map[string]bool)
+		for i := 0; i < 1500; i++ {
+			alog := dbgen.AuditLog(t, db, database.AuditLog{Time: at})
+			want[alog.ID.String()] = true
+		}
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		body, err := client.ExportAuditLogs(ctx, codersdk.AuditLogExportRequest{
+			Until: at,
+		})
+		require.NoError(t, err)
+		defer body.Close()
+		records, err := csv.NewReader(body).ReadAll()
+		require.NoError(t, err)
+		got := make(map[string]bool)
+		for _, record := range records[1:] {
+			require.False(t, got[record[0]], "audit log %s exported twice", record[0])
+			got[record[0]] = true
+		}
+		require.Equal(t, want, got)
+	})
+
+	t.Run("FormulaInjection", func(t *testing.T) {
+		t.Parallel()
+
+		db, pubsub := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{
+			Database: db,
+			Pubsub:   pubsub,
+		})
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		at := database.Now().Add(-time.Hour)
+		_ = dbgen.AuditLog(t, db, database.AuditLog{
+			Time:           at,
+			ResourceTarget: `=HYPERLINK("https://example.com","click")`,
+		})
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		body, err := client.ExportAuditLogs(ctx, codersdk.AuditLogExportRequest{
+			Until: at,
+		})
+		require.NoError(t, err)
+		defer body.Close()
+		records, err := csv.NewReader(body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, `'=HYPERLINK("https://example.com","click")`, records[1][10])
+	})
+
+	t.Run("PageError", func(t *testing.T) {
+		t.Parallel()
+
+		db, pubsub := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{
+			Database: failingAuditLogExportStore{Store: db},
+			Pubsub:   pubsub,
+		})
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		at := database.Now().Add(-time.Hour)
+		for i := 0; i < 1500; i++ {
+			_ = dbgen.AuditLog(t, db, database.AuditLog{Time: at})
+		}
+
+		// The first page is already written when the second one fails, so
+		// the export must not end as if it was complete.
+		ctx := testutil.Context(t, testutil.WaitLong)
+		body, err := client.ExportAuditLogs(ctx, codersdk.AuditLogExportRequest{
+			Until: at,
+		})
+		require.NoError(t, err)
+		defer body.Close()
+		_, err = io.ReadAll(body)
+		require.Error(t, err)
+	})
+}
+
+// failingAuditLogExportStore fails to fetch any page of audit logs to export
+// but the first.
+type failingAuditLogExportStore struct {
+	database.Store
+}
+
+func (s failingAuditLogExportStore) GetAuditLogsForExport(ctx context.Context, arg database.GetAuditLogsForExportParams) ([]database.GetAuditLogsForExportRow, error) {
+	if arg.AfterID != uuid.Nil {
+		return nil, xerrors.New("fetch failed")
+	}
+	return s.Store.GetAuditLogsForExport(ctx, arg)
+}
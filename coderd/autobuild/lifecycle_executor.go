@@ -3,6 +3,7 @@ package autobuild
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,16 +16,22 @@ import (
 	"github.com/coder/coder/coderd/database"
 	"github.com/coder/coder/coderd/database/db2sdk"
 	"github.com/coder/coder/coderd/database/dbauthz"
+	"github.com/coder/coder/coderd/notifications"
 	"github.com/coder/coder/coderd/schedule"
 	"github.com/coder/coder/coderd/wsbuilder"
 	"github.com/coder/coder/codersdk"
 )
 
+// AutostopNotifyBefore is how long before the autostop of a workspace its
+// owner is notified.
+const AutostopNotifyBefore = 30 * time.Minute
+
 // Executor automatically starts or stops workspaces.
 type Executor struct {
 	ctx                   context.Context
 	db                    database.Store
 	templateScheduleStore *atomic.Pointer[schedule.TemplateScheduleStore]
+	notifier              notifications.Enqueuer
 	log                   slog.Logger
 	tick                  <-chan time.Time
 	statsCh               chan<- Stats
@@ -38,12 +45,13 @@ type Stats struct {
 }
 
 // New returns a new wsactions executor.
-func NewExecutor(ctx context.Context, db database.Store, tss *atomic.Pointer[schedule.TemplateScheduleStore], log slog.Logger, tick <-chan time.Time) *Executor {
+func NewExecutor(ctx context.Context, db database.Store, tss *atomic.Pointer[schedule.TemplateScheduleStore], enq notifications.Enqueuer, log slog.Logger, tick <-chan time.Time) *Executor {
 	le := &Executor{
 		//nolint:gocritic // Autostart has a limited set of permissions.
 		ctx:                   dbauthz.AsAutostart(ctx),
 		db:                    db,
 		templateScheduleStore: tss,
+		notifier:              enq,
 		tick:                  tick,
 		log:                   log.Named("autobuild"),
 	}
@@ -116,6 +124,33 @@ func (e *Executor) runOnce(t time.Time) Stats {
 		return stats
 	}
 
+	// Exceptions without a template apply to every workspace.
+	deploymentExceptions, err := e.db.GetDeploymentAutostartExceptions(e.ctx)
+	if err != nil {
+		e.log.Error(e.ctx, "get deployment autostart exceptions", slog.Error(err))
+		return stats
+	}
+
+	// The exceptions of the templates are fetched once as well, and looked up
+	// by the template of each workspace.
+	templateIDs := make([]uuid.UUID, 0)
+	seenTemplates := map[uuid.UUID]bool{}
+	for _, ws := range workspaces {
+		if !seenTemplates[ws.TemplateID] {
+			seenTemplates[ws.TemplateID] = true
+			templateIDs = append(templateIDs, ws.TemplateID)
+		}
+	}
+	templateExceptions, err := e.db.GetTemplateAutostartExceptionsByTemplateIDs(e.ctx, templateIDs)
+	if err != nil {
+		e.log.Error(e.ctx, "get template autostart exceptions", slog.Error(err))
+		return stats
+	}
+	templateExceptionsByID := map[uuid.UUID][]database.AutostartException{}
+	for _, exception := range templateExceptions {
+		templateExceptionsByID[exception.TemplateID.UUID] = append(templateExceptionsByID[exception.TemplateID.UUID], exception)
+	}
+
 	// We only use errgroup here for convenience of API, not for early
 	// cancellation. This means we only return nil errors in th eg.Go.
 	eg := errgroup.Group{}
@@ -127,6 +162,9 @@ func (e *Executor) runOnce(t time.Time) Stats {
 		log := e.log.With(slog.F("workspace_id", wsID))
 
 		eg.Go(func() error {
+			// The owner is notified once the lock is committed.
+			var locked *database.Workspace
+			var inactivityTTL time.Duration
 			err := e.db.InTx(func(tx database.Store) error {
 				// Re-check eligibility since the first check was outside the
 				// transaction and the workspace settings may have changed.
@@ -154,13 +192,18 @@ func (e *Executor) runOnce(t time.Time) Stats {
 					return nil
 				}
 
-				nextTransition, reason, err := getNextTransition(ws, latestBuild, latestJob, templateSchedule, currentTick)
+				exceptions := schedule.ConvertAutostartExceptions(templateExceptionsByID[ws.TemplateID])
+				exceptions = append(exceptions, schedule.ConvertAutostartExceptions(deploymentExceptions)...)
+
+				nextTransition, reason, err := getNextTransition(ws, latestBuild, latestJob, templateSchedule, exceptions, currentTick)
 				if err != nil {
 					log.Debug(e.ctx, "skipping workspace", slog.Error(err))
 					return nil
 				}
 
 				if nextTransition != "" {
+					// Workspaces are autostarted with their previous version,
+					// unless the template requires the active one.
 					builder := wsbuilder.New(ws, nextTransition).
 						SetLastWorkspaceBuildInTx(&latestBuild).
 						SetLastWorkspaceBuildJobInTx(&latestJob).
@@ -198,6 +241,8 @@ func (e *Executor) runOnce(t time.Time) Stats {
 						slog.F("inactivity_ttl", templateSchedule.InactivityTTL),
 						slog.F("since_last_used_at", time.Since(ws.LastUsedAt)),
 					)
+					locked = &ws
+					inactivityTTL = templateSchedule.InactivityTTL
 				}
 
 				if reason == database.BuildReasonAutodelete {
@@ -227,6 +272,10 @@ func (e *Executor) runOnce(t time.Time) Stats {
 			}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 			if err != nil {
 				log.Error(e.ctx, "workspace scheduling failed", slog.Error(err))
+				return nil
+			}
+			if locked != nil {
+				e.notifyLocked(*locked, inactivityTTL)
 			}
 			return nil
 		})
@@ -238,9 +287,63 @@ func (e *Executor) runOnce(t time.Time) Stats {
 		e.log.Error(e.ctx, "workspace scheduling errgroup failed", slog.Error(err))
 	}
 
+	e.notifyAutostop(t)
+
 	return stats
 }
 
+// notifyAutostop warns the owners of the workspaces that will be stopped
+// within AutostopNotifyBefore. Owners are warned once per build.
+func (e *Executor) notifyAutostop(t time.Time) {
+	workspaces, err := e.db.GetWorkspacesAboutToAutostop(e.ctx, database.GetWorkspacesAboutToAutostopParams{
+		Now:    t,
+		Before: t.Add(AutostopNotifyBefore),
+	})
+	if err != nil {
+		e.log.Error(e.ctx, "get workspaces about to autostop", slog.Error(err))
+		return
+	}
+	for _, ws := range workspaces {
+		err := e.notifier.Enqueue(e.ctx, ws.OwnerID, database.NotificationEventWorkspaceAutostop, map[string]string{
+			"Workspace": ws.Name,
+			"Deadline":  ws.Deadline.UTC().Format(time.RFC1123),
+		}, "workspace_autostop:"+ws.BuildID.String())
+		if err != nil {
+			e.log.Error(e.ctx, "notify owner of autostop", slog.F("workspace_id", ws.ID), slog.Error(err))
+		}
+	}
+}
+
+// notifyLocked tells the owner of a workspace that it was locked due to
+// inactivity.
+func (e *Executor) notifyLocked(ws database.Workspace, inactivityTTL time.Duration) {
+	data := map[string]string{
+		"Workspace":  ws.Name,
+		"Inactivity": formatDays(inactivityTTL),
+	}
+	if ws.DeletingAt.Valid {
+		data["DeletingAt"] = ws.DeletingAt.Time.UTC().Format(time.RFC1123)
+	}
+	err := e.notifier.Enqueue(e.ctx, ws.OwnerID, database.NotificationEventWorkspaceLocked, data,
+		fmt.Sprintf("workspace_locked:%s:%d", ws.ID, ws.LockedAt.Time.Unix()))
+	if err != nil {
+		e.log.Error(e.ctx, "notify owner of locked workspace", slog.F("workspace_id", ws.ID), slog.Error(err))
+	}
+}
+
+// formatDays formats whole days as such, e.g. "30 days" instead of "720h0m0s".
+func formatDays(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d == day:
+		return "1 day"
+	case d > day && d%day == 0:
+		return fmt.Sprintf("%d days", d/day)
+	default:
+		return d.String()
+	}
+}
+
 // getNextTransition returns the next eligible transition for the workspace
 // as well as the reason for why it is transitioning. It is possible
 // for this function to return a nil error as well as an empty transition.
@@ -252,6 +355,7 @@ func getNextTransition(
 	latestBuild database.WorkspaceBuild,
 	latestJob database.ProvisionerJob,
 	templateSchedule schedule.TemplateScheduleOptions,
+	exceptions []schedule.AutostartException,
 	currentTick time.Time,
 ) (
 	database.WorkspaceTransition,
@@ -261,7 +365,7 @@ func getNextTransition(
 	switch {
 	case isEligibleForAutostop(ws, latestBuild, latestJob, currentTick):
 		return database.WorkspaceTransitionStop, database.BuildReasonAutostop, nil
-	case isEligibleForAutostart(ws, latestBuild, latestJob, templateSchedule, currentTick):
+	case isEligibleForAutostart(ws, latestBuild, latestJob, templateSchedule, exceptions, currentTick):
 		return database.WorkspaceTransitionStart, database.BuildReasonAutostart, nil
 	case isEligibleForFailedStop(latestBuild, latestJob, templateSchedule, currentTick):
 		return database.WorkspaceTransitionStop, database.BuildReasonAutostop, nil
@@ -282,7 +386,7 @@ func getNextTransition(
 }
 
 // isEligibleForAutostart returns true if the workspace should be autostarted.
-func isEligibleForAutostart(ws database.Workspace, build database.WorkspaceBuild, job database.ProvisionerJob, templateSchedule schedule.TemplateScheduleOptions, currentTick time.Time) bool {
+func isEligibleForAutostart(ws database.Workspace, build database.WorkspaceBuild, job database.ProvisionerJob, templateSchedule schedule.TemplateScheduleOptions, exceptions []schedule.AutostartException, currentTick time.Time) bool {
 	// Don't attempt to autostart failed workspaces.
 	if db2sdk.ProvisionerJobStatus(job) == codersdk.ProvisionerJobFailed {
 		return false
@@ -309,9 +413,15 @@ func isEligibleForAutostart(ws database.Workspace, build database.WorkspaceBuild
 	if err != nil {
 		return false
 	}
+	// Starts covered by an exception calendar or skipped by the user are
+	// passed over in favor of the next one.
+	next := schedule.NextAutostart(sched, build.CreatedAt, ws.AutostartSkipUntil.Time, exceptions)
+	if next.IsZero() {
+		return false
+	}
 	// Round down to the nearest minute, as this is the finest granularity cron supports.
 	// Truncate is probably not necessary here, but doing it anyway to be sure.
-	nextTransition := sched.Next(build.CreatedAt).Truncate(time.Minute)
+	nextTransition := next.Truncate(time.Minute)
 
 	return !currentTick.Before(nextTransition)
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/coder/coder/codersdk"
 	"github.com/coder/coder/provisioner/echo"
 	"github.com/coder/coder/provisionersdk/proto"
+	"github.com/coder/coder/testutil"
 )
 
 func TestExecutorAutostartOK(t *testing.T) {
@@ -109,6 +110,59 @@ func TestExecutorAutostartTemplateUpdated(t *testing.T) {
 	assert.Equal(t, workspace.LatestBuild.TemplateVersionID, ws.LatestBuild.TemplateVersionID, "expected workspace build to be using the old template version")
 }
 
+func TestExecutorAutostartTemplateRequireActiveVersion(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sched   = mustSchedule(t, "CRON_TZ=UTC 0 * * * *")
+		ctx     = context.Background()
+		err     error
+		tickCh  = make(chan time.Time)
+		statsCh = make(chan autobuild.Stats)
+		client  = coderdtest.New(t, &coderdtest.Options{
+			AutobuildTicker:          tickCh,
+			IncludeProvisionerDaemon: true,
+			AutobuildStats:           statsCh,
+		})
+		// Given: we have a user with a workspace that has autostart enabled
+		workspace = mustProvisionWorkspace(t, client, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref(sched.String())
+		})
+	)
+	// Given: workspace is stopped
+	workspace = coderdtest.MustTransitionWorkspace(t, client, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+	// Given: the workspace template has been updated and requires the active version
+	orgs, err := client.OrganizationsByUser(ctx, workspace.OwnerID.String())
+	require.NoError(t, err)
+	require.Len(t, orgs, 1)
+
+	newVersion := coderdtest.UpdateTemplateVersion(t, client, orgs[0].ID, nil, workspace.TemplateID)
+	coderdtest.AwaitTemplateVersionJob(t, client, newVersion.ID)
+	require.NoError(t, client.UpdateActiveTemplateVersion(ctx, workspace.TemplateID, codersdk.UpdateActiveTemplateVersion{
+		ID: newVersion.ID,
+	}))
+	_, err = client.UpdateTemplateMeta(ctx, workspace.TemplateID, codersdk.UpdateTemplateMeta{
+		RequireActiveVersion: true,
+	})
+	require.NoError(t, err)
+
+	// When: the autobuild executor ticks after the scheduled time
+	go func() {
+		tickCh <- sched.Next(workspace.LatestBuild.CreatedAt)
+		close(tickCh)
+	}()
+
+	// Then: the workspace should be started using the updated template version.
+	stats := <-statsCh
+	assert.NoError(t, stats.Error)
+	assert.Len(t, stats.Transitions, 1)
+	assert.Contains(t, stats.Transitions, workspace.ID)
+	assert.Equal(t, database.WorkspaceTransitionStart, stats.Transitions[workspace.ID])
+	ws := coderdtest.MustWorkspace(t, client, workspace.ID)
+	assert.Equal(t, newVersion.ID, ws.LatestBuild.TemplateVersionID, "expected workspace build to be using the active template version")
+}
+
 func TestExecutorAutostartAlreadyRunning(t *testing.T) {
 	t.Parallel()
 
@@ -177,6 +231,151 @@ func TestExecutorAutostartNotEnabled(t *testing.T) {
 	require.Len(t, stats.Transitions, 0)
 }
 
+func TestExecutorAutostartException(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = context.Background()
+		sched   = mustSchedule(t, "CRON_TZ=UTC 0 * * * *")
+		tickCh  = make(chan time.Time)
+		statsCh = make(chan autobuild.Stats)
+		client  = coderdtest.New(t, &coderdtest.Options{
+			AutobuildTicker:          tickCh,
+			IncludeProvisionerDaemon: true,
+			AutobuildStats:           statsCh,
+		})
+		// Given: we have a user with a workspace that has autostart enabled
+		workspace = mustProvisionWorkspace(t, client, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref(sched.String())
+		})
+	)
+	// Given: workspace is stopped
+	workspace = coderdtest.MustTransitionWorkspace(t, client, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+	// Given: the next scheduled start is covered by a deployment exception
+	next := sched.Next(workspace.LatestBuild.CreatedAt)
+	_, err := client.UpdateDeploymentAutostartExceptions(ctx, codersdk.UpdateAutostartExceptionsRequest{
+		Exceptions: []codersdk.CreateAutostartExceptionRequest{{
+			Name:     "Maintenance",
+			StartsAt: next.Add(-time.Minute),
+			EndsAt:   next.Add(time.Minute),
+		}},
+	})
+	require.NoError(t, err)
+
+	// When: the autobuild executor ticks at the scheduled time
+	go func() {
+		tickCh <- next
+		close(tickCh)
+	}()
+
+	// Then: the workspace should not be started.
+	stats := <-statsCh
+	require.NoError(t, stats.Error)
+	require.Len(t, stats.Transitions, 0)
+}
+
+func TestExecutorAutostartTemplateException(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = context.Background()
+		sched   = mustSchedule(t, "CRON_TZ=UTC 0 * * * *")
+		tickCh  = make(chan time.Time)
+		statsCh = make(chan autobuild.Stats)
+		client  = coderdtest.New(t, &coderdtest.Options{
+			AutobuildTicker:          tickCh,
+			IncludeProvisionerDaemon: true,
+			AutobuildStats:           statsCh,
+		})
+		// Given: we have a user with a workspace that has autostart enabled
+		excepted = mustProvisionWorkspace(t, client, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref(sched.String())
+		})
+	)
+	// Given: a workspace of another template that also has autostart enabled
+	version := coderdtest.CreateTemplateVersion(t, client, excepted.OrganizationID, nil)
+	coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+	template := coderdtest.CreateTemplate(t, client, excepted.OrganizationID, version.ID)
+	other := coderdtest.CreateWorkspace(t, client, excepted.OrganizationID, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+		cwr.AutostartSchedule = ptr.Ref(sched.String())
+	})
+	coderdtest.AwaitWorkspaceBuildJob(t, client, other.LatestBuild.ID)
+
+	// Given: both workspaces are stopped
+	excepted = coderdtest.MustTransitionWorkspace(t, client, excepted.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+	other = coderdtest.MustTransitionWorkspace(t, client, other.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+	// Given: the next scheduled start is covered by an exception of the
+	// template of the first workspace only
+	next := sched.Next(other.LatestBuild.CreatedAt)
+	_, err := client.UpdateTemplateAutostartExceptions(ctx, excepted.TemplateID, codersdk.UpdateAutostartExceptionsRequest{
+		Exceptions: []codersdk.CreateAutostartExceptionRequest{{
+			Name:     "Maintenance",
+			StartsAt: sched.Next(excepted.LatestBuild.CreatedAt).Add(-time.Minute),
+			EndsAt:   next.Add(time.Minute),
+		}},
+	})
+	require.NoError(t, err)
+
+	// When: the autobuild executor ticks at the scheduled time
+	go func() {
+		tickCh <- next
+		close(tickCh)
+	}()
+
+	// Then: only the workspace of the other template should be started.
+	stats := <-statsCh
+	require.NoError(t, stats.Error)
+	require.Len(t, stats.Transitions, 1)
+	require.Equal(t, database.WorkspaceTransitionStart, stats.Transitions[other.ID])
+}
+
+func TestExecutorAutostartSkipped(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = context.Background()
+		sched   = mustSchedule(t, "CRON_TZ=UTC 0 * * * *")
+		tickCh  = make(chan time.Time)
+		statsCh = make(chan autobuild.Stats)
+		client  = coderdtest.New(t, &coderdtest.Options{
+			AutobuildTicker:          tickCh,
+			IncludeProvisionerDaemon: true,
+			AutobuildStats:           statsCh,
+		})
+		// Given: we have a user with a workspace that has autostart enabled
+		workspace = mustProvisionWorkspace(t, client, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref(sched.String())
+		})
+	)
+	// Given: workspace is stopped
+	workspace = coderdtest.MustTransitionWorkspace(t, client, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+	// Given: the owner skipped the next scheduled start
+	err := client.SkipWorkspaceAutostart(ctx, workspace.ID, codersdk.SkipWorkspaceAutostartRequest{Skip: true})
+	require.NoError(t, err)
+	workspace = coderdtest.MustWorkspace(t, client, workspace.ID)
+	require.NotNil(t, workspace.AutostartSkipUntil)
+
+	// When: the autobuild executor ticks at the skipped time, and then at
+	// the start after it
+	go func() {
+		tickCh <- *workspace.AutostartSkipUntil
+		tickCh <- sched.Next(*workspace.AutostartSkipUntil)
+		close(tickCh)
+	}()
+
+	// Then: the workspace should only be started at the second tick.
+	stats := <-statsCh
+	require.NoError(t, stats.Error)
+	require.Len(t, stats.Transitions, 0)
+	stats = <-statsCh
+	require.NoError(t, stats.Error)
+	require.Len(t, stats.Transitions, 1)
+	require.Equal(t, database.WorkspaceTransitionStart, stats.Transitions[workspace.ID])
+}
+
 func TestExecutorAutostopOK(t *testing.T) {
 	t.Parallel()
 
@@ -337,6 +536,46 @@ func TestExecutorAutostopNotEnabled(t *testing.T) {
 	assert.Len(t, stats.Transitions, 0)
 }
 
+func TestExecutorAutostopNotification(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = testutil.Context(t, testutil.WaitLong)
+		tickCh  = make(chan time.Time)
+		statsCh = make(chan autobuild.Stats)
+		client  = coderdtest.New(t, &coderdtest.Options{
+			AutobuildTicker:          tickCh,
+			IncludeProvisionerDaemon: true,
+			AutobuildStats:           statsCh,
+		})
+		// Given: we have a user with a running workspace
+		workspace = mustProvisionWorkspace(t, client)
+	)
+	require.Equal(t, codersdk.WorkspaceTransitionStart, workspace.LatestBuild.Transition)
+	require.NotZero(t, workspace.LatestBuild.Deadline)
+
+	// When: the autobuild executor ticks twice shortly before the deadline
+	go func() {
+		tickCh <- workspace.LatestBuild.Deadline.Time.Add(-autobuild.AutostopNotifyBefore / 2)
+		tickCh <- workspace.LatestBuild.Deadline.Time.Add(-autobuild.AutostopNotifyBefore / 3)
+		close(tickCh)
+	}()
+
+	// Then: the workspace should not be stopped yet
+	for i := 0; i < 2; i++ {
+		stats := <-statsCh
+		assert.NoError(t, stats.Error)
+		assert.Len(t, stats.Transitions, 0)
+	}
+
+	// And: the owner should be warned once
+	notifications, err := client.Notifications(ctx, codersdk.Me, codersdk.NotificationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, codersdk.NotificationEventWorkspaceAutostop, notifications[0].Event)
+	assert.Contains(t, notifications[0].Title, workspace.Name)
+}
+
 func TestExecutorWorkspaceDeleted(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,295 @@
+package coderd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/coderd/audit"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbauthz"
+	"github.com/coder/coder/coderd/httpapi"
+	"github.com/coder/coder/coderd/httpmw"
+	"github.com/coder/coder/coderd/schedule"
+	"github.com/coder/coder/codersdk"
+)
+
+// @Summary Get deployment autostart exceptions
+// @ID get-deployment-autostart-exceptions
+// @Security CoderSessionToken
+// @Produce json
+// @Tags General
+// @Success 200 {array} codersdk.AutostartException
+// @Router /deployment/autostart-exceptions [get]
+func (api *API) deploymentAutostartExceptions(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	exceptions, err := api.Database.GetDeploymentAutostartExceptions(ctx)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching autostart exceptions.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertAutostartExceptions(exceptions))
+}
+
+// @Summary Update deployment autostart exceptions
+// @ID update-deployment-autostart-exceptions
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags General
+// @Param request body codersdk.UpdateAutostartExceptionsRequest true "Replace autostart exceptions request"
+// @Success 200 {array} codersdk.AutostartException
+// @Router /deployment/autostart-exceptions [put]
+func (api *API) putDeploymentAutostartExceptions(rw http.ResponseWriter, r *http.Request) {
+	api.replaceAutostartExceptions(rw, r, uuid.NullUUID{})
+}
+
+// @Summary Get template autostart exceptions
+// @ID get-template-autostart-exceptions
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {array} codersdk.AutostartException
+// @Router /templates/{template}/autostart-exceptions [get]
+func (api *API) templateAutostartExceptions(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx      = r.Context()
+		template = httpmw.TemplateParam(r)
+	)
+
+	exceptions, err := api.Database.GetTemplateAutostartExceptions(ctx, uuid.NullUUID{UUID: template.ID, Valid: true})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching autostart exceptions.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertAutostartExceptions(exceptions))
+}
+
+// @Summary Update template autostart exceptions
+// @ID update-template-autostart-exceptions
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Param request body codersdk.UpdateAutostartExceptionsRequest true "Replace autostart exceptions request"
+// @Success 200 {array} codersdk.AutostartException
+// @Router /templates/{template}/autostart-exceptions [put]
+func (api *API) putTemplateAutostartExceptions(rw http.ResponseWriter, r *http.Request) {
+	template := httpmw.TemplateParam(r)
+	api.replaceAutostartExceptions(rw, r, uuid.NullUUID{UUID: template.ID, Valid: true})
+}
+
+// replaceAutostartExceptions replaces the exceptions of a template, or of the
+// deployment if templateID is not valid, with the ones in the request.
+func (api *API) replaceAutostartExceptions(rw http.ResponseWriter, r *http.Request, templateID uuid.NullUUID) {
+	ctx := r.Context()
+
+	var req codersdk.UpdateAutostartExceptionsRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	var validations []codersdk.ValidationError
+	for i, exception := range req.Exceptions {
+		startsAt, endsAt := exception.StartsAt, exception.EndsAt
+		if exception.AllDay {
+			startsAt, endsAt = startsAt.UTC().Truncate(24*time.Hour), endsAt.UTC().Truncate(24*time.Hour)
+		}
+		if !endsAt.After(startsAt) {
+			validations = append(validations, codersdk.ValidationError{
+				Field:  fmt.Sprintf("exceptions[%d].ends_at", i),
+				Detail: "Must be after starts_at.",
+			})
+		}
+		req.Exceptions[i].StartsAt, req.Exceptions[i].EndsAt = startsAt, endsAt
+	}
+	if len(validations) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid autostart exceptions.",
+			Validations: validations,
+		})
+		return
+	}
+
+	var exceptions []database.AutostartException
+	err := api.Database.InTx(func(tx database.Store) error {
+		var err error
+		if templateID.Valid {
+			err = tx.DeleteTemplateAutostartExceptions(ctx, templateID)
+		} else {
+			err = tx.DeleteDeploymentAutostartExceptions(ctx)
+		}
+		if err != nil {
+			return xerrors.Errorf("delete autostart exceptions: %w", err)
+		}
+
+		now := database.Now()
+		exceptions = make([]database.AutostartException, 0, len(req.Exceptions))
+		for _, exception := range req.Exceptions {
+			inserted, err := tx.InsertAutostartException(ctx, database.InsertAutostartExceptionParams{
+				ID:         uuid.New(),
+				TemplateID: templateID,
+				Name:       exception.Name,
+				StartsAt:   exception.StartsAt,
+				EndsAt:     exception.EndsAt,
+				AllDay:     exception.AllDay,
+				CreatedAt:  now,
+			})
+			if err != nil {
+				return xerrors.Errorf("insert autostart exception: %w", err)
+			}
+			exceptions = append(exceptions, inserted)
+		}
+		return nil
+	}, nil)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating autostart exceptions.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertAutostartExceptions(exceptions))
+}
+
+// @Summary Skip next workspace autostart by ID
+// @ID skip-next-workspace-autostart-by-id
+// @Security CoderSessionToken
+// @Accept json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.SkipWorkspaceAutostartRequest true "Skip workspace autostart request"
+// @Success 204
+// @Router /workspaces/{workspace}/autostart/skip [put]
+func (api *API) putWorkspaceAutostartSkip(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		workspace         = httpmw.WorkspaceParam(r)
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.Workspace](rw, &audit.RequestParams{
+			Audit:   *auditor,
+			Log:     api.Logger,
+			Request: r,
+			Action:  database.AuditActionWrite,
+		})
+	)
+	defer commitAudit()
+	aReq.Old = workspace
+
+	var req codersdk.SkipWorkspaceAutostartRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	var skipUntil sql.NullTime
+	if req.Skip {
+		if !workspace.AutostartSchedule.Valid {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Workspace has no autostart schedule.",
+			})
+			return
+		}
+		sched, err := schedule.Weekly(workspace.AutostartSchedule.String)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error parsing workspace autostart schedule.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+
+		// Users may not be able to read the exceptions of the template, but
+		// they need to know which autostart comes next.
+		// nolint:gocritic
+		exceptions, err := api.workspaceAutostartExceptions(dbauthz.AsSystemRestricted(ctx), workspace)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching autostart exceptions.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+
+		next := schedule.NextAutostart(sched, database.Now(), workspace.AutostartSkipUntil.Time, exceptions)
+		if next.IsZero() {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Workspace has no upcoming autostart to skip.",
+			})
+			return
+		}
+		skipUntil = sql.NullTime{Time: next, Valid: true}
+	}
+
+	err := api.Database.UpdateWorkspaceAutostartSkipUntil(ctx, database.UpdateWorkspaceAutostartSkipUntilParams{
+		ID:                 workspace.ID,
+		AutostartSkipUntil: skipUntil,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating workspace autostart.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	newWorkspace := workspace
+	newWorkspace.AutostartSkipUntil = skipUntil
+	aReq.New = newWorkspace
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// workspaceAutostartExceptions returns the exceptions of the workspace's
+// template followed by the exceptions of the deployment.
+func (api *API) workspaceAutostartExceptions(ctx context.Context, workspace database.Workspace) ([]schedule.AutostartException, error) {
+	templateExceptions, err := api.Database.GetTemplateAutostartExceptions(ctx, uuid.NullUUID{UUID: workspace.TemplateID, Valid: true})
+	if err != nil {
+		return nil, xerrors.Errorf("get template autostart exceptions: %w", err)
+	}
+	deploymentExceptions, err := api.Database.GetDeploymentAutostartExceptions(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("get deployment autostart exceptions: %w", err)
+	}
+	return schedule.ConvertAutostartExceptions(append(templateExceptions, deploymentExceptions...)), nil
+}
+
+func convertAutostartExceptions(exceptions []database.AutostartException) []codersdk.AutostartException {
+	converted := make([]codersdk.AutostartException, 0, len(exceptions))
+	for _, exception := range exceptions {
+		var templateID *uuid.UUID
+		if exception.TemplateID.Valid {
+			id := exception.TemplateID.UUID
+			templateID = &id
+		}
+		converted = append(converted, codersdk.AutostartException{
+			ID:         exception.ID,
+			TemplateID: templateID,
+			Name:       exception.Name,
+			StartsAt:   exception.StartsAt,
+			EndsAt:     exception.EndsAt,
+			AllDay:     exception.AllDay,
+		})
+	}
+	return converted
+}
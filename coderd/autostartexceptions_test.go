@@ -0,0 +1,184 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/coderd/util/ptr"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/testutil"
+)
+
+func TestAutostartExceptions(t *testing.T) {
+	t.Parallel()
+
+	newYear := codersdk.CreateAutostartExceptionRequest{
+		Name:     "New Year's Day",
+		StartsAt: time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		AllDay:   true,
+	}
+
+	t.Run("Deployment", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		user := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		exceptions, err := client.UpdateDeploymentAutostartExceptions(ctx, codersdk.UpdateAutostartExceptionsRequest{
+			Exceptions: []codersdk.CreateAutostartExceptionRequest{newYear},
+		})
+		require.NoError(t, err)
+		require.Len(t, exceptions, 1)
+		require.Nil(t, exceptions[0].TemplateID)
+		// All-day exceptions are stored as dates.
+		require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), exceptions[0].StartsAt.UTC())
+
+		// Every user can see the exceptions of the deployment.
+		exceptions, err = member.DeploymentAutostartExceptions(ctx)
+		require.NoError(t, err)
+		require.Len(t, exceptions, 1)
+		require.Equal(t, newYear.Name, exceptions[0].Name)
+
+		// But only admins can change them.
+		_, err = member.UpdateDeploymentAutostartExceptions(ctx, codersdk.UpdateAutostartExceptionsRequest{})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+		exceptions, err = client.UpdateDeploymentAutostartExceptions(ctx, codersdk.UpdateAutostartExceptionsRequest{})
+		require.NoError(t, err)
+		require.Empty(t, exceptions)
+	})
+
+	t.Run("Template", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := client.UpdateDeploymentAutostartExceptions(ctx, codersdk.UpdateAutostartExceptionsRequest{
+			Exceptions: []codersdk.CreateAutostartExceptionRequest{newYear},
+		})
+		require.NoError(t, err)
+
+		exceptions, err := client.UpdateTemplateAutostartExceptions(ctx, template.ID, codersdk.UpdateAutostartExceptionsRequest{
+			Exceptions: []codersdk.CreateAutostartExceptionRequest{{
+				Name:     "Offsite",
+				StartsAt: time.Date(2023, 9, 12, 9, 0, 0, 0, time.UTC),
+				EndsAt:   time.Date(2023, 9, 14, 18, 0, 0, 0, time.UTC),
+			}},
+		})
+		require.NoError(t, err)
+		require.Len(t, exceptions, 1)
+		require.Equal(t, &template.ID, exceptions[0].TemplateID)
+
+		// The exceptions of the deployment are not part of the template's.
+		exceptions, err = client.TemplateAutostartExceptions(ctx, template.ID)
+		require.NoError(t, err)
+		require.Len(t, exceptions, 1)
+		require.Equal(t, "Offsite", exceptions[0].Name)
+		require.False(t, exceptions[0].AllDay)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := client.UpdateDeploymentAutostartExceptions(ctx, codersdk.UpdateAutostartExceptionsRequest{
+			Exceptions: []codersdk.CreateAutostartExceptionRequest{newYear, {
+				Name:     "Backwards",
+				StartsAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				EndsAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			}},
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+		require.Len(t, apiErr.Validations, 1)
+		require.Equal(t, "exceptions[1].ends_at", apiErr.Validations[0].Field)
+	})
+}
+
+func TestWorkspaceSkipAutostart(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref("CRON_TZ=UTC 0 9 * * *")
+		})
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		err := client.SkipWorkspaceAutostart(ctx, workspace.ID, codersdk.SkipWorkspaceAutostartRequest{Skip: true})
+		require.NoError(t, err)
+		workspace, err = client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.NotNil(t, workspace.AutostartSkipUntil)
+		first := *workspace.AutostartSkipUntil
+		require.True(t, first.After(time.Now()))
+		require.Equal(t, 9, first.UTC().Hour())
+
+		// Skipping again skips the start after the skipped one.
+		err = client.SkipWorkspaceAutostart(ctx, workspace.ID, codersdk.SkipWorkspaceAutostartRequest{Skip: true})
+		require.NoError(t, err)
+		workspace, err = client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.NotNil(t, workspace.AutostartSkipUntil)
+		require.Equal(t, first.Add(24*time.Hour).UTC(), workspace.AutostartSkipUntil.UTC())
+
+		// Changing the schedule undoes the skips.
+		err = client.UpdateWorkspaceAutostart(ctx, workspace.ID, codersdk.UpdateWorkspaceAutostartRequest{
+			Schedule: ptr.Ref("CRON_TZ=UTC 0 10 * * *"),
+		})
+		require.NoError(t, err)
+		workspace, err = client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Nil(t, workspace.AutostartSkipUntil)
+
+		err = client.SkipWorkspaceAutostart(ctx, workspace.ID, codersdk.SkipWorkspaceAutostartRequest{Skip: true})
+		require.NoError(t, err)
+		err = client.SkipWorkspaceAutostart(ctx, workspace.ID, codersdk.SkipWorkspaceAutostartRequest{Skip: false})
+		require.NoError(t, err)
+		workspace, err = client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Nil(t, workspace.AutostartSkipUntil)
+	})
+
+	t.Run("NoSchedule", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = nil
+		})
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		err := client.SkipWorkspaceAutostart(ctx, workspace.ID, codersdk.SkipWorkspaceAutostartRequest{Skip: true})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+}
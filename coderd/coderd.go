@@ -53,6 +53,7 @@ import (
 	"github.com/coder/coder/coderd/httpapi"
 	"github.com/coder/coder/coderd/httpmw"
 	"github.com/coder/coder/coderd/metricscache"
+	"github.com/coder/coder/coderd/notifications"
 	"github.com/coder/coder/coderd/provisionerdserver"
 	"github.com/coder/coder/coderd/rbac"
 	"github.com/coder/coder/coderd/schedule"
@@ -101,6 +102,7 @@ type Options struct {
 	CacheDir string
 
 	Auditor                        audit.Auditor
+	Notifications                  notifications.Enqueuer
 	AgentConnectionUpdateFrequency time.Duration
 	AgentInactiveDisconnectTimeout time.Duration
 	AWSCertificates                awsidentity.Certificates
@@ -255,6 +257,9 @@ func New(options *Options) *API {
 	if options.Auditor == nil {
 		options.Auditor = audit.NewNop()
 	}
+	if options.Notifications == nil {
+		options.Notifications = notifications.NewStoreEnqueuer(options.Database, options.Logger.Named("notifications"), false)
+	}
 	if options.SSHConfig.HostnamePrefix == "" {
 		options.SSHConfig.HostnamePrefix = "coder."
 	}
@@ -569,6 +574,8 @@ func New(options *Options) *API {
 			r.Get("/config", api.deploymentValues)
 			r.Get("/stats", api.deploymentStats)
 			r.Get("/ssh", api.sshConfig)
+			r.Get("/autostart-exceptions", api.deploymentAutostartExceptions)
+			r.Put("/autostart-exceptions", api.putDeploymentAutostartExceptions)
 		})
 		r.Route("/experiments", func(r chi.Router) {
 			r.Use(apiKeyMiddleware)
@@ -581,8 +588,13 @@ func New(options *Options) *API {
 			)
 
 			r.Get("/", api.auditLogs)
+			r.Get("/export", api.auditLogsExport)
 			r.Post("/testgenerate", api.generateFakeAuditLog)
 		})
+		r.Route("/notifications", func(r chi.Router) {
+			r.Use(apiKeyMiddleware)
+			r.Get("/deliveries", api.notificationDeliveries)
+		})
 		r.Route("/files", func(r chi.Router) {
 			r.Use(
 				apiKeyMiddleware,
@@ -624,14 +636,21 @@ func New(options *Options) *API {
 					})
 				})
 				r.Route("/members", func(r chi.Router) {
+					r.Get("/", api.organizationMembers)
 					r.Get("/roles", api.assignableOrgRoles)
 					r.Route("/{user}", func(r chi.Router) {
 						r.Use(
 							httpmw.ExtractUserParam(options.Database, false),
-							httpmw.ExtractOrganizationMemberParam(options.Database),
 						)
-						r.Put("/roles", api.putMemberRoles)
-						r.Post("/workspaces", api.postWorkspacesByOrganization)
+						r.Post("/", api.postOrganizationMember)
+						r.Group(func(r chi.Router) {
+							r.Use(
+								httpmw.ExtractOrganizationMemberParam(options.Database),
+							)
+							r.Delete("/", api.deleteOrganizationMember)
+							r.Put("/roles", api.putMemberRoles)
+							r.Post("/workspaces", api.postWorkspacesByOrganization)
+						})
 					})
 				})
 			})
@@ -645,6 +664,8 @@ func New(options *Options) *API {
 			r.Get("/", api.template)
 			r.Delete("/", api.deleteTemplate)
 			r.Patch("/", api.patchTemplateMeta)
+			r.Get("/autostart-exceptions", api.templateAutostartExceptions)
+			r.Put("/autostart-exceptions", api.putTemplateAutostartExceptions)
 			r.Route("/versions", func(r chi.Router) {
 				r.Get("/", api.templateVersionsByTemplate)
 				r.Patch("/", api.patchActiveTemplateVersion)
@@ -659,6 +680,8 @@ func New(options *Options) *API {
 			r.Get("/", api.templateVersion)
 			r.Patch("/", api.patchTemplateVersion)
 			r.Patch("/cancel", api.patchCancelTemplateVersion)
+			r.Post("/archive", api.postArchiveTemplateVersion)
+			r.Post("/unarchive", api.postUnarchiveTemplateVersion)
 			// Old agents may expect a non-error response from /schema and /parameters endpoints.
 			// The idea is to return an empty [], so that the coder CLI won't get blocked accidentally.
 			r.Get("/schema", templateVersionSchemaDeprecated)
@@ -759,6 +782,13 @@ func New(options *Options) *API {
 					})
 					r.Get("/gitsshkey", api.gitSSHKey)
 					r.Put("/gitsshkey", api.regenerateGitSSHKey)
+					r.Route("/notifications", func(r chi.Router) {
+						r.Get("/", api.notifications)
+						r.Put("/read", api.putNotificationsRead)
+						r.Get("/preferences", api.notificationPreferences)
+						r.Put("/preferences", api.putNotificationPreferences)
+						r.Patch("/{notification}", api.patchNotification)
+					})
 				})
 			})
 		})
@@ -823,6 +853,10 @@ func New(options *Options) *API {
 				apiKeyMiddleware,
 			)
 			r.Get("/", api.workspaces)
+			r.Route("/bulk", func(r chi.Router) {
+				r.Post("/", api.postWorkspaceBulkOperation)
+				r.Get("/{operation}", api.workspaceBulkOperation)
+			})
 			r.Route("/{workspace}", func(r chi.Router) {
 				r.Use(
 					httpmw.ExtractWorkspaceParam(options.Database),
@@ -832,9 +866,11 @@ func New(options *Options) *API {
 				r.Route("/builds", func(r chi.Router) {
 					r.Get("/", api.workspaceBuilds)
 					r.Post("/", api.postWorkspaceBuilds)
+					r.Get("/states", api.workspaceBuildStates)
 				})
 				r.Route("/autostart", func(r chi.Router) {
 					r.Put("/", api.putWorkspaceAutostart)
+					r.Put("/skip", api.putWorkspaceAutostartSkip)
 				})
 				r.Route("/ttl", func(r chi.Router) {
 					r.Put("/", api.putWorkspaceTTL)
@@ -842,6 +878,7 @@ func New(options *Options) *API {
 				r.Get("/watch", api.watchWorkspace)
 				r.Put("/extend", api.putExtendWorkspace)
 				r.Put("/lock", api.putWorkspaceLock)
+				r.Put("/owner", api.putWorkspaceOwner)
 			})
 		})
 		r.Route("/workspacebuilds/{workspacebuild}", func(r chi.Router) {
@@ -942,6 +979,12 @@ func New(options *Options) *API {
 	rootRouter.Mount("/", r)
 	api.RootHandler = rootRouter
 
+	api.workspaceBulkOperationsWaitGroup.Add(1)
+	go func() {
+		defer api.workspaceBulkOperationsWaitGroup.Done()
+		api.completeStaleWorkspaceBulkOperations()
+	}()
+
 	return api
 }
 
@@ -964,6 +1007,9 @@ type API struct {
 	WorkspaceClientCoordinateOverride atomic.Pointer[func(rw http.ResponseWriter) bool]
 	TailnetCoordinator                atomic.Pointer[tailnet.Coordinator]
 	QuotaCommitter                    atomic.Pointer[proto.QuotaCommitter]
+	// WorkspaceTransferQuotaCheck reports whether the new owner of a
+	// workspace has enough quota for it before it's transferred.
+	WorkspaceTransferQuotaCheck atomic.Pointer[func(ctx context.Context, store database.Store, workspace database.Workspace, newOwnerID uuid.UUID) (bool, error)]
 	// WorkspaceProxyHostsFn returns the hosts of healthy workspace proxies
 	// for header reasons.
 	WorkspaceProxyHostsFn atomic.Pointer[func() []string]
@@ -990,6 +1036,16 @@ type API struct {
 	WebsocketWaitGroup sync.WaitGroup
 	derpCloseFunc      func()
 
+	// workspaceBulkOperationsWaitGroup tracks the running workspace bulk
+	// operations, so they can record their final state on shutdown, and the
+	// loop completing operations left behind by stopped replicas.
+	workspaceBulkOperationsWaitMutex sync.Mutex
+	workspaceBulkOperationsWaitGroup sync.WaitGroup
+	// notificationsWaitGroup tracks the notifications being sent in the
+	// background.
+	notificationsWaitMutex sync.Mutex
+	notificationsWaitGroup sync.WaitGroup
+
 	metricsCache          *metricscache.Cache
 	updateChecker         *updatecheck.Checker
 	WorkspaceAppsProvider workspaceapps.SignedTokenProvider
@@ -1015,6 +1071,14 @@ func (api *API) Close() error {
 	api.WebsocketWaitGroup.Wait()
 	api.WebsocketWaitMutex.Unlock()
 
+	api.workspaceBulkOperationsWaitMutex.Lock()
+	api.workspaceBulkOperationsWaitGroup.Wait()
+	api.workspaceBulkOperationsWaitMutex.Unlock()
+
+	api.notificationsWaitMutex.Lock()
+	api.notificationsWaitGroup.Wait()
+	api.notificationsWaitMutex.Unlock()
+
 	api.metricsCache.Close()
 	if api.updateChecker != nil {
 		api.updateChecker.Close()
@@ -1099,6 +1163,7 @@ func (api *API) CreateInMemoryProvisionerDaemon(ctx context.Context, debounce ti
 		Tags:                        tags,
 		QuotaCommitter:              &api.QuotaCommitter,
 		Auditor:                     &api.Auditor,
+		Notifier:                    api.Notifications,
 		TemplateScheduleStore:       api.TemplateScheduleStore,
 		UserQuietHoursScheduleStore: api.UserQuietHoursScheduleStore,
 		AcquireJobDebounce:          debounce,
@@ -67,6 +67,7 @@ import (
 	"github.com/coder/coder/coderd/healthcheck"
 	"github.com/coder/coder/coderd/httpapi"
 	"github.com/coder/coder/coderd/httpmw"
+	"github.com/coder/coder/coderd/notifications"
 	"github.com/coder/coder/coderd/rbac"
 	"github.com/coder/coder/coderd/schedule"
 	"github.com/coder/coder/coderd/telemetry"
@@ -107,6 +108,7 @@ type Options struct {
 	AutobuildTicker       <-chan time.Time
 	AutobuildStats        chan<- autobuild.Stats
 	Auditor               audit.Auditor
+	Notifications         notifications.Enqueuer
 	TLSCertificates       []tls.Certificate
 	GitAuthConfigs        []*gitauth.Config
 	TrialGenerator        func(context.Context, string) error
@@ -231,6 +233,9 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 	if options.DeploymentValues == nil {
 		options.DeploymentValues = DeploymentValues(t)
 	}
+	if options.Notifications == nil {
+		options.Notifications = notifications.NewStoreEnqueuer(options.Database, slogtest.Make(t, nil).Named("notifications"), false)
+	}
 	// This value is not safe to run in parallel. Force it to be false.
 	options.DeploymentValues.DisableOwnerWorkspaceExec = false
 
@@ -268,6 +273,7 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 		ctx,
 		options.Database,
 		&templateScheduleStore,
+		options.Notifications,
 		slogtest.Make(t, nil).Named("autobuild.executor").Leveled(slog.LevelDebug),
 		options.AutobuildTicker,
 	).WithStatsChannel(options.AutobuildStats)
@@ -395,6 +401,7 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 			GitAuthConfigs:                 options.GitAuthConfigs,
 
 			Auditor:                     options.Auditor,
+			Notifications:               options.Notifications,
 			AWSCertificates:             options.AWSCertificates,
 			AzureCertificates:           options.AzureCertificates,
 			GithubOAuth2Config:          options.GithubOAuth2Config,
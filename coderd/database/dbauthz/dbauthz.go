@@ -639,6 +639,13 @@ func (q *querier) AcquireLock(ctx context.Context, id int64) error {
 	return q.db.AcquireLock(ctx, id)
 }
 
+func (q *querier) AcquireNotificationDeliveries(ctx context.Context, arg database.AcquireNotificationDeliveriesParams) ([]database.NotificationDelivery, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.AcquireNotificationDeliveries(ctx, arg)
+}
+
 // TODO: We need to create a ProvisionerJob resource type
 func (q *querier) AcquireProvisionerJob(ctx context.Context, arg database.AcquireProvisionerJobParams) (database.ProvisionerJob, error) {
 	// if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceSystem); err != nil {
@@ -654,6 +661,13 @@ func (q *querier) CleanTailnetCoordinators(ctx context.Context) error {
 	return q.db.CleanTailnetCoordinators(ctx)
 }
 
+func (q *querier) CompleteStaleWorkspaceBulkOperations(ctx context.Context, arg database.CompleteStaleWorkspaceBulkOperationsParams) ([]uuid.UUID, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.CompleteStaleWorkspaceBulkOperations(ctx, arg)
+}
+
 func (q *querier) DeleteAPIKeyByID(ctx context.Context, id string) error {
 	return deleteQ(q.log, q.auth, q.db.GetAPIKeyByID, q.db.DeleteAPIKeyByID)(ctx, id)
 }
@@ -685,6 +699,13 @@ func (q *querier) DeleteCoordinator(ctx context.Context, id uuid.UUID) error {
 	return q.db.DeleteCoordinator(ctx, id)
 }
 
+func (q *querier) DeleteDeploymentAutostartExceptions(ctx context.Context) error {
+	if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceDeploymentValues); err != nil {
+		return err
+	}
+	return q.db.DeleteDeploymentAutostartExceptions(ctx)
+}
+
 func (q *querier) DeleteGitSSHKey(ctx context.Context, userID uuid.UUID) error {
 	return deleteQ(q.log, q.auth, q.db.GetGitSSHKey, q.db.DeleteGitSSHKey)(ctx, userID)
 }
@@ -722,6 +743,27 @@ func (q *querier) DeleteLicense(ctx context.Context, id int32) (int32, error) {
 	return id, nil
 }
 
+func (q *querier) DeleteOldAuditLogCheckpoints(ctx context.Context) (int64, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionDelete, rbac.ResourceSystem); err != nil {
+		return 0, err
+	}
+	return q.db.DeleteOldAuditLogCheckpoints(ctx)
+}
+
+func (q *querier) DeleteOldAuditLogs(ctx context.Context, arg database.DeleteOldAuditLogsParams) (int64, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionDelete, rbac.ResourceSystem); err != nil {
+		return 0, err
+	}
+	return q.db.DeleteOldAuditLogs(ctx, arg)
+}
+
+func (q *querier) DeleteOldProvisionerJobLogs(ctx context.Context, arg database.DeleteOldProvisionerJobLogsParams) (int64, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionDelete, rbac.ResourceSystem); err != nil {
+		return 0, err
+	}
+	return q.db.DeleteOldProvisionerJobLogs(ctx, arg)
+}
+
 func (q *querier) DeleteOldWorkspaceAgentLogs(ctx context.Context) error {
 	if err := q.authorizeContext(ctx, rbac.ActionDelete, rbac.ResourceSystem); err != nil {
 		return err
@@ -736,6 +778,23 @@ func (q *querier) DeleteOldWorkspaceAgentStats(ctx context.Context) error {
 	return q.db.DeleteOldWorkspaceAgentStats(ctx)
 }
 
+func (q *querier) DeleteOldWorkspaceBuildProvisionerState(ctx context.Context, arg database.DeleteOldWorkspaceBuildProvisionerStateParams) (int64, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionDelete, rbac.ResourceSystem); err != nil {
+		return 0, err
+	}
+	return q.db.DeleteOldWorkspaceBuildProvisionerState(ctx, arg)
+}
+
+func (q *querier) DeleteOrganizationMember(ctx context.Context, arg database.DeleteOrganizationMemberParams) error {
+	fetch := func(ctx context.Context, arg database.DeleteOrganizationMemberParams) (database.OrganizationMember, error) {
+		return q.db.GetOrganizationMemberByUserID(ctx, database.GetOrganizationMemberByUserIDParams{
+			OrganizationID: arg.OrganizationID,
+			UserID:         arg.UserID,
+		})
+	}
+	return deleteQ(q.log, q.auth, fetch, q.db.DeleteOrganizationMember)(ctx, arg)
+}
+
 func (q *querier) DeleteReplicasUpdatedBefore(ctx context.Context, updatedAt time.Time) error {
 	if err := q.authorizeContext(ctx, rbac.ActionDelete, rbac.ResourceSystem); err != nil {
 		return err
@@ -757,6 +816,13 @@ func (q *querier) DeleteTailnetClient(ctx context.Context, arg database.DeleteTa
 	return q.db.DeleteTailnetClient(ctx, arg)
 }
 
+func (q *querier) DeleteTemplateAutostartExceptions(ctx context.Context, templateID uuid.NullUUID) error {
+	fetch := func(ctx context.Context, templateID uuid.NullUUID) (database.Template, error) {
+		return q.db.GetTemplateByID(ctx, templateID.UUID)
+	}
+	return fetchAndExec(q.log, q.auth, rbac.ActionUpdate, fetch, q.db.DeleteTemplateAutostartExceptions)(ctx, templateID)
+}
+
 func (q *querier) GetAPIKeyByID(ctx context.Context, id string) (database.APIKey, error) {
 	return fetch(q.log, q.auth, q.db.GetAPIKeyByID)(ctx, id)
 }
@@ -803,6 +869,22 @@ func (q *querier) GetAppSecurityKey(ctx context.Context) (string, error) {
 	return q.db.GetAppSecurityKey(ctx)
 }
 
+func (q *querier) GetAuditLogCheckpoints(ctx context.Context) ([]database.AuditLogCheckpoint, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceAuditLog); err != nil {
+		return nil, err
+	}
+	return q.db.GetAuditLogCheckpoints(ctx)
+}
+
+func (q *querier) GetAuditLogsForExport(ctx context.Context, arg database.GetAuditLogsForExportParams) ([]database.GetAuditLogsForExportRow, error) {
+	// Like GetAuditLogsOffset, the global audit log permission is only
+	// checked once.
+	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceAuditLog); err != nil {
+		return nil, err
+	}
+	return q.db.GetAuditLogsForExport(ctx, arg)
+}
+
 func (q *querier) GetAuditLogsOffset(ctx context.Context, arg database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	// To optimize audit logs, we only check the global audit log permission once.
 	// This is because we expect a large unbounded set of audit logs, and applying a SQL
@@ -820,6 +902,15 @@ func (q *querier) GetAuthorizationUserRoles(ctx context.Context, userID uuid.UUI
 	return q.db.GetAuthorizationUserRoles(ctx, userID)
 }
 
+func (q *querier) GetChainedAuditLogs(ctx context.Context, arg database.GetChainedAuditLogsParams) ([]database.AuditLog, error) {
+	// Like GetAuditLogsOffset, the global audit log permission is only
+	// checked once.
+	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceAuditLog); err != nil {
+		return nil, err
+	}
+	return q.db.GetChainedAuditLogs(ctx, arg)
+}
+
 func (q *querier) GetDERPMeshKey(ctx context.Context) (string, error) {
 	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceSystem); err != nil {
 		return "", err
@@ -832,6 +923,11 @@ func (q *querier) GetDefaultProxyConfig(ctx context.Context) (database.GetDefaul
 	return q.db.GetDefaultProxyConfig(ctx)
 }
 
+func (q *querier) GetDeploymentAutostartExceptions(ctx context.Context) ([]database.AutostartException, error) {
+	// No authz checks, every user can see when workspaces are not autostarted.
+	return q.db.GetDeploymentAutostartExceptions(ctx)
+}
+
 // Only used by metrics cache.
 func (q *querier) GetDeploymentDAUs(ctx context.Context, tzOffset int32) ([]database.GetDeploymentDAUsRow, error) {
 	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceSystem); err != nil {
@@ -934,6 +1030,13 @@ func (q *querier) GetLastUpdateCheck(ctx context.Context) (string, error) {
 	return q.db.GetLastUpdateCheck(ctx)
 }
 
+func (q *querier) GetLatestChainedAuditLog(ctx context.Context) (database.AuditLog, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceAuditLog); err != nil {
+		return database.AuditLog{}, err
+	}
+	return q.db.GetLatestChainedAuditLog(ctx)
+}
+
 func (q *querier) GetLatestWorkspaceBuildByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) (database.WorkspaceBuild, error) {
 	if _, err := q.GetWorkspaceByID(ctx, workspaceID); err != nil {
 		return database.WorkspaceBuild{}, err
@@ -977,6 +1080,34 @@ func (q *querier) GetLogoURL(ctx context.Context) (string, error) {
 	return q.db.GetLogoURL(ctx)
 }
 
+func (q *querier) GetNotificationDeliveries(ctx context.Context, arg database.GetNotificationDeliveriesParams) ([]database.GetNotificationDeliveriesRow, error) {
+	// The delivery log contains the messages and addresses of all users.
+	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceDeploymentValues); err != nil {
+		return nil, err
+	}
+	return q.db.GetNotificationDeliveries(ctx, arg)
+}
+
+func (q *querier) GetNotificationMessageByID(ctx context.Context, id uuid.UUID) (database.NotificationMessage, error) {
+	return fetch(q.log, q.auth, q.db.GetNotificationMessageByID)(ctx, id)
+}
+
+func (q *querier) GetNotificationMessagesByUserID(ctx context.Context, arg database.GetNotificationMessagesByUserIDParams) ([]database.NotificationMessage, error) {
+	obj := rbac.ResourceUserData.WithID(arg.UserID).WithOwner(arg.UserID.String())
+	if err := q.authorizeContext(ctx, rbac.ActionRead, obj); err != nil {
+		return nil, err
+	}
+	return q.db.GetNotificationMessagesByUserID(ctx, arg)
+}
+
+func (q *querier) GetNotificationPreferencesByUserID(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreference, error) {
+	obj := rbac.ResourceUserData.WithID(userID).WithOwner(userID.String())
+	if err := q.authorizeContext(ctx, rbac.ActionRead, obj); err != nil {
+		return nil, err
+	}
+	return q.db.GetNotificationPreferencesByUserID(ctx, userID)
+}
+
 func (q *querier) GetOAuthSigningKey(ctx context.Context) (string, error) {
 	if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return "", err
@@ -1002,6 +1133,10 @@ func (q *querier) GetOrganizationMemberByUserID(ctx context.Context, arg databas
 	return fetch(q.log, q.auth, q.db.GetOrganizationMemberByUserID)(ctx, arg)
 }
 
+func (q *querier) GetOrganizationMembersByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]database.OrganizationMember, error) {
+	return fetchWithPostFilter(q.auth, q.db.GetOrganizationMembersByOrganizationID)(ctx, organizationID)
+}
+
 func (q *querier) GetOrganizationMembershipsByUserID(ctx context.Context, userID uuid.UUID) ([]database.OrganizationMember, error) {
 	return fetchWithPostFilter(q.auth, q.db.GetOrganizationMembershipsByUserID)(ctx, userID)
 }
@@ -1165,6 +1300,26 @@ func (q *querier) GetTailnetClientsForAgent(ctx context.Context, agentID uuid.UU
 	return q.db.GetTailnetClientsForAgent(ctx, agentID)
 }
 
+func (q *querier) GetTemplateAutostartExceptions(ctx context.Context, templateID uuid.NullUUID) ([]database.AutostartException, error) {
+	// An actor can read the autostart exceptions of the templates they can read.
+	template, err := q.db.GetTemplateByID(ctx, templateID.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.authorizeContext(ctx, rbac.ActionRead, template); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplateAutostartExceptions(ctx, templateID)
+}
+
+// Only used by the lifecycle executor.
+func (q *querier) GetTemplateAutostartExceptionsByTemplateIDs(ctx context.Context, templateIds []uuid.UUID) ([]database.AutostartException, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplateAutostartExceptionsByTemplateIDs(ctx, templateIds)
+}
+
 // Only used by metrics cache.
 func (q *querier) GetTemplateAverageBuildTime(ctx context.Context, arg database.GetTemplateAverageBuildTimeParams) (database.GetTemplateAverageBuildTimeRow, error) {
 	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceSystem); err != nil {
@@ -1375,6 +1530,13 @@ func (q *querier) GetTemplateVersionsCreatedAfter(ctx context.Context, createdAt
 	return q.db.GetTemplateVersionsCreatedAfter(ctx, createdAt)
 }
 
+func (q *querier) GetTemplateWorkspaceCounts(ctx context.Context, templateIds []uuid.UUID) ([]database.GetTemplateWorkspaceCountsRow, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplateWorkspaceCounts(ctx, templateIds)
+}
+
 func (q *querier) GetTemplates(ctx context.Context) ([]database.Template, error) {
 	if err := q.authorizeContext(ctx, rbac.ActionRead, rbac.ResourceSystem); err != nil {
 		return nil, err
@@ -1635,6 +1797,13 @@ func (q *querier) GetWorkspaceBuildParameters(ctx context.Context, workspaceBuil
 	return q.db.GetWorkspaceBuildParameters(ctx, workspaceBuildID)
 }
 
+func (q *querier) GetWorkspaceBuildStateSummariesByWorkspaceID(ctx context.Context, arg database.GetWorkspaceBuildStateSummariesByWorkspaceIDParams) ([]database.GetWorkspaceBuildStateSummariesByWorkspaceIDRow, error) {
+	if _, err := q.GetWorkspaceByID(ctx, arg.WorkspaceID); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceBuildStateSummariesByWorkspaceID(ctx, arg)
+}
+
 func (q *querier) GetWorkspaceBuildsByWorkspaceID(ctx context.Context, arg database.GetWorkspaceBuildsByWorkspaceIDParams) ([]database.WorkspaceBuild, error) {
 	if _, err := q.GetWorkspaceByID(ctx, arg.WorkspaceID); err != nil {
 		return nil, err
@@ -1652,6 +1821,20 @@ func (q *querier) GetWorkspaceBuildsCreatedAfter(ctx context.Context, createdAt
 	return q.db.GetWorkspaceBuildsCreatedAfter(ctx, createdAt)
 }
 
+func (q *querier) GetWorkspaceBulkOperationByID(ctx context.Context, id uuid.UUID) (database.WorkspaceBulkOperation, error) {
+	return fetch(q.log, q.auth, q.db.GetWorkspaceBulkOperationByID)(ctx, id)
+}
+
+func (q *querier) GetWorkspaceBulkOperationWorkspacesByOperationID(ctx context.Context, operationID uuid.UUID) ([]database.GetWorkspaceBulkOperationWorkspacesByOperationIDRow, error) {
+	// Reading the workspaces of an operation is the same as reading the
+	// operation itself.
+	_, err := q.GetWorkspaceBulkOperationByID(ctx, operationID)
+	if err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceBulkOperationWorkspacesByOperationID(ctx, operationID)
+}
+
 func (q *querier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUID) (database.Workspace, error) {
 	return fetch(q.log, q.auth, q.db.GetWorkspaceByAgentID)(ctx, agentID)
 }
@@ -1660,6 +1843,10 @@ func (q *querier) GetWorkspaceByID(ctx context.Context, id uuid.UUID) (database.
 	return fetch(q.log, q.auth, q.db.GetWorkspaceByID)(ctx, id)
 }
 
+func (q *querier) GetWorkspaceByIDForUpdate(ctx context.Context, id uuid.UUID) (database.Workspace, error) {
+	return fetch(q.log, q.auth, q.db.GetWorkspaceByIDForUpdate)(ctx, id)
+}
+
 func (q *querier) GetWorkspaceByOwnerIDAndName(ctx context.Context, arg database.GetWorkspaceByOwnerIDAndNameParams) (database.Workspace, error) {
 	return fetch(q.log, q.auth, q.db.GetWorkspaceByOwnerIDAndName)(ctx, arg)
 }
@@ -1790,6 +1977,10 @@ func (q *querier) GetWorkspaces(ctx context.Context, arg database.GetWorkspacesP
 	return q.db.GetAuthorizedWorkspaces(ctx, arg, prep)
 }
 
+func (q *querier) GetWorkspacesAboutToAutostop(ctx context.Context, arg database.GetWorkspacesAboutToAutostopParams) ([]database.GetWorkspacesAboutToAutostopRow, error) {
+	return q.db.GetWorkspacesAboutToAutostop(ctx, arg)
+}
+
 func (q *querier) GetWorkspacesEligibleForTransition(ctx context.Context, now time.Time) ([]database.Workspace, error) {
 	return q.db.GetWorkspacesEligibleForTransition(ctx, now)
 }
@@ -1809,6 +2000,30 @@ func (q *querier) InsertAuditLog(ctx context.Context, arg database.InsertAuditLo
 	return insert(q.log, q.auth, rbac.ResourceAuditLog, q.db.InsertAuditLog)(ctx, arg)
 }
 
+func (q *querier) InsertAuditLogCheckpoint(ctx context.Context, arg database.InsertAuditLogCheckpointParams) error {
+	if err := q.authorizeContext(ctx, rbac.ActionCreate, rbac.ResourceAuditLog); err != nil {
+		return err
+	}
+	return q.db.InsertAuditLogCheckpoint(ctx, arg)
+}
+
+func (q *querier) InsertAutostartException(ctx context.Context, arg database.InsertAutostartExceptionParams) (database.AutostartException, error) {
+	if !arg.TemplateID.Valid {
+		if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceDeploymentValues); err != nil {
+			return database.AutostartException{}, err
+		}
+		return q.db.InsertAutostartException(ctx, arg)
+	}
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID.UUID)
+	if err != nil {
+		return database.AutostartException{}, err
+	}
+	if err := q.authorizeContext(ctx, rbac.ActionUpdate, template); err != nil {
+		return database.AutostartException{}, err
+	}
+	return q.db.InsertAutostartException(ctx, arg)
+}
+
 func (q *querier) InsertDERPMeshKey(ctx context.Context, value string) error {
 	if err := q.authorizeContext(ctx, rbac.ActionCreate, rbac.ResourceSystem); err != nil {
 		return err
@@ -1860,6 +2075,21 @@ func (q *querier) InsertMissingGroups(ctx context.Context, arg database.InsertMi
 	return q.db.InsertMissingGroups(ctx, arg)
 }
 
+func (q *querier) InsertNotificationDelivery(ctx context.Context, arg database.InsertNotificationDeliveryParams) (database.NotificationDelivery, error) {
+	if err := q.authorizeContext(ctx, rbac.ActionCreate, rbac.ResourceSystem); err != nil {
+		return database.NotificationDelivery{}, err
+	}
+	return q.db.InsertNotificationDelivery(ctx, arg)
+}
+
+func (q *querier) InsertNotificationMessage(ctx context.Context, arg database.InsertNotificationMessageParams) (database.NotificationMessage, error) {
+	// Only the system notifies users of events.
+	if err := q.authorizeContext(ctx, rbac.ActionCreate, rbac.ResourceSystem); err != nil {
+		return database.NotificationMessage{}, err
+	}
+	return q.db.InsertNotificationMessage(ctx, arg)
+}
+
 func (q *querier) InsertOrganization(ctx context.Context, arg database.InsertOrganizationParams) (database.Organization, error) {
 	return insert(q.log, q.auth, rbac.ResourceOrganization, q.db.InsertOrganization)(ctx, arg)
 }
@@ -2076,6 +2306,25 @@ func (q *querier) InsertWorkspaceBuildParameters(ctx context.Context, arg databa
 	return q.db.InsertWorkspaceBuildParameters(ctx, arg)
 }
 
+func (q *querier) InsertWorkspaceBulkOperation(ctx context.Context, arg database.InsertWorkspaceBulkOperationParams) (database.WorkspaceBulkOperation, error) {
+	obj := rbac.ResourceWorkspaceBulkOperation.WithOwner(arg.InitiatorID.String())
+	return insert(q.log, q.auth, obj, q.db.InsertWorkspaceBulkOperation)(ctx, arg)
+}
+
+func (q *querier) InsertWorkspaceBulkOperationWorkspaces(ctx context.Context, arg database.InsertWorkspaceBulkOperationWorkspacesParams) ([]database.WorkspaceBulkOperationWorkspace, error) {
+	operation, err := q.db.GetWorkspaceBulkOperationByID(ctx, arg.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.authorizeContext(ctx, rbac.ActionUpdate, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.db.InsertWorkspaceBulkOperationWorkspaces(ctx, arg)
+}
+
 func (q *querier) InsertWorkspaceProxy(ctx context.Context, arg database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	return insert(q.log, q.auth, rbac.ResourceWorkspaceProxy, q.db.InsertWorkspaceProxy)(ctx, arg)
 }
@@ -2161,6 +2410,28 @@ func (q *querier) UpdateMemberRoles(ctx context.Context, arg database.UpdateMemb
 	return q.db.UpdateMemberRoles(ctx, arg)
 }
 
+func (q *querier) UpdateNotificationDelivery(ctx context.Context, arg database.UpdateNotificationDeliveryParams) error {
+	if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.UpdateNotificationDelivery(ctx, arg)
+}
+
+func (q *querier) UpdateNotificationMessageReadAt(ctx context.Context, arg database.UpdateNotificationMessageReadAtParams) (database.NotificationMessage, error) {
+	fetch := func(ctx context.Context, arg database.UpdateNotificationMessageReadAtParams) (database.NotificationMessage, error) {
+		return q.db.GetNotificationMessageByID(ctx, arg.ID)
+	}
+	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateNotificationMessageReadAt)(ctx, arg)
+}
+
+func (q *querier) UpdateNotificationMessagesReadAtByUserID(ctx context.Context, arg database.UpdateNotificationMessagesReadAtByUserIDParams) error {
+	obj := rbac.ResourceUserData.WithID(arg.UserID).WithOwner(arg.UserID.String())
+	if err := q.authorizeContext(ctx, rbac.ActionUpdate, obj); err != nil {
+		return err
+	}
+	return q.db.UpdateNotificationMessagesReadAtByUserID(ctx, arg)
+}
+
 // TODO: We need to create a ProvisionerJob resource type
 func (q *querier) UpdateProvisionerJobByID(ctx context.Context, arg database.UpdateProvisionerJobByIDParams) error {
 	// if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceSystem); err != nil {
@@ -2286,6 +2557,28 @@ func (q *querier) UpdateTemplateScheduleByID(ctx context.Context, arg database.U
 	return update(q.log, q.auth, fetch, q.db.UpdateTemplateScheduleByID)(ctx, arg)
 }
 
+func (q *querier) UpdateTemplateVersionArchivedByID(ctx context.Context, arg database.UpdateTemplateVersionArchivedByIDParams) error {
+	// An actor is allowed to archive the template version if they are authorized to update the template.
+	tv, err := q.db.GetTemplateVersionByID(ctx, arg.ID)
+	if err != nil {
+		return err
+	}
+	var obj rbac.Objecter
+	if !tv.TemplateID.Valid {
+		obj = rbac.ResourceTemplate.InOrg(tv.OrganizationID)
+	} else {
+		tpl, err := q.db.GetTemplateByID(ctx, tv.TemplateID.UUID)
+		if err != nil {
+			return err
+		}
+		obj = tpl
+	}
+	if err := q.authorizeContext(ctx, rbac.ActionUpdate, obj); err != nil {
+		return err
+	}
+	return q.db.UpdateTemplateVersionArchivedByID(ctx, arg)
+}
+
 func (q *querier) UpdateTemplateVersionByID(ctx context.Context, arg database.UpdateTemplateVersionByIDParams) error {
 	// An actor is allowed to update the template version if they are authorized to update the template.
 	tv, err := q.db.GetTemplateVersionByID(ctx, arg.ID)
@@ -2468,6 +2761,13 @@ func (q *querier) UpdateWorkspace(ctx context.Context, arg database.UpdateWorksp
 	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateWorkspace)(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceACLByID(ctx context.Context, arg database.UpdateWorkspaceACLByIDParams) error {
+	fetch := func(ctx context.Context, arg database.UpdateWorkspaceACLByIDParams) (database.Workspace, error) {
+		return q.db.GetWorkspaceByID(ctx, arg.ID)
+	}
+	return update(q.log, q.auth, fetch, q.db.UpdateWorkspaceACLByID)(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceAgentConnectionByID(ctx context.Context, arg database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return err
@@ -2559,6 +2859,13 @@ func (q *querier) UpdateWorkspaceAutostart(ctx context.Context, arg database.Upd
 	return update(q.log, q.auth, fetch, q.db.UpdateWorkspaceAutostart)(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceAutostartSkipUntil(ctx context.Context, arg database.UpdateWorkspaceAutostartSkipUntilParams) error {
+	fetch := func(ctx context.Context, arg database.UpdateWorkspaceAutostartSkipUntilParams) (database.Workspace, error) {
+		return q.db.GetWorkspaceByID(ctx, arg.ID)
+	}
+	return update(q.log, q.auth, fetch, q.db.UpdateWorkspaceAutostartSkipUntil)(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceBuildByID(ctx context.Context, arg database.UpdateWorkspaceBuildByIDParams) error {
 	build, err := q.db.GetWorkspaceBuildByID(ctx, arg.ID)
 	if err != nil {
@@ -2585,6 +2892,27 @@ func (q *querier) UpdateWorkspaceBuildCostByID(ctx context.Context, arg database
 	return q.db.UpdateWorkspaceBuildCostByID(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceBulkOperationCompletedAt(ctx context.Context, arg database.UpdateWorkspaceBulkOperationCompletedAtParams) error {
+	fetch := func(ctx context.Context, arg database.UpdateWorkspaceBulkOperationCompletedAtParams) (database.WorkspaceBulkOperation, error) {
+		return q.db.GetWorkspaceBulkOperationByID(ctx, arg.ID)
+	}
+	return update(q.log, q.auth, fetch, q.db.UpdateWorkspaceBulkOperationCompletedAt)(ctx, arg)
+}
+
+func (q *querier) UpdateWorkspaceBulkOperationHeartbeat(ctx context.Context, arg database.UpdateWorkspaceBulkOperationHeartbeatParams) error {
+	fetch := func(ctx context.Context, arg database.UpdateWorkspaceBulkOperationHeartbeatParams) (database.WorkspaceBulkOperation, error) {
+		return q.db.GetWorkspaceBulkOperationByID(ctx, arg.ID)
+	}
+	return update(q.log, q.auth, fetch, q.db.UpdateWorkspaceBulkOperationHeartbeat)(ctx, arg)
+}
+
+func (q *querier) UpdateWorkspaceBulkOperationWorkspace(ctx context.Context, arg database.UpdateWorkspaceBulkOperationWorkspaceParams) error {
+	fetch := func(ctx context.Context, arg database.UpdateWorkspaceBulkOperationWorkspaceParams) (database.WorkspaceBulkOperation, error) {
+		return q.db.GetWorkspaceBulkOperationByID(ctx, arg.OperationID)
+	}
+	return update(q.log, q.auth, fetch, q.db.UpdateWorkspaceBulkOperationWorkspace)(ctx, arg)
+}
+
 // Deprecated: Use SoftDeleteWorkspaceByID
 func (q *querier) UpdateWorkspaceDeletedByID(ctx context.Context, arg database.UpdateWorkspaceDeletedByIDParams) error {
 	// TODO deleteQ me, placeholder for database.Store
@@ -2609,6 +2937,23 @@ func (q *querier) UpdateWorkspaceLockedDeletingAt(ctx context.Context, arg datab
 	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateWorkspaceLockedDeletingAt)(ctx, arg)
 }
 
+// UpdateWorkspaceOwner moves a workspace to another user. This is the same as
+// updating the workspace and creating it for the new owner.
+func (q *querier) UpdateWorkspaceOwner(ctx context.Context, arg database.UpdateWorkspaceOwnerParams) (database.Workspace, error) {
+	workspace, err := q.db.GetWorkspaceByID(ctx, arg.ID)
+	if err != nil {
+		return database.Workspace{}, err
+	}
+	if err := q.authorizeContext(ctx, rbac.ActionUpdate, workspace); err != nil {
+		return database.Workspace{}, err
+	}
+	obj := rbac.ResourceWorkspace.WithOwner(arg.OwnerID.String()).InOrg(workspace.OrganizationID)
+	if err := q.authorizeContext(ctx, rbac.ActionCreate, obj); err != nil {
+		return database.Workspace{}, err
+	}
+	return q.db.UpdateWorkspaceOwner(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceProxy(ctx context.Context, arg database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	fetch := func(ctx context.Context, arg database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 		return q.db.GetWorkspaceProxyByID(ctx, arg.ID)
@@ -2664,6 +3009,14 @@ func (q *querier) UpsertLogoURL(ctx context.Context, value string) error {
 	return q.db.UpsertLogoURL(ctx, value)
 }
 
+func (q *querier) UpsertNotificationPreference(ctx context.Context, arg database.UpsertNotificationPreferenceParams) (database.NotificationPreference, error) {
+	obj := rbac.ResourceUserData.WithID(arg.UserID).WithOwner(arg.UserID.String())
+	if err := q.authorizeContext(ctx, rbac.ActionUpdate, obj); err != nil {
+		return database.NotificationPreference{}, err
+	}
+	return q.db.UpsertNotificationPreference(ctx, arg)
+}
+
 func (q *querier) UpsertOAuthSigningKey(ctx context.Context, value string) error {
 	if err := q.authorizeContext(ctx, rbac.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return err
@@ -230,6 +230,13 @@ func (s *MethodTestSuite) TestAuditLogs() {
 			Action:       database.AuditActionCreate,
 		}).Asserts(rbac.ResourceAuditLog, rbac.ActionCreate)
 	}))
+	s.Run("GetAuditLogsForExport", s.Subtest(func(db database.Store, check *expects) {
+		_ = dbgen.AuditLog(s.T(), db, database.AuditLog{})
+		_ = dbgen.AuditLog(s.T(), db, database.AuditLog{})
+		check.Args(database.GetAuditLogsForExportParams{
+			LimitCount: 10,
+		}).Asserts(rbac.ResourceAuditLog, rbac.ActionRead)
+	}))
 	s.Run("GetAuditLogsOffset", s.Subtest(func(db database.Store, check *expects) {
 		_ = dbgen.AuditLog(s.T(), db, database.AuditLog{})
 		_ = dbgen.AuditLog(s.T(), db, database.AuditLog{})
@@ -237,6 +244,57 @@ func (s *MethodTestSuite) TestAuditLogs() {
 			Limit: 10,
 		}).Asserts(rbac.ResourceAuditLog, rbac.ActionRead)
 	}))
+	s.Run("GetChainedAuditLogs", s.Subtest(func(db database.Store, check *expects) {
+		_ = dbgen.AuditLog(s.T(), db, database.AuditLog{ChainSequence: sql.NullInt64{Int64: 1, Valid: true}})
+		check.Args(database.GetChainedAuditLogsParams{
+			LimitCount: 10,
+		}).Asserts(rbac.ResourceAuditLog, rbac.ActionRead)
+	}))
+	s.Run("GetLatestChainedAuditLog", s.Subtest(func(db database.Store, check *expects) {
+		_ = dbgen.AuditLog(s.T(), db, database.AuditLog{ChainSequence: sql.NullInt64{Int64: 1, Valid: true}})
+		check.Args().Asserts(rbac.ResourceAuditLog, rbac.ActionRead)
+	}))
+	s.Run("InsertAuditLogCheckpoint", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.InsertAuditLogCheckpointParams{
+			ChainSequence: 1,
+		}).Asserts(rbac.ResourceAuditLog, rbac.ActionCreate)
+	}))
+	s.Run("GetAuditLogCheckpoints", s.Subtest(func(db database.Store, check *expects) {
+		check.Args().Asserts(rbac.ResourceAuditLog, rbac.ActionRead)
+	}))
+}
+
+func (s *MethodTestSuite) TestAutostartException() {
+	s.Run("GetDeploymentAutostartExceptions", s.Subtest(func(db database.Store, check *expects) {
+		check.Args().Asserts().Returns([]database.AutostartException{})
+	}))
+	s.Run("GetTemplateAutostartExceptions", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		check.Args(uuid.NullUUID{UUID: t1.ID, Valid: true}).Asserts(t1, rbac.ActionRead).Returns([]database.AutostartException{})
+	}))
+	s.Run("GetTemplateAutostartExceptionsByTemplateIDs", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		check.Args([]uuid.UUID{t1.ID}).Asserts(rbac.ResourceSystem, rbac.ActionRead).Returns([]database.AutostartException{})
+	}))
+	s.Run("Deployment/InsertAutostartException", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.InsertAutostartExceptionParams{
+			ID: uuid.New(),
+		}).Asserts(rbac.ResourceDeploymentValues, rbac.ActionUpdate)
+	}))
+	s.Run("Template/InsertAutostartException", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		check.Args(database.InsertAutostartExceptionParams{
+			ID:         uuid.New(),
+			TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true},
+		}).Asserts(t1, rbac.ActionUpdate)
+	}))
+	s.Run("DeleteDeploymentAutostartExceptions", s.Subtest(func(db database.Store, check *expects) {
+		check.Args().Asserts(rbac.ResourceDeploymentValues, rbac.ActionUpdate).Returns()
+	}))
+	s.Run("DeleteTemplateAutostartExceptions", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		check.Args(uuid.NullUUID{UUID: t1.ID, Valid: true}).Asserts(t1, rbac.ActionUpdate).Returns()
+	}))
 }
 
 func (s *MethodTestSuite) TestFile() {
@@ -530,6 +588,12 @@ func (s *MethodTestSuite) TestOrganization() {
 			UserID:         mem.UserID,
 		}).Asserts(mem, rbac.ActionRead).Returns(mem)
 	}))
+	s.Run("GetOrganizationMembersByOrganizationID", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		a := dbgen.OrganizationMember(s.T(), db, database.OrganizationMember{OrganizationID: o.ID})
+		b := dbgen.OrganizationMember(s.T(), db, database.OrganizationMember{OrganizationID: o.ID})
+		check.Args(o.ID).Asserts(a, rbac.ActionRead, b, rbac.ActionRead).Returns(slice.New(a, b))
+	}))
 	s.Run("GetOrganizationMembershipsByUserID", s.Subtest(func(db database.Store, check *expects) {
 		u := dbgen.User(s.T(), db, database.User{})
 		a := dbgen.OrganizationMember(s.T(), db, database.OrganizationMember{UserID: u.ID})
@@ -567,6 +631,18 @@ func (s *MethodTestSuite) TestOrganization() {
 			rbac.ResourceRoleAssignment.InOrg(o.ID), rbac.ActionCreate,
 			rbac.ResourceOrganizationMember.InOrg(o.ID).WithID(u.ID), rbac.ActionCreate)
 	}))
+	s.Run("DeleteOrganizationMember", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		u := dbgen.User(s.T(), db, database.User{})
+		mem := dbgen.OrganizationMember(s.T(), db, database.OrganizationMember{
+			OrganizationID: o.ID,
+			UserID:         u.ID,
+		})
+		check.Args(database.DeleteOrganizationMemberParams{
+			OrganizationID: o.ID,
+			UserID:         u.ID,
+		}).Asserts(mem, rbac.ActionDelete).Returns()
+	}))
 	s.Run("UpdateMemberRoles", s.Subtest(func(db database.Store, check *expects) {
 		o := dbgen.Organization(s.T(), db, database.Organization{})
 		u := dbgen.User(s.T(), db, database.User{})
@@ -800,6 +876,17 @@ func (s *MethodTestSuite) TestTemplate() {
 			ID: t1.ID,
 		}).Asserts(t1, rbac.ActionUpdate)
 	}))
+	s.Run("UpdateTemplateVersionArchivedByID", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{
+			TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true},
+		})
+		check.Args(database.UpdateTemplateVersionArchivedByIDParams{
+			ID:        tv.ID,
+			Archived:  true,
+			UpdatedAt: tv.UpdatedAt,
+		}).Asserts(t1, rbac.ActionUpdate)
+	}))
 	s.Run("UpdateTemplateVersionByID", s.Subtest(func(db database.Store, check *expects) {
 		t1 := dbgen.Template(s.T(), db, database.Template{})
 		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{
@@ -1007,6 +1094,10 @@ func (s *MethodTestSuite) TestWorkspace() {
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
 		check.Args(ws.ID).Asserts(ws, rbac.ActionRead)
 	}))
+	s.Run("GetWorkspaceByIDForUpdate", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		check.Args(ws.ID).Asserts(ws, rbac.ActionRead)
+	}))
 	s.Run("GetWorkspaces", s.Subtest(func(db database.Store, check *expects) {
 		_ = dbgen.Workspace(s.T(), db, database.Workspace{})
 		_ = dbgen.Workspace(s.T(), db, database.Workspace{})
@@ -1125,6 +1216,11 @@ func (s *MethodTestSuite) TestWorkspace() {
 		check.Args(build.ID).Asserts(ws, rbac.ActionRead).
 			Returns([]database.WorkspaceBuildParameter{})
 	}))
+	s.Run("GetWorkspaceBuildStateSummariesByWorkspaceID", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		_ = dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, BuildNumber: 1})
+		check.Args(database.GetWorkspaceBuildStateSummariesByWorkspaceIDParams{WorkspaceID: ws.ID}).Asserts(ws, rbac.ActionRead)
+	}))
 	s.Run("GetWorkspaceBuildsByWorkspaceID", s.Subtest(func(db database.Store, check *expects) {
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
 		_ = dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, BuildNumber: 1})
@@ -1208,6 +1304,25 @@ func (s *MethodTestSuite) TestWorkspace() {
 			ID: w.ID,
 		}).Asserts(w, rbac.ActionUpdate).Returns(expected)
 	}))
+	s.Run("UpdateWorkspaceACLByID", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		check.Args(database.UpdateWorkspaceACLByIDParams{
+			ID: w.ID,
+		}).Asserts(w, rbac.ActionUpdate).Returns()
+	}))
+	s.Run("UpdateWorkspaceOwner", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		u := dbgen.User(s.T(), db, database.User{})
+		expected := w
+		expected.OwnerID = u.ID
+		check.Args(database.UpdateWorkspaceOwnerParams{
+			ID:      w.ID,
+			OwnerID: u.ID,
+		}).Asserts(
+			w, rbac.ActionUpdate,
+			rbac.ResourceWorkspace.WithOwner(u.ID.String()).InOrg(w.OrganizationID), rbac.ActionCreate,
+		).Returns(expected)
+	}))
 	s.Run("InsertWorkspaceAgentStat", s.Subtest(func(db database.Store, check *expects) {
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
 		check.Args(database.InsertWorkspaceAgentStatParams{
@@ -1231,6 +1346,12 @@ func (s *MethodTestSuite) TestWorkspace() {
 			ID: ws.ID,
 		}).Asserts(ws, rbac.ActionUpdate).Returns()
 	}))
+	s.Run("UpdateWorkspaceAutostartSkipUntil", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		check.Args(database.UpdateWorkspaceAutostartSkipUntilParams{
+			ID: ws.ID,
+		}).Asserts(ws, rbac.ActionUpdate).Returns()
+	}))
 	s.Run("UpdateWorkspaceBuildByID", s.Subtest(func(db database.Store, check *expects) {
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
 		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, JobID: uuid.New()})
@@ -1275,6 +1396,139 @@ func (s *MethodTestSuite) TestWorkspace() {
 	}))
 }
 
+func (s *MethodTestSuite) TestWorkspaceBulkOperation() {
+	s.Run("InsertWorkspaceBulkOperation", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.InsertWorkspaceBulkOperationParams{
+			ID:          uuid.New(),
+			InitiatorID: u.ID,
+			Transition:  database.WorkspaceBulkOperationTransitionStop,
+		}).Asserts(rbac.ResourceWorkspaceBulkOperation.WithOwner(u.ID.String()), rbac.ActionCreate)
+	}))
+	s.Run("GetWorkspaceBulkOperationByID", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.WorkspaceBulkOperation(s.T(), db, database.WorkspaceBulkOperation{})
+		check.Args(o.ID).Asserts(o, rbac.ActionRead).Returns(o)
+	}))
+	s.Run("GetWorkspaceBulkOperationWorkspacesByOperationID", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.WorkspaceBulkOperation(s.T(), db, database.WorkspaceBulkOperation{})
+		check.Args(o.ID).Asserts(o, rbac.ActionRead).Returns([]database.GetWorkspaceBulkOperationWorkspacesByOperationIDRow{})
+	}))
+	s.Run("InsertWorkspaceBulkOperationWorkspaces", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.WorkspaceBulkOperation(s.T(), db, database.WorkspaceBulkOperation{})
+		check.Args(database.InsertWorkspaceBulkOperationWorkspacesParams{
+			OperationID: o.ID,
+		}).Asserts(o, rbac.ActionUpdate).Returns([]database.WorkspaceBulkOperationWorkspace{})
+	}))
+	s.Run("UpdateWorkspaceBulkOperationCompletedAt", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.WorkspaceBulkOperation(s.T(), db, database.WorkspaceBulkOperation{})
+		check.Args(database.UpdateWorkspaceBulkOperationCompletedAtParams{
+			ID: o.ID,
+		}).Asserts(o, rbac.ActionUpdate).Returns()
+	}))
+	s.Run("UpdateWorkspaceBulkOperationHeartbeat", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.WorkspaceBulkOperation(s.T(), db, database.WorkspaceBulkOperation{})
+		check.Args(database.UpdateWorkspaceBulkOperationHeartbeatParams{
+			ID:          o.ID,
+			HeartbeatAt: database.Now(),
+		}).Asserts(o, rbac.ActionUpdate).Returns()
+	}))
+	s.Run("CompleteStaleWorkspaceBulkOperations", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.CompleteStaleWorkspaceBulkOperationsParams{}).Asserts(rbac.ResourceSystem, rbac.ActionUpdate).Returns([]uuid.UUID{})
+	}))
+	s.Run("UpdateWorkspaceBulkOperationWorkspace", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.WorkspaceBulkOperation(s.T(), db, database.WorkspaceBulkOperation{})
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		_, err := db.InsertWorkspaceBulkOperationWorkspaces(context.Background(), database.InsertWorkspaceBulkOperationWorkspacesParams{
+			OperationID:  o.ID,
+			WorkspaceIDs: []uuid.UUID{ws.ID},
+		})
+		require.NoError(s.T(), err)
+		check.Args(database.UpdateWorkspaceBulkOperationWorkspaceParams{
+			OperationID: o.ID,
+			WorkspaceID: ws.ID,
+			Status:      database.WorkspaceBulkOperationWorkspaceStatusSkipped,
+		}).Asserts(o, rbac.ActionUpdate).Returns()
+	}))
+}
+
+func (s *MethodTestSuite) TestNotification() {
+	s.Run("GetNotificationMessageByID", s.Subtest(func(db database.Store, check *expects) {
+		m := dbgen.NotificationMessage(s.T(), db, database.NotificationMessage{})
+		check.Args(m.ID).Asserts(m, rbac.ActionRead).Returns(m)
+	}))
+	s.Run("GetNotificationMessagesByUserID", s.Subtest(func(db database.Store, check *expects) {
+		m := dbgen.NotificationMessage(s.T(), db, database.NotificationMessage{})
+		check.Args(database.GetNotificationMessagesByUserIDParams{
+			UserID: m.UserID,
+		}).Asserts(rbac.ResourceUserData.WithID(m.UserID).WithOwner(m.UserID.String()), rbac.ActionRead).Returns(slice.New(m))
+	}))
+	s.Run("UpdateNotificationMessageReadAt", s.Subtest(func(db database.Store, check *expects) {
+		m := dbgen.NotificationMessage(s.T(), db, database.NotificationMessage{})
+		m.ReadAt = sql.NullTime{Time: database.Now(), Valid: true}
+		check.Args(database.UpdateNotificationMessageReadAtParams{
+			ID:     m.ID,
+			ReadAt: m.ReadAt,
+		}).Asserts(m, rbac.ActionUpdate).Returns(m)
+	}))
+	s.Run("UpdateNotificationMessagesReadAtByUserID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.UpdateNotificationMessagesReadAtByUserIDParams{
+			UserID: u.ID,
+		}).Asserts(rbac.ResourceUserData.WithID(u.ID).WithOwner(u.ID.String()), rbac.ActionUpdate).Returns()
+	}))
+	s.Run("GetNotificationPreferencesByUserID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(u.ID).Asserts(rbac.ResourceUserData.WithID(u.ID).WithOwner(u.ID.String()), rbac.ActionRead).Returns([]database.NotificationPreference{})
+	}))
+	s.Run("UpsertNotificationPreference", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.UpsertNotificationPreferenceParams{
+			UserID: u.ID,
+			Event:  database.NotificationEventWorkspaceAutostop,
+		}).Asserts(rbac.ResourceUserData.WithID(u.ID).WithOwner(u.ID.String()), rbac.ActionUpdate)
+	}))
+	s.Run("InsertNotificationMessage", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.InsertNotificationMessageParams{
+			ID:    uuid.New(),
+			Event: database.NotificationEventUserSuspended,
+		}).Asserts(rbac.ResourceSystem, rbac.ActionCreate)
+	}))
+	s.Run("InsertNotificationDelivery", s.Subtest(func(db database.Store, check *expects) {
+		m := dbgen.NotificationMessage(s.T(), db, database.NotificationMessage{})
+		check.Args(database.InsertNotificationDeliveryParams{
+			ID:        uuid.New(),
+			MessageID: m.ID,
+		}).Asserts(rbac.ResourceSystem, rbac.ActionCreate)
+	}))
+	s.Run("AcquireNotificationDeliveries", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.AcquireNotificationDeliveriesParams{
+			Now:      database.Now(),
+			LimitOpt: 10,
+		}).Asserts(rbac.ResourceSystem, rbac.ActionUpdate).Returns([]database.NotificationDelivery{})
+	}))
+	s.Run("UpdateNotificationDelivery", s.Subtest(func(db database.Store, check *expects) {
+		m := dbgen.NotificationMessage(s.T(), db, database.NotificationMessage{})
+		d, err := db.InsertNotificationDelivery(context.Background(), database.InsertNotificationDeliveryParams{
+			ID:        uuid.New(),
+			MessageID: m.ID,
+		})
+		require.NoError(s.T(), err)
+		check.Args(database.UpdateNotificationDeliveryParams{
+			ID:     d.ID,
+			Status: database.NotificationDeliveryStatusSent,
+		}).Asserts(rbac.ResourceSystem, rbac.ActionUpdate).Returns()
+	}))
+	s.Run("GetNotificationDeliveries", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.GetNotificationDeliveriesParams{}).Asserts(rbac.ResourceDeploymentValues, rbac.ActionRead).Returns([]database.GetNotificationDeliveriesRow{})
+	}))
+	s.Run("GetWorkspacesAboutToAutostop", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.GetWorkspacesAboutToAutostopParams{
+			Now:    database.Now(),
+			Before: database.Now().Add(time.Hour),
+		}).Asserts().Returns([]database.GetWorkspacesAboutToAutostopRow{})
+	}))
+}
+
 func (s *MethodTestSuite) TestExtraMethods() {
 	s.Run("GetProvisionerDaemons", s.Subtest(func(db database.Store, check *expects) {
 		d, err := db.InsertProvisionerDaemon(context.Background(), database.InsertProvisionerDaemonParams{
@@ -1372,6 +1626,11 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 		_ = dbgen.Template(s.T(), db, database.Template{})
 		check.Args().Asserts(rbac.ResourceSystem, rbac.ActionRead)
 	}))
+	s.Run("GetTemplateWorkspaceCounts", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		check.Args([]uuid.UUID{w.TemplateID}).Asserts(rbac.ResourceSystem, rbac.ActionRead).
+			Returns([]database.GetTemplateWorkspaceCountsRow{{TemplateID: w.TemplateID, WorkspaceCount: 1}})
+	}))
 	s.Run("UpdateWorkspaceBuildCostByID", s.Subtest(func(db database.Store, check *expects) {
 		b := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{})
 		o := b
@@ -1412,6 +1671,27 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 	s.Run("DeleteOldWorkspaceAgentStats", s.Subtest(func(db database.Store, check *expects) {
 		check.Args().Asserts(rbac.ResourceSystem, rbac.ActionDelete)
 	}))
+	s.Run("DeleteOldAuditLogs", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.DeleteOldAuditLogsParams{
+			BeforeTime: time.Now(),
+			LimitCount: 10,
+		}).Asserts(rbac.ResourceSystem, rbac.ActionDelete)
+	}))
+	s.Run("DeleteOldAuditLogCheckpoints", s.Subtest(func(db database.Store, check *expects) {
+		check.Args().Asserts(rbac.ResourceSystem, rbac.ActionDelete)
+	}))
+	s.Run("DeleteOldProvisionerJobLogs", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.DeleteOldProvisionerJobLogsParams{
+			CompletedBefore: time.Now(),
+			LimitCount:      10,
+		}).Asserts(rbac.ResourceSystem, rbac.ActionDelete)
+	}))
+	s.Run("DeleteOldWorkspaceBuildProvisionerState", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.DeleteOldWorkspaceBuildProvisionerStateParams{
+			KeepBuilds: 1,
+			LimitCount: 10,
+		}).Asserts(rbac.ResourceSystem, rbac.ActionDelete)
+	}))
 	s.Run("GetProvisionerJobsCreatedAfter", s.Subtest(func(db database.Store, check *expects) {
 		// TODO: add provisioner job resource type
 		_ = dbgen.ProvisionerJob(s.T(), db, database.ProvisionerJob{CreatedAt: time.Now().Add(-time.Hour)})
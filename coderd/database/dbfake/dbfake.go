@@ -1,6 +1,7 @@
 package dbfake
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -114,33 +115,40 @@ type data struct {
 	userLinks           []database.UserLink
 
 	// New tables
-	workspaceAgentStats       []database.WorkspaceAgentStat
-	auditLogs                 []database.AuditLog
-	files                     []database.File
-	gitAuthLinks              []database.GitAuthLink
-	gitSSHKey                 []database.GitSSHKey
-	groupMembers              []database.GroupMember
-	groups                    []database.Group
-	licenses                  []database.License
-	parameterSchemas          []database.ParameterSchema
-	provisionerDaemons        []database.ProvisionerDaemon
-	provisionerJobLogs        []database.ProvisionerJobLog
-	provisionerJobs           []database.ProvisionerJob
-	replicas                  []database.Replica
-	templateVersions          []database.TemplateVersionTable
-	templateVersionParameters []database.TemplateVersionParameter
-	templateVersionVariables  []database.TemplateVersionVariable
-	templates                 []database.TemplateTable
-	workspaceAgents           []database.WorkspaceAgent
-	workspaceAgentMetadata    []database.WorkspaceAgentMetadatum
-	workspaceAgentLogs        []database.WorkspaceAgentLog
-	workspaceApps             []database.WorkspaceApp
-	workspaceBuilds           []database.WorkspaceBuildTable
-	workspaceBuildParameters  []database.WorkspaceBuildParameter
-	workspaceResourceMetadata []database.WorkspaceResourceMetadatum
-	workspaceResources        []database.WorkspaceResource
-	workspaces                []database.Workspace
-	workspaceProxies          []database.WorkspaceProxy
+	workspaceAgentStats              []database.WorkspaceAgentStat
+	auditLogs                        []database.AuditLog
+	auditLogCheckpoints              []database.AuditLogCheckpoint
+	autostartExceptions              []database.AutostartException
+	files                            []database.File
+	gitAuthLinks                     []database.GitAuthLink
+	gitSSHKey                        []database.GitSSHKey
+	groupMembers                     []database.GroupMember
+	groups                           []database.Group
+	licenses                         []database.License
+	notificationDeliveries           []database.NotificationDelivery
+	notificationMessages             []database.NotificationMessage
+	notificationPreferences          []database.NotificationPreference
+	parameterSchemas                 []database.ParameterSchema
+	provisionerDaemons               []database.ProvisionerDaemon
+	provisionerJobLogs               []database.ProvisionerJobLog
+	provisionerJobs                  []database.ProvisionerJob
+	replicas                         []database.Replica
+	templateVersions                 []database.TemplateVersionTable
+	templateVersionParameters        []database.TemplateVersionParameter
+	templateVersionVariables         []database.TemplateVersionVariable
+	templates                        []database.TemplateTable
+	workspaceAgents                  []database.WorkspaceAgent
+	workspaceAgentMetadata           []database.WorkspaceAgentMetadatum
+	workspaceAgentLogs               []database.WorkspaceAgentLog
+	workspaceApps                    []database.WorkspaceApp
+	workspaceBuilds                  []database.WorkspaceBuildTable
+	workspaceBuildParameters         []database.WorkspaceBuildParameter
+	workspaceBulkOperations          []database.WorkspaceBulkOperation
+	workspaceBulkOperationWorkspaces []database.WorkspaceBulkOperationWorkspace
+	workspaceResourceMetadata        []database.WorkspaceResourceMetadatum
+	workspaceResources               []database.WorkspaceResource
+	workspaces                       []database.Workspace
+	workspaceProxies                 []database.WorkspaceProxy
 	// Locks is a map of lock names. Any keys within the map are currently
 	// locked.
 	locks                   map[int64]struct{}
@@ -257,6 +265,31 @@ func (q *FakeQuerier) InTx(fn func(database.Store) error, _ *sql.TxOptions) erro
 	return fn(tx)
 }
 
+// getAutostartExceptionsNoLock returns the exceptions of a template sorted like
+// the SQL query, or those of the deployment if the template ID is not valid.
+func (q *FakeQuerier) getAutostartExceptionsNoLock(templateID uuid.NullUUID) []database.AutostartException {
+	exceptions := make([]database.AutostartException, 0)
+	for _, exception := range q.autostartExceptions {
+		if exception.TemplateID == templateID {
+			exceptions = append(exceptions, exception)
+		}
+	}
+	sortAutostartExceptions(exceptions)
+	return exceptions
+}
+
+func sortAutostartExceptions(exceptions []database.AutostartException) {
+	slices.SortFunc(exceptions, func(a, b database.AutostartException) int {
+		if !a.StartsAt.Equal(b.StartsAt) {
+			if a.StartsAt.Before(b.StartsAt) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
 // getUserByIDNoLock is used by other functions in the database fake.
 func (q *FakeQuerier) getUserByIDNoLock(id uuid.UUID) (database.User, error) {
 	for _, user := range q.users {
@@ -328,20 +361,23 @@ func (q *FakeQuerier) convertToWorkspaceRowsNoLock(ctx context.Context, workspac
 	rows := make([]database.GetWorkspacesRow, 0, len(workspaces))
 	for _, w := range workspaces {
 		wr := database.GetWorkspacesRow{
-			ID:                w.ID,
-			CreatedAt:         w.CreatedAt,
-			UpdatedAt:         w.UpdatedAt,
-			OwnerID:           w.OwnerID,
-			OrganizationID:    w.OrganizationID,
-			TemplateID:        w.TemplateID,
-			Deleted:           w.Deleted,
-			Name:              w.Name,
-			AutostartSchedule: w.AutostartSchedule,
-			Ttl:               w.Ttl,
-			LastUsedAt:        w.LastUsedAt,
-			LockedAt:          w.LockedAt,
-			DeletingAt:        w.DeletingAt,
-			Count:             count,
+			ID:                 w.ID,
+			CreatedAt:          w.CreatedAt,
+			UpdatedAt:          w.UpdatedAt,
+			OwnerID:            w.OwnerID,
+			OrganizationID:     w.OrganizationID,
+			TemplateID:         w.TemplateID,
+			Deleted:            w.Deleted,
+			Name:               w.Name,
+			AutostartSchedule:  w.AutostartSchedule,
+			Ttl:                w.Ttl,
+			LastUsedAt:         w.LastUsedAt,
+			LockedAt:           w.LockedAt,
+			DeletingAt:         w.DeletingAt,
+			UserACL:            w.UserACL,
+			GroupACL:           w.GroupACL,
+			AutostartSkipUntil: w.AutostartSkipUntil,
+			Count:              count,
 		}
 
 		for _, t := range q.templates {
@@ -615,6 +651,36 @@ func (*FakeQuerier) AcquireLock(_ context.Context, _ int64) error {
 	return xerrors.New("AcquireLock must only be called within a transaction")
 }
 
+func (q *FakeQuerier) AcquireNotificationDeliveries(_ context.Context, arg database.AcquireNotificationDeliveriesParams) ([]database.NotificationDelivery, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	indexes := make([]int, 0)
+	for i, delivery := range q.notificationDeliveries {
+		if delivery.Status == database.NotificationDeliveryStatusPending && !delivery.NextAttemptAt.After(arg.Now) {
+			indexes = append(indexes, i)
+		}
+	}
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		return q.notificationDeliveries[a].NextAttemptAt.Compare(q.notificationDeliveries[b].NextAttemptAt)
+	})
+	if len(indexes) > int(arg.LimitOpt) {
+		indexes = indexes[:arg.LimitOpt]
+	}
+
+	deliveries := make([]database.NotificationDelivery, 0, len(indexes))
+	for _, i := range indexes {
+		q.notificationDeliveries[i].NextAttemptAt = arg.LeaseUntil
+		q.notificationDeliveries[i].UpdatedAt = arg.Now
+		deliveries = append(deliveries, q.notificationDeliveries[i])
+	}
+	return deliveries, nil
+}
+
 func (q *FakeQuerier) AcquireProvisionerJob(_ context.Context, arg database.AcquireProvisionerJobParams) (database.ProvisionerJob, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.ProvisionerJob{}, err
@@ -674,6 +740,40 @@ func (*FakeQuerier) CleanTailnetCoordinators(_ context.Context) error {
 	return ErrUnimplemented
 }
 
+func (q *FakeQuerier) CompleteStaleWorkspaceBulkOperations(_ context.Context, arg database.CompleteStaleWorkspaceBulkOperationsParams) ([]uuid.UUID, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	completed := make([]uuid.UUID, 0)
+	for index, operation := range q.workspaceBulkOperations {
+		if operation.CompletedAt.Valid || !operation.HeartbeatAt.Before(arg.HeartbeatBefore) {
+			continue
+		}
+		operation.CompletedAt = sql.NullTime{Time: arg.CompletedAt, Valid: true}
+		q.workspaceBulkOperations[index] = operation
+		completed = append(completed, operation.ID)
+
+		for index, operationWorkspace := range q.workspaceBulkOperationWorkspaces {
+			if operationWorkspace.OperationID != operation.ID {
+				continue
+			}
+			if operationWorkspace.Status != database.WorkspaceBulkOperationWorkspaceStatusPending &&
+				operationWorkspace.Status != database.WorkspaceBulkOperationWorkspaceStatusBuilding {
+				continue
+			}
+			operationWorkspace.Status = database.WorkspaceBulkOperationWorkspaceStatusFailed
+			operationWorkspace.Error = arg.Error
+			operationWorkspace.UpdatedAt = arg.CompletedAt
+			q.workspaceBulkOperationWorkspaces[index] = operationWorkspace
+		}
+	}
+	return completed, nil
+}
+
 func (q *FakeQuerier) DeleteAPIKeyByID(_ context.Context, id string) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -719,6 +819,20 @@ func (*FakeQuerier) DeleteCoordinator(context.Context, uuid.UUID) error {
 	return ErrUnimplemented
 }
 
+func (q *FakeQuerier) DeleteDeploymentAutostartExceptions(_ context.Context) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	exceptions := make([]database.AutostartException, 0, len(q.autostartExceptions))
+	for _, exception := range q.autostartExceptions {
+		if exception.TemplateID.Valid {
+			exceptions = append(exceptions, exception)
+		}
+	}
+	q.autostartExceptions = exceptions
+	return nil
+}
+
 func (q *FakeQuerier) DeleteGitSSHKey(_ context.Context, userID uuid.UUID) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -804,6 +918,126 @@ func (q *FakeQuerier) DeleteLicense(_ context.Context, id int32) (int32, error)
 	return 0, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) DeleteOldAuditLogCheckpoints(_ context.Context) (int64, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var (
+		oldest int64
+		found  bool
+	)
+	for _, alog := range q.auditLogs {
+		if alog.ChainSequence.Valid && (!found || alog.ChainSequence.Int64 < oldest) {
+			oldest = alog.ChainSequence.Int64
+			found = true
+		}
+	}
+	if !found {
+		return 0, nil
+	}
+
+	var deleted int64
+	checkpoints := make([]database.AuditLogCheckpoint, 0, len(q.auditLogCheckpoints))
+	for _, checkpoint := range q.auditLogCheckpoints {
+		if checkpoint.ChainSequence < oldest-1 {
+			deleted++
+			continue
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	q.auditLogCheckpoints = checkpoints
+	return deleted, nil
+}
+
+func (q *FakeQuerier) DeleteOldAuditLogs(_ context.Context, arg database.DeleteOldAuditLogsParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	// Chained audit logs are deleted up to the newest checkpoint before the
+	// cutoff that comes before the head of the chain.
+	var head int64
+	for _, alog := range q.auditLogs {
+		if alog.ChainSequence.Valid && alog.ChainSequence.Int64 > head {
+			head = alog.ChainSequence.Int64
+		}
+	}
+	var anchor int64
+	for _, checkpoint := range q.auditLogCheckpoints {
+		if checkpoint.CreatedAt.Before(arg.BeforeTime) && checkpoint.ChainSequence < head && checkpoint.ChainSequence > anchor {
+			anchor = checkpoint.ChainSequence
+		}
+	}
+
+	candidates := make([]database.AuditLog, 0)
+	for _, alog := range q.auditLogs {
+		if (!alog.ChainSequence.Valid && alog.Time.Before(arg.BeforeTime)) ||
+			(alog.ChainSequence.Valid && alog.ChainSequence.Int64 <= anchor) {
+			candidates = append(candidates, alog)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b database.AuditLog) int {
+		if a.ChainSequence.Valid != b.ChainSequence.Valid {
+			if a.ChainSequence.Valid {
+				return 1
+			}
+			return -1
+		}
+		return int(a.ChainSequence.Int64 - b.ChainSequence.Int64)
+	})
+	if len(candidates) > int(arg.LimitCount) {
+		candidates = candidates[:arg.LimitCount]
+	}
+	deleteIDs := make(map[uuid.UUID]struct{}, len(candidates))
+	for _, alog := range candidates {
+		deleteIDs[alog.ID] = struct{}{}
+	}
+
+	alogs := make([]database.AuditLog, 0, len(q.auditLogs))
+	for _, alog := range q.auditLogs {
+		if _, ok := deleteIDs[alog.ID]; ok {
+			continue
+		}
+		alogs = append(alogs, alog)
+	}
+	q.auditLogs = alogs
+	return int64(len(candidates)), nil
+}
+
+func (q *FakeQuerier) DeleteOldProvisionerJobLogs(_ context.Context, arg database.DeleteOldProvisionerJobLogsParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	completedJobs := make(map[uuid.UUID]struct{})
+	for _, job := range q.provisionerJobs {
+		if job.CompletedAt.Valid && job.CompletedAt.Time.Before(arg.CompletedBefore) {
+			completedJobs[job.ID] = struct{}{}
+		}
+	}
+
+	// Logs are stored in insertion order, which is the order of their IDs.
+	var deleted int64
+	logs := make([]database.ProvisionerJobLog, 0, len(q.provisionerJobLogs))
+	for _, jobLog := range q.provisionerJobLogs {
+		if _, ok := completedJobs[jobLog.JobID]; ok && deleted < int64(arg.LimitCount) {
+			deleted++
+			continue
+		}
+		logs = append(logs, jobLog)
+	}
+	q.provisionerJobLogs = logs
+	return deleted, nil
+}
+
 func (*FakeQuerier) DeleteOldWorkspaceAgentLogs(_ context.Context) error {
 	// noop
 	return nil
@@ -814,6 +1048,58 @@ func (*FakeQuerier) DeleteOldWorkspaceAgentStats(_ context.Context) error {
 	return nil
 }
 
+func (q *FakeQuerier) DeleteOldWorkspaceBuildProvisionerState(_ context.Context, arg database.DeleteOldWorkspaceBuildProvisionerStateParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	latestBuildNumbers := make(map[uuid.UUID]int32)
+	for _, build := range q.workspaceBuilds {
+		if build.BuildNumber > latestBuildNumbers[build.WorkspaceID] {
+			latestBuildNumbers[build.WorkspaceID] = build.BuildNumber
+		}
+	}
+
+	var updated int64
+	for i, build := range q.workspaceBuilds {
+		if updated >= int64(arg.LimitCount) {
+			break
+		}
+		if build.ProvisionerState == nil {
+			continue
+		}
+		if int64(build.BuildNumber) > int64(latestBuildNumbers[build.WorkspaceID])-arg.KeepBuilds {
+			continue
+		}
+		q.workspaceBuilds[i].ProvisionerState = nil
+		updated++
+	}
+	return updated, nil
+}
+
+func (q *FakeQuerier) DeleteOrganizationMember(_ context.Context, arg database.DeleteOrganizationMemberParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, member := range q.organizationMembers {
+		if member.OrganizationID != arg.OrganizationID || member.UserID != arg.UserID {
+			continue
+		}
+		q.organizationMembers = append(q.organizationMembers[:i], q.organizationMembers[i+1:]...)
+		return nil
+	}
+	return nil
+}
+
 func (q *FakeQuerier) DeleteReplicasUpdatedBefore(_ context.Context, before time.Time) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -835,6 +1121,20 @@ func (*FakeQuerier) DeleteTailnetClient(context.Context, database.DeleteTailnetC
 	return database.DeleteTailnetClientRow{}, ErrUnimplemented
 }
 
+func (q *FakeQuerier) DeleteTemplateAutostartExceptions(_ context.Context, templateID uuid.NullUUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	exceptions := make([]database.AutostartException, 0, len(q.autostartExceptions))
+	for _, exception := range q.autostartExceptions {
+		if !templateID.Valid || exception.TemplateID != templateID {
+			exceptions = append(exceptions, exception)
+		}
+	}
+	q.autostartExceptions = exceptions
+	return nil
+}
+
 func (q *FakeQuerier) GetAPIKeyByID(_ context.Context, id string) (database.APIKey, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -933,6 +1233,120 @@ func (q *FakeQuerier) GetAppSecurityKey(_ context.Context) (string, error) {
 	return q.appSecurityKey, nil
 }
 
+func (q *FakeQuerier) GetAuditLogCheckpoints(_ context.Context) ([]database.AuditLogCheckpoint, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	checkpoints := slices.Clone(q.auditLogCheckpoints)
+	slices.SortFunc(checkpoints, func(a, b database.AuditLogCheckpoint) int {
+		return int(a.ChainSequence - b.ChainSequence)
+	})
+	return checkpoints, nil
+}
+
+func (q *FakeQuerier) GetAuditLogsForExport(_ context.Context, arg database.GetAuditLogsForExportParams) ([]database.GetAuditLogsForExportRow, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	// Audit logs with the same time are ordered by ID, as in the keyset.
+	auditLogs := slices.Clone(q.auditLogs)
+	slices.SortFunc(auditLogs, func(a, b database.AuditLog) int {
+		if !a.Time.Equal(b.Time) {
+			if a.Time.After(b.Time) {
+				return -1
+			}
+			return 1
+		}
+		return -bytes.Compare(a.ID[:], b.ID[:])
+	})
+
+	logs := make([]database.GetAuditLogsForExportRow, 0, arg.LimitCount)
+	for _, alog := range auditLogs {
+		if arg.AfterID != uuid.Nil {
+			if alog.Time.After(arg.AfterTime) {
+				continue
+			}
+			if alog.Time.Equal(arg.AfterTime) && bytes.Compare(alog.ID[:], arg.AfterID[:]) >= 0 {
+				continue
+			}
+		}
+		if arg.Action != "" && !strings.Contains(string(alog.Action), arg.Action) {
+			continue
+		}
+		if arg.ResourceType != "" && !strings.Contains(string(alog.ResourceType), arg.ResourceType) {
+			continue
+		}
+		if arg.ResourceID != uuid.Nil && alog.ResourceID != arg.ResourceID {
+			continue
+		}
+		if arg.UserID != uuid.Nil && alog.UserID != arg.UserID {
+			continue
+		}
+		if arg.Username != "" {
+			user, err := q.getUserByIDNoLock(alog.UserID)
+			if err == nil && !strings.EqualFold(arg.Username, user.Username) {
+				continue
+			}
+		}
+		if arg.Email != "" {
+			user, err := q.getUserByIDNoLock(alog.UserID)
+			if err == nil && !strings.EqualFold(arg.Email, user.Email) {
+				continue
+			}
+		}
+		if !arg.DateFrom.IsZero() && alog.Time.Before(arg.DateFrom) {
+			continue
+		}
+		if !arg.DateTo.IsZero() && alog.Time.After(arg.DateTo) {
+			continue
+		}
+		if arg.BuildReason != "" {
+			workspaceBuild, err := q.getWorkspaceBuildByIDNoLock(context.Background(), alog.ResourceID)
+			if err == nil && !strings.EqualFold(arg.BuildReason, string(workspaceBuild.Reason)) {
+				continue
+			}
+		}
+
+		user, err := q.getUserByIDNoLock(alog.UserID)
+		userValid := err == nil
+
+		logs = append(logs, database.GetAuditLogsForExportRow{
+			ID:               alog.ID,
+			Time:             alog.Time,
+			RequestID:        alog.RequestID,
+			OrganizationID:   alog.OrganizationID,
+			Ip:               alog.Ip,
+			UserAgent:        alog.UserAgent,
+			ResourceType:     alog.ResourceType,
+			ResourceID:       alog.ResourceID,
+			ResourceTarget:   alog.ResourceTarget,
+			ResourceIcon:     alog.ResourceIcon,
+			ChainSequence:    alog.ChainSequence,
+			ChainHash:        alog.ChainHash,
+			Action:           alog.Action,
+			Diff:             alog.Diff,
+			StatusCode:       alog.StatusCode,
+			AdditionalFields: alog.AdditionalFields,
+			UserID:           alog.UserID,
+			UserUsername:     sql.NullString{String: user.Username, Valid: userValid},
+			UserEmail:        sql.NullString{String: user.Email, Valid: userValid},
+			UserCreatedAt:    sql.NullTime{Time: user.CreatedAt, Valid: userValid},
+			UserStatus:       database.NullUserStatus{UserStatus: user.Status, Valid: userValid},
+			UserRoles:        user.RBACRoles,
+		})
+
+		if len(logs) >= int(arg.LimitCount) {
+			break
+		}
+	}
+
+	return logs, nil
+}
+
 func (q *FakeQuerier) GetAuditLogsOffset(_ context.Context, arg database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return nil, err
@@ -945,10 +1359,6 @@ func (q *FakeQuerier) GetAuditLogsOffset(_ context.Context, arg database.GetAudi
 
 	// q.auditLogs are already sorted by time DESC, so no need to sort after the fact.
 	for _, alog := range q.auditLogs {
-		if arg.Offset > 0 {
-			arg.Offset--
-			continue
-		}
 		if arg.Action != "" && !strings.Contains(string(alog.Action), arg.Action) {
 			continue
 		}
@@ -987,6 +1397,12 @@ func (q *FakeQuerier) GetAuditLogsOffset(_ context.Context, arg database.GetAudi
 			}
 		}
 
+		// The offset applies to the filtered audit logs.
+		if arg.Offset > 0 {
+			arg.Offset--
+			continue
+		}
+
 		user, err := q.getUserByIDNoLock(alog.UserID)
 		userValid := err == nil
 
@@ -1000,6 +1416,8 @@ func (q *FakeQuerier) GetAuditLogsOffset(_ context.Context, arg database.GetAudi
 			ResourceID:       alog.ResourceID,
 			ResourceTarget:   alog.ResourceTarget,
 			ResourceIcon:     alog.ResourceIcon,
+			ChainSequence:    alog.ChainSequence,
+			ChainHash:        alog.ChainHash,
 			Action:           alog.Action,
 			Diff:             alog.Diff,
 			StatusCode:       alog.StatusCode,
@@ -1069,6 +1487,30 @@ func (q *FakeQuerier) GetAuthorizationUserRoles(_ context.Context, userID uuid.U
 	}, nil
 }
 
+func (q *FakeQuerier) GetChainedAuditLogs(_ context.Context, arg database.GetChainedAuditLogsParams) ([]database.AuditLog, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	logs := make([]database.AuditLog, 0)
+	for _, alog := range q.auditLogs {
+		if alog.ChainSequence.Valid && alog.ChainSequence.Int64 > arg.AfterSequence {
+			logs = append(logs, alog)
+		}
+	}
+	slices.SortFunc(logs, func(a, b database.AuditLog) int {
+		return int(a.ChainSequence.Int64 - b.ChainSequence.Int64)
+	})
+	if len(logs) > int(arg.LimitCount) {
+		logs = logs[:arg.LimitCount]
+	}
+	return logs, nil
+}
+
 func (q *FakeQuerier) GetDERPMeshKey(_ context.Context) (string, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -1083,6 +1525,13 @@ func (q *FakeQuerier) GetDefaultProxyConfig(_ context.Context) (database.GetDefa
 	}, nil
 }
 
+func (q *FakeQuerier) GetDeploymentAutostartExceptions(_ context.Context) ([]database.AutostartException, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	return q.getAutostartExceptionsNoLock(uuid.NullUUID{}), nil
+}
+
 func (q *FakeQuerier) GetDeploymentDAUs(_ context.Context, tzOffset int32) ([]database.GetDeploymentDAUsRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -1411,6 +1860,22 @@ func (q *FakeQuerier) GetLastUpdateCheck(_ context.Context) (string, error) {
 	return string(q.lastUpdateCheck), nil
 }
 
+func (q *FakeQuerier) GetLatestChainedAuditLog(_ context.Context) (database.AuditLog, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var latest database.AuditLog
+	for _, alog := range q.auditLogs {
+		if alog.ChainSequence.Valid && (!latest.ChainSequence.Valid || alog.ChainSequence.Int64 > latest.ChainSequence.Int64) {
+			latest = alog
+		}
+	}
+	if !latest.ChainSequence.Valid {
+		return database.AuditLog{}, sql.ErrNoRows
+	}
+	return latest, nil
+}
+
 func (q *FakeQuerier) GetLatestWorkspaceBuildByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) (database.WorkspaceBuild, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -1503,6 +1968,135 @@ func (q *FakeQuerier) GetLogoURL(_ context.Context) (string, error) {
 	return q.logoURL, nil
 }
 
+func (q *FakeQuerier) GetNotificationDeliveries(_ context.Context, arg database.GetNotificationDeliveriesParams) ([]database.GetNotificationDeliveriesRow, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	rows := make([]database.GetNotificationDeliveriesRow, 0)
+	for _, delivery := range q.notificationDeliveries {
+		if arg.Status != "" && string(delivery.Status) != arg.Status {
+			continue
+		}
+		for _, message := range q.notificationMessages {
+			if message.ID != delivery.MessageID {
+				continue
+			}
+			rows = append(rows, database.GetNotificationDeliveriesRow{
+				ID:            delivery.ID,
+				MessageID:     delivery.MessageID,
+				Address:       delivery.Address,
+				Status:        delivery.Status,
+				Attempts:      delivery.Attempts,
+				LastError:     delivery.LastError,
+				NextAttemptAt: delivery.NextAttemptAt,
+				CreatedAt:     delivery.CreatedAt,
+				UpdatedAt:     delivery.UpdatedAt,
+				UserID:        message.UserID,
+				Event:         message.Event,
+				Title:         message.Title,
+			})
+		}
+	}
+	slices.SortFunc(rows, func(a, b database.GetNotificationDeliveriesRow) int {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return b.CreatedAt.Compare(a.CreatedAt)
+		}
+		return slice.Ascending(a.ID.String(), b.ID.String())
+	})
+
+	if arg.OffsetOpt > 0 {
+		if int(arg.OffsetOpt) > len(rows)-1 {
+			return []database.GetNotificationDeliveriesRow{}, nil
+		}
+		rows = rows[arg.OffsetOpt:]
+	}
+
+	if arg.LimitOpt > 0 {
+		if int(arg.LimitOpt) > len(rows) {
+			arg.LimitOpt = int32(len(rows))
+		}
+		rows = rows[:arg.LimitOpt]
+	}
+
+	return rows, nil
+}
+
+func (q *FakeQuerier) GetNotificationMessageByID(_ context.Context, id uuid.UUID) (database.NotificationMessage, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, message := range q.notificationMessages {
+		if message.ID == id {
+			return message, nil
+		}
+	}
+	return database.NotificationMessage{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetNotificationMessagesByUserID(_ context.Context, arg database.GetNotificationMessagesByUserIDParams) ([]database.NotificationMessage, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	messages := make([]database.NotificationMessage, 0)
+	for _, message := range q.notificationMessages {
+		if message.UserID != arg.UserID || !message.Inbox {
+			continue
+		}
+		if arg.UnreadOnly && message.ReadAt.Valid {
+			continue
+		}
+		messages = append(messages, message)
+	}
+	slices.SortFunc(messages, func(a, b database.NotificationMessage) int {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return b.CreatedAt.Compare(a.CreatedAt)
+		}
+		return slice.Ascending(a.ID.String(), b.ID.String())
+	})
+
+	if arg.OffsetOpt > 0 {
+		if int(arg.OffsetOpt) > len(messages)-1 {
+			return []database.NotificationMessage{}, nil
+		}
+		messages = messages[arg.OffsetOpt:]
+	}
+
+	if arg.LimitOpt > 0 {
+		if int(arg.LimitOpt) > len(messages) {
+			arg.LimitOpt = int32(len(messages))
+		}
+		messages = messages[:arg.LimitOpt]
+	}
+
+	return messages, nil
+}
+
+func (q *FakeQuerier) GetNotificationPreferencesByUserID(_ context.Context, userID uuid.UUID) ([]database.NotificationPreference, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	preferences := make([]database.NotificationPreference, 0)
+	for _, preference := range q.notificationPreferences {
+		if preference.UserID == userID {
+			preferences = append(preferences, preference)
+		}
+	}
+	// Enums sort in the order of their values.
+	order := database.AllNotificationEventValues()
+	slices.SortFunc(preferences, func(a, b database.NotificationPreference) int {
+		return slices.Index(order, a.Event) - slices.Index(order, b.Event)
+	})
+	return preferences, nil
+}
+
 func (q *FakeQuerier) GetOAuthSigningKey(_ context.Context) (string, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -1577,6 +2171,20 @@ func (q *FakeQuerier) GetOrganizationMemberByUserID(_ context.Context, arg datab
 	return database.OrganizationMember{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) GetOrganizationMembersByOrganizationID(_ context.Context, organizationID uuid.UUID) ([]database.OrganizationMember, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var members []database.OrganizationMember
+	for _, member := range q.organizationMembers {
+		if member.OrganizationID != organizationID {
+			continue
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
 func (q *FakeQuerier) GetOrganizationMembershipsByUserID(_ context.Context, userID uuid.UUID) ([]database.OrganizationMember, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -1887,6 +2495,30 @@ func (*FakeQuerier) GetTailnetClientsForAgent(context.Context, uuid.UUID) ([]dat
 	return nil, ErrUnimplemented
 }
 
+func (q *FakeQuerier) GetTemplateAutostartExceptions(_ context.Context, templateID uuid.NullUUID) ([]database.AutostartException, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	if !templateID.Valid {
+		return []database.AutostartException{}, nil
+	}
+	return q.getAutostartExceptionsNoLock(templateID), nil
+}
+
+func (q *FakeQuerier) GetTemplateAutostartExceptionsByTemplateIDs(_ context.Context, templateIds []uuid.UUID) ([]database.AutostartException, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	exceptions := make([]database.AutostartException, 0)
+	for _, exception := range q.autostartExceptions {
+		if exception.TemplateID.Valid && slices.Contains(templateIds, exception.TemplateID.UUID) {
+			exceptions = append(exceptions, exception)
+		}
+	}
+	sortAutostartExceptions(exceptions)
+	return exceptions, nil
+}
+
 func (q *FakeQuerier) GetTemplateAverageBuildTime(ctx context.Context, arg database.GetTemplateAverageBuildTimeParams) (database.GetTemplateAverageBuildTimeRow, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.GetTemplateAverageBuildTimeRow{}, err
@@ -2339,6 +2971,9 @@ func (q *FakeQuerier) GetTemplateVersionsByTemplateID(_ context.Context, arg dat
 		if templateVersion.TemplateID.UUID != arg.TemplateID {
 			continue
 		}
+		if arg.Archived.Valid && templateVersion.Archived != arg.Archived.Bool {
+			continue
+		}
 		version = append(version, q.templateVersionWithUserNoLock(templateVersion))
 	}
 
@@ -2406,6 +3041,28 @@ func (q *FakeQuerier) GetTemplateVersionsCreatedAfter(_ context.Context, after t
 	return versions, nil
 }
 
+func (q *FakeQuerier) GetTemplateWorkspaceCounts(_ context.Context, templateIDs []uuid.UUID) ([]database.GetTemplateWorkspaceCountsRow, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	counts := map[uuid.UUID]int64{}
+	for _, workspace := range q.workspaces {
+		if workspace.Deleted || !slices.Contains(templateIDs, workspace.TemplateID) {
+			continue
+		}
+		counts[workspace.TemplateID]++
+	}
+
+	rows := make([]database.GetTemplateWorkspaceCountsRow, 0, len(counts))
+	for templateID, count := range counts {
+		rows = append(rows, database.GetTemplateWorkspaceCountsRow{
+			TemplateID:     templateID,
+			WorkspaceCount: count,
+		})
+	}
+	return rows, nil
+}
+
 func (q *FakeQuerier) GetTemplates(_ context.Context) ([]database.Template, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -3114,6 +3771,46 @@ func (q *FakeQuerier) GetWorkspaceBuildParameters(_ context.Context, workspaceBu
 	return params, nil
 }
 
+func (q *FakeQuerier) GetWorkspaceBuildStateSummariesByWorkspaceID(_ context.Context, arg database.GetWorkspaceBuildStateSummariesByWorkspaceIDParams) ([]database.GetWorkspaceBuildStateSummariesByWorkspaceIDRow, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	builds := make([]database.WorkspaceBuildTable, 0)
+	for _, build := range q.workspaceBuilds {
+		if build.WorkspaceID == arg.WorkspaceID {
+			builds = append(builds, build)
+		}
+	}
+	slices.SortFunc(builds, func(a, b database.WorkspaceBuildTable) int {
+		return slice.Descending(a.BuildNumber, b.BuildNumber)
+	})
+	if int(arg.OffsetOpt) >= len(builds) {
+		return nil, nil
+	}
+	builds = builds[arg.OffsetOpt:]
+	if arg.LimitOpt > 0 && int(arg.LimitOpt) < len(builds) {
+		builds = builds[:arg.LimitOpt]
+	}
+
+	summaries := make([]database.GetWorkspaceBuildStateSummariesByWorkspaceIDRow, 0, len(builds))
+	for _, build := range builds {
+		summaries = append(summaries, database.GetWorkspaceBuildStateSummariesByWorkspaceIDRow{
+			ID:                   build.ID,
+			BuildNumber:          build.BuildNumber,
+			Transition:           build.Transition,
+			TemplateVersionID:    build.TemplateVersionID,
+			CreatedAt:            build.CreatedAt,
+			ProvisionerStateSize: int32(len(build.ProvisionerState)),
+		})
+	}
+	return summaries, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceBuildsByWorkspaceID(_ context.Context,
 	params database.GetWorkspaceBuildsByWorkspaceIDParams,
 ) ([]database.WorkspaceBuild, error) {
@@ -3189,6 +3886,56 @@ func (q *FakeQuerier) GetWorkspaceBuildsCreatedAfter(_ context.Context, after ti
 	return workspaceBuilds, nil
 }
 
+func (q *FakeQuerier) GetWorkspaceBulkOperationByID(_ context.Context, id uuid.UUID) (database.WorkspaceBulkOperation, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, operation := range q.workspaceBulkOperations {
+		if operation.ID == id {
+			return operation, nil
+		}
+	}
+	return database.WorkspaceBulkOperation{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetWorkspaceBulkOperationWorkspacesByOperationID(_ context.Context, operationID uuid.UUID) ([]database.GetWorkspaceBulkOperationWorkspacesByOperationIDRow, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	rows := make([]database.GetWorkspaceBulkOperationWorkspacesByOperationIDRow, 0)
+	for _, operationWorkspace := range q.workspaceBulkOperationWorkspaces {
+		if operationWorkspace.OperationID != operationID {
+			continue
+		}
+		workspace, err := q.getWorkspaceByIDNoLock(context.Background(), operationWorkspace.WorkspaceID)
+		if err != nil {
+			continue
+		}
+		owner, err := q.getUserByIDNoLock(workspace.OwnerID)
+		if err != nil {
+			continue
+		}
+		rows = append(rows, database.GetWorkspaceBulkOperationWorkspacesByOperationIDRow{
+			OperationID:        operationWorkspace.OperationID,
+			WorkspaceID:        operationWorkspace.WorkspaceID,
+			Status:             operationWorkspace.Status,
+			WorkspaceBuildID:   operationWorkspace.WorkspaceBuildID,
+			Error:              operationWorkspace.Error,
+			UpdatedAt:          operationWorkspace.UpdatedAt,
+			WorkspaceName:      workspace.Name,
+			WorkspaceOwnerName: owner.Username,
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].WorkspaceOwnerName != rows[j].WorkspaceOwnerName {
+			return rows[i].WorkspaceOwnerName < rows[j].WorkspaceOwnerName
+		}
+		return rows[i].WorkspaceName < rows[j].WorkspaceName
+	})
+	return rows, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUID) (database.Workspace, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -3203,6 +3950,15 @@ func (q *FakeQuerier) GetWorkspaceByID(ctx context.Context, id uuid.UUID) (datab
 	return q.getWorkspaceByIDNoLock(ctx, id)
 }
 
+// GetWorkspaceByIDForUpdate doesn't lock anything, InTx holds the mutex for
+// the whole transaction.
+func (q *FakeQuerier) GetWorkspaceByIDForUpdate(ctx context.Context, id uuid.UUID) (database.Workspace, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	return q.getWorkspaceByIDNoLock(ctx, id)
+}
+
 func (q *FakeQuerier) GetWorkspaceByOwnerIDAndName(_ context.Context, arg database.GetWorkspaceByOwnerIDAndNameParams) (database.Workspace, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.Workspace{}, err
@@ -3420,10 +4176,49 @@ func (q *FakeQuerier) GetWorkspaces(ctx context.Context, arg database.GetWorkspa
 	if err := validateDatabaseType(arg); err != nil {
 		return nil, err
 	}
-
-	// A nil auth filter means no auth filter.
-	workspaceRows, err := q.GetAuthorizedWorkspaces(ctx, arg, nil)
-	return workspaceRows, err
+
+	// A nil auth filter means no auth filter.
+	workspaceRows, err := q.GetAuthorizedWorkspaces(ctx, arg, nil)
+	return workspaceRows, err
+}
+
+func (q *FakeQuerier) GetWorkspacesAboutToAutostop(ctx context.Context, arg database.GetWorkspacesAboutToAutostopParams) ([]database.GetWorkspacesAboutToAutostopRow, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	rows := make([]database.GetWorkspacesAboutToAutostopRow, 0)
+	for _, workspace := range q.workspaces {
+		if workspace.Deleted {
+			continue
+		}
+		build, err := q.getLatestWorkspaceBuildByWorkspaceIDNoLock(ctx, workspace.ID)
+		if err != nil {
+			return nil, err
+		}
+		if build.Transition != database.WorkspaceTransitionStart ||
+			!build.Deadline.After(arg.Now) || build.Deadline.After(arg.Before) {
+			continue
+		}
+		job, err := q.getProvisionerJobByIDNoLock(ctx, build.JobID)
+		if err != nil {
+			return nil, xerrors.Errorf("get provisioner job by ID: %w", err)
+		}
+		if !job.CompletedAt.Valid || (job.Error.Valid && job.Error.String != "") {
+			continue
+		}
+		rows = append(rows, database.GetWorkspacesAboutToAutostopRow{
+			ID:       workspace.ID,
+			OwnerID:  workspace.OwnerID,
+			Name:     workspace.Name,
+			BuildID:  build.ID,
+			Deadline: build.Deadline,
+		})
+	}
+	return rows, nil
 }
 
 func (q *FakeQuerier) GetWorkspacesEligibleForTransition(ctx context.Context, now time.Time) ([]database.Workspace, error) {
@@ -3533,6 +4328,13 @@ func (q *FakeQuerier) InsertAuditLog(_ context.Context, arg database.InsertAudit
 	defer q.mutex.Unlock()
 
 	alog := database.AuditLog(arg)
+	if alog.ChainSequence.Valid {
+		for _, existing := range q.auditLogs {
+			if existing.ChainSequence == alog.ChainSequence {
+				return database.AuditLog{}, errDuplicateKey
+			}
+		}
+	}
 
 	q.auditLogs = append(q.auditLogs, alog)
 	slices.SortFunc(q.auditLogs, func(a, b database.AuditLog) int {
@@ -3548,6 +4350,46 @@ func (q *FakeQuerier) InsertAuditLog(_ context.Context, arg database.InsertAudit
 	return alog, nil
 }
 
+func (q *FakeQuerier) InsertAuditLogCheckpoint(_ context.Context, arg database.InsertAuditLogCheckpointParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, checkpoint := range q.auditLogCheckpoints {
+		if checkpoint.ChainSequence == arg.ChainSequence {
+			return nil
+		}
+	}
+	q.auditLogCheckpoints = append(q.auditLogCheckpoints, database.AuditLogCheckpoint(arg))
+	return nil
+}
+
+func (q *FakeQuerier) InsertAutostartException(_ context.Context, arg database.InsertAutostartExceptionParams) (database.AutostartException, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.AutostartException{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	//nolint:gosimple
+	exception := database.AutostartException{
+		ID:         arg.ID,
+		TemplateID: arg.TemplateID,
+		Name:       arg.Name,
+		StartsAt:   arg.StartsAt,
+		EndsAt:     arg.EndsAt,
+		AllDay:     arg.AllDay,
+		CreatedAt:  arg.CreatedAt,
+	}
+	q.autostartExceptions = append(q.autostartExceptions, exception)
+	return exception, nil
+}
+
 func (q *FakeQuerier) InsertDERPMeshKey(_ context.Context, id string) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -3741,6 +4583,56 @@ func (q *FakeQuerier) InsertMissingGroups(_ context.Context, arg database.Insert
 	return newGroups, nil
 }
 
+func (q *FakeQuerier) InsertNotificationDelivery(_ context.Context, arg database.InsertNotificationDeliveryParams) (database.NotificationDelivery, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.NotificationDelivery{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	delivery := database.NotificationDelivery{
+		ID:            arg.ID,
+		MessageID:     arg.MessageID,
+		Address:       arg.Address,
+		Status:        database.NotificationDeliveryStatusPending,
+		NextAttemptAt: arg.NextAttemptAt,
+		CreatedAt:     arg.CreatedAt,
+		UpdatedAt:     arg.CreatedAt,
+	}
+	q.notificationDeliveries = append(q.notificationDeliveries, delivery)
+	return delivery, nil
+}
+
+func (q *FakeQuerier) InsertNotificationMessage(_ context.Context, arg database.InsertNotificationMessageParams) (database.NotificationMessage, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.NotificationMessage{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, message := range q.notificationMessages {
+		if message.UserID == arg.UserID && message.DedupeKey == arg.DedupeKey {
+			return database.NotificationMessage{}, sql.ErrNoRows
+		}
+	}
+
+	//nolint:gosimple
+	message := database.NotificationMessage{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		Event:     arg.Event,
+		Title:     arg.Title,
+		Body:      arg.Body,
+		DedupeKey: arg.DedupeKey,
+		Inbox:     arg.Inbox,
+		CreatedAt: arg.CreatedAt,
+	}
+	q.notificationMessages = append(q.notificationMessages, message)
+	return message, nil
+}
+
 func (q *FakeQuerier) InsertOrganization(_ context.Context, arg database.InsertOrganizationParams) (database.Organization, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.Organization{}, err
@@ -3931,6 +4823,8 @@ func (q *FakeQuerier) InsertTemplateVersion(_ context.Context, arg database.Inse
 		Readme:         arg.Readme,
 		JobID:          arg.JobID,
 		CreatedBy:      arg.CreatedBy,
+		GitURL:         arg.GitURL,
+		GitCommitSHA:   arg.GitCommitSHA,
 	}
 	q.templateVersions = append(q.templateVersions, version)
 	return nil
@@ -4097,6 +4991,8 @@ func (q *FakeQuerier) InsertWorkspace(_ context.Context, arg database.InsertWork
 		AutostartSchedule: arg.AutostartSchedule,
 		Ttl:               arg.Ttl,
 		LastUsedAt:        arg.LastUsedAt,
+		UserACL:           database.WorkspaceACL{},
+		GroupACL:          database.WorkspaceACL{},
 	}
 	q.workspaces = append(q.workspaces, workspace)
 	return workspace, nil
@@ -4352,6 +5248,49 @@ func (q *FakeQuerier) InsertWorkspaceBuildParameters(_ context.Context, arg data
 	return nil
 }
 
+func (q *FakeQuerier) InsertWorkspaceBulkOperation(_ context.Context, arg database.InsertWorkspaceBulkOperationParams) (database.WorkspaceBulkOperation, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.WorkspaceBulkOperation{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	operation := database.WorkspaceBulkOperation{
+		ID:          arg.ID,
+		CreatedAt:   arg.CreatedAt,
+		InitiatorID: arg.InitiatorID,
+		Transition:  arg.Transition,
+		Query:       arg.Query,
+		Concurrency: arg.Concurrency,
+		HeartbeatAt: arg.CreatedAt,
+	}
+	q.workspaceBulkOperations = append(q.workspaceBulkOperations, operation)
+	return operation, nil
+}
+
+func (q *FakeQuerier) InsertWorkspaceBulkOperationWorkspaces(_ context.Context, arg database.InsertWorkspaceBulkOperationWorkspacesParams) ([]database.WorkspaceBulkOperationWorkspace, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	operationWorkspaces := make([]database.WorkspaceBulkOperationWorkspace, 0, len(arg.WorkspaceIDs))
+	for _, workspaceID := range arg.WorkspaceIDs {
+		operationWorkspace := database.WorkspaceBulkOperationWorkspace{
+			OperationID: arg.OperationID,
+			WorkspaceID: workspaceID,
+			Status:      database.WorkspaceBulkOperationWorkspaceStatusPending,
+			UpdatedAt:   arg.UpdatedAt,
+		}
+		q.workspaceBulkOperationWorkspaces = append(q.workspaceBulkOperationWorkspaces, operationWorkspace)
+		operationWorkspaces = append(operationWorkspaces, operationWorkspace)
+	}
+	return operationWorkspaces, nil
+}
+
 func (q *FakeQuerier) InsertWorkspaceProxy(_ context.Context, arg database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -4601,6 +5540,66 @@ func (q *FakeQuerier) UpdateMemberRoles(_ context.Context, arg database.UpdateMe
 	return database.OrganizationMember{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateNotificationDelivery(_ context.Context, arg database.UpdateNotificationDeliveryParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, delivery := range q.notificationDeliveries {
+		if delivery.ID != arg.ID {
+			continue
+		}
+		delivery.Status = arg.Status
+		delivery.Attempts = arg.Attempts
+		delivery.LastError = arg.LastError
+		delivery.NextAttemptAt = arg.NextAttemptAt
+		delivery.UpdatedAt = arg.UpdatedAt
+		q.notificationDeliveries[i] = delivery
+		return nil
+	}
+	return sql.ErrNoRows
+}
+
+func (q *FakeQuerier) UpdateNotificationMessageReadAt(_ context.Context, arg database.UpdateNotificationMessageReadAtParams) (database.NotificationMessage, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.NotificationMessage{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, message := range q.notificationMessages {
+		if message.ID != arg.ID {
+			continue
+		}
+		message.ReadAt = arg.ReadAt
+		q.notificationMessages[i] = message
+		return message, nil
+	}
+	return database.NotificationMessage{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) UpdateNotificationMessagesReadAtByUserID(_ context.Context, arg database.UpdateNotificationMessagesReadAtByUserIDParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, message := range q.notificationMessages {
+		if message.UserID != arg.UserID || !message.Inbox || message.ReadAt.Valid {
+			continue
+		}
+		message.ReadAt = arg.ReadAt
+		q.notificationMessages[i] = message
+	}
+	return nil
+}
+
 func (q *FakeQuerier) UpdateProvisionerJobByID(_ context.Context, arg database.UpdateProvisionerJobByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -4768,6 +5767,9 @@ func (q *FakeQuerier) UpdateTemplateMetaByID(_ context.Context, arg database.Upd
 		tpl.DisplayName = arg.DisplayName
 		tpl.Description = arg.Description
 		tpl.Icon = arg.Icon
+		tpl.Deprecated = arg.Deprecated
+		tpl.ReplacementTemplateID = arg.ReplacementTemplateID
+		tpl.RequireActiveVersion = arg.RequireActiveVersion
 		q.templates[idx] = tpl
 		return nil
 	}
@@ -4804,6 +5806,26 @@ func (q *FakeQuerier) UpdateTemplateScheduleByID(_ context.Context, arg database
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateTemplateVersionArchivedByID(_ context.Context, arg database.UpdateTemplateVersionArchivedByIDParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, templateVersion := range q.templateVersions {
+		if templateVersion.ID != arg.ID {
+			continue
+		}
+		templateVersion.Archived = arg.Archived
+		templateVersion.UpdatedAt = arg.UpdatedAt
+		q.templateVersions[index] = templateVersion
+		return nil
+	}
+	return sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateTemplateVersionByID(_ context.Context, arg database.UpdateTemplateVersionByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -5125,6 +6147,27 @@ func (q *FakeQuerier) UpdateWorkspace(_ context.Context, arg database.UpdateWork
 	return database.Workspace{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceACLByID(_ context.Context, arg database.UpdateWorkspaceACLByIDParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, workspace := range q.workspaces {
+		if workspace.ID != arg.ID {
+			continue
+		}
+		workspace.GroupACL = arg.GroupACL
+		workspace.UserACL = arg.UserACL
+		q.workspaces[i] = workspace
+		return nil
+	}
+
+	return sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateWorkspaceAgentConnectionByID(_ context.Context, arg database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -5277,6 +6320,27 @@ func (q *FakeQuerier) UpdateWorkspaceAutostart(_ context.Context, arg database.U
 			continue
 		}
 		workspace.AutostartSchedule = arg.AutostartSchedule
+		workspace.AutostartSkipUntil = sql.NullTime{}
+		q.workspaces[index] = workspace
+		return nil
+	}
+
+	return sql.ErrNoRows
+}
+
+func (q *FakeQuerier) UpdateWorkspaceAutostartSkipUntil(_ context.Context, arg database.UpdateWorkspaceAutostartSkipUntilParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, workspace := range q.workspaces {
+		if workspace.ID != arg.ID {
+			continue
+		}
+		workspace.AutostartSkipUntil = arg.AutostartSkipUntil
 		q.workspaces[index] = workspace
 		return nil
 	}
@@ -5325,6 +6389,66 @@ func (q *FakeQuerier) UpdateWorkspaceBuildCostByID(_ context.Context, arg databa
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceBulkOperationCompletedAt(_ context.Context, arg database.UpdateWorkspaceBulkOperationCompletedAtParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, operation := range q.workspaceBulkOperations {
+		if operation.ID != arg.ID {
+			continue
+		}
+		operation.CompletedAt = arg.CompletedAt
+		q.workspaceBulkOperations[index] = operation
+		return nil
+	}
+	return sql.ErrNoRows
+}
+
+func (q *FakeQuerier) UpdateWorkspaceBulkOperationHeartbeat(_ context.Context, arg database.UpdateWorkspaceBulkOperationHeartbeatParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, operation := range q.workspaceBulkOperations {
+		if operation.ID != arg.ID {
+			continue
+		}
+		operation.HeartbeatAt = arg.HeartbeatAt
+		q.workspaceBulkOperations[index] = operation
+		return nil
+	}
+	return sql.ErrNoRows
+}
+
+func (q *FakeQuerier) UpdateWorkspaceBulkOperationWorkspace(_ context.Context, arg database.UpdateWorkspaceBulkOperationWorkspaceParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, operationWorkspace := range q.workspaceBulkOperationWorkspaces {
+		if operationWorkspace.OperationID != arg.OperationID || operationWorkspace.WorkspaceID != arg.WorkspaceID {
+			continue
+		}
+		operationWorkspace.Status = arg.Status
+		operationWorkspace.WorkspaceBuildID = arg.WorkspaceBuildID
+		operationWorkspace.Error = arg.Error
+		operationWorkspace.UpdatedAt = arg.UpdatedAt
+		q.workspaceBulkOperationWorkspaces[index] = operationWorkspace
+		return nil
+	}
+	return sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateWorkspaceDeletedByID(_ context.Context, arg database.UpdateWorkspaceDeletedByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -5403,6 +6527,38 @@ func (q *FakeQuerier) UpdateWorkspaceLockedDeletingAt(_ context.Context, arg dat
 	return database.Workspace{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceOwner(_ context.Context, arg database.UpdateWorkspaceOwnerParams) (database.Workspace, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.Workspace{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, workspace := range q.workspaces {
+		if workspace.Deleted || workspace.ID != arg.ID {
+			continue
+		}
+		for _, other := range q.workspaces {
+			if other.Deleted || other.ID == workspace.ID || other.OwnerID != arg.OwnerID {
+				continue
+			}
+			if strings.EqualFold(other.Name, workspace.Name) {
+				return database.Workspace{}, errDuplicateKey
+			}
+		}
+
+		workspace.OwnerID = arg.OwnerID
+		workspace.UserACL = database.WorkspaceACL{}
+		workspace.GroupACL = database.WorkspaceACL{}
+		q.workspaces[i] = workspace
+
+		return workspace, nil
+	}
+
+	return database.Workspace{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateWorkspaceProxy(_ context.Context, arg database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -5519,6 +6675,32 @@ func (q *FakeQuerier) UpsertLogoURL(_ context.Context, data string) error {
 	return nil
 }
 
+func (q *FakeQuerier) UpsertNotificationPreference(_ context.Context, arg database.UpsertNotificationPreferenceParams) (database.NotificationPreference, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.NotificationPreference{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	//nolint:gosimple
+	preference := database.NotificationPreference{
+		UserID:    arg.UserID,
+		Event:     arg.Event,
+		Email:     arg.Email,
+		Inbox:     arg.Inbox,
+		UpdatedAt: arg.UpdatedAt,
+	}
+	for i, existing := range q.notificationPreferences {
+		if existing.UserID == arg.UserID && existing.Event == arg.Event {
+			q.notificationPreferences[i] = preference
+			return preference, nil
+		}
+	}
+	q.notificationPreferences = append(q.notificationPreferences, preference)
+	return preference, nil
+}
+
 func (q *FakeQuerier) UpsertOAuthSigningKey(_ context.Context, value string) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -5697,7 +6879,7 @@ func (q *FakeQuerier) GetAuthorizedWorkspaces(ctx context.Context, arg database.
 
 	if prepared != nil {
 		// Call this to match the same function calls as the SQL implementation.
-		_, err := prepared.CompileToSQL(ctx, rbac.ConfigWithoutACL())
+		_, err := prepared.CompileToSQL(ctx, rbac.ConfigWithACL())
 		if err != nil {
 			return nil, err
 		}
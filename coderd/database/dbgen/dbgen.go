@@ -56,6 +56,8 @@ func AuditLog(t testing.TB, db database.Store, seed database.AuditLog) database.
 		AdditionalFields: takeFirstSlice(seed.Diff, []byte("{}")),
 		RequestID:        takeFirst(seed.RequestID, uuid.New()),
 		ResourceIcon:     takeFirst(seed.ResourceIcon, ""),
+		ChainSequence:    seed.ChainSequence,
+		ChainHash:        seed.ChainHash,
 	})
 	require.NoError(t, err, "insert audit log")
 	return log
@@ -211,6 +213,19 @@ func WorkspaceBuild(t testing.TB, db database.Store, orig database.WorkspaceBuil
 	return build
 }
 
+func WorkspaceBulkOperation(t testing.TB, db database.Store, orig database.WorkspaceBulkOperation) database.WorkspaceBulkOperation {
+	operation, err := db.InsertWorkspaceBulkOperation(genCtx, database.InsertWorkspaceBulkOperationParams{
+		ID:          takeFirst(orig.ID, uuid.New()),
+		CreatedAt:   takeFirst(orig.CreatedAt, database.Now()),
+		InitiatorID: takeFirst(orig.InitiatorID, uuid.New()),
+		Transition:  takeFirst(orig.Transition, database.WorkspaceBulkOperationTransitionStop),
+		Query:       takeFirst(orig.Query, "owner:me"),
+		Concurrency: takeFirst(orig.Concurrency, 5),
+	})
+	require.NoError(t, err, "insert workspace bulk operation")
+	return operation
+}
+
 func User(t testing.TB, db database.Store, orig database.User) database.User {
 	user, err := db.InsertUser(genCtx, database.InsertUserParams{
 		ID:             takeFirst(orig.ID, uuid.New()),
@@ -254,6 +269,21 @@ func GitSSHKey(t testing.TB, db database.Store, orig database.GitSSHKey) databas
 	return key
 }
 
+func NotificationMessage(t testing.TB, db database.Store, orig database.NotificationMessage) database.NotificationMessage {
+	message, err := db.InsertNotificationMessage(genCtx, database.InsertNotificationMessageParams{
+		ID:        takeFirst(orig.ID, uuid.New()),
+		UserID:    takeFirst(orig.UserID, uuid.New()),
+		Event:     takeFirst(orig.Event, database.NotificationEventUserSuspended),
+		Title:     takeFirst(orig.Title, namesgenerator.GetRandomName(1)),
+		Body:      takeFirst(orig.Body, namesgenerator.GetRandomName(1)),
+		DedupeKey: takeFirst(orig.DedupeKey, uuid.NewString()),
+		Inbox:     takeFirst(orig.Inbox, true),
+		CreatedAt: takeFirst(orig.CreatedAt, database.Now()),
+	})
+	require.NoError(t, err, "insert notification message")
+	return message
+}
+
 func Organization(t testing.TB, db database.Store, orig database.Organization) database.Organization {
 	org, err := db.InsertOrganization(genCtx, database.InsertOrganizationParams{
 		ID:          takeFirst(orig.ID, uuid.New()),
@@ -83,6 +83,13 @@ func TestGenerator(t *testing.T) {
 		require.Equal(t, exp, must(db.GetWorkspaceProxyByID(context.Background(), exp.ID)))
 	})
 
+	t.Run("WorkspaceBulkOperation", func(t *testing.T) {
+		t.Parallel()
+		db := dbfake.New()
+		exp := dbgen.WorkspaceBulkOperation(t, db, database.WorkspaceBulkOperation{})
+		require.Equal(t, exp, must(db.GetWorkspaceBulkOperationByID(context.Background(), exp.ID)))
+	})
+
 	t.Run("Job", func(t *testing.T) {
 		t.Parallel()
 		db := dbfake.New()
@@ -86,6 +86,13 @@ func (m metricsStore) AcquireLock(ctx context.Context, pgAdvisoryXactLock int64)
 	return err
 }
 
+func (m metricsStore) AcquireNotificationDeliveries(ctx context.Context, arg database.AcquireNotificationDeliveriesParams) ([]database.NotificationDelivery, error) {
+	start := time.Now()
+	r0, r1 := m.s.AcquireNotificationDeliveries(ctx, arg)
+	m.queryLatencies.WithLabelValues("AcquireNotificationDeliveries").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) AcquireProvisionerJob(ctx context.Context, arg database.AcquireProvisionerJobParams) (database.ProvisionerJob, error) {
 	start := time.Now()
 	provisionerJob, err := m.s.AcquireProvisionerJob(ctx, arg)
@@ -100,6 +107,13 @@ func (m metricsStore) CleanTailnetCoordinators(ctx context.Context) error {
 	return err
 }
 
+func (m metricsStore) CompleteStaleWorkspaceBulkOperations(ctx context.Context, arg database.CompleteStaleWorkspaceBulkOperationsParams) ([]uuid.UUID, error) {
+	start := time.Now()
+	r0, r1 := m.s.CompleteStaleWorkspaceBulkOperations(ctx, arg)
+	m.queryLatencies.WithLabelValues("CompleteStaleWorkspaceBulkOperations").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) DeleteAPIKeyByID(ctx context.Context, id string) error {
 	start := time.Now()
 	err := m.s.DeleteAPIKeyByID(ctx, id)
@@ -127,6 +141,13 @@ func (m metricsStore) DeleteCoordinator(ctx context.Context, id uuid.UUID) error
 	return m.s.DeleteCoordinator(ctx, id)
 }
 
+func (m metricsStore) DeleteDeploymentAutostartExceptions(ctx context.Context) error {
+	start := time.Now()
+	err := m.s.DeleteDeploymentAutostartExceptions(ctx)
+	m.queryLatencies.WithLabelValues("DeleteDeploymentAutostartExceptions").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) DeleteGitSSHKey(ctx context.Context, userID uuid.UUID) error {
 	start := time.Now()
 	err := m.s.DeleteGitSSHKey(ctx, userID)
@@ -162,6 +183,27 @@ func (m metricsStore) DeleteLicense(ctx context.Context, id int32) (int32, error
 	return licenseID, err
 }
 
+func (m metricsStore) DeleteOldAuditLogCheckpoints(ctx context.Context) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.DeleteOldAuditLogCheckpoints(ctx)
+	m.queryLatencies.WithLabelValues("DeleteOldAuditLogCheckpoints").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) DeleteOldAuditLogs(ctx context.Context, arg database.DeleteOldAuditLogsParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.DeleteOldAuditLogs(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteOldAuditLogs").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) DeleteOldProvisionerJobLogs(ctx context.Context, arg database.DeleteOldProvisionerJobLogsParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.DeleteOldProvisionerJobLogs(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteOldProvisionerJobLogs").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) DeleteOldWorkspaceAgentLogs(ctx context.Context) error {
 	start := time.Now()
 	r0 := m.s.DeleteOldWorkspaceAgentLogs(ctx)
@@ -176,6 +218,20 @@ func (m metricsStore) DeleteOldWorkspaceAgentStats(ctx context.Context) error {
 	return err
 }
 
+func (m metricsStore) DeleteOldWorkspaceBuildProvisionerState(ctx context.Context, arg database.DeleteOldWorkspaceBuildProvisionerStateParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.DeleteOldWorkspaceBuildProvisionerState(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteOldWorkspaceBuildProvisionerState").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) DeleteOrganizationMember(ctx context.Context, arg database.DeleteOrganizationMemberParams) error {
+	start := time.Now()
+	r0 := m.s.DeleteOrganizationMember(ctx, arg)
+	m.queryLatencies.WithLabelValues("DeleteOrganizationMember").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) DeleteReplicasUpdatedBefore(ctx context.Context, updatedAt time.Time) error {
 	start := time.Now()
 	err := m.s.DeleteReplicasUpdatedBefore(ctx, updatedAt)
@@ -195,6 +251,13 @@ func (m metricsStore) DeleteTailnetClient(ctx context.Context, arg database.Dele
 	return m.s.DeleteTailnetClient(ctx, arg)
 }
 
+func (m metricsStore) DeleteTemplateAutostartExceptions(ctx context.Context, templateID uuid.NullUUID) error {
+	start := time.Now()
+	err := m.s.DeleteTemplateAutostartExceptions(ctx, templateID)
+	m.queryLatencies.WithLabelValues("DeleteTemplateAutostartExceptions").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) GetAPIKeyByID(ctx context.Context, id string) (database.APIKey, error) {
 	start := time.Now()
 	apiKey, err := m.s.GetAPIKeyByID(ctx, id)
@@ -258,6 +321,20 @@ func (m metricsStore) GetAppSecurityKey(ctx context.Context) (string, error) {
 	return key, err
 }
 
+func (m metricsStore) GetAuditLogCheckpoints(ctx context.Context) ([]database.AuditLogCheckpoint, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetAuditLogCheckpoints(ctx)
+	m.queryLatencies.WithLabelValues("GetAuditLogCheckpoints").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetAuditLogsForExport(ctx context.Context, arg database.GetAuditLogsForExportParams) ([]database.GetAuditLogsForExportRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetAuditLogsForExport(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetAuditLogsForExport").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetAuditLogsOffset(ctx context.Context, arg database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	start := time.Now()
 	rows, err := m.s.GetAuditLogsOffset(ctx, arg)
@@ -272,6 +349,13 @@ func (m metricsStore) GetAuthorizationUserRoles(ctx context.Context, userID uuid
 	return row, err
 }
 
+func (m metricsStore) GetChainedAuditLogs(ctx context.Context, arg database.GetChainedAuditLogsParams) ([]database.AuditLog, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetChainedAuditLogs(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetChainedAuditLogs").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetDERPMeshKey(ctx context.Context) (string, error) {
 	start := time.Now()
 	key, err := m.s.GetDERPMeshKey(ctx)
@@ -286,6 +370,13 @@ func (m metricsStore) GetDefaultProxyConfig(ctx context.Context) (database.GetDe
 	return resp, err
 }
 
+func (m metricsStore) GetDeploymentAutostartExceptions(ctx context.Context) ([]database.AutostartException, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetDeploymentAutostartExceptions(ctx)
+	m.queryLatencies.WithLabelValues("GetDeploymentAutostartExceptions").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetDeploymentDAUs(ctx context.Context, tzOffset int32) ([]database.GetDeploymentDAUsRow, error) {
 	start := time.Now()
 	rows, err := m.s.GetDeploymentDAUs(ctx, tzOffset)
@@ -391,6 +482,13 @@ func (m metricsStore) GetLastUpdateCheck(ctx context.Context) (string, error) {
 	return version, err
 }
 
+func (m metricsStore) GetLatestChainedAuditLog(ctx context.Context) (database.AuditLog, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetLatestChainedAuditLog(ctx)
+	m.queryLatencies.WithLabelValues("GetLatestChainedAuditLog").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetLatestWorkspaceBuildByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) (database.WorkspaceBuild, error) {
 	start := time.Now()
 	build, err := m.s.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspaceID)
@@ -433,6 +531,34 @@ func (m metricsStore) GetLogoURL(ctx context.Context) (string, error) {
 	return url, err
 }
 
+func (m metricsStore) GetNotificationDeliveries(ctx context.Context, arg database.GetNotificationDeliveriesParams) ([]database.GetNotificationDeliveriesRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationDeliveries(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetNotificationDeliveries").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetNotificationMessageByID(ctx context.Context, id uuid.UUID) (database.NotificationMessage, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationMessageByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetNotificationMessageByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetNotificationMessagesByUserID(ctx context.Context, arg database.GetNotificationMessagesByUserIDParams) ([]database.NotificationMessage, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationMessagesByUserID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetNotificationMessagesByUserID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetNotificationPreferencesByUserID(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreference, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationPreferencesByUserID(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetNotificationPreferencesByUserID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetOAuthSigningKey(ctx context.Context) (string, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetOAuthSigningKey(ctx)
@@ -468,6 +594,13 @@ func (m metricsStore) GetOrganizationMemberByUserID(ctx context.Context, arg dat
 	return member, err
 }
 
+func (m metricsStore) GetOrganizationMembersByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]database.OrganizationMember, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetOrganizationMembersByOrganizationID(ctx, organizationID)
+	m.queryLatencies.WithLabelValues("GetOrganizationMembersByOrganizationID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetOrganizationMembershipsByUserID(ctx context.Context, userID uuid.UUID) ([]database.OrganizationMember, error) {
 	start := time.Now()
 	memberships, err := m.s.GetOrganizationMembershipsByUserID(ctx, userID)
@@ -592,6 +725,20 @@ func (m metricsStore) GetTailnetClientsForAgent(ctx context.Context, agentID uui
 	return m.s.GetTailnetClientsForAgent(ctx, agentID)
 }
 
+func (m metricsStore) GetTemplateAutostartExceptions(ctx context.Context, templateID uuid.NullUUID) ([]database.AutostartException, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateAutostartExceptions(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetTemplateAutostartExceptions").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetTemplateAutostartExceptionsByTemplateIDs(ctx context.Context, templateIds []uuid.UUID) ([]database.AutostartException, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateAutostartExceptionsByTemplateIDs(ctx, templateIds)
+	m.queryLatencies.WithLabelValues("GetTemplateAutostartExceptionsByTemplateIDs").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetTemplateAverageBuildTime(ctx context.Context, arg database.GetTemplateAverageBuildTimeParams) (database.GetTemplateAverageBuildTimeRow, error) {
 	start := time.Now()
 	buildTime, err := m.s.GetTemplateAverageBuildTime(ctx, arg)
@@ -697,6 +844,13 @@ func (m metricsStore) GetTemplateVersionsCreatedAfter(ctx context.Context, creat
 	return versions, err
 }
 
+func (m metricsStore) GetTemplateWorkspaceCounts(ctx context.Context, templateIds []uuid.UUID) ([]database.GetTemplateWorkspaceCountsRow, error) {
+	start := time.Now()
+	counts, err := m.s.GetTemplateWorkspaceCounts(ctx, templateIds)
+	m.queryLatencies.WithLabelValues("GetTemplateWorkspaceCounts").Observe(time.Since(start).Seconds())
+	return counts, err
+}
+
 func (m metricsStore) GetTemplates(ctx context.Context) ([]database.Template, error) {
 	start := time.Now()
 	templates, err := m.s.GetTemplates(ctx)
@@ -907,6 +1061,13 @@ func (m metricsStore) GetWorkspaceBuildParameters(ctx context.Context, workspace
 	return params, err
 }
 
+func (m metricsStore) GetWorkspaceBuildStateSummariesByWorkspaceID(ctx context.Context, arg database.GetWorkspaceBuildStateSummariesByWorkspaceIDParams) ([]database.GetWorkspaceBuildStateSummariesByWorkspaceIDRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceBuildStateSummariesByWorkspaceID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetWorkspaceBuildStateSummariesByWorkspaceID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetWorkspaceBuildsByWorkspaceID(ctx context.Context, arg database.GetWorkspaceBuildsByWorkspaceIDParams) ([]database.WorkspaceBuild, error) {
 	start := time.Now()
 	builds, err := m.s.GetWorkspaceBuildsByWorkspaceID(ctx, arg)
@@ -921,6 +1082,20 @@ func (m metricsStore) GetWorkspaceBuildsCreatedAfter(ctx context.Context, create
 	return builds, err
 }
 
+func (m metricsStore) GetWorkspaceBulkOperationByID(ctx context.Context, id uuid.UUID) (database.WorkspaceBulkOperation, error) {
+	start := time.Now()
+	operation, err := m.s.GetWorkspaceBulkOperationByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetWorkspaceBulkOperationByID").Observe(time.Since(start).Seconds())
+	return operation, err
+}
+
+func (m metricsStore) GetWorkspaceBulkOperationWorkspacesByOperationID(ctx context.Context, operationID uuid.UUID) ([]database.GetWorkspaceBulkOperationWorkspacesByOperationIDRow, error) {
+	start := time.Now()
+	workspaces, err := m.s.GetWorkspaceBulkOperationWorkspacesByOperationID(ctx, operationID)
+	m.queryLatencies.WithLabelValues("GetWorkspaceBulkOperationWorkspacesByOperationID").Observe(time.Since(start).Seconds())
+	return workspaces, err
+}
+
 func (m metricsStore) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUID) (database.Workspace, error) {
 	start := time.Now()
 	workspace, err := m.s.GetWorkspaceByAgentID(ctx, agentID)
@@ -935,6 +1110,13 @@ func (m metricsStore) GetWorkspaceByID(ctx context.Context, id uuid.UUID) (datab
 	return workspace, err
 }
 
+func (m metricsStore) GetWorkspaceByIDForUpdate(ctx context.Context, id uuid.UUID) (database.Workspace, error) {
+	start := time.Now()
+	workspace, err := m.s.GetWorkspaceByIDForUpdate(ctx, id)
+	m.queryLatencies.WithLabelValues("GetWorkspaceByIDForUpdate").Observe(time.Since(start).Seconds())
+	return workspace, err
+}
+
 func (m metricsStore) GetWorkspaceByOwnerIDAndName(ctx context.Context, arg database.GetWorkspaceByOwnerIDAndNameParams) (database.Workspace, error) {
 	start := time.Now()
 	workspace, err := m.s.GetWorkspaceByOwnerIDAndName(ctx, arg)
@@ -1026,6 +1208,13 @@ func (m metricsStore) GetWorkspaces(ctx context.Context, arg database.GetWorkspa
 	return workspaces, err
 }
 
+func (m metricsStore) GetWorkspacesAboutToAutostop(ctx context.Context, arg database.GetWorkspacesAboutToAutostopParams) ([]database.GetWorkspacesAboutToAutostopRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspacesAboutToAutostop(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetWorkspacesAboutToAutostop").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetWorkspacesEligibleForTransition(ctx context.Context, now time.Time) ([]database.Workspace, error) {
 	start := time.Now()
 	workspaces, err := m.s.GetWorkspacesEligibleForTransition(ctx, now)
@@ -1054,6 +1243,20 @@ func (m metricsStore) InsertAuditLog(ctx context.Context, arg database.InsertAud
 	return log, err
 }
 
+func (m metricsStore) InsertAuditLogCheckpoint(ctx context.Context, arg database.InsertAuditLogCheckpointParams) error {
+	start := time.Now()
+	r0 := m.s.InsertAuditLogCheckpoint(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertAuditLogCheckpoint").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) InsertAutostartException(ctx context.Context, arg database.InsertAutostartExceptionParams) (database.AutostartException, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertAutostartException(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertAutostartException").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) InsertDERPMeshKey(ctx context.Context, value string) error {
 	start := time.Now()
 	err := m.s.InsertDERPMeshKey(ctx, value)
@@ -1117,6 +1320,20 @@ func (m metricsStore) InsertMissingGroups(ctx context.Context, arg database.Inse
 	return r0, r1
 }
 
+func (m metricsStore) InsertNotificationDelivery(ctx context.Context, arg database.InsertNotificationDeliveryParams) (database.NotificationDelivery, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertNotificationDelivery(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertNotificationDelivery").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) InsertNotificationMessage(ctx context.Context, arg database.InsertNotificationMessageParams) (database.NotificationMessage, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertNotificationMessage(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertNotificationMessage").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) InsertOrganization(ctx context.Context, arg database.InsertOrganizationParams) (database.Organization, error) {
 	start := time.Now()
 	organization, err := m.s.InsertOrganization(ctx, arg)
@@ -1271,6 +1488,20 @@ func (m metricsStore) InsertWorkspaceBuildParameters(ctx context.Context, arg da
 	return err
 }
 
+func (m metricsStore) InsertWorkspaceBulkOperation(ctx context.Context, arg database.InsertWorkspaceBulkOperationParams) (database.WorkspaceBulkOperation, error) {
+	start := time.Now()
+	operation, err := m.s.InsertWorkspaceBulkOperation(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceBulkOperation").Observe(time.Since(start).Seconds())
+	return operation, err
+}
+
+func (m metricsStore) InsertWorkspaceBulkOperationWorkspaces(ctx context.Context, arg database.InsertWorkspaceBulkOperationWorkspacesParams) ([]database.WorkspaceBulkOperationWorkspace, error) {
+	start := time.Now()
+	workspaces, err := m.s.InsertWorkspaceBulkOperationWorkspaces(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceBulkOperationWorkspaces").Observe(time.Since(start).Seconds())
+	return workspaces, err
+}
+
 func (m metricsStore) InsertWorkspaceProxy(ctx context.Context, arg database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	start := time.Now()
 	proxy, err := m.s.InsertWorkspaceProxy(ctx, arg)
@@ -1348,6 +1579,27 @@ func (m metricsStore) UpdateMemberRoles(ctx context.Context, arg database.Update
 	return member, err
 }
 
+func (m metricsStore) UpdateNotificationDelivery(ctx context.Context, arg database.UpdateNotificationDeliveryParams) error {
+	start := time.Now()
+	err := m.s.UpdateNotificationDelivery(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateNotificationDelivery").Observe(time.Since(start).Seconds())
+	return err
+}
+
+func (m metricsStore) UpdateNotificationMessageReadAt(ctx context.Context, arg database.UpdateNotificationMessageReadAtParams) (database.NotificationMessage, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateNotificationMessageReadAt(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateNotificationMessageReadAt").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpdateNotificationMessagesReadAtByUserID(ctx context.Context, arg database.UpdateNotificationMessagesReadAtByUserIDParams) error {
+	start := time.Now()
+	err := m.s.UpdateNotificationMessagesReadAtByUserID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateNotificationMessagesReadAtByUserID").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) UpdateProvisionerJobByID(ctx context.Context, arg database.UpdateProvisionerJobByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateProvisionerJobByID(ctx, arg)
@@ -1411,6 +1663,13 @@ func (m metricsStore) UpdateTemplateScheduleByID(ctx context.Context, arg databa
 	return err
 }
 
+func (m metricsStore) UpdateTemplateVersionArchivedByID(ctx context.Context, arg database.UpdateTemplateVersionArchivedByIDParams) error {
+	start := time.Now()
+	err := m.s.UpdateTemplateVersionArchivedByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateTemplateVersionArchivedByID").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) UpdateTemplateVersionByID(ctx context.Context, arg database.UpdateTemplateVersionByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateTemplateVersionByID(ctx, arg)
@@ -1509,6 +1768,13 @@ func (m metricsStore) UpdateWorkspace(ctx context.Context, arg database.UpdateWo
 	return workspace, err
 }
 
+func (m metricsStore) UpdateWorkspaceACLByID(ctx context.Context, arg database.UpdateWorkspaceACLByIDParams) error {
+	start := time.Now()
+	err := m.s.UpdateWorkspaceACLByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceACLByID").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) UpdateWorkspaceAgentConnectionByID(ctx context.Context, arg database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateWorkspaceAgentConnectionByID(ctx, arg)
@@ -1558,6 +1824,13 @@ func (m metricsStore) UpdateWorkspaceAutostart(ctx context.Context, arg database
 	return err
 }
 
+func (m metricsStore) UpdateWorkspaceAutostartSkipUntil(ctx context.Context, arg database.UpdateWorkspaceAutostartSkipUntilParams) error {
+	start := time.Now()
+	err := m.s.UpdateWorkspaceAutostartSkipUntil(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceAutostartSkipUntil").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) UpdateWorkspaceBuildByID(ctx context.Context, arg database.UpdateWorkspaceBuildByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateWorkspaceBuildByID(ctx, arg)
@@ -1572,6 +1845,27 @@ func (m metricsStore) UpdateWorkspaceBuildCostByID(ctx context.Context, arg data
 	return err
 }
 
+func (m metricsStore) UpdateWorkspaceBulkOperationCompletedAt(ctx context.Context, arg database.UpdateWorkspaceBulkOperationCompletedAtParams) error {
+	start := time.Now()
+	err := m.s.UpdateWorkspaceBulkOperationCompletedAt(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceBulkOperationCompletedAt").Observe(time.Since(start).Seconds())
+	return err
+}
+
+func (m metricsStore) UpdateWorkspaceBulkOperationHeartbeat(ctx context.Context, arg database.UpdateWorkspaceBulkOperationHeartbeatParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateWorkspaceBulkOperationHeartbeat(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceBulkOperationHeartbeat").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) UpdateWorkspaceBulkOperationWorkspace(ctx context.Context, arg database.UpdateWorkspaceBulkOperationWorkspaceParams) error {
+	start := time.Now()
+	err := m.s.UpdateWorkspaceBulkOperationWorkspace(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceBulkOperationWorkspace").Observe(time.Since(start).Seconds())
+	return err
+}
+
 func (m metricsStore) UpdateWorkspaceDeletedByID(ctx context.Context, arg database.UpdateWorkspaceDeletedByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateWorkspaceDeletedByID(ctx, arg)
@@ -1593,6 +1887,13 @@ func (m metricsStore) UpdateWorkspaceLockedDeletingAt(ctx context.Context, arg d
 	return ws, r0
 }
 
+func (m metricsStore) UpdateWorkspaceOwner(ctx context.Context, arg database.UpdateWorkspaceOwnerParams) (database.Workspace, error) {
+	start := time.Now()
+	workspace, err := m.s.UpdateWorkspaceOwner(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceOwner").Observe(time.Since(start).Seconds())
+	return workspace, err
+}
+
 func (m metricsStore) UpdateWorkspaceProxy(ctx context.Context, arg database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	start := time.Now()
 	proxy, err := m.s.UpdateWorkspaceProxy(ctx, arg)
@@ -1649,6 +1950,13 @@ func (m metricsStore) UpsertLogoURL(ctx context.Context, value string) error {
 	return r0
 }
 
+func (m metricsStore) UpsertNotificationPreference(ctx context.Context, arg database.UpsertNotificationPreferenceParams) (database.NotificationPreference, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertNotificationPreference(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertNotificationPreference").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpsertOAuthSigningKey(ctx context.Context, value string) error {
 	start := time.Now()
 	r0 := m.s.UpsertOAuthSigningKey(ctx, value)
@@ -53,6 +53,21 @@ func (mr *MockStoreMockRecorder) AcquireLock(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLock", reflect.TypeOf((*MockStore)(nil).AcquireLock), arg0, arg1)
 }
 
+// AcquireNotificationDeliveries mocks base method.
+func (m *MockStore) AcquireNotificationDeliveries(arg0 context.Context, arg1 database.AcquireNotificationDeliveriesParams) ([]database.NotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireNotificationDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]database.NotificationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireNotificationDeliveries indicates an expected call of AcquireNotificationDeliveries.
+func (mr *MockStoreMockRecorder) AcquireNotificationDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireNotificationDeliveries", reflect.TypeOf((*MockStore)(nil).AcquireNotificationDeliveries), arg0, arg1)
+}
+
 // AcquireProvisionerJob mocks base method.
 func (m *MockStore) AcquireProvisionerJob(arg0 context.Context, arg1 database.AcquireProvisionerJobParams) (database.ProvisionerJob, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +97,21 @@ func (mr *MockStoreMockRecorder) CleanTailnetCoordinators(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanTailnetCoordinators", reflect.TypeOf((*MockStore)(nil).CleanTailnetCoordinators), arg0)
 }
 
+// CompleteStaleWorkspaceBulkOperations mocks base method.
+func (m *MockStore) CompleteStaleWorkspaceBulkOperations(arg0 context.Context, arg1 database.CompleteStaleWorkspaceBulkOperationsParams) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteStaleWorkspaceBulkOperations", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteStaleWorkspaceBulkOperations indicates an expected call of CompleteStaleWorkspaceBulkOperations.
+func (mr *MockStoreMockRecorder) CompleteStaleWorkspaceBulkOperations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteStaleWorkspaceBulkOperations", reflect.TypeOf((*MockStore)(nil).CompleteStaleWorkspaceBulkOperations), arg0, arg1)
+}
+
 // DeleteAPIKeyByID mocks base method.
 func (m *MockStore) DeleteAPIKeyByID(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -138,6 +168,20 @@ func (mr *MockStoreMockRecorder) DeleteCoordinator(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCoordinator", reflect.TypeOf((*MockStore)(nil).DeleteCoordinator), arg0, arg1)
 }
 
+// DeleteDeploymentAutostartExceptions mocks base method.
+func (m *MockStore) DeleteDeploymentAutostartExceptions(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeploymentAutostartExceptions", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeploymentAutostartExceptions indicates an expected call of DeleteDeploymentAutostartExceptions.
+func (mr *MockStoreMockRecorder) DeleteDeploymentAutostartExceptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeploymentAutostartExceptions", reflect.TypeOf((*MockStore)(nil).DeleteDeploymentAutostartExceptions), arg0)
+}
+
 // DeleteGitSSHKey mocks base method.
 func (m *MockStore) DeleteGitSSHKey(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -209,6 +253,51 @@ func (mr *MockStoreMockRecorder) DeleteLicense(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLicense", reflect.TypeOf((*MockStore)(nil).DeleteLicense), arg0, arg1)
 }
 
+// DeleteOldAuditLogCheckpoints mocks base method.
+func (m *MockStore) DeleteOldAuditLogCheckpoints(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldAuditLogCheckpoints", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOldAuditLogCheckpoints indicates an expected call of DeleteOldAuditLogCheckpoints.
+func (mr *MockStoreMockRecorder) DeleteOldAuditLogCheckpoints(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldAuditLogCheckpoints", reflect.TypeOf((*MockStore)(nil).DeleteOldAuditLogCheckpoints), arg0)
+}
+
+// DeleteOldAuditLogs mocks base method.
+func (m *MockStore) DeleteOldAuditLogs(arg0 context.Context, arg1 database.DeleteOldAuditLogsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOldAuditLogs indicates an expected call of DeleteOldAuditLogs.
+func (mr *MockStoreMockRecorder) DeleteOldAuditLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldAuditLogs", reflect.TypeOf((*MockStore)(nil).DeleteOldAuditLogs), arg0, arg1)
+}
+
+// DeleteOldProvisionerJobLogs mocks base method.
+func (m *MockStore) DeleteOldProvisionerJobLogs(arg0 context.Context, arg1 database.DeleteOldProvisionerJobLogsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldProvisionerJobLogs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOldProvisionerJobLogs indicates an expected call of DeleteOldProvisionerJobLogs.
+func (mr *MockStoreMockRecorder) DeleteOldProvisionerJobLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldProvisionerJobLogs", reflect.TypeOf((*MockStore)(nil).DeleteOldProvisionerJobLogs), arg0, arg1)
+}
+
 // DeleteOldWorkspaceAgentLogs mocks base method.
 func (m *MockStore) DeleteOldWorkspaceAgentLogs(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -237,6 +326,35 @@ func (mr *MockStoreMockRecorder) DeleteOldWorkspaceAgentStats(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldWorkspaceAgentStats", reflect.TypeOf((*MockStore)(nil).DeleteOldWorkspaceAgentStats), arg0)
 }
 
+// DeleteOldWorkspaceBuildProvisionerState mocks base method.
+func (m *MockStore) DeleteOldWorkspaceBuildProvisionerState(arg0 context.Context, arg1 database.DeleteOldWorkspaceBuildProvisionerStateParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldWorkspaceBuildProvisionerState", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOldWorkspaceBuildProvisionerState indicates an expected call of DeleteOldWorkspaceBuildProvisionerState.
+func (mr *MockStoreMockRecorder) DeleteOldWorkspaceBuildProvisionerState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldWorkspaceBuildProvisionerState", reflect.TypeOf((*MockStore)(nil).DeleteOldWorkspaceBuildProvisionerState), arg0, arg1)
+}
+
+// DeleteOrganizationMember mocks base method.
+func (m *MockStore) DeleteOrganizationMember(arg0 context.Context, arg1 database.DeleteOrganizationMemberParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganizationMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganizationMember indicates an expected call of DeleteOrganizationMember.
+func (mr *MockStoreMockRecorder) DeleteOrganizationMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganizationMember", reflect.TypeOf((*MockStore)(nil).DeleteOrganizationMember), arg0, arg1)
+}
+
 // DeleteReplicasUpdatedBefore mocks base method.
 func (m *MockStore) DeleteReplicasUpdatedBefore(arg0 context.Context, arg1 time.Time) error {
 	m.ctrl.T.Helper()
@@ -281,6 +399,20 @@ func (mr *MockStoreMockRecorder) DeleteTailnetClient(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTailnetClient", reflect.TypeOf((*MockStore)(nil).DeleteTailnetClient), arg0, arg1)
 }
 
+// DeleteTemplateAutostartExceptions mocks base method.
+func (m *MockStore) DeleteTemplateAutostartExceptions(arg0 context.Context, arg1 uuid.NullUUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplateAutostartExceptions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplateAutostartExceptions indicates an expected call of DeleteTemplateAutostartExceptions.
+func (mr *MockStoreMockRecorder) DeleteTemplateAutostartExceptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplateAutostartExceptions", reflect.TypeOf((*MockStore)(nil).DeleteTemplateAutostartExceptions), arg0, arg1)
+}
+
 // GetAPIKeyByID mocks base method.
 func (m *MockStore) GetAPIKeyByID(arg0 context.Context, arg1 string) (database.APIKey, error) {
 	m.ctrl.T.Helper()
@@ -416,6 +548,36 @@ func (mr *MockStoreMockRecorder) GetAppSecurityKey(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppSecurityKey", reflect.TypeOf((*MockStore)(nil).GetAppSecurityKey), arg0)
 }
 
+// GetAuditLogCheckpoints mocks base method.
+func (m *MockStore) GetAuditLogCheckpoints(arg0 context.Context) ([]database.AuditLogCheckpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogCheckpoints", arg0)
+	ret0, _ := ret[0].([]database.AuditLogCheckpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogCheckpoints indicates an expected call of GetAuditLogCheckpoints.
+func (mr *MockStoreMockRecorder) GetAuditLogCheckpoints(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogCheckpoints", reflect.TypeOf((*MockStore)(nil).GetAuditLogCheckpoints), arg0)
+}
+
+// GetAuditLogsForExport mocks base method.
+func (m *MockStore) GetAuditLogsForExport(arg0 context.Context, arg1 database.GetAuditLogsForExportParams) ([]database.GetAuditLogsForExportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogsForExport", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetAuditLogsForExportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogsForExport indicates an expected call of GetAuditLogsForExport.
+func (mr *MockStoreMockRecorder) GetAuditLogsForExport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogsForExport", reflect.TypeOf((*MockStore)(nil).GetAuditLogsForExport), arg0, arg1)
+}
+
 // GetAuditLogsOffset mocks base method.
 func (m *MockStore) GetAuditLogsOffset(arg0 context.Context, arg1 database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	m.ctrl.T.Helper()
@@ -491,6 +653,21 @@ func (mr *MockStoreMockRecorder) GetAuthorizedWorkspaces(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizedWorkspaces", reflect.TypeOf((*MockStore)(nil).GetAuthorizedWorkspaces), arg0, arg1, arg2)
 }
 
+// GetChainedAuditLogs mocks base method.
+func (m *MockStore) GetChainedAuditLogs(arg0 context.Context, arg1 database.GetChainedAuditLogsParams) ([]database.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainedAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].([]database.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainedAuditLogs indicates an expected call of GetChainedAuditLogs.
+func (mr *MockStoreMockRecorder) GetChainedAuditLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainedAuditLogs", reflect.TypeOf((*MockStore)(nil).GetChainedAuditLogs), arg0, arg1)
+}
+
 // GetDERPMeshKey mocks base method.
 func (m *MockStore) GetDERPMeshKey(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -521,6 +698,21 @@ func (mr *MockStoreMockRecorder) GetDefaultProxyConfig(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultProxyConfig", reflect.TypeOf((*MockStore)(nil).GetDefaultProxyConfig), arg0)
 }
 
+// GetDeploymentAutostartExceptions mocks base method.
+func (m *MockStore) GetDeploymentAutostartExceptions(arg0 context.Context) ([]database.AutostartException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeploymentAutostartExceptions", arg0)
+	ret0, _ := ret[0].([]database.AutostartException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeploymentAutostartExceptions indicates an expected call of GetDeploymentAutostartExceptions.
+func (mr *MockStoreMockRecorder) GetDeploymentAutostartExceptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentAutostartExceptions", reflect.TypeOf((*MockStore)(nil).GetDeploymentAutostartExceptions), arg0)
+}
+
 // GetDeploymentDAUs mocks base method.
 func (m *MockStore) GetDeploymentDAUs(arg0 context.Context, arg1 int32) ([]database.GetDeploymentDAUsRow, error) {
 	m.ctrl.T.Helper()
@@ -746,6 +938,21 @@ func (mr *MockStoreMockRecorder) GetLastUpdateCheck(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastUpdateCheck", reflect.TypeOf((*MockStore)(nil).GetLastUpdateCheck), arg0)
 }
 
+// GetLatestChainedAuditLog mocks base method.
+func (m *MockStore) GetLatestChainedAuditLog(arg0 context.Context) (database.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestChainedAuditLog", arg0)
+	ret0, _ := ret[0].(database.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestChainedAuditLog indicates an expected call of GetLatestChainedAuditLog.
+func (mr *MockStoreMockRecorder) GetLatestChainedAuditLog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestChainedAuditLog", reflect.TypeOf((*MockStore)(nil).GetLatestChainedAuditLog), arg0)
+}
+
 // GetLatestWorkspaceBuildByWorkspaceID mocks base method.
 func (m *MockStore) GetLatestWorkspaceBuildByWorkspaceID(arg0 context.Context, arg1 uuid.UUID) (database.WorkspaceBuild, error) {
 	m.ctrl.T.Helper()
@@ -836,6 +1043,66 @@ func (mr *MockStoreMockRecorder) GetLogoURL(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogoURL", reflect.TypeOf((*MockStore)(nil).GetLogoURL), arg0)
 }
 
+// GetNotificationDeliveries mocks base method.
+func (m *MockStore) GetNotificationDeliveries(arg0 context.Context, arg1 database.GetNotificationDeliveriesParams) ([]database.GetNotificationDeliveriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetNotificationDeliveriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationDeliveries indicates an expected call of GetNotificationDeliveries.
+func (mr *MockStoreMockRecorder) GetNotificationDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationDeliveries", reflect.TypeOf((*MockStore)(nil).GetNotificationDeliveries), arg0, arg1)
+}
+
+// GetNotificationMessageByID mocks base method.
+func (m *MockStore) GetNotificationMessageByID(arg0 context.Context, arg1 uuid.UUID) (database.NotificationMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationMessageByID", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationMessageByID indicates an expected call of GetNotificationMessageByID.
+func (mr *MockStoreMockRecorder) GetNotificationMessageByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationMessageByID", reflect.TypeOf((*MockStore)(nil).GetNotificationMessageByID), arg0, arg1)
+}
+
+// GetNotificationMessagesByUserID mocks base method.
+func (m *MockStore) GetNotificationMessagesByUserID(arg0 context.Context, arg1 database.GetNotificationMessagesByUserIDParams) ([]database.NotificationMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationMessagesByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]database.NotificationMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationMessagesByUserID indicates an expected call of GetNotificationMessagesByUserID.
+func (mr *MockStoreMockRecorder) GetNotificationMessagesByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationMessagesByUserID", reflect.TypeOf((*MockStore)(nil).GetNotificationMessagesByUserID), arg0, arg1)
+}
+
+// GetNotificationPreferencesByUserID mocks base method.
+func (m *MockStore) GetNotificationPreferencesByUserID(arg0 context.Context, arg1 uuid.UUID) ([]database.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferencesByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]database.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferencesByUserID indicates an expected call of GetNotificationPreferencesByUserID.
+func (mr *MockStoreMockRecorder) GetNotificationPreferencesByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferencesByUserID", reflect.TypeOf((*MockStore)(nil).GetNotificationPreferencesByUserID), arg0, arg1)
+}
+
 // GetOAuthSigningKey mocks base method.
 func (m *MockStore) GetOAuthSigningKey(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -911,6 +1178,21 @@ func (mr *MockStoreMockRecorder) GetOrganizationMemberByUserID(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationMemberByUserID", reflect.TypeOf((*MockStore)(nil).GetOrganizationMemberByUserID), arg0, arg1)
 }
 
+// GetOrganizationMembersByOrganizationID mocks base method.
+func (m *MockStore) GetOrganizationMembersByOrganizationID(arg0 context.Context, arg1 uuid.UUID) ([]database.OrganizationMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationMembersByOrganizationID", arg0, arg1)
+	ret0, _ := ret[0].([]database.OrganizationMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationMembersByOrganizationID indicates an expected call of GetOrganizationMembersByOrganizationID.
+func (mr *MockStoreMockRecorder) GetOrganizationMembersByOrganizationID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationMembersByOrganizationID", reflect.TypeOf((*MockStore)(nil).GetOrganizationMembersByOrganizationID), arg0, arg1)
+}
+
 // GetOrganizationMembershipsByUserID mocks base method.
 func (m *MockStore) GetOrganizationMembershipsByUserID(arg0 context.Context, arg1 uuid.UUID) ([]database.OrganizationMember, error) {
 	m.ctrl.T.Helper()
@@ -1181,6 +1463,36 @@ func (mr *MockStoreMockRecorder) GetTailnetClientsForAgent(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTailnetClientsForAgent", reflect.TypeOf((*MockStore)(nil).GetTailnetClientsForAgent), arg0, arg1)
 }
 
+// GetTemplateAutostartExceptions mocks base method.
+func (m *MockStore) GetTemplateAutostartExceptions(arg0 context.Context, arg1 uuid.NullUUID) ([]database.AutostartException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateAutostartExceptions", arg0, arg1)
+	ret0, _ := ret[0].([]database.AutostartException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateAutostartExceptions indicates an expected call of GetTemplateAutostartExceptions.
+func (mr *MockStoreMockRecorder) GetTemplateAutostartExceptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateAutostartExceptions", reflect.TypeOf((*MockStore)(nil).GetTemplateAutostartExceptions), arg0, arg1)
+}
+
+// GetTemplateAutostartExceptionsByTemplateIDs mocks base method.
+func (m *MockStore) GetTemplateAutostartExceptionsByTemplateIDs(arg0 context.Context, arg1 []uuid.UUID) ([]database.AutostartException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateAutostartExceptionsByTemplateIDs", arg0, arg1)
+	ret0, _ := ret[0].([]database.AutostartException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateAutostartExceptionsByTemplateIDs indicates an expected call of GetTemplateAutostartExceptionsByTemplateIDs.
+func (mr *MockStoreMockRecorder) GetTemplateAutostartExceptionsByTemplateIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateAutostartExceptionsByTemplateIDs", reflect.TypeOf((*MockStore)(nil).GetTemplateAutostartExceptionsByTemplateIDs), arg0, arg1)
+}
+
 // GetTemplateAverageBuildTime mocks base method.
 func (m *MockStore) GetTemplateAverageBuildTime(arg0 context.Context, arg1 database.GetTemplateAverageBuildTimeParams) (database.GetTemplateAverageBuildTimeRow, error) {
 	m.ctrl.T.Helper()
@@ -1436,6 +1748,21 @@ func (mr *MockStoreMockRecorder) GetTemplateVersionsCreatedAfter(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateVersionsCreatedAfter", reflect.TypeOf((*MockStore)(nil).GetTemplateVersionsCreatedAfter), arg0, arg1)
 }
 
+// GetTemplateWorkspaceCounts mocks base method.
+func (m *MockStore) GetTemplateWorkspaceCounts(arg0 context.Context, arg1 []uuid.UUID) ([]database.GetTemplateWorkspaceCountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateWorkspaceCounts", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetTemplateWorkspaceCountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateWorkspaceCounts indicates an expected call of GetTemplateWorkspaceCounts.
+func (mr *MockStoreMockRecorder) GetTemplateWorkspaceCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateWorkspaceCounts", reflect.TypeOf((*MockStore)(nil).GetTemplateWorkspaceCounts), arg0, arg1)
+}
+
 // GetTemplates mocks base method.
 func (m *MockStore) GetTemplates(arg0 context.Context) ([]database.Template, error) {
 	m.ctrl.T.Helper()
@@ -1886,6 +2213,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaceBuildParameters(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBuildParameters", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBuildParameters), arg0, arg1)
 }
 
+// GetWorkspaceBuildStateSummariesByWorkspaceID mocks base method.
+func (m *MockStore) GetWorkspaceBuildStateSummariesByWorkspaceID(arg0 context.Context, arg1 database.GetWorkspaceBuildStateSummariesByWorkspaceIDParams) ([]database.GetWorkspaceBuildStateSummariesByWorkspaceIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceBuildStateSummariesByWorkspaceID", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetWorkspaceBuildStateSummariesByWorkspaceIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceBuildStateSummariesByWorkspaceID indicates an expected call of GetWorkspaceBuildStateSummariesByWorkspaceID.
+func (mr *MockStoreMockRecorder) GetWorkspaceBuildStateSummariesByWorkspaceID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBuildStateSummariesByWorkspaceID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBuildStateSummariesByWorkspaceID), arg0, arg1)
+}
+
 // GetWorkspaceBuildsByWorkspaceID mocks base method.
 func (m *MockStore) GetWorkspaceBuildsByWorkspaceID(arg0 context.Context, arg1 database.GetWorkspaceBuildsByWorkspaceIDParams) ([]database.WorkspaceBuild, error) {
 	m.ctrl.T.Helper()
@@ -1916,6 +2258,36 @@ func (mr *MockStoreMockRecorder) GetWorkspaceBuildsCreatedAfter(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBuildsCreatedAfter", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBuildsCreatedAfter), arg0, arg1)
 }
 
+// GetWorkspaceBulkOperationByID mocks base method.
+func (m *MockStore) GetWorkspaceBulkOperationByID(arg0 context.Context, arg1 uuid.UUID) (database.WorkspaceBulkOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceBulkOperationByID", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceBulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceBulkOperationByID indicates an expected call of GetWorkspaceBulkOperationByID.
+func (mr *MockStoreMockRecorder) GetWorkspaceBulkOperationByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBulkOperationByID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBulkOperationByID), arg0, arg1)
+}
+
+// GetWorkspaceBulkOperationWorkspacesByOperationID mocks base method.
+func (m *MockStore) GetWorkspaceBulkOperationWorkspacesByOperationID(arg0 context.Context, arg1 uuid.UUID) ([]database.GetWorkspaceBulkOperationWorkspacesByOperationIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceBulkOperationWorkspacesByOperationID", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetWorkspaceBulkOperationWorkspacesByOperationIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceBulkOperationWorkspacesByOperationID indicates an expected call of GetWorkspaceBulkOperationWorkspacesByOperationID.
+func (mr *MockStoreMockRecorder) GetWorkspaceBulkOperationWorkspacesByOperationID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBulkOperationWorkspacesByOperationID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBulkOperationWorkspacesByOperationID), arg0, arg1)
+}
+
 // GetWorkspaceByAgentID mocks base method.
 func (m *MockStore) GetWorkspaceByAgentID(arg0 context.Context, arg1 uuid.UUID) (database.Workspace, error) {
 	m.ctrl.T.Helper()
@@ -1946,6 +2318,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaceByID(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceByID), arg0, arg1)
 }
 
+// GetWorkspaceByIDForUpdate mocks base method.
+func (m *MockStore) GetWorkspaceByIDForUpdate(arg0 context.Context, arg1 uuid.UUID) (database.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceByIDForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(database.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceByIDForUpdate indicates an expected call of GetWorkspaceByIDForUpdate.
+func (mr *MockStoreMockRecorder) GetWorkspaceByIDForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByIDForUpdate", reflect.TypeOf((*MockStore)(nil).GetWorkspaceByIDForUpdate), arg0, arg1)
+}
+
 // GetWorkspaceByOwnerIDAndName mocks base method.
 func (m *MockStore) GetWorkspaceByOwnerIDAndName(arg0 context.Context, arg1 database.GetWorkspaceByOwnerIDAndNameParams) (database.Workspace, error) {
 	m.ctrl.T.Helper()
@@ -2141,6 +2528,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaces(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockStore)(nil).GetWorkspaces), arg0, arg1)
 }
 
+// GetWorkspacesAboutToAutostop mocks base method.
+func (m *MockStore) GetWorkspacesAboutToAutostop(arg0 context.Context, arg1 database.GetWorkspacesAboutToAutostopParams) ([]database.GetWorkspacesAboutToAutostopRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspacesAboutToAutostop", arg0, arg1)
+	ret0, _ := ret[0].([]database.GetWorkspacesAboutToAutostopRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspacesAboutToAutostop indicates an expected call of GetWorkspacesAboutToAutostop.
+func (mr *MockStoreMockRecorder) GetWorkspacesAboutToAutostop(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspacesAboutToAutostop", reflect.TypeOf((*MockStore)(nil).GetWorkspacesAboutToAutostop), arg0, arg1)
+}
+
 // GetWorkspacesEligibleForTransition mocks base method.
 func (m *MockStore) GetWorkspacesEligibleForTransition(arg0 context.Context, arg1 time.Time) ([]database.Workspace, error) {
 	m.ctrl.T.Helper()
@@ -2215,6 +2617,35 @@ func (mr *MockStoreMockRecorder) InsertAuditLog(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuditLog", reflect.TypeOf((*MockStore)(nil).InsertAuditLog), arg0, arg1)
 }
 
+// InsertAuditLogCheckpoint mocks base method.
+func (m *MockStore) InsertAuditLogCheckpoint(arg0 context.Context, arg1 database.InsertAuditLogCheckpointParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAuditLogCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAuditLogCheckpoint indicates an expected call of InsertAuditLogCheckpoint.
+func (mr *MockStoreMockRecorder) InsertAuditLogCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuditLogCheckpoint", reflect.TypeOf((*MockStore)(nil).InsertAuditLogCheckpoint), arg0, arg1)
+}
+
+// InsertAutostartException mocks base method.
+func (m *MockStore) InsertAutostartException(arg0 context.Context, arg1 database.InsertAutostartExceptionParams) (database.AutostartException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAutostartException", arg0, arg1)
+	ret0, _ := ret[0].(database.AutostartException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAutostartException indicates an expected call of InsertAutostartException.
+func (mr *MockStoreMockRecorder) InsertAutostartException(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAutostartException", reflect.TypeOf((*MockStore)(nil).InsertAutostartException), arg0, arg1)
+}
+
 // InsertDERPMeshKey mocks base method.
 func (m *MockStore) InsertDERPMeshKey(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -2347,6 +2778,36 @@ func (mr *MockStoreMockRecorder) InsertMissingGroups(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMissingGroups", reflect.TypeOf((*MockStore)(nil).InsertMissingGroups), arg0, arg1)
 }
 
+// InsertNotificationDelivery mocks base method.
+func (m *MockStore) InsertNotificationDelivery(arg0 context.Context, arg1 database.InsertNotificationDeliveryParams) (database.NotificationDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNotificationDelivery", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNotificationDelivery indicates an expected call of InsertNotificationDelivery.
+func (mr *MockStoreMockRecorder) InsertNotificationDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNotificationDelivery", reflect.TypeOf((*MockStore)(nil).InsertNotificationDelivery), arg0, arg1)
+}
+
+// InsertNotificationMessage mocks base method.
+func (m *MockStore) InsertNotificationMessage(arg0 context.Context, arg1 database.InsertNotificationMessageParams) (database.NotificationMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertNotificationMessage", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertNotificationMessage indicates an expected call of InsertNotificationMessage.
+func (mr *MockStoreMockRecorder) InsertNotificationMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertNotificationMessage", reflect.TypeOf((*MockStore)(nil).InsertNotificationMessage), arg0, arg1)
+}
+
 // InsertOrganization mocks base method.
 func (m *MockStore) InsertOrganization(arg0 context.Context, arg1 database.InsertOrganizationParams) (database.Organization, error) {
 	m.ctrl.T.Helper()
@@ -2670,6 +3131,36 @@ func (mr *MockStoreMockRecorder) InsertWorkspaceBuildParameters(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceBuildParameters", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceBuildParameters), arg0, arg1)
 }
 
+// InsertWorkspaceBulkOperation mocks base method.
+func (m *MockStore) InsertWorkspaceBulkOperation(arg0 context.Context, arg1 database.InsertWorkspaceBulkOperationParams) (database.WorkspaceBulkOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceBulkOperation", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceBulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWorkspaceBulkOperation indicates an expected call of InsertWorkspaceBulkOperation.
+func (mr *MockStoreMockRecorder) InsertWorkspaceBulkOperation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceBulkOperation", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceBulkOperation), arg0, arg1)
+}
+
+// InsertWorkspaceBulkOperationWorkspaces mocks base method.
+func (m *MockStore) InsertWorkspaceBulkOperationWorkspaces(arg0 context.Context, arg1 database.InsertWorkspaceBulkOperationWorkspacesParams) ([]database.WorkspaceBulkOperationWorkspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceBulkOperationWorkspaces", arg0, arg1)
+	ret0, _ := ret[0].([]database.WorkspaceBulkOperationWorkspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWorkspaceBulkOperationWorkspaces indicates an expected call of InsertWorkspaceBulkOperationWorkspaces.
+func (mr *MockStoreMockRecorder) InsertWorkspaceBulkOperationWorkspaces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceBulkOperationWorkspaces", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceBulkOperationWorkspaces), arg0, arg1)
+}
+
 // InsertWorkspaceProxy mocks base method.
 func (m *MockStore) InsertWorkspaceProxy(arg0 context.Context, arg1 database.InsertWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	m.ctrl.T.Helper()
@@ -2849,6 +3340,49 @@ func (mr *MockStoreMockRecorder) UpdateMemberRoles(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRoles", reflect.TypeOf((*MockStore)(nil).UpdateMemberRoles), arg0, arg1)
 }
 
+// UpdateNotificationDelivery mocks base method.
+func (m *MockStore) UpdateNotificationDelivery(arg0 context.Context, arg1 database.UpdateNotificationDeliveryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationDelivery indicates an expected call of UpdateNotificationDelivery.
+func (mr *MockStoreMockRecorder) UpdateNotificationDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationDelivery", reflect.TypeOf((*MockStore)(nil).UpdateNotificationDelivery), arg0, arg1)
+}
+
+// UpdateNotificationMessageReadAt mocks base method.
+func (m *MockStore) UpdateNotificationMessageReadAt(arg0 context.Context, arg1 database.UpdateNotificationMessageReadAtParams) (database.NotificationMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationMessageReadAt", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotificationMessageReadAt indicates an expected call of UpdateNotificationMessageReadAt.
+func (mr *MockStoreMockRecorder) UpdateNotificationMessageReadAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationMessageReadAt", reflect.TypeOf((*MockStore)(nil).UpdateNotificationMessageReadAt), arg0, arg1)
+}
+
+// UpdateNotificationMessagesReadAtByUserID mocks base method.
+func (m *MockStore) UpdateNotificationMessagesReadAtByUserID(arg0 context.Context, arg1 database.UpdateNotificationMessagesReadAtByUserIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationMessagesReadAtByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationMessagesReadAtByUserID indicates an expected call of UpdateNotificationMessagesReadAtByUserID.
+func (mr *MockStoreMockRecorder) UpdateNotificationMessagesReadAtByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationMessagesReadAtByUserID", reflect.TypeOf((*MockStore)(nil).UpdateNotificationMessagesReadAtByUserID), arg0, arg1)
+}
+
 // UpdateProvisionerJobByID mocks base method.
 func (m *MockStore) UpdateProvisionerJobByID(arg0 context.Context, arg1 database.UpdateProvisionerJobByIDParams) error {
 	m.ctrl.T.Helper()
@@ -2976,6 +3510,20 @@ func (mr *MockStoreMockRecorder) UpdateTemplateScheduleByID(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplateScheduleByID", reflect.TypeOf((*MockStore)(nil).UpdateTemplateScheduleByID), arg0, arg1)
 }
 
+// UpdateTemplateVersionArchivedByID mocks base method.
+func (m *MockStore) UpdateTemplateVersionArchivedByID(arg0 context.Context, arg1 database.UpdateTemplateVersionArchivedByIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplateVersionArchivedByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplateVersionArchivedByID indicates an expected call of UpdateTemplateVersionArchivedByID.
+func (mr *MockStoreMockRecorder) UpdateTemplateVersionArchivedByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplateVersionArchivedByID", reflect.TypeOf((*MockStore)(nil).UpdateTemplateVersionArchivedByID), arg0, arg1)
+}
+
 // UpdateTemplateVersionByID mocks base method.
 func (m *MockStore) UpdateTemplateVersionByID(arg0 context.Context, arg1 database.UpdateTemplateVersionByIDParams) error {
 	m.ctrl.T.Helper()
@@ -3181,6 +3729,20 @@ func (mr *MockStoreMockRecorder) UpdateWorkspace(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockStore)(nil).UpdateWorkspace), arg0, arg1)
 }
 
+// UpdateWorkspaceACLByID mocks base method.
+func (m *MockStore) UpdateWorkspaceACLByID(arg0 context.Context, arg1 database.UpdateWorkspaceACLByIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceACLByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceACLByID indicates an expected call of UpdateWorkspaceACLByID.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceACLByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceACLByID", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceACLByID), arg0, arg1)
+}
+
 // UpdateWorkspaceAgentConnectionByID mocks base method.
 func (m *MockStore) UpdateWorkspaceAgentConnectionByID(arg0 context.Context, arg1 database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	m.ctrl.T.Helper()
@@ -3279,6 +3841,20 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceAutostart(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceAutostart", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceAutostart), arg0, arg1)
 }
 
+// UpdateWorkspaceAutostartSkipUntil mocks base method.
+func (m *MockStore) UpdateWorkspaceAutostartSkipUntil(arg0 context.Context, arg1 database.UpdateWorkspaceAutostartSkipUntilParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceAutostartSkipUntil", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceAutostartSkipUntil indicates an expected call of UpdateWorkspaceAutostartSkipUntil.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceAutostartSkipUntil(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceAutostartSkipUntil", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceAutostartSkipUntil), arg0, arg1)
+}
+
 // UpdateWorkspaceBuildByID mocks base method.
 func (m *MockStore) UpdateWorkspaceBuildByID(arg0 context.Context, arg1 database.UpdateWorkspaceBuildByIDParams) error {
 	m.ctrl.T.Helper()
@@ -3307,6 +3883,48 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceBuildCostByID(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBuildCostByID", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBuildCostByID), arg0, arg1)
 }
 
+// UpdateWorkspaceBulkOperationCompletedAt mocks base method.
+func (m *MockStore) UpdateWorkspaceBulkOperationCompletedAt(arg0 context.Context, arg1 database.UpdateWorkspaceBulkOperationCompletedAtParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceBulkOperationCompletedAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceBulkOperationCompletedAt indicates an expected call of UpdateWorkspaceBulkOperationCompletedAt.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceBulkOperationCompletedAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBulkOperationCompletedAt", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBulkOperationCompletedAt), arg0, arg1)
+}
+
+// UpdateWorkspaceBulkOperationHeartbeat mocks base method.
+func (m *MockStore) UpdateWorkspaceBulkOperationHeartbeat(arg0 context.Context, arg1 database.UpdateWorkspaceBulkOperationHeartbeatParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceBulkOperationHeartbeat", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceBulkOperationHeartbeat indicates an expected call of UpdateWorkspaceBulkOperationHeartbeat.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceBulkOperationHeartbeat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBulkOperationHeartbeat", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBulkOperationHeartbeat), arg0, arg1)
+}
+
+// UpdateWorkspaceBulkOperationWorkspace mocks base method.
+func (m *MockStore) UpdateWorkspaceBulkOperationWorkspace(arg0 context.Context, arg1 database.UpdateWorkspaceBulkOperationWorkspaceParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceBulkOperationWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceBulkOperationWorkspace indicates an expected call of UpdateWorkspaceBulkOperationWorkspace.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceBulkOperationWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBulkOperationWorkspace", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBulkOperationWorkspace), arg0, arg1)
+}
+
 // UpdateWorkspaceDeletedByID mocks base method.
 func (m *MockStore) UpdateWorkspaceDeletedByID(arg0 context.Context, arg1 database.UpdateWorkspaceDeletedByIDParams) error {
 	m.ctrl.T.Helper()
@@ -3350,6 +3968,21 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceLockedDeletingAt(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceLockedDeletingAt", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceLockedDeletingAt), arg0, arg1)
 }
 
+// UpdateWorkspaceOwner mocks base method.
+func (m *MockStore) UpdateWorkspaceOwner(arg0 context.Context, arg1 database.UpdateWorkspaceOwnerParams) (database.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceOwner", arg0, arg1)
+	ret0, _ := ret[0].(database.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorkspaceOwner indicates an expected call of UpdateWorkspaceOwner.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceOwner", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceOwner), arg0, arg1)
+}
+
 // UpdateWorkspaceProxy mocks base method.
 func (m *MockStore) UpdateWorkspaceProxy(arg0 context.Context, arg1 database.UpdateWorkspaceProxyParams) (database.WorkspaceProxy, error) {
 	m.ctrl.T.Helper()
@@ -3463,6 +4096,21 @@ func (mr *MockStoreMockRecorder) UpsertLogoURL(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLogoURL", reflect.TypeOf((*MockStore)(nil).UpsertLogoURL), arg0, arg1)
 }
 
+// UpsertNotificationPreference mocks base method.
+func (m *MockStore) UpsertNotificationPreference(arg0 context.Context, arg1 database.UpsertNotificationPreferenceParams) (database.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertNotificationPreference indicates an expected call of UpsertNotificationPreference.
+func (mr *MockStoreMockRecorder) UpsertNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationPreference", reflect.TypeOf((*MockStore)(nil).UpsertNotificationPreference), arg0, arg1)
+}
+
 // UpsertOAuthSigningKey mocks base method.
 func (m *MockStore) UpsertOAuthSigningKey(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
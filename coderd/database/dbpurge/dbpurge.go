@@ -6,22 +6,30 @@ import (
 	"io"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 
 	"cdr.dev/slog"
 	"github.com/coder/coder/coderd/database"
 	"github.com/coder/coder/coderd/database/dbauthz"
+	"github.com/coder/coder/codersdk"
 )
 
 const (
 	delay = 24 * time.Hour
+	// batchSize is the number of rows deleted by a single query, so that
+	// purging a large backlog does not hold locks for long.
+	batchSize = 10000
 )
 
 // New creates a new periodically purging database instance.
 // It is the caller's responsibility to call Close on the returned instance.
 //
 // This is for cleaning up old, unused resources from the database that take up space.
-func New(ctx context.Context, logger slog.Logger, db database.Store) io.Closer {
+// Data covered by the retention config is only purged if retention is enabled for it.
+func New(ctx context.Context, logger slog.Logger, db database.Store, reg prometheus.Registerer, retention codersdk.RetentionConfig) io.Closer {
+	p := newPurger(db, reg, retention)
+
 	closed := make(chan struct{})
 	ctx, cancelFunc := context.WithCancel(ctx)
 	//nolint:gocritic // The system purges old db records without user input.
@@ -38,14 +46,7 @@ func New(ctx context.Context, logger slog.Logger, db database.Store) io.Closer {
 			case <-ticker.C:
 			}
 
-			var eg errgroup.Group
-			eg.Go(func() error {
-				return db.DeleteOldWorkspaceAgentLogs(ctx)
-			})
-			eg.Go(func() error {
-				return db.DeleteOldWorkspaceAgentStats(ctx)
-			})
-			err := eg.Wait()
+			err := p.purge(ctx)
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					return
@@ -62,6 +63,101 @@ func New(ctx context.Context, logger slog.Logger, db database.Store) io.Closer {
 	}
 }
 
+type purger struct {
+	db         database.Store
+	retention  codersdk.RetentionConfig
+	purgedRows *prometheus.CounterVec
+}
+
+func newPurger(db database.Store, reg prometheus.Registerer, retention codersdk.RetentionConfig) *purger {
+	purgedRows := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "coderd",
+		Subsystem: "dbpurge",
+		Name:      "purged_rows_total",
+		Help:      "The number of rows purged from the database. Purged workspace build state is counted per build.",
+	}, []string{"type"})
+	reg.MustRegister(purgedRows)
+	return &purger{
+		db:         db,
+		retention:  retention,
+		purgedRows: purgedRows,
+	}
+}
+
+// purge deletes old database entries once.
+func (p *purger) purge(ctx context.Context) error {
+	var eg errgroup.Group
+	eg.Go(func() error {
+		return p.db.DeleteOldWorkspaceAgentLogs(ctx)
+	})
+	eg.Go(func() error {
+		return p.db.DeleteOldWorkspaceAgentStats(ctx)
+	})
+	if auditLogs := p.retention.AuditLogs.Value(); auditLogs > 0 {
+		eg.Go(func() error {
+			deleted, err := purgeBatches(func(limit int32) (int64, error) {
+				return p.db.DeleteOldAuditLogs(ctx, database.DeleteOldAuditLogsParams{
+					BeforeTime: database.Now().Add(-auditLogs),
+					LimitCount: limit,
+				})
+			})
+			p.purgedRows.WithLabelValues("audit_logs").Add(float64(deleted))
+			if err != nil {
+				return err
+			}
+			// Checkpoints of purged audit logs would otherwise be
+			// reported as truncation of the hash chain.
+			deleted, err = p.db.DeleteOldAuditLogCheckpoints(ctx)
+			if err != nil {
+				return err
+			}
+			p.purgedRows.WithLabelValues("audit_log_checkpoints").Add(float64(deleted))
+			return nil
+		})
+	}
+	if jobLogs := p.retention.ProvisionerJobLogs.Value(); jobLogs > 0 {
+		eg.Go(func() error {
+			deleted, err := purgeBatches(func(limit int32) (int64, error) {
+				return p.db.DeleteOldProvisionerJobLogs(ctx, database.DeleteOldProvisionerJobLogsParams{
+					CompletedBefore: database.Now().Add(-jobLogs),
+					LimitCount:      limit,
+				})
+			})
+			p.purgedRows.WithLabelValues("provisioner_job_logs").Add(float64(deleted))
+			return err
+		})
+	}
+	if keepBuilds := p.retention.WorkspaceBuildState.Value(); keepBuilds > 0 {
+		eg.Go(func() error {
+			updated, err := purgeBatches(func(limit int32) (int64, error) {
+				return p.db.DeleteOldWorkspaceBuildProvisionerState(ctx, database.DeleteOldWorkspaceBuildProvisionerStateParams{
+					KeepBuilds: keepBuilds,
+					LimitCount: limit,
+				})
+			})
+			p.purgedRows.WithLabelValues("workspace_build_provisioner_state").Add(float64(updated))
+			return err
+		})
+	}
+	return eg.Wait()
+}
+
+// purgeBatches calls purge until it purges fewer rows than the batch size,
+// and returns the total number of rows purged.
+func purgeBatches(purge func(limit int32) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		rows, err := purge(batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += rows
+		if rows < batchSize {
+			return total, nil
+		}
+	}
+}
+
 type instance struct {
 	cancel context.CancelFunc
 	closed chan struct{}
@@ -0,0 +1,239 @@
+package dbpurge
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbfake"
+	"github.com/coder/coder/coderd/database/dbgen"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/testutil"
+)
+
+func TestPurgeAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbfake.New()
+		now := database.Now()
+		old := now.Add(-48 * time.Hour)
+		_ = auditLog(t, db, old, 0)
+		newUnchained := auditLog(t, db, now, 0)
+		_ = auditLog(t, db, old, 1)
+		_ = auditLog(t, db, old, 2)
+		newChained := auditLog(t, db, now, 3)
+		checkpoint(t, db, 1, old)
+		checkpoint(t, db, 2, old)
+		checkpoint(t, db, 3, now)
+
+		p := purge(t, db, codersdk.RetentionConfig{AuditLogs: clibase.Duration(24 * time.Hour)})
+
+		alogs := remainingAuditLogs(t, db)
+		require.ElementsMatch(t, []uuid.UUID{newUnchained.ID, newChained.ID}, alogs)
+		// The newest checkpoint before the cutoff anchors the remaining chain.
+		require.Equal(t, []int64{2, 3}, remainingCheckpoints(t, db))
+		require.EqualValues(t, 3, purgedRows(p, "audit_logs"))
+		require.EqualValues(t, 1, purgedRows(p, "audit_log_checkpoints"))
+	})
+
+	t.Run("KeepsHead", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbfake.New()
+		old := database.Now().Add(-48 * time.Hour)
+		_ = auditLog(t, db, old, 1)
+		head := auditLog(t, db, old, 2)
+		checkpoint(t, db, 1, old)
+		checkpoint(t, db, 2, old)
+
+		_ = purge(t, db, codersdk.RetentionConfig{AuditLogs: clibase.Duration(24 * time.Hour)})
+
+		require.Equal(t, []uuid.UUID{head.ID}, remainingAuditLogs(t, db))
+		require.Equal(t, []int64{1, 2}, remainingCheckpoints(t, db))
+	})
+
+	t.Run("NoCheckpoint", func(t *testing.T) {
+		t.Parallel()
+
+		// Without a signed checkpoint to anchor the remaining chain, chained
+		// audit logs are kept.
+		db := dbfake.New()
+		old := database.Now().Add(-48 * time.Hour)
+		first := auditLog(t, db, old, 1)
+		second := auditLog(t, db, old, 2)
+		checkpoint(t, db, 2, database.Now())
+
+		_ = purge(t, db, codersdk.RetentionConfig{AuditLogs: clibase.Duration(24 * time.Hour)})
+
+		require.ElementsMatch(t, []uuid.UUID{first.ID, second.ID}, remainingAuditLogs(t, db))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbfake.New()
+		alog := auditLog(t, db, database.Now().Add(-48*time.Hour), 0)
+
+		_ = purge(t, db, codersdk.RetentionConfig{})
+
+		require.Equal(t, []uuid.UUID{alog.ID}, remainingAuditLogs(t, db))
+	})
+}
+
+func TestPurgeProvisionerJobLogs(t *testing.T) {
+	t.Parallel()
+
+	db := dbfake.New()
+	now := database.Now()
+	oldJob := dbgen.ProvisionerJob(t, db, database.ProvisionerJob{
+		StartedAt:   sql.NullTime{Time: now.Add(-49 * time.Hour), Valid: true},
+		CompletedAt: sql.NullTime{Time: now.Add(-48 * time.Hour), Valid: true},
+	})
+	newJob := dbgen.ProvisionerJob(t, db, database.ProvisionerJob{
+		StartedAt:   sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+		CompletedAt: sql.NullTime{Time: now, Valid: true},
+	})
+	runningJob := dbgen.ProvisionerJob(t, db, database.ProvisionerJob{
+		StartedAt: sql.NullTime{Time: now.Add(-48 * time.Hour), Valid: true},
+	})
+	for _, job := range []database.ProvisionerJob{oldJob, newJob, runningJob} {
+		jobLogs(t, db, job.ID, 2)
+	}
+
+	p := purge(t, db, codersdk.RetentionConfig{ProvisionerJobLogs: clibase.Duration(24 * time.Hour)})
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	for _, tc := range []struct {
+		job  database.ProvisionerJob
+		logs int
+	}{
+		{job: oldJob, logs: 0},
+		{job: newJob, logs: 2},
+		{job: runningJob, logs: 2},
+	} {
+		logs, err := db.GetProvisionerLogsAfterID(ctx, database.GetProvisionerLogsAfterIDParams{JobID: tc.job.ID})
+		require.NoError(t, err)
+		require.Len(t, logs, tc.logs)
+	}
+	require.EqualValues(t, 2, purgedRows(p, "provisioner_job_logs"))
+}
+
+func TestPurgeWorkspaceBuildState(t *testing.T) {
+	t.Parallel()
+
+	db := dbfake.New()
+	workspaceID := uuid.New()
+	builds := make([]database.WorkspaceBuild, 0, 3)
+	for number := int32(1); number <= 3; number++ {
+		builds = append(builds, dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+			WorkspaceID:      workspaceID,
+			BuildNumber:      number,
+			ProvisionerState: []byte("state"),
+		}))
+	}
+	otherBuild := dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+		BuildNumber:      1,
+		ProvisionerState: []byte("state"),
+	})
+
+	p := purge(t, db, codersdk.RetentionConfig{WorkspaceBuildState: 2})
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	for _, tc := range []struct {
+		build database.WorkspaceBuild
+		state []byte
+	}{
+		{build: builds[0], state: nil},
+		{build: builds[1], state: []byte("state")},
+		{build: builds[2], state: []byte("state")},
+		{build: otherBuild, state: []byte("state")},
+	} {
+		build, err := db.GetWorkspaceBuildByID(ctx, tc.build.ID)
+		require.NoError(t, err)
+		require.Equal(t, tc.state, build.ProvisionerState)
+	}
+	require.EqualValues(t, 1, purgedRows(p, "workspace_build_provisioner_state"))
+}
+
+// purge runs a single purge.
+func purge(t *testing.T, db database.Store, retention codersdk.RetentionConfig) *purger {
+	t.Helper()
+
+	p := newPurger(db, prometheus.NewRegistry(), retention)
+	err := p.purge(testutil.Context(t, testutil.WaitShort))
+	require.NoError(t, err)
+	return p
+}
+
+func purgedRows(p *purger, typ string) float64 {
+	return promtestutil.ToFloat64(p.purgedRows.WithLabelValues(typ))
+}
+
+func auditLog(t *testing.T, db database.Store, at time.Time, sequence int64) database.AuditLog {
+	t.Helper()
+
+	return dbgen.AuditLog(t, db, database.AuditLog{
+		Time:          at,
+		ChainSequence: sql.NullInt64{Int64: sequence, Valid: sequence > 0},
+	})
+}
+
+func checkpoint(t *testing.T, db database.Store, sequence int64, createdAt time.Time) {
+	t.Helper()
+
+	err := db.InsertAuditLogCheckpoint(context.Background(), database.InsertAuditLogCheckpointParams{
+		ChainSequence: sequence,
+		CreatedAt:     createdAt,
+	})
+	require.NoError(t, err)
+}
+
+func jobLogs(t *testing.T, db database.Store, jobID uuid.UUID, n int) {
+	t.Helper()
+
+	params := database.InsertProvisionerJobLogsParams{JobID: jobID}
+	for i := 0; i < n; i++ {
+		params.CreatedAt = append(params.CreatedAt, database.Now())
+		params.Source = append(params.Source, database.LogSourceProvisioner)
+		params.Level = append(params.Level, database.LogLevelInfo)
+		params.Stage = append(params.Stage, "stage")
+		params.Output = append(params.Output, "output")
+	}
+	_, err := db.InsertProvisionerJobLogs(context.Background(), params)
+	require.NoError(t, err)
+}
+
+func remainingAuditLogs(t *testing.T, db database.Store) []uuid.UUID {
+	t.Helper()
+
+	alogs, err := db.GetAuditLogsOffset(context.Background(), database.GetAuditLogsOffsetParams{Limit: 100})
+	require.NoError(t, err)
+	ids := make([]uuid.UUID, 0, len(alogs))
+	for _, alog := range alogs {
+		ids = append(ids, alog.ID)
+	}
+	return ids
+}
+
+func remainingCheckpoints(t *testing.T, db database.Store) []int64 {
+	t.Helper()
+
+	checkpoints, err := db.GetAuditLogCheckpoints(context.Background())
+	require.NoError(t, err)
+	sequences := make([]int64, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		sequences = append(sequences, checkpoint.ChainSequence)
+	}
+	return sequences
+}
@@ -59,6 +59,20 @@ CREATE TYPE login_type AS ENUM (
 
 COMMENT ON TYPE login_type IS 'Specifies the method of authentication. "none" is a special case in which no authentication method is allowed.';
 
+CREATE TYPE notification_delivery_status AS ENUM (
+    'pending',
+    'sent',
+    'failed'
+);
+
+CREATE TYPE notification_event AS ENUM (
+    'workspace_build_failed',
+    'workspace_autostop',
+    'workspace_locked',
+    'template_version_promoted',
+    'user_suspended'
+);
+
 CREATE TYPE parameter_destination_scheme AS ENUM (
     'none',
     'environment_variable',
@@ -159,6 +173,21 @@ CREATE TYPE workspace_app_health AS ENUM (
     'unhealthy'
 );
 
+CREATE TYPE workspace_bulk_operation_transition AS ENUM (
+    'start',
+    'stop',
+    'update',
+    'delete'
+);
+
+CREATE TYPE workspace_bulk_operation_workspace_status AS ENUM (
+    'pending',
+    'building',
+    'succeeded',
+    'failed',
+    'skipped'
+);
+
 CREATE TYPE workspace_transition AS ENUM (
     'start',
     'stop',
@@ -249,6 +278,17 @@ CREATE TABLE api_keys (
 
 COMMENT ON COLUMN api_keys.hashed_secret IS 'hashed_secret contains a SHA256 hash of the key secret. This is considered a secret and MUST NOT be returned from the API as it is used for API key encryption in app proxying code.';
 
+CREATE TABLE audit_log_checkpoints (
+    chain_sequence bigint NOT NULL,
+    chain_hash bytea NOT NULL,
+    signature bytea NOT NULL,
+    created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE audit_log_checkpoints IS 'Signed snapshots of the head of the audit log hash chain. They detect audit logs that were deleted from the end of the chain.';
+
+COMMENT ON COLUMN audit_log_checkpoints.signature IS 'HMAC-SHA256 of the chain sequence and hash, keyed with a secret that is not stored in the database.';
+
 CREATE TABLE audit_logs (
     id uuid NOT NULL,
     "time" timestamp with time zone NOT NULL,
@@ -264,9 +304,29 @@ CREATE TABLE audit_logs (
     status_code integer NOT NULL,
     additional_fields jsonb NOT NULL,
     request_id uuid NOT NULL,
-    resource_icon text NOT NULL
+    resource_icon text NOT NULL,
+    chain_sequence bigint,
+    chain_hash bytea
 );
 
+COMMENT ON COLUMN audit_logs.chain_sequence IS 'Position of the audit log in the hash chain. NULL if the audit log was inserted while the hash chain was disabled.';
+
+COMMENT ON COLUMN audit_logs.chain_hash IS 'SHA-256 hash over the chain_hash of the previous audit log in the chain and the contents of this audit log.';
+
+CREATE TABLE autostart_exceptions (
+    id uuid NOT NULL,
+    template_id uuid,
+    name text NOT NULL,
+    starts_at timestamp with time zone NOT NULL,
+    ends_at timestamp with time zone NOT NULL,
+    all_day boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE autostart_exceptions IS 'Periods in which workspaces are not automatically started. Exceptions without a template apply to the whole deployment.';
+
+COMMENT ON COLUMN autostart_exceptions.all_day IS 'All-day exceptions cover the dates from starts_at up to ends_at in the time zone of each autostart schedule.';
+
 CREATE TABLE files (
     hash character varying(64) NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -333,6 +393,48 @@ CREATE SEQUENCE licenses_id_seq
 
 ALTER SEQUENCE licenses_id_seq OWNED BY licenses.id;
 
+CREATE TABLE notification_deliveries (
+    id uuid NOT NULL,
+    message_id uuid NOT NULL,
+    address text NOT NULL,
+    status notification_delivery_status DEFAULT 'pending'::notification_delivery_status NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    last_error text DEFAULT ''::text NOT NULL,
+    next_attempt_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE notification_deliveries IS 'Log of the emails sent for notification messages.';
+
+COMMENT ON COLUMN notification_deliveries.next_attempt_at IS 'Pending deliveries are not attempted before this time. Acquiring a delivery moves it forward so that other replicas skip it.';
+
+CREATE TABLE notification_messages (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    event notification_event NOT NULL,
+    title text NOT NULL,
+    body text NOT NULL,
+    dedupe_key text NOT NULL,
+    inbox boolean NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    read_at timestamp with time zone
+);
+
+COMMENT ON COLUMN notification_messages.dedupe_key IS 'Identifies the occurrence of the event. A user is notified of an occurrence at most once.';
+
+COMMENT ON COLUMN notification_messages.inbox IS 'Whether the message is shown in the inbox of the user.';
+
+CREATE TABLE notification_preferences (
+    user_id uuid NOT NULL,
+    event notification_event NOT NULL,
+    email boolean NOT NULL,
+    inbox boolean NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE notification_preferences IS 'Users are notified of events without a preference by email and in their inbox.';
+
 CREATE TABLE organization_members (
     user_id uuid NOT NULL,
     organization_id uuid NOT NULL,
@@ -562,13 +664,22 @@ CREATE TABLE template_versions (
     job_id uuid NOT NULL,
     created_by uuid NOT NULL,
     git_auth_providers text[],
-    message character varying(1048576) DEFAULT ''::character varying NOT NULL
+    message character varying(1048576) DEFAULT ''::character varying NOT NULL,
+    git_url text DEFAULT ''::text NOT NULL,
+    git_commit_sha text DEFAULT ''::text NOT NULL,
+    archived boolean DEFAULT false NOT NULL
 );
 
 COMMENT ON COLUMN template_versions.git_auth_providers IS 'IDs of Git auth providers for a specific template version';
 
 COMMENT ON COLUMN template_versions.message IS 'Message describing the changes in this version of the template, similar to a Git commit message. Like a commit message, this should be a short, high-level description of the changes in this version of the template. This message is immutable and should not be updated after the fact.';
 
+COMMENT ON COLUMN template_versions.git_url IS 'URL of the Git repository the template version was pushed from, without credentials. Empty if it was not pushed from Git.';
+
+COMMENT ON COLUMN template_versions.git_commit_sha IS 'SHA of the Git commit the template version was pushed from. Empty if it was not pushed from Git.';
+
+COMMENT ON COLUMN template_versions.archived IS 'Archived versions are hidden from version lists by default and can''t be used for new workspace builds.';
+
 CREATE TABLE users (
     id uuid NOT NULL,
     email text NOT NULL,
@@ -607,6 +718,9 @@ CREATE VIEW template_version_with_user AS
     template_versions.created_by,
     template_versions.git_auth_providers,
     template_versions.message,
+    template_versions.git_url,
+    template_versions.git_commit_sha,
+    template_versions.archived,
     COALESCE(visible_users.avatar_url, ''::text) AS created_by_avatar_url,
     COALESCE(visible_users.username, ''::text) AS created_by_username
    FROM (public.template_versions
@@ -638,7 +752,10 @@ CREATE TABLE templates (
     inactivity_ttl bigint DEFAULT 0 NOT NULL,
     locked_ttl bigint DEFAULT 0 NOT NULL,
     restart_requirement_days_of_week smallint DEFAULT 0 NOT NULL,
-    restart_requirement_weeks bigint DEFAULT 0 NOT NULL
+    restart_requirement_weeks bigint DEFAULT 0 NOT NULL,
+    deprecated text DEFAULT ''::text NOT NULL,
+    replacement_template_id uuid,
+    require_active_version boolean DEFAULT false NOT NULL
 );
 
 COMMENT ON COLUMN templates.default_ttl IS 'The default duration for autostop for workspaces created from this template.';
@@ -655,6 +772,12 @@ COMMENT ON COLUMN templates.restart_requirement_days_of_week IS 'A bitmap of day
 
 COMMENT ON COLUMN templates.restart_requirement_weeks IS 'The number of weeks between restarts. 0 or 1 weeks means "every week", 2 week means "every second week", etc. Weeks are counted from January 2, 2023, which is the first Monday of 2023. This is to ensure workspaces are started consistently for all customers on the same n-week cycles.';
 
+COMMENT ON COLUMN templates.deprecated IS 'If set to a non empty string, the template will no longer be able to be used for new workspaces. The message explains why and is shown to users of existing workspaces.';
+
+COMMENT ON COLUMN templates.replacement_template_id IS 'The template users should move to from a deprecated template.';
+
+COMMENT ON COLUMN templates.require_active_version IS 'If set to true, start builds of workspaces created from this template always use the active template version.';
+
 CREATE VIEW template_with_users AS
  SELECT templates.id,
     templates.created_at,
@@ -680,6 +803,9 @@ CREATE VIEW template_with_users AS
     templates.locked_ttl,
     templates.restart_requirement_days_of_week,
     templates.restart_requirement_weeks,
+    templates.deprecated,
+    templates.replacement_template_id,
+    templates.require_active_version,
     COALESCE(visible_users.avatar_url, ''::text) AS created_by_avatar_url,
     COALESCE(visible_users.username, ''::text) AS created_by_username
    FROM (public.templates
@@ -879,6 +1005,32 @@ CREATE VIEW workspace_build_with_user AS
 
 COMMENT ON VIEW workspace_build_with_user IS 'Joins in the username + avatar url of the initiated by user.';
 
+CREATE TABLE workspace_bulk_operation_workspaces (
+    operation_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    status workspace_bulk_operation_workspace_status DEFAULT 'pending'::workspace_bulk_operation_workspace_status NOT NULL,
+    workspace_build_id uuid,
+    error text DEFAULT ''::text NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+CREATE TABLE workspace_bulk_operations (
+    id uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    initiator_id uuid NOT NULL,
+    transition workspace_bulk_operation_transition NOT NULL,
+    query text NOT NULL,
+    concurrency integer NOT NULL,
+    completed_at timestamp with time zone,
+    heartbeat_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON COLUMN workspace_bulk_operations.query IS 'Workspace search query that selected the workspaces of the operation.';
+
+COMMENT ON COLUMN workspace_bulk_operations.concurrency IS 'Maximum number of builds the operation runs at the same time.';
+
+COMMENT ON COLUMN workspace_bulk_operations.heartbeat_at IS 'Updated periodically by the replica running the operation. Operations without a recent heartbeat are completed by other replicas.';
+
 CREATE TABLE workspace_proxies (
     id uuid NOT NULL,
     name text NOT NULL,
@@ -960,9 +1112,18 @@ CREATE TABLE workspaces (
     ttl bigint,
     last_used_at timestamp without time zone DEFAULT '0001-01-01 00:00:00'::timestamp without time zone NOT NULL,
     locked_at timestamp with time zone,
-    deleting_at timestamp with time zone
+    deleting_at timestamp with time zone,
+    user_acl jsonb DEFAULT '{}'::jsonb NOT NULL,
+    group_acl jsonb DEFAULT '{}'::jsonb NOT NULL,
+    autostart_skip_until timestamp with time zone
 );
 
+COMMENT ON COLUMN workspaces.user_acl IS 'Users the workspace is shared with, mapped to the actions they are allowed to perform.';
+
+COMMENT ON COLUMN workspaces.group_acl IS 'Groups the workspace is shared with, mapped to the actions their members are allowed to perform.';
+
+COMMENT ON COLUMN workspaces.autostart_skip_until IS 'Scheduled autostarts up to and including this time are skipped.';
+
 ALTER TABLE ONLY licenses ALTER COLUMN id SET DEFAULT nextval('licenses_id_seq'::regclass);
 
 ALTER TABLE ONLY provisioner_job_logs ALTER COLUMN id SET DEFAULT nextval('provisioner_job_logs_id_seq'::regclass);
@@ -979,9 +1140,15 @@ ALTER TABLE ONLY workspace_agent_stats
 ALTER TABLE ONLY api_keys
     ADD CONSTRAINT api_keys_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY audit_log_checkpoints
+    ADD CONSTRAINT audit_log_checkpoints_pkey PRIMARY KEY (chain_sequence);
+
 ALTER TABLE ONLY audit_logs
     ADD CONSTRAINT audit_logs_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY autostart_exceptions
+    ADD CONSTRAINT autostart_exceptions_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY files
     ADD CONSTRAINT files_hash_created_by_key UNIQUE (hash, created_by);
 
@@ -1009,6 +1176,15 @@ ALTER TABLE ONLY licenses
 ALTER TABLE ONLY licenses
     ADD CONSTRAINT licenses_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY notification_deliveries
+    ADD CONSTRAINT notification_deliveries_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY notification_messages
+    ADD CONSTRAINT notification_messages_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY notification_preferences
+    ADD CONSTRAINT notification_preferences_pkey PRIMARY KEY (user_id, event);
+
 ALTER TABLE ONLY organization_members
     ADD CONSTRAINT organization_members_pkey PRIMARY KEY (organization_id, user_id);
 
@@ -1099,6 +1275,12 @@ ALTER TABLE ONLY workspace_builds
 ALTER TABLE ONLY workspace_builds
     ADD CONSTRAINT workspace_builds_workspace_id_build_number_key UNIQUE (workspace_id, build_number);
 
+ALTER TABLE ONLY workspace_bulk_operation_workspaces
+    ADD CONSTRAINT workspace_bulk_operation_workspaces_pkey PRIMARY KEY (operation_id, workspace_id);
+
+ALTER TABLE ONLY workspace_bulk_operations
+    ADD CONSTRAINT workspace_bulk_operations_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY workspace_proxies
     ADD CONSTRAINT workspace_proxies_pkey PRIMARY KEY (id);
 
@@ -1117,6 +1299,10 @@ ALTER TABLE ONLY workspace_resources
 ALTER TABLE ONLY workspaces
     ADD CONSTRAINT workspaces_pkey PRIMARY KEY (id);
 
+CREATE UNIQUE INDEX audit_logs_chain_sequence_idx ON audit_logs USING btree (chain_sequence) WHERE (chain_sequence IS NOT NULL);
+
+CREATE INDEX autostart_exceptions_template_id_idx ON autostart_exceptions USING btree (template_id);
+
 CREATE INDEX idx_agent_stats_created_at ON workspace_agent_stats USING btree (created_at);
 
 CREATE INDEX idx_agent_stats_user_id ON workspace_agent_stats USING btree (user_id);
@@ -1131,7 +1317,7 @@ CREATE INDEX idx_audit_log_resource_id ON audit_logs USING btree (resource_id);
 
 CREATE INDEX idx_audit_log_user_id ON audit_logs USING btree (user_id);
 
-CREATE INDEX idx_audit_logs_time_desc ON audit_logs USING btree ("time" DESC);
+CREATE INDEX idx_audit_logs_time_id_desc ON audit_logs USING btree ("time" DESC, id DESC);
 
 CREATE INDEX idx_organization_member_organization_id_uuid ON organization_members USING btree (organization_id);
 
@@ -1151,6 +1337,12 @@ CREATE UNIQUE INDEX idx_users_email ON users USING btree (email) WHERE (deleted
 
 CREATE UNIQUE INDEX idx_users_username ON users USING btree (username) WHERE (deleted = false);
 
+CREATE INDEX notification_deliveries_pending_idx ON notification_deliveries USING btree (next_attempt_at) WHERE (status = 'pending'::notification_delivery_status);
+
+CREATE INDEX notification_messages_user_id_created_at_idx ON notification_messages USING btree (user_id, created_at DESC);
+
+CREATE UNIQUE INDEX notification_messages_user_id_dedupe_key_idx ON notification_messages USING btree (user_id, dedupe_key);
+
 CREATE INDEX provisioner_job_logs_id_job_id_idx ON provisioner_job_logs USING btree (job_id, id);
 
 CREATE INDEX provisioner_jobs_started_at_idx ON provisioner_jobs USING btree (started_at) WHERE (started_at IS NULL);
@@ -1186,6 +1378,9 @@ CREATE TRIGGER trigger_update_users AFTER INSERT OR UPDATE ON users FOR EACH ROW
 ALTER TABLE ONLY api_keys
     ADD CONSTRAINT api_keys_user_id_uuid_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY autostart_exceptions
+    ADD CONSTRAINT autostart_exceptions_template_id_fkey FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY gitsshkeys
     ADD CONSTRAINT gitsshkeys_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
 
@@ -1198,6 +1393,15 @@ ALTER TABLE ONLY group_members
 ALTER TABLE ONLY groups
     ADD CONSTRAINT groups_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY notification_deliveries
+    ADD CONSTRAINT notification_deliveries_message_id_fkey FOREIGN KEY (message_id) REFERENCES notification_messages(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY notification_messages
+    ADD CONSTRAINT notification_messages_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY notification_preferences
+    ADD CONSTRAINT notification_preferences_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY organization_members
     ADD CONSTRAINT organization_members_organization_id_uuid_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
@@ -1240,6 +1444,9 @@ ALTER TABLE ONLY templates
 ALTER TABLE ONLY templates
     ADD CONSTRAINT templates_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY templates
+    ADD CONSTRAINT templates_replacement_template_id_fkey FOREIGN KEY (replacement_template_id) REFERENCES templates(id) ON DELETE SET NULL;
+
 ALTER TABLE ONLY user_links
     ADD CONSTRAINT user_links_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
@@ -1267,6 +1474,18 @@ ALTER TABLE ONLY workspace_builds
 ALTER TABLE ONLY workspace_builds
     ADD CONSTRAINT workspace_builds_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_bulk_operation_workspaces
+    ADD CONSTRAINT workspace_bulk_operation_workspaces_operation_id_fkey FOREIGN KEY (operation_id) REFERENCES workspace_bulk_operations(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_bulk_operation_workspaces
+    ADD CONSTRAINT workspace_bulk_operation_workspaces_workspace_build_id_fkey FOREIGN KEY (workspace_build_id) REFERENCES workspace_builds(id) ON DELETE SET NULL;
+
+ALTER TABLE ONLY workspace_bulk_operation_workspaces
+    ADD CONSTRAINT workspace_bulk_operation_workspaces_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_bulk_operations
+    ADD CONSTRAINT workspace_bulk_operations_initiator_id_fkey FOREIGN KEY (initiator_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspace_resource_metadata
     ADD CONSTRAINT workspace_resource_metadata_workspace_resource_id_fkey FOREIGN KEY (workspace_resource_id) REFERENCES workspace_resources(id) ON DELETE CASCADE;
 
@@ -0,0 +1,30 @@
+BEGIN;
+
+ALTER TABLE template_versions
+	ADD COLUMN git_url text NOT NULL DEFAULT '',
+	ADD COLUMN git_commit_sha text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN template_versions.git_url IS 'URL of the Git repository the template version was pushed from, without credentials. Empty if it was not pushed from Git.';
+COMMENT ON COLUMN template_versions.git_commit_sha IS 'SHA of the Git commit the template version was pushed from. Empty if it was not pushed from Git.';
+
+-- The view selects template_versions.*, so it has to be recreated to include
+-- the new columns.
+DROP VIEW template_version_with_user;
+
+CREATE VIEW
+	template_version_with_user
+AS
+SELECT
+	template_versions.*,
+	coalesce(visible_users.avatar_url, '') AS created_by_avatar_url,
+	coalesce(visible_users.username, '') AS created_by_username
+FROM
+	template_versions
+	LEFT JOIN
+		visible_users
+	ON
+		template_versions.created_by = visible_users.id;
+
+COMMENT ON VIEW template_version_with_user IS 'Joins in the username + avatar url of the created by user.';
+
+COMMIT;
@@ -0,0 +1,28 @@
+BEGIN;
+
+ALTER TABLE template_versions
+	ADD COLUMN archived boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN template_versions.archived IS 'Archived versions are hidden from version lists by default and can''t be used for new workspace builds.';
+
+-- The view selects template_versions.*, so it has to be recreated to include
+-- the new column.
+DROP VIEW template_version_with_user;
+
+CREATE VIEW
+	template_version_with_user
+AS
+SELECT
+	template_versions.*,
+	coalesce(visible_users.avatar_url, '') AS created_by_avatar_url,
+	coalesce(visible_users.username, '') AS created_by_username
+FROM
+	template_versions
+	LEFT JOIN
+		visible_users
+	ON
+		template_versions.created_by = visible_users.id;
+
+COMMENT ON VIEW template_version_with_user IS 'Joins in the username + avatar url of the created by user.';
+
+COMMIT;
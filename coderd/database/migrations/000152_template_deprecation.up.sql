@@ -0,0 +1,27 @@
+BEGIN;
+
+ALTER TABLE templates
+	ADD COLUMN deprecated text NOT NULL DEFAULT '',
+	ADD COLUMN replacement_template_id uuid NULL REFERENCES templates(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN templates.deprecated IS 'If set to a non empty string, the template will no longer be able to be used for new workspaces. The message explains why and is shown to users of existing workspaces.';
+COMMENT ON COLUMN templates.replacement_template_id IS 'The template users should move to from a deprecated template.';
+
+-- Update the template_with_users view by recreating it.
+DROP VIEW template_with_users;
+CREATE VIEW
+    template_with_users
+AS
+    SELECT
+        templates.*,
+		coalesce(visible_users.avatar_url, '') AS created_by_avatar_url,
+		coalesce(visible_users.username, '') AS created_by_username
+    FROM
+        templates
+    LEFT JOIN
+		visible_users
+	ON
+	    templates.created_by = visible_users.id;
+COMMENT ON VIEW template_with_users IS 'Joins in the username + avatar url of the created by user.';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE workspaces DROP COLUMN group_acl;
+ALTER TABLE workspaces DROP COLUMN user_acl;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE workspaces ADD COLUMN user_acl jsonb NOT NULL default '{}';
+ALTER TABLE workspaces ADD COLUMN group_acl jsonb NOT NULL default '{}';
+
+COMMENT ON COLUMN workspaces.user_acl IS 'Users the workspace is shared with, mapped to the actions they are allowed to perform.';
+COMMENT ON COLUMN workspaces.group_acl IS 'Groups the workspace is shared with, mapped to the actions their members are allowed to perform.';
+
+COMMIT;
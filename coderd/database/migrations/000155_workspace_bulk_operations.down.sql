@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE workspace_bulk_operation_workspaces;
+DROP TABLE workspace_bulk_operations;
+DROP TYPE workspace_bulk_operation_workspace_status;
+DROP TYPE workspace_bulk_operation_transition;
+
+COMMIT;
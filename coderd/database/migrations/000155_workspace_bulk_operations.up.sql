@@ -0,0 +1,43 @@
+BEGIN;
+
+CREATE TYPE workspace_bulk_operation_transition AS ENUM (
+	'start',
+	'stop',
+	'update',
+	'delete'
+);
+
+CREATE TYPE workspace_bulk_operation_workspace_status AS ENUM (
+	'pending',
+	'building',
+	'succeeded',
+	'failed',
+	'skipped'
+);
+
+CREATE TABLE workspace_bulk_operations (
+	id uuid NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	initiator_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	transition workspace_bulk_operation_transition NOT NULL,
+	query text NOT NULL,
+	concurrency integer NOT NULL,
+	completed_at timestamp with time zone,
+	PRIMARY KEY (id)
+);
+
+COMMENT ON COLUMN workspace_bulk_operations.query IS 'Workspace search query that selected the workspaces of the operation.';
+
+COMMENT ON COLUMN workspace_bulk_operations.concurrency IS 'Maximum number of builds the operation runs at the same time.';
+
+CREATE TABLE workspace_bulk_operation_workspaces (
+	operation_id uuid NOT NULL REFERENCES workspace_bulk_operations (id) ON DELETE CASCADE,
+	workspace_id uuid NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+	status workspace_bulk_operation_workspace_status NOT NULL DEFAULT 'pending',
+	workspace_build_id uuid REFERENCES workspace_builds (id) ON DELETE SET NULL,
+	error text NOT NULL DEFAULT '',
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY (operation_id, workspace_id)
+);
+
+COMMIT;
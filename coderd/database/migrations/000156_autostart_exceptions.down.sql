@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE workspaces DROP COLUMN autostart_skip_until;
+DROP TABLE autostart_exceptions;
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE autostart_exceptions (
+	id uuid NOT NULL,
+	template_id uuid REFERENCES templates (id) ON DELETE CASCADE,
+	name text NOT NULL,
+	starts_at timestamp with time zone NOT NULL,
+	ends_at timestamp with time zone NOT NULL,
+	all_day boolean NOT NULL DEFAULT false,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY (id)
+);
+
+COMMENT ON TABLE autostart_exceptions IS 'Periods in which workspaces are not automatically started. Exceptions without a template apply to the whole deployment.';
+
+COMMENT ON COLUMN autostart_exceptions.all_day IS 'All-day exceptions cover the dates from starts_at up to ends_at in the time zone of each autostart schedule.';
+
+CREATE INDEX autostart_exceptions_template_id_idx ON autostart_exceptions (template_id);
+
+ALTER TABLE workspaces ADD COLUMN autostart_skip_until timestamp with time zone;
+
+COMMENT ON COLUMN workspaces.autostart_skip_until IS 'Scheduled autostarts up to and including this time are skipped.';
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE notification_preferences;
+DROP TABLE notification_deliveries;
+DROP TABLE notification_messages;
+DROP TYPE notification_delivery_status;
+DROP TYPE notification_event;
+
+COMMIT;
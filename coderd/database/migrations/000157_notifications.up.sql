@@ -0,0 +1,68 @@
+BEGIN;
+
+CREATE TYPE notification_event AS ENUM (
+	'workspace_build_failed',
+	'workspace_autostop',
+	'workspace_locked',
+	'template_version_promoted',
+	'user_suspended'
+);
+
+CREATE TYPE notification_delivery_status AS ENUM (
+	'pending',
+	'sent',
+	'failed'
+);
+
+CREATE TABLE notification_messages (
+	id uuid NOT NULL,
+	user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	event notification_event NOT NULL,
+	title text NOT NULL,
+	body text NOT NULL,
+	dedupe_key text NOT NULL,
+	inbox boolean NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	read_at timestamp with time zone,
+	PRIMARY KEY (id)
+);
+
+COMMENT ON COLUMN notification_messages.dedupe_key IS 'Identifies the occurrence of the event. A user is notified of an occurrence at most once.';
+
+COMMENT ON COLUMN notification_messages.inbox IS 'Whether the message is shown in the inbox of the user.';
+
+CREATE UNIQUE INDEX notification_messages_user_id_dedupe_key_idx ON notification_messages (user_id, dedupe_key);
+
+CREATE INDEX notification_messages_user_id_created_at_idx ON notification_messages (user_id, created_at DESC);
+
+CREATE TABLE notification_deliveries (
+	id uuid NOT NULL,
+	message_id uuid NOT NULL REFERENCES notification_messages (id) ON DELETE CASCADE,
+	address text NOT NULL,
+	status notification_delivery_status NOT NULL DEFAULT 'pending',
+	attempts integer NOT NULL DEFAULT 0,
+	last_error text NOT NULL DEFAULT '',
+	next_attempt_at timestamp with time zone NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY (id)
+);
+
+COMMENT ON TABLE notification_deliveries IS 'Log of the emails sent for notification messages.';
+
+COMMENT ON COLUMN notification_deliveries.next_attempt_at IS 'Pending deliveries are not attempted before this time. Acquiring a delivery moves it forward so that other replicas skip it.';
+
+CREATE INDEX notification_deliveries_pending_idx ON notification_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE notification_preferences (
+	user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	event notification_event NOT NULL,
+	email boolean NOT NULL,
+	inbox boolean NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY (user_id, event)
+);
+
+COMMENT ON TABLE notification_preferences IS 'Users are notified of events without a preference by email and in their inbox.';
+
+COMMIT;
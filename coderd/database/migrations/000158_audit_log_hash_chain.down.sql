@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE audit_log_checkpoints;
+DROP INDEX audit_logs_chain_sequence_idx;
+ALTER TABLE audit_logs
+	DROP COLUMN chain_hash,
+	DROP COLUMN chain_sequence;
+
+COMMIT;
@@ -0,0 +1,25 @@
+BEGIN;
+
+ALTER TABLE audit_logs
+	ADD COLUMN chain_sequence bigint,
+	ADD COLUMN chain_hash bytea;
+
+COMMENT ON COLUMN audit_logs.chain_sequence IS 'Position of the audit log in the hash chain. NULL if the audit log was inserted while the hash chain was disabled.';
+
+COMMENT ON COLUMN audit_logs.chain_hash IS 'SHA-256 hash over the chain_hash of the previous audit log in the chain and the contents of this audit log.';
+
+CREATE UNIQUE INDEX audit_logs_chain_sequence_idx ON audit_logs (chain_sequence) WHERE chain_sequence IS NOT NULL;
+
+CREATE TABLE audit_log_checkpoints (
+	chain_sequence bigint NOT NULL,
+	chain_hash bytea NOT NULL,
+	signature bytea NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY (chain_sequence)
+);
+
+COMMENT ON TABLE audit_log_checkpoints IS 'Signed snapshots of the head of the audit log hash chain. They detect audit logs that were deleted from the end of the chain.';
+
+COMMENT ON COLUMN audit_log_checkpoints.signature IS 'HMAC-SHA256 of the chain sequence and hash, keyed with a secret that is not stored in the database.';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE workspace_bulk_operations
+	DROP COLUMN heartbeat_at;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE workspace_bulk_operations
+	ADD COLUMN heartbeat_at timestamp with time zone NOT NULL DEFAULT now();
+
+COMMENT ON COLUMN workspace_bulk_operations.heartbeat_at IS 'Updated periodically by the replica running the operation. Operations without a recent heartbeat are completed by other replicas.';
+
+COMMIT;
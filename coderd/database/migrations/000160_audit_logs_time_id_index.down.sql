@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX idx_audit_logs_time_id_desc;
+CREATE INDEX idx_audit_logs_time_desc ON audit_logs USING btree ("time" DESC);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Audit log exports page through audit logs by ("time", id).
+DROP INDEX idx_audit_logs_time_desc;
+CREATE INDEX idx_audit_logs_time_id_desc ON audit_logs USING btree ("time" DESC, id DESC);
+
+COMMIT;
@@ -0,0 +1,24 @@
+INSERT INTO workspace_bulk_operations
+	(id, created_at, initiator_id, transition, query, concurrency, completed_at)
+VALUES
+	(
+		'b3ac6b2e-7d2b-4b0e-8c7a-2d3d5f1c9e41',
+		'2023-08-01 12:00:00.000+02',
+		'30095c71-380b-457a-8995-97b8ee6e5307',
+		'stop',
+		'owner:admin',
+		5,
+		'2023-08-01 12:05:00.000+02'
+	);
+
+INSERT INTO workspace_bulk_operation_workspaces
+	(operation_id, workspace_id, status, workspace_build_id, error, updated_at)
+VALUES
+	(
+		'b3ac6b2e-7d2b-4b0e-8c7a-2d3d5f1c9e41',
+		'3a9a1feb-e89d-457c-9d53-ac751b198ebe',
+		'succeeded',
+		'c1d2c9d5-6f30-4cd0-9ac5-6bf1c6039988',
+		'',
+		'2023-08-01 12:05:00.000+02'
+	);
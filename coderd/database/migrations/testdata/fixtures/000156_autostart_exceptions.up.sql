@@ -0,0 +1,21 @@
+INSERT INTO autostart_exceptions
+	(id, template_id, name, starts_at, ends_at, all_day, created_at)
+VALUES
+	(
+		'8f2a9c4e-3b1d-4f6a-9e7c-2d5b8a1f0c63',
+		NULL,
+		'New Year''s Day',
+		'2024-01-01 00:00:00+00',
+		'2024-01-02 00:00:00+00',
+		true,
+		'2023-08-01 12:00:00.000+02'
+	),
+	(
+		'1c7e5d2b-9a4f-4e8b-b3d6-7f0a2c9e5b14',
+		'4cc1f466-f326-477e-8762-9d0c6781fc56',
+		'Team offsite',
+		'2023-09-12 06:00:00+00',
+		'2023-09-14 18:00:00+00',
+		false,
+		'2023-08-01 12:00:00.000+02'
+	);
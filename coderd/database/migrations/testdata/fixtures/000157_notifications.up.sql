@@ -0,0 +1,40 @@
+INSERT INTO notification_messages
+	(id, user_id, event, title, body, dedupe_key, inbox, created_at, read_at)
+VALUES
+	(
+		'5a3c9e1f-7b2d-4c8a-9f6e-1d4b7a2c8e30',
+		'0ed9befc-4911-4ccf-a8e2-559bf72daa94',
+		'user_suspended',
+		'Your account has been suspended',
+		'Your account oauthuser1 has been suspended.',
+		'user_suspended:2023-08-01T10:00:00Z',
+		true,
+		'2023-08-01 12:00:00.000+02',
+		NULL
+	);
+
+INSERT INTO notification_deliveries
+	(id, message_id, address, status, attempts, last_error, next_attempt_at, created_at, updated_at)
+VALUES
+	(
+		'b9e4d2a7-1c6f-4e3b-8a5d-0f7c2e9b4a16',
+		'5a3c9e1f-7b2d-4c8a-9f6e-1d4b7a2c8e30',
+		'oauthuser1@coder.com',
+		'sent',
+		1,
+		'',
+		'2023-08-01 12:00:00.000+02',
+		'2023-08-01 12:00:00.000+02',
+		'2023-08-01 12:00:01.000+02'
+	);
+
+INSERT INTO notification_preferences
+	(user_id, event, email, inbox, updated_at)
+VALUES
+	(
+		'0ed9befc-4911-4ccf-a8e2-559bf72daa94',
+		'workspace_autostop',
+		false,
+		true,
+		'2023-08-01 12:00:00.000+02'
+	);
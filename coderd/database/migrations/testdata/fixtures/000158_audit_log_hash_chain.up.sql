@@ -0,0 +1,9 @@
+INSERT INTO audit_log_checkpoints
+	(chain_sequence, chain_hash, signature, created_at)
+VALUES
+	(
+		1,
+		'\x9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08',
+		'\x5f4dcc3b5aa765d61d8327deb882cf992b95990a9151374abd8ff8c5a7a0fe08',
+		'2023-08-01 12:00:00.000+02'
+	);
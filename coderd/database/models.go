@@ -532,6 +532,134 @@ func AllLoginTypeValues() []LoginType {
 	}
 }
 
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryStatusPending NotificationDeliveryStatus = "pending"
+	NotificationDeliveryStatusSent    NotificationDeliveryStatus = "sent"
+	NotificationDeliveryStatusFailed  NotificationDeliveryStatus = "failed"
+)
+
+func (e *NotificationDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = NotificationDeliveryStatus(s)
+	case string:
+		*e = NotificationDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for NotificationDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullNotificationDeliveryStatus struct {
+	NotificationDeliveryStatus NotificationDeliveryStatus `json:"notification_delivery_status"`
+	Valid                      bool                       `json:"valid"` // Valid is true if NotificationDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullNotificationDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.NotificationDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.NotificationDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullNotificationDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.NotificationDeliveryStatus), nil
+}
+
+func (e NotificationDeliveryStatus) Valid() bool {
+	switch e {
+	case NotificationDeliveryStatusPending,
+		NotificationDeliveryStatusSent,
+		NotificationDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func AllNotificationDeliveryStatusValues() []NotificationDeliveryStatus {
+	return []NotificationDeliveryStatus{
+		NotificationDeliveryStatusPending,
+		NotificationDeliveryStatusSent,
+		NotificationDeliveryStatusFailed,
+	}
+}
+
+type NotificationEvent string
+
+const (
+	NotificationEventWorkspaceBuildFailed    NotificationEvent = "workspace_build_failed"
+	NotificationEventWorkspaceAutostop       NotificationEvent = "workspace_autostop"
+	NotificationEventWorkspaceLocked         NotificationEvent = "workspace_locked"
+	NotificationEventTemplateVersionPromoted NotificationEvent = "template_version_promoted"
+	NotificationEventUserSuspended           NotificationEvent = "user_suspended"
+)
+
+func (e *NotificationEvent) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = NotificationEvent(s)
+	case string:
+		*e = NotificationEvent(s)
+	default:
+		return fmt.Errorf("unsupported scan type for NotificationEvent: %T", src)
+	}
+	return nil
+}
+
+type NullNotificationEvent struct {
+	NotificationEvent NotificationEvent `json:"notification_event"`
+	Valid             bool              `json:"valid"` // Valid is true if NotificationEvent is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullNotificationEvent) Scan(value interface{}) error {
+	if value == nil {
+		ns.NotificationEvent, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.NotificationEvent.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullNotificationEvent) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.NotificationEvent), nil
+}
+
+func (e NotificationEvent) Valid() bool {
+	switch e {
+	case NotificationEventWorkspaceBuildFailed,
+		NotificationEventWorkspaceAutostop,
+		NotificationEventWorkspaceLocked,
+		NotificationEventTemplateVersionPromoted,
+		NotificationEventUserSuspended:
+		return true
+	}
+	return false
+}
+
+func AllNotificationEventValues() []NotificationEvent {
+	return []NotificationEvent{
+		NotificationEventWorkspaceBuildFailed,
+		NotificationEventWorkspaceAutostop,
+		NotificationEventWorkspaceLocked,
+		NotificationEventTemplateVersionPromoted,
+		NotificationEventUserSuspended,
+	}
+}
+
 type ParameterDestinationScheme string
 
 const (
@@ -1429,6 +1557,137 @@ func AllWorkspaceAppHealthValues() []WorkspaceAppHealth {
 	}
 }
 
+type WorkspaceBulkOperationTransition string
+
+const (
+	WorkspaceBulkOperationTransitionStart  WorkspaceBulkOperationTransition = "start"
+	WorkspaceBulkOperationTransitionStop   WorkspaceBulkOperationTransition = "stop"
+	WorkspaceBulkOperationTransitionUpdate WorkspaceBulkOperationTransition = "update"
+	WorkspaceBulkOperationTransitionDelete WorkspaceBulkOperationTransition = "delete"
+)
+
+func (e *WorkspaceBulkOperationTransition) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WorkspaceBulkOperationTransition(s)
+	case string:
+		*e = WorkspaceBulkOperationTransition(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WorkspaceBulkOperationTransition: %T", src)
+	}
+	return nil
+}
+
+type NullWorkspaceBulkOperationTransition struct {
+	WorkspaceBulkOperationTransition WorkspaceBulkOperationTransition `json:"workspace_bulk_operation_transition"`
+	Valid                            bool                             `json:"valid"` // Valid is true if WorkspaceBulkOperationTransition is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWorkspaceBulkOperationTransition) Scan(value interface{}) error {
+	if value == nil {
+		ns.WorkspaceBulkOperationTransition, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WorkspaceBulkOperationTransition.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWorkspaceBulkOperationTransition) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WorkspaceBulkOperationTransition), nil
+}
+
+func (e WorkspaceBulkOperationTransition) Valid() bool {
+	switch e {
+	case WorkspaceBulkOperationTransitionStart,
+		WorkspaceBulkOperationTransitionStop,
+		WorkspaceBulkOperationTransitionUpdate,
+		WorkspaceBulkOperationTransitionDelete:
+		return true
+	}
+	return false
+}
+
+func AllWorkspaceBulkOperationTransitionValues() []WorkspaceBulkOperationTransition {
+	return []WorkspaceBulkOperationTransition{
+		WorkspaceBulkOperationTransitionStart,
+		WorkspaceBulkOperationTransitionStop,
+		WorkspaceBulkOperationTransitionUpdate,
+		WorkspaceBulkOperationTransitionDelete,
+	}
+}
+
+type WorkspaceBulkOperationWorkspaceStatus string
+
+const (
+	WorkspaceBulkOperationWorkspaceStatusPending   WorkspaceBulkOperationWorkspaceStatus = "pending"
+	WorkspaceBulkOperationWorkspaceStatusBuilding  WorkspaceBulkOperationWorkspaceStatus = "building"
+	WorkspaceBulkOperationWorkspaceStatusSucceeded WorkspaceBulkOperationWorkspaceStatus = "succeeded"
+	WorkspaceBulkOperationWorkspaceStatusFailed    WorkspaceBulkOperationWorkspaceStatus = "failed"
+	WorkspaceBulkOperationWorkspaceStatusSkipped   WorkspaceBulkOperationWorkspaceStatus = "skipped"
+)
+
+func (e *WorkspaceBulkOperationWorkspaceStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WorkspaceBulkOperationWorkspaceStatus(s)
+	case string:
+		*e = WorkspaceBulkOperationWorkspaceStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WorkspaceBulkOperationWorkspaceStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWorkspaceBulkOperationWorkspaceStatus struct {
+	WorkspaceBulkOperationWorkspaceStatus WorkspaceBulkOperationWorkspaceStatus `json:"workspace_bulk_operation_workspace_status"`
+	Valid                                 bool                                  `json:"valid"` // Valid is true if WorkspaceBulkOperationWorkspaceStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWorkspaceBulkOperationWorkspaceStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WorkspaceBulkOperationWorkspaceStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WorkspaceBulkOperationWorkspaceStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWorkspaceBulkOperationWorkspaceStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WorkspaceBulkOperationWorkspaceStatus), nil
+}
+
+func (e WorkspaceBulkOperationWorkspaceStatus) Valid() bool {
+	switch e {
+	case WorkspaceBulkOperationWorkspaceStatusPending,
+		WorkspaceBulkOperationWorkspaceStatusBuilding,
+		WorkspaceBulkOperationWorkspaceStatusSucceeded,
+		WorkspaceBulkOperationWorkspaceStatusFailed,
+		WorkspaceBulkOperationWorkspaceStatusSkipped:
+		return true
+	}
+	return false
+}
+
+func AllWorkspaceBulkOperationWorkspaceStatusValues() []WorkspaceBulkOperationWorkspaceStatus {
+	return []WorkspaceBulkOperationWorkspaceStatus{
+		WorkspaceBulkOperationWorkspaceStatusPending,
+		WorkspaceBulkOperationWorkspaceStatusBuilding,
+		WorkspaceBulkOperationWorkspaceStatusSucceeded,
+		WorkspaceBulkOperationWorkspaceStatusFailed,
+		WorkspaceBulkOperationWorkspaceStatusSkipped,
+	}
+}
+
 type WorkspaceTransition string
 
 const (
@@ -1522,6 +1781,31 @@ type AuditLog struct {
 	AdditionalFields json.RawMessage `db:"additional_fields" json:"additional_fields"`
 	RequestID        uuid.UUID       `db:"request_id" json:"request_id"`
 	ResourceIcon     string          `db:"resource_icon" json:"resource_icon"`
+	// Position of the audit log in the hash chain. NULL if the audit log was inserted while the hash chain was disabled.
+	ChainSequence sql.NullInt64 `db:"chain_sequence" json:"chain_sequence"`
+	// SHA-256 hash over the chain_hash of the previous audit log in the chain and the contents of this audit log.
+	ChainHash []byte `db:"chain_hash" json:"chain_hash"`
+}
+
+// Signed snapshots of the head of the audit log hash chain. They detect audit logs that were deleted from the end of the chain.
+type AuditLogCheckpoint struct {
+	ChainSequence int64  `db:"chain_sequence" json:"chain_sequence"`
+	ChainHash     []byte `db:"chain_hash" json:"chain_hash"`
+	// HMAC-SHA256 of the chain sequence and hash, keyed with a secret that is not stored in the database.
+	Signature []byte    `db:"signature" json:"signature"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// Periods in which workspaces are not automatically started. Exceptions without a template apply to the whole deployment.
+type AutostartException struct {
+	ID         uuid.UUID     `db:"id" json:"id"`
+	TemplateID uuid.NullUUID `db:"template_id" json:"template_id"`
+	Name       string        `db:"name" json:"name"`
+	StartsAt   time.Time     `db:"starts_at" json:"starts_at"`
+	EndsAt     time.Time     `db:"ends_at" json:"ends_at"`
+	// All-day exceptions cover the dates from starts_at up to ends_at in the time zone of each autostart schedule.
+	AllDay    bool      `db:"all_day" json:"all_day"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
 }
 
 type File struct {
@@ -1577,6 +1861,41 @@ type License struct {
 	UUID uuid.UUID `db:"uuid" json:"uuid"`
 }
 
+type NotificationDelivery struct {
+	ID        uuid.UUID                  `db:"id" json:"id"`
+	MessageID uuid.UUID                  `db:"message_id" json:"message_id"`
+	Address   string                     `db:"address" json:"address"`
+	Status    NotificationDeliveryStatus `db:"status" json:"status"`
+	Attempts  int32                      `db:"attempts" json:"attempts"`
+	LastError string                     `db:"last_error" json:"last_error"`
+	// Pending deliveries are not attempted before this time. Acquiring a delivery moves it forward so that other replicas skip it.
+	NextAttemptAt time.Time `db:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type NotificationMessage struct {
+	ID     uuid.UUID         `db:"id" json:"id"`
+	UserID uuid.UUID         `db:"user_id" json:"user_id"`
+	Event  NotificationEvent `db:"event" json:"event"`
+	Title  string            `db:"title" json:"title"`
+	Body   string            `db:"body" json:"body"`
+	// Identifies the occurrence of the event. A user is notified of an occurrence at most once.
+	DedupeKey string `db:"dedupe_key" json:"dedupe_key"`
+	// Whether the message is shown in the inbox of the user.
+	Inbox     bool         `db:"inbox" json:"inbox"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+	ReadAt    sql.NullTime `db:"read_at" json:"read_at"`
+}
+
+type NotificationPreference struct {
+	UserID    uuid.UUID         `db:"user_id" json:"user_id"`
+	Event     NotificationEvent `db:"event" json:"event"`
+	Email     bool              `db:"email" json:"email"`
+	Inbox     bool              `db:"inbox" json:"inbox"`
+	UpdatedAt time.Time         `db:"updated_at" json:"updated_at"`
+}
+
 type Organization struct {
 	ID          uuid.UUID `db:"id" json:"id"`
 	Name        string    `db:"name" json:"name"`
@@ -1733,6 +2052,9 @@ type Template struct {
 	LockedTTL                    int64           `db:"locked_ttl" json:"locked_ttl"`
 	RestartRequirementDaysOfWeek int16           `db:"restart_requirement_days_of_week" json:"restart_requirement_days_of_week"`
 	RestartRequirementWeeks      int64           `db:"restart_requirement_weeks" json:"restart_requirement_weeks"`
+	Deprecated                   string          `db:"deprecated" json:"deprecated"`
+	ReplacementTemplateID        uuid.NullUUID   `db:"replacement_template_id" json:"replacement_template_id"`
+	RequireActiveVersion         bool            `db:"require_active_version" json:"require_active_version"`
 	CreatedByAvatarURL           sql.NullString  `db:"created_by_avatar_url" json:"created_by_avatar_url"`
 	CreatedByUsername            string          `db:"created_by_username" json:"created_by_username"`
 }
@@ -1769,6 +2091,12 @@ type TemplateTable struct {
 	RestartRequirementDaysOfWeek int16 `db:"restart_requirement_days_of_week" json:"restart_requirement_days_of_week"`
 	// The number of weeks between restarts. 0 or 1 weeks means "every week", 2 week means "every second week", etc. Weeks are counted from January 2, 2023, which is the first Monday of 2023. This is to ensure workspaces are started consistently for all customers on the same n-week cycles.
 	RestartRequirementWeeks int64 `db:"restart_requirement_weeks" json:"restart_requirement_weeks"`
+	// If set to a non empty string, the template will no longer be able to be used for new workspaces. The message explains why and is shown to users of existing workspaces.
+	Deprecated string `db:"deprecated" json:"deprecated"`
+	// The template users should move to from a deprecated template.
+	ReplacementTemplateID uuid.NullUUID `db:"replacement_template_id" json:"replacement_template_id"`
+	// If set to true, start builds of workspaces created from this template always use the active template version.
+	RequireActiveVersion bool `db:"require_active_version" json:"require_active_version"`
 }
 
 // Joins in the username + avatar url of the created by user.
@@ -1784,6 +2112,9 @@ type TemplateVersion struct {
 	CreatedBy          uuid.UUID      `db:"created_by" json:"created_by"`
 	GitAuthProviders   []string       `db:"git_auth_providers" json:"git_auth_providers"`
 	Message            string         `db:"message" json:"message"`
+	GitURL             string         `db:"git_url" json:"git_url"`
+	GitCommitSHA       string         `db:"git_commit_sha" json:"git_commit_sha"`
+	Archived           bool           `db:"archived" json:"archived"`
 	CreatedByAvatarURL sql.NullString `db:"created_by_avatar_url" json:"created_by_avatar_url"`
 	CreatedByUsername  string         `db:"created_by_username" json:"created_by_username"`
 }
@@ -1838,6 +2169,12 @@ type TemplateVersionTable struct {
 	GitAuthProviders []string `db:"git_auth_providers" json:"git_auth_providers"`
 	// Message describing the changes in this version of the template, similar to a Git commit message. Like a commit message, this should be a short, high-level description of the changes in this version of the template. This message is immutable and should not be updated after the fact.
 	Message string `db:"message" json:"message"`
+	// URL of the Git repository the template version was pushed from, without credentials. Empty if it was not pushed from Git.
+	GitURL string `db:"git_url" json:"git_url"`
+	// SHA of the Git commit the template version was pushed from. Empty if it was not pushed from Git.
+	GitCommitSHA string `db:"git_commit_sha" json:"git_commit_sha"`
+	// Archived versions are hidden from version lists by default and can't be used for new workspace builds.
+	Archived bool `db:"archived" json:"archived"`
 }
 
 type TemplateVersionVariable struct {
@@ -1905,6 +2242,12 @@ type Workspace struct {
 	LastUsedAt        time.Time      `db:"last_used_at" json:"last_used_at"`
 	LockedAt          sql.NullTime   `db:"locked_at" json:"locked_at"`
 	DeletingAt        sql.NullTime   `db:"deleting_at" json:"deleting_at"`
+	// Users the workspace is shared with, mapped to the actions they are allowed to perform.
+	UserACL WorkspaceACL `db:"user_acl" json:"user_acl"`
+	// Groups the workspace is shared with, mapped to the actions their members are allowed to perform.
+	GroupACL WorkspaceACL `db:"group_acl" json:"group_acl"`
+	// Scheduled autostarts up to and including this time are skipped.
+	AutostartSkipUntil sql.NullTime `db:"autostart_skip_until" json:"autostart_skip_until"`
 }
 
 type WorkspaceAgent struct {
@@ -2061,6 +2404,29 @@ type WorkspaceBuildTable struct {
 	MaxDeadline       time.Time           `db:"max_deadline" json:"max_deadline"`
 }
 
+type WorkspaceBulkOperation struct {
+	ID          uuid.UUID                        `db:"id" json:"id"`
+	CreatedAt   time.Time                        `db:"created_at" json:"created_at"`
+	InitiatorID uuid.UUID                        `db:"initiator_id" json:"initiator_id"`
+	Transition  WorkspaceBulkOperationTransition `db:"transition" json:"transition"`
+	// Workspace search query that selected the workspaces of the operation.
+	Query string `db:"query" json:"query"`
+	// Maximum number of builds the operation runs at the same time.
+	Concurrency int32        `db:"concurrency" json:"concurrency"`
+	CompletedAt sql.NullTime `db:"completed_at" json:"completed_at"`
+	// Updated periodically by the replica running the operation. Operations without a recent heartbeat are completed by other replicas.
+	HeartbeatAt time.Time `db:"heartbeat_at" json:"heartbeat_at"`
+}
+
+type WorkspaceBulkOperationWorkspace struct {
+	OperationID      uuid.UUID                             `db:"operation_id" json:"operation_id"`
+	WorkspaceID      uuid.UUID                             `db:"workspace_id" json:"workspace_id"`
+	Status           WorkspaceBulkOperationWorkspaceStatus `db:"status" json:"status"`
+	WorkspaceBuildID uuid.NullUUID                         `db:"workspace_build_id" json:"workspace_build_id"`
+	Error            string                                `db:"error" json:"error"`
+	UpdatedAt        time.Time                             `db:"updated_at" json:"updated_at"`
+}
+
 type WorkspaceProxy struct {
 	ID          uuid.UUID `db:"id" json:"id"`
 	Name        string    `db:"name" json:"name"`
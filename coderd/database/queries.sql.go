@@ -349,9 +349,333 @@ func (q *sqlQuerier) UpdateAPIKeyByID(ctx context.Context, arg UpdateAPIKeyByIDP
 	return err
 }
 
+const deleteOldAuditLogCheckpoints = `-- name: DeleteOldAuditLogCheckpoints :execrows
+DELETE FROM
+	audit_log_checkpoints
+WHERE
+	chain_sequence < (
+		SELECT
+			MIN(chain_sequence)
+		FROM
+			audit_logs
+	) - 1
+`
+
+// Deletes checkpoints of purged audit logs. The checkpoint right before the
+// oldest remaining chained audit log is kept, since it anchors the chain.
+func (q *sqlQuerier) DeleteOldAuditLogCheckpoints(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteOldAuditLogCheckpoints)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteOldAuditLogs = `-- name: DeleteOldAuditLogs :execrows
+DELETE FROM
+	audit_logs
+WHERE
+	id IN (
+		SELECT
+			id
+		FROM
+			audit_logs
+		WHERE
+			(chain_sequence IS NULL AND "time" < $1 :: timestamptz)
+			OR chain_sequence <= (
+				SELECT
+					MAX(chain_sequence)
+				FROM
+					audit_log_checkpoints
+				WHERE
+					created_at < $1 :: timestamptz
+					AND chain_sequence < (
+						SELECT
+							MAX(chain_sequence)
+						FROM
+							audit_logs
+					)
+			)
+		ORDER BY
+			chain_sequence ASC NULLS FIRST
+		LIMIT
+			$2
+	)
+`
+
+type DeleteOldAuditLogsParams struct {
+	BeforeTime time.Time `db:"before_time" json:"before_time"`
+	LimitCount int32     `db:"limit_count" json:"limit_count"`
+}
+
+// Unchained audit logs are deleted by age. Chained audit logs are deleted up
+// to and including the newest checkpoint created before the cutoff, so that
+// the remaining chain starts right after a signed anchor. That checkpoint must
+// come before the head of the chain, so that new audit logs continue its
+// sequence.
+func (q *sqlQuerier) DeleteOldAuditLogs(ctx context.Context, arg DeleteOldAuditLogsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteOldAuditLogs, arg.BeforeTime, arg.LimitCount)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAuditLogCheckpoints = `-- name: GetAuditLogCheckpoints :many
+SELECT
+	chain_sequence, chain_hash, signature, created_at
+FROM
+	audit_log_checkpoints
+ORDER BY
+	chain_sequence ASC
+`
+
+func (q *sqlQuerier) GetAuditLogCheckpoints(ctx context.Context) ([]AuditLogCheckpoint, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditLogCheckpoints)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLogCheckpoint
+	for rows.Next() {
+		var i AuditLogCheckpoint
+		if err := rows.Scan(
+			&i.ChainSequence,
+			&i.ChainHash,
+			&i.Signature,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuditLogsForExport = `-- name: GetAuditLogsForExport :many
+SELECT
+    audit_logs.id, audit_logs.time, audit_logs.user_id, audit_logs.organization_id, audit_logs.ip, audit_logs.user_agent, audit_logs.resource_type, audit_logs.resource_id, audit_logs.resource_target, audit_logs.action, audit_logs.diff, audit_logs.status_code, audit_logs.additional_fields, audit_logs.request_id, audit_logs.resource_icon, audit_logs.chain_sequence, audit_logs.chain_hash,
+    users.username AS user_username,
+    users.email AS user_email,
+    users.created_at AS user_created_at,
+    users.status AS user_status,
+    users.rbac_roles AS user_roles,
+    users.avatar_url AS user_avatar_url
+FROM
+    audit_logs
+    LEFT JOIN users ON audit_logs.user_id = users.id
+    LEFT JOIN
+        -- First join on workspaces to get the initial workspace create
+        -- to workspace build 1 id. This is because the first create is
+        -- is a different audit log than subsequent starts.
+        workspaces ON
+		    audit_logs.resource_type = 'workspace' AND
+			audit_logs.resource_id = workspaces.id
+    LEFT JOIN
+	    workspace_builds ON
+            -- Get the reason from the build if the resource type
+            -- is a workspace_build
+            (
+			    audit_logs.resource_type = 'workspace_build'
+                AND audit_logs.resource_id = workspace_builds.id
+			)
+            OR
+            -- Get the reason from the build #1 if this is the first
+            -- workspace create.
+            (
+				audit_logs.resource_type = 'workspace' AND
+				audit_logs.action = 'create' AND
+				workspaces.id = workspace_builds.workspace_id AND
+				workspace_builds.build_number = 1
+			)
+WHERE
+    -- Filter resource_type
+	CASE
+		WHEN $1 :: text != '' THEN
+			resource_type = $1 :: resource_type
+		ELSE true
+	END
+	-- Filter resource_id
+	AND CASE
+		WHEN $2 :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			resource_id = $2
+		ELSE true
+	END
+	-- Filter by resource_target
+	AND CASE
+		WHEN $3 :: text != '' THEN
+			resource_target = $3
+		ELSE true
+	END
+	-- Filter action
+	AND CASE
+		WHEN $4 :: text != '' THEN
+			action = $4 :: audit_action
+		ELSE true
+	END
+	-- Filter by user_id
+	AND CASE
+		WHEN $5 :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			user_id = $5
+		ELSE true
+	END
+	-- Filter by username
+	AND CASE
+		WHEN $6 :: text != '' THEN
+			user_id = (SELECT id FROM users WHERE lower(username) = lower($6) AND deleted = false)
+		ELSE true
+	END
+	-- Filter by user_email
+	AND CASE
+		WHEN $7 :: text != '' THEN
+			users.email = $7
+		ELSE true
+	END
+	-- Filter by date_from
+	AND CASE
+		WHEN $8 :: timestamp with time zone != '0001-01-01 00:00:00Z' THEN
+			"time" >= $8
+		ELSE true
+	END
+	-- Filter by date_to
+	AND CASE
+		WHEN $9 :: timestamp with time zone != '0001-01-01 00:00:00Z' THEN
+			"time" <= $9
+		ELSE true
+	END
+    -- Filter by build_reason
+    AND CASE
+	    WHEN $10::text != '' THEN
+            workspace_builds.reason::text = $10
+        ELSE true
+    END
+	-- Continue after the last audit log of the previous page
+	AND CASE
+		WHEN $11 :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			("time", audit_logs.id) < ($12 :: timestamp with time zone, $11 :: uuid)
+		ELSE true
+	END
+ORDER BY
+    "time" DESC, audit_logs.id DESC
+LIMIT
+    $13
+`
+
+type GetAuditLogsForExportParams struct {
+	ResourceType   string    `db:"resource_type" json:"resource_type"`
+	ResourceID     uuid.UUID `db:"resource_id" json:"resource_id"`
+	ResourceTarget string    `db:"resource_target" json:"resource_target"`
+	Action         string    `db:"action" json:"action"`
+	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+	Username       string    `db:"username" json:"username"`
+	Email          string    `db:"email" json:"email"`
+	DateFrom       time.Time `db:"date_from" json:"date_from"`
+	DateTo         time.Time `db:"date_to" json:"date_to"`
+	BuildReason    string    `db:"build_reason" json:"build_reason"`
+	AfterID        uuid.UUID `db:"after_id" json:"after_id"`
+	AfterTime      time.Time `db:"after_time" json:"after_time"`
+	LimitCount     int32     `db:"limit_count" json:"limit_count"`
+}
+
+type GetAuditLogsForExportRow struct {
+	ID               uuid.UUID       `db:"id" json:"id"`
+	Time             time.Time       `db:"time" json:"time"`
+	UserID           uuid.UUID       `db:"user_id" json:"user_id"`
+	OrganizationID   uuid.UUID       `db:"organization_id" json:"organization_id"`
+	Ip               pqtype.Inet     `db:"ip" json:"ip"`
+	UserAgent        sql.NullString  `db:"user_agent" json:"user_agent"`
+	ResourceType     ResourceType    `db:"resource_type" json:"resource_type"`
+	ResourceID       uuid.UUID       `db:"resource_id" json:"resource_id"`
+	ResourceTarget   string          `db:"resource_target" json:"resource_target"`
+	Action           AuditAction     `db:"action" json:"action"`
+	Diff             json.RawMessage `db:"diff" json:"diff"`
+	StatusCode       int32           `db:"status_code" json:"status_code"`
+	AdditionalFields json.RawMessage `db:"additional_fields" json:"additional_fields"`
+	RequestID        uuid.UUID       `db:"request_id" json:"request_id"`
+	ResourceIcon     string          `db:"resource_icon" json:"resource_icon"`
+	ChainSequence    sql.NullInt64   `db:"chain_sequence" json:"chain_sequence"`
+	ChainHash        []byte          `db:"chain_hash" json:"chain_hash"`
+	UserUsername     sql.NullString  `db:"user_username" json:"user_username"`
+	UserEmail        sql.NullString  `db:"user_email" json:"user_email"`
+	UserCreatedAt    sql.NullTime    `db:"user_created_at" json:"user_created_at"`
+	UserStatus       NullUserStatus  `db:"user_status" json:"user_status"`
+	UserRoles        pq.StringArray  `db:"user_roles" json:"user_roles"`
+	UserAvatarUrl    sql.NullString  `db:"user_avatar_url" json:"user_avatar_url"`
+}
+
+// GetAuditLogsForExport pages through audit logs newest first by ("time", id),
+// starting after the given audit log. Unlike GetAuditLogsOffset, it doesn't
+// count the matching audit logs.
+func (q *sqlQuerier) GetAuditLogsForExport(ctx context.Context, arg GetAuditLogsForExportParams) ([]GetAuditLogsForExportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditLogsForExport,
+		arg.ResourceType,
+		arg.ResourceID,
+		arg.ResourceTarget,
+		arg.Action,
+		arg.UserID,
+		arg.Username,
+		arg.Email,
+		arg.DateFrom,
+		arg.DateTo,
+		arg.BuildReason,
+		arg.AfterID,
+		arg.AfterTime,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuditLogsForExportRow
+	for rows.Next() {
+		var i GetAuditLogsForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Time,
+			&i.UserID,
+			&i.OrganizationID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.ResourceTarget,
+			&i.Action,
+			&i.Diff,
+			&i.StatusCode,
+			&i.AdditionalFields,
+			&i.RequestID,
+			&i.ResourceIcon,
+			&i.ChainSequence,
+			&i.ChainHash,
+			&i.UserUsername,
+			&i.UserEmail,
+			&i.UserCreatedAt,
+			&i.UserStatus,
+			&i.UserRoles,
+			&i.UserAvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAuditLogsOffset = `-- name: GetAuditLogsOffset :many
 SELECT
-    audit_logs.id, audit_logs.time, audit_logs.user_id, audit_logs.organization_id, audit_logs.ip, audit_logs.user_agent, audit_logs.resource_type, audit_logs.resource_id, audit_logs.resource_target, audit_logs.action, audit_logs.diff, audit_logs.status_code, audit_logs.additional_fields, audit_logs.request_id, audit_logs.resource_icon,
+    audit_logs.id, audit_logs.time, audit_logs.user_id, audit_logs.organization_id, audit_logs.ip, audit_logs.user_agent, audit_logs.resource_type, audit_logs.resource_id, audit_logs.resource_target, audit_logs.action, audit_logs.diff, audit_logs.status_code, audit_logs.additional_fields, audit_logs.request_id, audit_logs.resource_icon, audit_logs.chain_sequence, audit_logs.chain_hash,
     users.username AS user_username,
     users.email AS user_email,
     users.created_at AS user_created_at,
@@ -486,6 +810,8 @@ type GetAuditLogsOffsetRow struct {
 	AdditionalFields json.RawMessage `db:"additional_fields" json:"additional_fields"`
 	RequestID        uuid.UUID       `db:"request_id" json:"request_id"`
 	ResourceIcon     string          `db:"resource_icon" json:"resource_icon"`
+	ChainSequence    sql.NullInt64   `db:"chain_sequence" json:"chain_sequence"`
+	ChainHash        []byte          `db:"chain_hash" json:"chain_hash"`
 	UserUsername     sql.NullString  `db:"user_username" json:"user_username"`
 	UserEmail        sql.NullString  `db:"user_email" json:"user_email"`
 	UserCreatedAt    sql.NullTime    `db:"user_created_at" json:"user_created_at"`
@@ -535,6 +861,8 @@ func (q *sqlQuerier) GetAuditLogsOffset(ctx context.Context, arg GetAuditLogsOff
 			&i.AdditionalFields,
 			&i.RequestID,
 			&i.ResourceIcon,
+			&i.ChainSequence,
+			&i.ChainHash,
 			&i.UserUsername,
 			&i.UserEmail,
 			&i.UserCreatedAt,
@@ -556,30 +884,131 @@ func (q *sqlQuerier) GetAuditLogsOffset(ctx context.Context, arg GetAuditLogsOff
 	return items, nil
 }
 
-const insertAuditLog = `-- name: InsertAuditLog :one
-INSERT INTO
-	audit_logs (
-        id,
-        "time",
-        user_id,
-        organization_id,
-        ip,
-        user_agent,
-        resource_type,
-        resource_id,
-        resource_target,
-        action,
-        diff,
-        status_code,
-        additional_fields,
-        request_id,
-        resource_icon
-    )
-VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING id, time, user_id, organization_id, ip, user_agent, resource_type, resource_id, resource_target, action, diff, status_code, additional_fields, request_id, resource_icon
-`
-
-type InsertAuditLogParams struct {
+const getChainedAuditLogs = `-- name: GetChainedAuditLogs :many
+SELECT
+	id, time, user_id, organization_id, ip, user_agent, resource_type, resource_id, resource_target, action, diff, status_code, additional_fields, request_id, resource_icon, chain_sequence, chain_hash
+FROM
+	audit_logs
+WHERE
+	chain_sequence > $1 :: bigint
+ORDER BY
+	chain_sequence ASC
+LIMIT
+	$2
+`
+
+type GetChainedAuditLogsParams struct {
+	AfterSequence int64 `db:"after_sequence" json:"after_sequence"`
+	LimitCount    int32 `db:"limit_count" json:"limit_count"`
+}
+
+// GetChainedAuditLogs returns the audit logs in the hash chain after the
+// given sequence, in chain order.
+func (q *sqlQuerier) GetChainedAuditLogs(ctx context.Context, arg GetChainedAuditLogsParams) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, getChainedAuditLogs, arg.AfterSequence, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Time,
+			&i.UserID,
+			&i.OrganizationID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.ResourceTarget,
+			&i.Action,
+			&i.Diff,
+			&i.StatusCode,
+			&i.AdditionalFields,
+			&i.RequestID,
+			&i.ResourceIcon,
+			&i.ChainSequence,
+			&i.ChainHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestChainedAuditLog = `-- name: GetLatestChainedAuditLog :one
+SELECT
+	id, time, user_id, organization_id, ip, user_agent, resource_type, resource_id, resource_target, action, diff, status_code, additional_fields, request_id, resource_icon, chain_sequence, chain_hash
+FROM
+	audit_logs
+WHERE
+	chain_sequence IS NOT NULL
+ORDER BY
+	chain_sequence DESC
+LIMIT
+	1
+`
+
+func (q *sqlQuerier) GetLatestChainedAuditLog(ctx context.Context) (AuditLog, error) {
+	row := q.db.QueryRowContext(ctx, getLatestChainedAuditLog)
+	var i AuditLog
+	err := row.Scan(
+		&i.ID,
+		&i.Time,
+		&i.UserID,
+		&i.OrganizationID,
+		&i.Ip,
+		&i.UserAgent,
+		&i.ResourceType,
+		&i.ResourceID,
+		&i.ResourceTarget,
+		&i.Action,
+		&i.Diff,
+		&i.StatusCode,
+		&i.AdditionalFields,
+		&i.RequestID,
+		&i.ResourceIcon,
+		&i.ChainSequence,
+		&i.ChainHash,
+	)
+	return i, err
+}
+
+const insertAuditLog = `-- name: InsertAuditLog :one
+INSERT INTO
+	audit_logs (
+        id,
+        "time",
+        user_id,
+        organization_id,
+        ip,
+        user_agent,
+        resource_type,
+        resource_id,
+        resource_target,
+        action,
+        diff,
+        status_code,
+        additional_fields,
+        request_id,
+        resource_icon,
+        chain_sequence,
+        chain_hash
+    )
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING id, time, user_id, organization_id, ip, user_agent, resource_type, resource_id, resource_target, action, diff, status_code, additional_fields, request_id, resource_icon, chain_sequence, chain_hash
+`
+
+type InsertAuditLogParams struct {
 	ID               uuid.UUID       `db:"id" json:"id"`
 	Time             time.Time       `db:"time" json:"time"`
 	UserID           uuid.UUID       `db:"user_id" json:"user_id"`
@@ -595,6 +1024,8 @@ type InsertAuditLogParams struct {
 	AdditionalFields json.RawMessage `db:"additional_fields" json:"additional_fields"`
 	RequestID        uuid.UUID       `db:"request_id" json:"request_id"`
 	ResourceIcon     string          `db:"resource_icon" json:"resource_icon"`
+	ChainSequence    sql.NullInt64   `db:"chain_sequence" json:"chain_sequence"`
+	ChainHash        []byte          `db:"chain_hash" json:"chain_hash"`
 }
 
 func (q *sqlQuerier) InsertAuditLog(ctx context.Context, arg InsertAuditLogParams) (AuditLog, error) {
@@ -614,6 +1045,8 @@ func (q *sqlQuerier) InsertAuditLog(ctx context.Context, arg InsertAuditLogParam
 		arg.AdditionalFields,
 		arg.RequestID,
 		arg.ResourceIcon,
+		arg.ChainSequence,
+		arg.ChainHash,
 	)
 	var i AuditLog
 	err := row.Scan(
@@ -632,6 +1065,236 @@ func (q *sqlQuerier) InsertAuditLog(ctx context.Context, arg InsertAuditLogParam
 		&i.AdditionalFields,
 		&i.RequestID,
 		&i.ResourceIcon,
+		&i.ChainSequence,
+		&i.ChainHash,
+	)
+	return i, err
+}
+
+const insertAuditLogCheckpoint = `-- name: InsertAuditLogCheckpoint :exec
+INSERT INTO
+	audit_log_checkpoints (
+		chain_sequence,
+		chain_hash,
+		signature,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4)
+ON CONFLICT (chain_sequence) DO NOTHING
+`
+
+type InsertAuditLogCheckpointParams struct {
+	ChainSequence int64     `db:"chain_sequence" json:"chain_sequence"`
+	ChainHash     []byte    `db:"chain_hash" json:"chain_hash"`
+	Signature     []byte    `db:"signature" json:"signature"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+}
+
+func (q *sqlQuerier) InsertAuditLogCheckpoint(ctx context.Context, arg InsertAuditLogCheckpointParams) error {
+	_, err := q.db.ExecContext(ctx, insertAuditLogCheckpoint,
+		arg.ChainSequence,
+		arg.ChainHash,
+		arg.Signature,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const deleteDeploymentAutostartExceptions = `-- name: DeleteDeploymentAutostartExceptions :exec
+DELETE FROM
+	autostart_exceptions
+WHERE
+	template_id IS NULL
+`
+
+func (q *sqlQuerier) DeleteDeploymentAutostartExceptions(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteDeploymentAutostartExceptions)
+	return err
+}
+
+const deleteTemplateAutostartExceptions = `-- name: DeleteTemplateAutostartExceptions :exec
+DELETE FROM
+	autostart_exceptions
+WHERE
+	template_id = $1
+`
+
+func (q *sqlQuerier) DeleteTemplateAutostartExceptions(ctx context.Context, templateID uuid.NullUUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTemplateAutostartExceptions, templateID)
+	return err
+}
+
+const getDeploymentAutostartExceptions = `-- name: GetDeploymentAutostartExceptions :many
+SELECT
+	id, template_id, name, starts_at, ends_at, all_day, created_at
+FROM
+	autostart_exceptions
+WHERE
+	template_id IS NULL
+ORDER BY
+	starts_at ASC, name ASC
+`
+
+func (q *sqlQuerier) GetDeploymentAutostartExceptions(ctx context.Context) ([]AutostartException, error) {
+	rows, err := q.db.QueryContext(ctx, getDeploymentAutostartExceptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AutostartException
+	for rows.Next() {
+		var i AutostartException
+		if err := rows.Scan(
+			&i.ID,
+			&i.TemplateID,
+			&i.Name,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.AllDay,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTemplateAutostartExceptions = `-- name: GetTemplateAutostartExceptions :many
+SELECT
+	id, template_id, name, starts_at, ends_at, all_day, created_at
+FROM
+	autostart_exceptions
+WHERE
+	template_id = $1
+ORDER BY
+	starts_at ASC, name ASC
+`
+
+func (q *sqlQuerier) GetTemplateAutostartExceptions(ctx context.Context, templateID uuid.NullUUID) ([]AutostartException, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplateAutostartExceptions, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AutostartException
+	for rows.Next() {
+		var i AutostartException
+		if err := rows.Scan(
+			&i.ID,
+			&i.TemplateID,
+			&i.Name,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.AllDay,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTemplateAutostartExceptionsByTemplateIDs = `-- name: GetTemplateAutostartExceptionsByTemplateIDs :many
+SELECT
+	id, template_id, name, starts_at, ends_at, all_day, created_at
+FROM
+	autostart_exceptions
+WHERE
+	template_id = ANY($1 :: uuid[])
+ORDER BY
+	starts_at ASC, name ASC
+`
+
+func (q *sqlQuerier) GetTemplateAutostartExceptionsByTemplateIDs(ctx context.Context, templateIds []uuid.UUID) ([]AutostartException, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplateAutostartExceptionsByTemplateIDs, pq.Array(templateIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AutostartException
+	for rows.Next() {
+		var i AutostartException
+		if err := rows.Scan(
+			&i.ID,
+			&i.TemplateID,
+			&i.Name,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.AllDay,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertAutostartException = `-- name: InsertAutostartException :one
+INSERT INTO
+	autostart_exceptions (
+		id,
+		template_id,
+		name,
+		starts_at,
+		ends_at,
+		all_day,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7) RETURNING id, template_id, name, starts_at, ends_at, all_day, created_at
+`
+
+type InsertAutostartExceptionParams struct {
+	ID         uuid.UUID     `db:"id" json:"id"`
+	TemplateID uuid.NullUUID `db:"template_id" json:"template_id"`
+	Name       string        `db:"name" json:"name"`
+	StartsAt   time.Time     `db:"starts_at" json:"starts_at"`
+	EndsAt     time.Time     `db:"ends_at" json:"ends_at"`
+	AllDay     bool          `db:"all_day" json:"all_day"`
+	CreatedAt  time.Time     `db:"created_at" json:"created_at"`
+}
+
+func (q *sqlQuerier) InsertAutostartException(ctx context.Context, arg InsertAutostartExceptionParams) (AutostartException, error) {
+	row := q.db.QueryRowContext(ctx, insertAutostartException,
+		arg.ID,
+		arg.TemplateID,
+		arg.Name,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.AllDay,
+		arg.CreatedAt,
+	)
+	var i AutostartException
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateID,
+		&i.Name,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.AllDay,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -1966,32 +2629,61 @@ func (q *sqlQuerier) TryAcquireLock(ctx context.Context, pgTryAdvisoryXactLock i
 	return pg_try_advisory_xact_lock, err
 }
 
-const getOrganizationIDsByMemberIDs = `-- name: GetOrganizationIDsByMemberIDs :many
-SELECT
-    user_id, array_agg(organization_id) :: uuid [ ] AS "organization_IDs"
-FROM
-    organization_members
+const acquireNotificationDeliveries = `-- name: AcquireNotificationDeliveries :many
+UPDATE
+	notification_deliveries
+SET
+	next_attempt_at = $1,
+	updated_at = $2
 WHERE
-    user_id = ANY($1 :: uuid [ ])
-GROUP BY
-    user_id
-`
-
-type GetOrganizationIDsByMemberIDsRow struct {
-	UserID          uuid.UUID   `db:"user_id" json:"user_id"`
-	OrganizationIDs []uuid.UUID `db:"organization_IDs" json:"organization_IDs"`
+	id IN (
+		SELECT
+			id
+		FROM
+			notification_deliveries AS nested
+		WHERE
+			nested.status = 'pending'::notification_delivery_status
+			AND nested.next_attempt_at <= $2
+		ORDER BY
+			nested.next_attempt_at
+		FOR UPDATE
+		SKIP LOCKED
+		LIMIT
+			$3
+	) RETURNING id, message_id, address, status, attempts, last_error, next_attempt_at, created_at, updated_at
+`
+
+type AcquireNotificationDeliveriesParams struct {
+	LeaseUntil time.Time `db:"lease_until" json:"lease_until"`
+	Now        time.Time `db:"now" json:"now"`
+	LimitOpt   int32     `db:"limit_opt" json:"limit_opt"`
 }
 
-func (q *sqlQuerier) GetOrganizationIDsByMemberIDs(ctx context.Context, ids []uuid.UUID) ([]GetOrganizationIDsByMemberIDsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getOrganizationIDsByMemberIDs, pq.Array(ids))
+// Acquires pending deliveries that are due. The attempt time of the acquired
+// deliveries is moved to @lease_until so that they are retried if the caller
+// goes away before recording the result.
+//
+// SKIP LOCKED prevents multiple replicas from acquiring the same deliveries.
+func (q *sqlQuerier) AcquireNotificationDeliveries(ctx context.Context, arg AcquireNotificationDeliveriesParams) ([]NotificationDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, acquireNotificationDeliveries, arg.LeaseUntil, arg.Now, arg.LimitOpt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetOrganizationIDsByMemberIDsRow
+	var items []NotificationDelivery
 	for rows.Next() {
-		var i GetOrganizationIDsByMemberIDsRow
-		if err := rows.Scan(&i.UserID, pq.Array(&i.OrganizationIDs)); err != nil {
+		var i NotificationDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.MessageID,
+			&i.Address,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -2005,60 +2697,71 @@ func (q *sqlQuerier) GetOrganizationIDsByMemberIDs(ctx context.Context, ids []uu
 	return items, nil
 }
 
-const getOrganizationMemberByUserID = `-- name: GetOrganizationMemberByUserID :one
+const getNotificationDeliveries = `-- name: GetNotificationDeliveries :many
 SELECT
-	user_id, organization_id, created_at, updated_at, roles
+	notification_deliveries.id, notification_deliveries.message_id, notification_deliveries.address, notification_deliveries.status, notification_deliveries.attempts, notification_deliveries.last_error, notification_deliveries.next_attempt_at, notification_deliveries.created_at, notification_deliveries.updated_at,
+	notification_messages.user_id,
+	notification_messages.event,
+	notification_messages.title
 FROM
-	organization_members
+	notification_deliveries
+INNER JOIN
+	notification_messages ON notification_messages.id = notification_deliveries.message_id
 WHERE
-	organization_id = $1
-	AND user_id = $2
+	CASE
+		WHEN $1 :: text != '' THEN notification_deliveries.status = $1 :: notification_delivery_status
+		ELSE true
+	END
+ORDER BY
+	notification_deliveries.created_at DESC, notification_deliveries.id ASC OFFSET $2
 LIMIT
-	1
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF($3 :: int, 0)
 `
 
-type GetOrganizationMemberByUserIDParams struct {
-	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
-	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+type GetNotificationDeliveriesParams struct {
+	Status    string `db:"status" json:"status"`
+	OffsetOpt int32  `db:"offset_opt" json:"offset_opt"`
+	LimitOpt  int32  `db:"limit_opt" json:"limit_opt"`
 }
 
-func (q *sqlQuerier) GetOrganizationMemberByUserID(ctx context.Context, arg GetOrganizationMemberByUserIDParams) (OrganizationMember, error) {
-	row := q.db.QueryRowContext(ctx, getOrganizationMemberByUserID, arg.OrganizationID, arg.UserID)
-	var i OrganizationMember
-	err := row.Scan(
-		&i.UserID,
-		&i.OrganizationID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		pq.Array(&i.Roles),
-	)
-	return i, err
+type GetNotificationDeliveriesRow struct {
+	ID            uuid.UUID                  `db:"id" json:"id"`
+	MessageID     uuid.UUID                  `db:"message_id" json:"message_id"`
+	Address       string                     `db:"address" json:"address"`
+	Status        NotificationDeliveryStatus `db:"status" json:"status"`
+	Attempts      int32                      `db:"attempts" json:"attempts"`
+	LastError     string                     `db:"last_error" json:"last_error"`
+	NextAttemptAt time.Time                  `db:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     time.Time                  `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time                  `db:"updated_at" json:"updated_at"`
+	UserID        uuid.UUID                  `db:"user_id" json:"user_id"`
+	Event         NotificationEvent          `db:"event" json:"event"`
+	Title         string                     `db:"title" json:"title"`
 }
 
-const getOrganizationMembershipsByUserID = `-- name: GetOrganizationMembershipsByUserID :many
-SELECT
-	user_id, organization_id, created_at, updated_at, roles
-FROM
-	organization_members
-WHERE
-  user_id = $1
-`
-
-func (q *sqlQuerier) GetOrganizationMembershipsByUserID(ctx context.Context, userID uuid.UUID) ([]OrganizationMember, error) {
-	rows, err := q.db.QueryContext(ctx, getOrganizationMembershipsByUserID, userID)
+func (q *sqlQuerier) GetNotificationDeliveries(ctx context.Context, arg GetNotificationDeliveriesParams) ([]GetNotificationDeliveriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationDeliveries, arg.Status, arg.OffsetOpt, arg.LimitOpt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []OrganizationMember
+	var items []GetNotificationDeliveriesRow
 	for rows.Next() {
-		var i OrganizationMember
+		var i GetNotificationDeliveriesRow
 		if err := rows.Scan(
-			&i.UserID,
-			&i.OrganizationID,
+			&i.ID,
+			&i.MessageID,
+			&i.Address,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			pq.Array(&i.Roles),
+			&i.UserID,
+			&i.Event,
+			&i.Title,
 		); err != nil {
 			return nil, err
 		}
@@ -2073,145 +2776,84 @@ func (q *sqlQuerier) GetOrganizationMembershipsByUserID(ctx context.Context, use
 	return items, nil
 }
 
-const insertOrganizationMember = `-- name: InsertOrganizationMember :one
-INSERT INTO
-	organization_members (
-		organization_id,
-		user_id,
-		created_at,
-		updated_at,
-		roles
-	)
-VALUES
-	($1, $2, $3, $4, $5) RETURNING user_id, organization_id, created_at, updated_at, roles
-`
-
-type InsertOrganizationMemberParams struct {
-	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
-	UserID         uuid.UUID `db:"user_id" json:"user_id"`
-	CreatedAt      time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
-	Roles          []string  `db:"roles" json:"roles"`
-}
-
-func (q *sqlQuerier) InsertOrganizationMember(ctx context.Context, arg InsertOrganizationMemberParams) (OrganizationMember, error) {
-	row := q.db.QueryRowContext(ctx, insertOrganizationMember,
-		arg.OrganizationID,
-		arg.UserID,
-		arg.CreatedAt,
-		arg.UpdatedAt,
-		pq.Array(arg.Roles),
-	)
-	var i OrganizationMember
-	err := row.Scan(
-		&i.UserID,
-		&i.OrganizationID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		pq.Array(&i.Roles),
-	)
-	return i, err
-}
-
-const updateMemberRoles = `-- name: UpdateMemberRoles :one
-UPDATE
-	organization_members
-SET
-	-- Remove all duplicates from the roles.
-	roles = ARRAY(SELECT DISTINCT UNNEST($1 :: text[]))
-WHERE
-	user_id = $2
-	AND organization_id = $3
-RETURNING user_id, organization_id, created_at, updated_at, roles
-`
-
-type UpdateMemberRolesParams struct {
-	GrantedRoles []string  `db:"granted_roles" json:"granted_roles"`
-	UserID       uuid.UUID `db:"user_id" json:"user_id"`
-	OrgID        uuid.UUID `db:"org_id" json:"org_id"`
-}
-
-func (q *sqlQuerier) UpdateMemberRoles(ctx context.Context, arg UpdateMemberRolesParams) (OrganizationMember, error) {
-	row := q.db.QueryRowContext(ctx, updateMemberRoles, pq.Array(arg.GrantedRoles), arg.UserID, arg.OrgID)
-	var i OrganizationMember
-	err := row.Scan(
-		&i.UserID,
-		&i.OrganizationID,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		pq.Array(&i.Roles),
-	)
-	return i, err
-}
-
-const getOrganizationByID = `-- name: GetOrganizationByID :one
+const getNotificationMessageByID = `-- name: GetNotificationMessageByID :one
 SELECT
-	id, name, description, created_at, updated_at
+	id, user_id, event, title, body, dedupe_key, inbox, created_at, read_at
 FROM
-	organizations
+	notification_messages
 WHERE
 	id = $1
+LIMIT
+	1
 `
 
-func (q *sqlQuerier) GetOrganizationByID(ctx context.Context, id uuid.UUID) (Organization, error) {
-	row := q.db.QueryRowContext(ctx, getOrganizationByID, id)
-	var i Organization
+func (q *sqlQuerier) GetNotificationMessageByID(ctx context.Context, id uuid.UUID) (NotificationMessage, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationMessageByID, id)
+	var i NotificationMessage
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.Description,
+		&i.UserID,
+		&i.Event,
+		&i.Title,
+		&i.Body,
+		&i.DedupeKey,
+		&i.Inbox,
 		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.ReadAt,
 	)
 	return i, err
 }
 
-const getOrganizationByName = `-- name: GetOrganizationByName :one
+const getNotificationMessagesByUserID = `-- name: GetNotificationMessagesByUserID :many
 SELECT
-	id, name, description, created_at, updated_at
+	id, user_id, event, title, body, dedupe_key, inbox, created_at, read_at
 FROM
-	organizations
+	notification_messages
 WHERE
-	LOWER("name") = LOWER($1)
+	user_id = $1
+	AND inbox = true
+	AND CASE
+		WHEN $2 :: boolean THEN read_at IS NULL
+		ELSE true
+	END
+ORDER BY
+	created_at DESC, id ASC OFFSET $3
 LIMIT
-	1
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF($4 :: int, 0)
 `
 
-func (q *sqlQuerier) GetOrganizationByName(ctx context.Context, name string) (Organization, error) {
-	row := q.db.QueryRowContext(ctx, getOrganizationByName, name)
-	var i Organization
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Description,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type GetNotificationMessagesByUserIDParams struct {
+	UserID     uuid.UUID `db:"user_id" json:"user_id"`
+	UnreadOnly bool      `db:"unread_only" json:"unread_only"`
+	OffsetOpt  int32     `db:"offset_opt" json:"offset_opt"`
+	LimitOpt   int32     `db:"limit_opt" json:"limit_opt"`
 }
 
-const getOrganizations = `-- name: GetOrganizations :many
-SELECT
-	id, name, description, created_at, updated_at
-FROM
-	organizations
-`
-
-func (q *sqlQuerier) GetOrganizations(ctx context.Context) ([]Organization, error) {
-	rows, err := q.db.QueryContext(ctx, getOrganizations)
+func (q *sqlQuerier) GetNotificationMessagesByUserID(ctx context.Context, arg GetNotificationMessagesByUserIDParams) ([]NotificationMessage, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationMessagesByUserID,
+		arg.UserID,
+		arg.UnreadOnly,
+		arg.OffsetOpt,
+		arg.LimitOpt,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Organization
+	var items []NotificationMessage
 	for rows.Next() {
-		var i Organization
+		var i NotificationMessage
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
-			&i.Description,
+			&i.UserID,
+			&i.Event,
+			&i.Title,
+			&i.Body,
+			&i.DedupeKey,
+			&i.Inbox,
 			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.ReadAt,
 		); err != nil {
 			return nil, err
 		}
@@ -2226,36 +2868,31 @@ func (q *sqlQuerier) GetOrganizations(ctx context.Context) ([]Organization, erro
 	return items, nil
 }
 
-const getOrganizationsByUserID = `-- name: GetOrganizationsByUserID :many
+const getNotificationPreferencesByUserID = `-- name: GetNotificationPreferencesByUserID :many
 SELECT
-	id, name, description, created_at, updated_at
+	user_id, event, email, inbox, updated_at
 FROM
-	organizations
+	notification_preferences
 WHERE
-	id = (
-		SELECT
-			organization_id
-		FROM
-			organization_members
-		WHERE
-			user_id = $1
-	)
+	user_id = $1
+ORDER BY
+	event ASC
 `
 
-func (q *sqlQuerier) GetOrganizationsByUserID(ctx context.Context, userID uuid.UUID) ([]Organization, error) {
-	rows, err := q.db.QueryContext(ctx, getOrganizationsByUserID, userID)
+func (q *sqlQuerier) GetNotificationPreferencesByUserID(ctx context.Context, userID uuid.UUID) ([]NotificationPreference, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationPreferencesByUserID, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Organization
+	var items []NotificationPreference
 	for rows.Next() {
-		var i Organization
+		var i NotificationPreference
 		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
-			&i.CreatedAt,
+			&i.UserID,
+			&i.Event,
+			&i.Email,
+			&i.Inbox,
 			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
@@ -2271,52 +2908,645 @@ func (q *sqlQuerier) GetOrganizationsByUserID(ctx context.Context, userID uuid.U
 	return items, nil
 }
 
-const insertOrganization = `-- name: InsertOrganization :one
+const insertNotificationDelivery = `-- name: InsertNotificationDelivery :one
 INSERT INTO
-	organizations (id, "name", description, created_at, updated_at)
+	notification_deliveries (
+		id,
+		message_id,
+		address,
+		next_attempt_at,
+		created_at,
+		updated_at
+	)
 VALUES
-	($1, $2, $3, $4, $5) RETURNING id, name, description, created_at, updated_at
+	($1, $2, $3, $4, $5, $5) RETURNING id, message_id, address, status, attempts, last_error, next_attempt_at, created_at, updated_at
 `
 
-type InsertOrganizationParams struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	Name        string    `db:"name" json:"name"`
-	Description string    `db:"description" json:"description"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+type InsertNotificationDeliveryParams struct {
+	ID            uuid.UUID `db:"id" json:"id"`
+	MessageID     uuid.UUID `db:"message_id" json:"message_id"`
+	Address       string    `db:"address" json:"address"`
+	NextAttemptAt time.Time `db:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
 }
 
-func (q *sqlQuerier) InsertOrganization(ctx context.Context, arg InsertOrganizationParams) (Organization, error) {
-	row := q.db.QueryRowContext(ctx, insertOrganization,
+func (q *sqlQuerier) InsertNotificationDelivery(ctx context.Context, arg InsertNotificationDeliveryParams) (NotificationDelivery, error) {
+	row := q.db.QueryRowContext(ctx, insertNotificationDelivery,
 		arg.ID,
-		arg.Name,
-		arg.Description,
+		arg.MessageID,
+		arg.Address,
+		arg.NextAttemptAt,
 		arg.CreatedAt,
-		arg.UpdatedAt,
 	)
-	var i Organization
+	var i NotificationDelivery
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.Description,
+		&i.MessageID,
+		&i.Address,
+		&i.Status,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getParameterSchemasByJobID = `-- name: GetParameterSchemasByJobID :many
-SELECT
-	id, created_at, job_id, name, description, default_source_scheme, default_source_value, allow_override_source, default_destination_scheme, allow_override_destination, default_refresh, redisplay_value, validation_error, validation_condition, validation_type_system, validation_value_type, index
-FROM
-	parameter_schemas
-WHERE
-	job_id = $1
-ORDER BY
-	index
+const insertNotificationMessage = `-- name: InsertNotificationMessage :one
+INSERT INTO
+	notification_messages (
+		id,
+		user_id,
+		event,
+		title,
+		body,
+		dedupe_key,
+		inbox,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (user_id, dedupe_key) DO NOTHING RETURNING id, user_id, event, title, body, dedupe_key, inbox, created_at, read_at
 `
 
-func (q *sqlQuerier) GetParameterSchemasByJobID(ctx context.Context, jobID uuid.UUID) ([]ParameterSchema, error) {
+type InsertNotificationMessageParams struct {
+	ID        uuid.UUID         `db:"id" json:"id"`
+	UserID    uuid.UUID         `db:"user_id" json:"user_id"`
+	Event     NotificationEvent `db:"event" json:"event"`
+	Title     string            `db:"title" json:"title"`
+	Body      string            `db:"body" json:"body"`
+	DedupeKey string            `db:"dedupe_key" json:"dedupe_key"`
+	Inbox     bool              `db:"inbox" json:"inbox"`
+	CreatedAt time.Time         `db:"created_at" json:"created_at"`
+}
+
+// Messages are deduplicated per user. If the user already received a message
+// with the same dedupe key, nothing is inserted and no row is returned.
+func (q *sqlQuerier) InsertNotificationMessage(ctx context.Context, arg InsertNotificationMessageParams) (NotificationMessage, error) {
+	row := q.db.QueryRowContext(ctx, insertNotificationMessage,
+		arg.ID,
+		arg.UserID,
+		arg.Event,
+		arg.Title,
+		arg.Body,
+		arg.DedupeKey,
+		arg.Inbox,
+		arg.CreatedAt,
+	)
+	var i NotificationMessage
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Event,
+		&i.Title,
+		&i.Body,
+		&i.DedupeKey,
+		&i.Inbox,
+		&i.CreatedAt,
+		&i.ReadAt,
+	)
+	return i, err
+}
+
+const updateNotificationDelivery = `-- name: UpdateNotificationDelivery :exec
+UPDATE
+	notification_deliveries
+SET
+	status = $2,
+	attempts = $3,
+	last_error = $4,
+	next_attempt_at = $5,
+	updated_at = $6
+WHERE
+	id = $1
+`
+
+type UpdateNotificationDeliveryParams struct {
+	ID            uuid.UUID                  `db:"id" json:"id"`
+	Status        NotificationDeliveryStatus `db:"status" json:"status"`
+	Attempts      int32                      `db:"attempts" json:"attempts"`
+	LastError     string                     `db:"last_error" json:"last_error"`
+	NextAttemptAt time.Time                  `db:"next_attempt_at" json:"next_attempt_at"`
+	UpdatedAt     time.Time                  `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpdateNotificationDelivery(ctx context.Context, arg UpdateNotificationDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, updateNotificationDelivery,
+		arg.ID,
+		arg.Status,
+		arg.Attempts,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const updateNotificationMessageReadAt = `-- name: UpdateNotificationMessageReadAt :one
+UPDATE
+	notification_messages
+SET
+	read_at = $2
+WHERE
+	id = $1 RETURNING id, user_id, event, title, body, dedupe_key, inbox, created_at, read_at
+`
+
+type UpdateNotificationMessageReadAtParams struct {
+	ID     uuid.UUID    `db:"id" json:"id"`
+	ReadAt sql.NullTime `db:"read_at" json:"read_at"`
+}
+
+func (q *sqlQuerier) UpdateNotificationMessageReadAt(ctx context.Context, arg UpdateNotificationMessageReadAtParams) (NotificationMessage, error) {
+	row := q.db.QueryRowContext(ctx, updateNotificationMessageReadAt, arg.ID, arg.ReadAt)
+	var i NotificationMessage
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Event,
+		&i.Title,
+		&i.Body,
+		&i.DedupeKey,
+		&i.Inbox,
+		&i.CreatedAt,
+		&i.ReadAt,
+	)
+	return i, err
+}
+
+const updateNotificationMessagesReadAtByUserID = `-- name: UpdateNotificationMessagesReadAtByUserID :exec
+UPDATE
+	notification_messages
+SET
+	read_at = $1
+WHERE
+	user_id = $2
+	AND inbox = true
+	AND read_at IS NULL
+`
+
+type UpdateNotificationMessagesReadAtByUserIDParams struct {
+	ReadAt sql.NullTime `db:"read_at" json:"read_at"`
+	UserID uuid.UUID    `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) UpdateNotificationMessagesReadAtByUserID(ctx context.Context, arg UpdateNotificationMessagesReadAtByUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateNotificationMessagesReadAtByUserID, arg.ReadAt, arg.UserID)
+	return err
+}
+
+const upsertNotificationPreference = `-- name: UpsertNotificationPreference :one
+INSERT INTO
+	notification_preferences (
+		user_id,
+		event,
+		email,
+		inbox,
+		updated_at
+	)
+VALUES
+	($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, event) DO UPDATE SET
+	email = $3,
+	inbox = $4,
+	updated_at = $5
+RETURNING user_id, event, email, inbox, updated_at
+`
+
+type UpsertNotificationPreferenceParams struct {
+	UserID    uuid.UUID         `db:"user_id" json:"user_id"`
+	Event     NotificationEvent `db:"event" json:"event"`
+	Email     bool              `db:"email" json:"email"`
+	Inbox     bool              `db:"inbox" json:"inbox"`
+	UpdatedAt time.Time         `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error) {
+	row := q.db.QueryRowContext(ctx, upsertNotificationPreference,
+		arg.UserID,
+		arg.Event,
+		arg.Email,
+		arg.Inbox,
+		arg.UpdatedAt,
+	)
+	var i NotificationPreference
+	err := row.Scan(
+		&i.UserID,
+		&i.Event,
+		&i.Email,
+		&i.Inbox,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteOrganizationMember = `-- name: DeleteOrganizationMember :exec
+DELETE FROM
+	organization_members
+WHERE
+	organization_id = $1
+	AND user_id = $2
+`
+
+type DeleteOrganizationMemberParams struct {
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOrganizationMember, arg.OrganizationID, arg.UserID)
+	return err
+}
+
+const getOrganizationIDsByMemberIDs = `-- name: GetOrganizationIDsByMemberIDs :many
+SELECT
+    user_id, array_agg(organization_id) :: uuid [ ] AS "organization_IDs"
+FROM
+    organization_members
+WHERE
+    user_id = ANY($1 :: uuid [ ])
+GROUP BY
+    user_id
+`
+
+type GetOrganizationIDsByMemberIDsRow struct {
+	UserID          uuid.UUID   `db:"user_id" json:"user_id"`
+	OrganizationIDs []uuid.UUID `db:"organization_IDs" json:"organization_IDs"`
+}
+
+func (q *sqlQuerier) GetOrganizationIDsByMemberIDs(ctx context.Context, ids []uuid.UUID) ([]GetOrganizationIDsByMemberIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getOrganizationIDsByMemberIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOrganizationIDsByMemberIDsRow
+	for rows.Next() {
+		var i GetOrganizationIDsByMemberIDsRow
+		if err := rows.Scan(&i.UserID, pq.Array(&i.OrganizationIDs)); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrganizationMemberByUserID = `-- name: GetOrganizationMemberByUserID :one
+SELECT
+	user_id, organization_id, created_at, updated_at, roles
+FROM
+	organization_members
+WHERE
+	organization_id = $1
+	AND user_id = $2
+LIMIT
+	1
+`
+
+type GetOrganizationMemberByUserIDParams struct {
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) GetOrganizationMemberByUserID(ctx context.Context, arg GetOrganizationMemberByUserIDParams) (OrganizationMember, error) {
+	row := q.db.QueryRowContext(ctx, getOrganizationMemberByUserID, arg.OrganizationID, arg.UserID)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.UserID,
+		&i.OrganizationID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		pq.Array(&i.Roles),
+	)
+	return i, err
+}
+
+const getOrganizationMembersByOrganizationID = `-- name: GetOrganizationMembersByOrganizationID :many
+SELECT
+	user_id, organization_id, created_at, updated_at, roles
+FROM
+	organization_members
+WHERE
+	organization_id = $1
+`
+
+func (q *sqlQuerier) GetOrganizationMembersByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]OrganizationMember, error) {
+	rows, err := q.db.QueryContext(ctx, getOrganizationMembersByOrganizationID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrganizationMember
+	for rows.Next() {
+		var i OrganizationMember
+		if err := rows.Scan(
+			&i.UserID,
+			&i.OrganizationID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			pq.Array(&i.Roles),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrganizationMembershipsByUserID = `-- name: GetOrganizationMembershipsByUserID :many
+SELECT
+	user_id, organization_id, created_at, updated_at, roles
+FROM
+	organization_members
+WHERE
+  user_id = $1
+`
+
+func (q *sqlQuerier) GetOrganizationMembershipsByUserID(ctx context.Context, userID uuid.UUID) ([]OrganizationMember, error) {
+	rows, err := q.db.QueryContext(ctx, getOrganizationMembershipsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrganizationMember
+	for rows.Next() {
+		var i OrganizationMember
+		if err := rows.Scan(
+			&i.UserID,
+			&i.OrganizationID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			pq.Array(&i.Roles),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertOrganizationMember = `-- name: InsertOrganizationMember :one
+INSERT INTO
+	organization_members (
+		organization_id,
+		user_id,
+		created_at,
+		updated_at,
+		roles
+	)
+VALUES
+	($1, $2, $3, $4, $5) RETURNING user_id, organization_id, created_at, updated_at, roles
+`
+
+type InsertOrganizationMemberParams struct {
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
+	Roles          []string  `db:"roles" json:"roles"`
+}
+
+func (q *sqlQuerier) InsertOrganizationMember(ctx context.Context, arg InsertOrganizationMemberParams) (OrganizationMember, error) {
+	row := q.db.QueryRowContext(ctx, insertOrganizationMember,
+		arg.OrganizationID,
+		arg.UserID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		pq.Array(arg.Roles),
+	)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.UserID,
+		&i.OrganizationID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		pq.Array(&i.Roles),
+	)
+	return i, err
+}
+
+const updateMemberRoles = `-- name: UpdateMemberRoles :one
+UPDATE
+	organization_members
+SET
+	-- Remove all duplicates from the roles.
+	roles = ARRAY(SELECT DISTINCT UNNEST($1 :: text[]))
+WHERE
+	user_id = $2
+	AND organization_id = $3
+RETURNING user_id, organization_id, created_at, updated_at, roles
+`
+
+type UpdateMemberRolesParams struct {
+	GrantedRoles []string  `db:"granted_roles" json:"granted_roles"`
+	UserID       uuid.UUID `db:"user_id" json:"user_id"`
+	OrgID        uuid.UUID `db:"org_id" json:"org_id"`
+}
+
+func (q *sqlQuerier) UpdateMemberRoles(ctx context.Context, arg UpdateMemberRolesParams) (OrganizationMember, error) {
+	row := q.db.QueryRowContext(ctx, updateMemberRoles, pq.Array(arg.GrantedRoles), arg.UserID, arg.OrgID)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.UserID,
+		&i.OrganizationID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		pq.Array(&i.Roles),
+	)
+	return i, err
+}
+
+const getOrganizationByID = `-- name: GetOrganizationByID :one
+SELECT
+	id, name, description, created_at, updated_at
+FROM
+	organizations
+WHERE
+	id = $1
+`
+
+func (q *sqlQuerier) GetOrganizationByID(ctx context.Context, id uuid.UUID) (Organization, error) {
+	row := q.db.QueryRowContext(ctx, getOrganizationByID, id)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOrganizationByName = `-- name: GetOrganizationByName :one
+SELECT
+	id, name, description, created_at, updated_at
+FROM
+	organizations
+WHERE
+	LOWER("name") = LOWER($1)
+LIMIT
+	1
+`
+
+func (q *sqlQuerier) GetOrganizationByName(ctx context.Context, name string) (Organization, error) {
+	row := q.db.QueryRowContext(ctx, getOrganizationByName, name)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOrganizations = `-- name: GetOrganizations :many
+SELECT
+	id, name, description, created_at, updated_at
+FROM
+	organizations
+`
+
+func (q *sqlQuerier) GetOrganizations(ctx context.Context) ([]Organization, error) {
+	rows, err := q.db.QueryContext(ctx, getOrganizations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Organization
+	for rows.Next() {
+		var i Organization
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrganizationsByUserID = `-- name: GetOrganizationsByUserID :many
+SELECT
+	id, name, description, created_at, updated_at
+FROM
+	organizations
+WHERE
+	id = (
+		SELECT
+			organization_id
+		FROM
+			organization_members
+		WHERE
+			user_id = $1
+	)
+`
+
+func (q *sqlQuerier) GetOrganizationsByUserID(ctx context.Context, userID uuid.UUID) ([]Organization, error) {
+	rows, err := q.db.QueryContext(ctx, getOrganizationsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Organization
+	for rows.Next() {
+		var i Organization
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertOrganization = `-- name: InsertOrganization :one
+INSERT INTO
+	organizations (id, "name", description, created_at, updated_at)
+VALUES
+	($1, $2, $3, $4, $5) RETURNING id, name, description, created_at, updated_at
+`
+
+type InsertOrganizationParams struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	Description string    `db:"description" json:"description"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) InsertOrganization(ctx context.Context, arg InsertOrganizationParams) (Organization, error) {
+	row := q.db.QueryRowContext(ctx, insertOrganization,
+		arg.ID,
+		arg.Name,
+		arg.Description,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getParameterSchemasByJobID = `-- name: GetParameterSchemasByJobID :many
+SELECT
+	id, created_at, job_id, name, description, default_source_scheme, default_source_value, allow_override_source, default_destination_scheme, allow_override_destination, default_refresh, redisplay_value, validation_error, validation_condition, validation_type_system, validation_value_type, index
+FROM
+	parameter_schemas
+WHERE
+	job_id = $1
+ORDER BY
+	index
+`
+
+func (q *sqlQuerier) GetParameterSchemasByJobID(ctx context.Context, jobID uuid.UUID) ([]ParameterSchema, error) {
 	rows, err := q.db.QueryContext(ctx, getParameterSchemasByJobID, jobID)
 	if err != nil {
 		return nil, err
@@ -2437,6 +3667,39 @@ func (q *sqlQuerier) InsertProvisionerDaemon(ctx context.Context, arg InsertProv
 	return i, err
 }
 
+const deleteOldProvisionerJobLogs = `-- name: DeleteOldProvisionerJobLogs :execrows
+DELETE FROM
+	provisioner_job_logs
+WHERE
+	id IN (
+		SELECT
+			provisioner_job_logs.id
+		FROM
+			provisioner_job_logs
+		JOIN
+			provisioner_jobs ON provisioner_jobs.id = provisioner_job_logs.job_id
+		WHERE
+			provisioner_jobs.completed_at < $1 :: timestamptz
+		ORDER BY
+			provisioner_job_logs.id ASC
+		LIMIT
+			$2
+	)
+`
+
+type DeleteOldProvisionerJobLogsParams struct {
+	CompletedBefore time.Time `db:"completed_before" json:"completed_before"`
+	LimitCount      int32     `db:"limit_count" json:"limit_count"`
+}
+
+func (q *sqlQuerier) DeleteOldProvisionerJobLogs(ctx context.Context, arg DeleteOldProvisionerJobLogsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteOldProvisionerJobLogs, arg.CompletedBefore, arg.LimitCount)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getProvisionerLogsAfterID = `-- name: GetProvisionerLogsAfterID :many
 SELECT
 	job_id, created_at, source, level, stage, output, id
@@ -4194,7 +5457,7 @@ func (q *sqlQuerier) GetTemplateAverageBuildTime(ctx context.Context, arg GetTem
 
 const getTemplateByID = `-- name: GetTemplateByID :one
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, max_ttl, allow_user_autostart, allow_user_autostop, failure_ttl, inactivity_ttl, locked_ttl, restart_requirement_days_of_week, restart_requirement_weeks, created_by_avatar_url, created_by_username
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, max_ttl, allow_user_autostart, allow_user_autostop, failure_ttl, inactivity_ttl, locked_ttl, restart_requirement_days_of_week, restart_requirement_weeks, deprecated, replacement_template_id, require_active_version, created_by_avatar_url, created_by_username
 FROM
 	template_with_users
 WHERE
@@ -4231,6 +5494,9 @@ func (q *sqlQuerier) GetTemplateByID(ctx context.Context, id uuid.UUID) (Templat
 		&i.LockedTTL,
 		&i.RestartRequirementDaysOfWeek,
 		&i.RestartRequirementWeeks,
+		&i.Deprecated,
+		&i.ReplacementTemplateID,
+		&i.RequireActiveVersion,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 	)
@@ -4239,7 +5505,7 @@ func (q *sqlQuerier) GetTemplateByID(ctx context.Context, id uuid.UUID) (Templat
 
 const getTemplateByOrganizationAndName = `-- name: GetTemplateByOrganizationAndName :one
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, max_ttl, allow_user_autostart, allow_user_autostop, failure_ttl, inactivity_ttl, locked_ttl, restart_requirement_days_of_week, restart_requirement_weeks, created_by_avatar_url, created_by_username
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, max_ttl, allow_user_autostart, allow_user_autostop, failure_ttl, inactivity_ttl, locked_ttl, restart_requirement_days_of_week, restart_requirement_weeks, deprecated, replacement_template_id, require_active_version, created_by_avatar_url, created_by_username
 FROM
 	template_with_users AS templates
 WHERE
@@ -4284,14 +5550,60 @@ func (q *sqlQuerier) GetTemplateByOrganizationAndName(ctx context.Context, arg G
 		&i.LockedTTL,
 		&i.RestartRequirementDaysOfWeek,
 		&i.RestartRequirementWeeks,
+		&i.Deprecated,
+		&i.ReplacementTemplateID,
+		&i.RequireActiveVersion,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 	)
 	return i, err
 }
 
+const getTemplateWorkspaceCounts = `-- name: GetTemplateWorkspaceCounts :many
+SELECT
+	template_id,
+	COUNT(*) AS workspace_count
+FROM
+	workspaces
+WHERE
+	deleted = false
+	AND template_id = ANY($1 :: uuid[])
+GROUP BY
+	template_id
+`
+
+type GetTemplateWorkspaceCountsRow struct {
+	TemplateID     uuid.UUID `db:"template_id" json:"template_id"`
+	WorkspaceCount int64     `db:"workspace_count" json:"workspace_count"`
+}
+
+// Returns the number of non-deleted workspaces of each of the given templates.
+// Templates without workspaces are omitted.
+func (q *sqlQuerier) GetTemplateWorkspaceCounts(ctx context.Context, templateIds []uuid.UUID) ([]GetTemplateWorkspaceCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplateWorkspaceCounts, pq.Array(templateIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTemplateWorkspaceCountsRow
+	for rows.Next() {
+		var i GetTemplateWorkspaceCountsRow
+		if err := rows.Scan(&i.TemplateID, &i.WorkspaceCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTemplates = `-- name: GetTemplates :many
-SELECT id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, max_ttl, allow_user_autostart, allow_user_autostop, failure_ttl, inactivity_ttl, locked_ttl, restart_requirement_days_of_week, restart_requirement_weeks, created_by_avatar_url, created_by_username FROM template_with_users AS templates
+SELECT id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, max_ttl, allow_user_autostart, allow_user_autostop, failure_ttl, inactivity_ttl, locked_ttl, restart_requirement_days_of_week, restart_requirement_weeks, deprecated, replacement_template_id, require_active_version, created_by_avatar_url, created_by_username FROM template_with_users AS templates
 ORDER BY (name, id) ASC
 `
 
@@ -4329,6 +5641,9 @@ func (q *sqlQuerier) GetTemplates(ctx context.Context) ([]Template, error) {
 			&i.LockedTTL,
 			&i.RestartRequirementDaysOfWeek,
 			&i.RestartRequirementWeeks,
+			&i.Deprecated,
+			&i.ReplacementTemplateID,
+			&i.RequireActiveVersion,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 		); err != nil {
@@ -4347,7 +5662,7 @@ func (q *sqlQuerier) GetTemplates(ctx context.Context) ([]Template, error) {
 
 const getTemplatesWithFilter = `-- name: GetTemplatesWithFilter :many
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, max_ttl, allow_user_autostart, allow_user_autostop, failure_ttl, inactivity_ttl, locked_ttl, restart_requirement_days_of_week, restart_requirement_weeks, created_by_avatar_url, created_by_username
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, max_ttl, allow_user_autostart, allow_user_autostop, failure_ttl, inactivity_ttl, locked_ttl, restart_requirement_days_of_week, restart_requirement_weeks, deprecated, replacement_template_id, require_active_version, created_by_avatar_url, created_by_username
 FROM
 	template_with_users AS templates
 WHERE
@@ -4422,6 +5737,9 @@ func (q *sqlQuerier) GetTemplatesWithFilter(ctx context.Context, arg GetTemplate
 			&i.LockedTTL,
 			&i.RestartRequirementDaysOfWeek,
 			&i.RestartRequirementWeeks,
+			&i.Deprecated,
+			&i.ReplacementTemplateID,
+			&i.RequireActiveVersion,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 		); err != nil {
@@ -4569,19 +5887,25 @@ SET
 	name = $4,
 	icon = $5,
 	display_name = $6,
-	allow_user_cancel_workspace_jobs = $7
+	allow_user_cancel_workspace_jobs = $7,
+	deprecated = $8,
+	replacement_template_id = $9,
+	require_active_version = $10
 WHERE
 	id = $1
 `
 
 type UpdateTemplateMetaByIDParams struct {
-	ID                           uuid.UUID `db:"id" json:"id"`
-	UpdatedAt                    time.Time `db:"updated_at" json:"updated_at"`
-	Description                  string    `db:"description" json:"description"`
-	Name                         string    `db:"name" json:"name"`
-	Icon                         string    `db:"icon" json:"icon"`
-	DisplayName                  string    `db:"display_name" json:"display_name"`
-	AllowUserCancelWorkspaceJobs bool      `db:"allow_user_cancel_workspace_jobs" json:"allow_user_cancel_workspace_jobs"`
+	ID                           uuid.UUID     `db:"id" json:"id"`
+	UpdatedAt                    time.Time     `db:"updated_at" json:"updated_at"`
+	Description                  string        `db:"description" json:"description"`
+	Name                         string        `db:"name" json:"name"`
+	Icon                         string        `db:"icon" json:"icon"`
+	DisplayName                  string        `db:"display_name" json:"display_name"`
+	AllowUserCancelWorkspaceJobs bool          `db:"allow_user_cancel_workspace_jobs" json:"allow_user_cancel_workspace_jobs"`
+	Deprecated                   string        `db:"deprecated" json:"deprecated"`
+	ReplacementTemplateID        uuid.NullUUID `db:"replacement_template_id" json:"replacement_template_id"`
+	RequireActiveVersion         bool          `db:"require_active_version" json:"require_active_version"`
 }
 
 func (q *sqlQuerier) UpdateTemplateMetaByID(ctx context.Context, arg UpdateTemplateMetaByIDParams) error {
@@ -4593,6 +5917,9 @@ func (q *sqlQuerier) UpdateTemplateMetaByID(ctx context.Context, arg UpdateTempl
 		arg.Icon,
 		arg.DisplayName,
 		arg.AllowUserCancelWorkspaceJobs,
+		arg.Deprecated,
+		arg.ReplacementTemplateID,
+		arg.RequireActiveVersion,
 	)
 	return err
 }
@@ -4799,7 +6126,7 @@ func (q *sqlQuerier) InsertTemplateVersionParameter(ctx context.Context, arg Ins
 
 const getPreviousTemplateVersion = `-- name: GetPreviousTemplateVersion :one
 SELECT
-	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, created_by_avatar_url, created_by_username
+	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, git_url, git_commit_sha, archived, created_by_avatar_url, created_by_username
 FROM
 	template_version_with_user AS template_versions
 WHERE
@@ -4835,6 +6162,9 @@ func (q *sqlQuerier) GetPreviousTemplateVersion(ctx context.Context, arg GetPrev
 		&i.CreatedBy,
 		pq.Array(&i.GitAuthProviders),
 		&i.Message,
+		&i.GitURL,
+		&i.GitCommitSHA,
+		&i.Archived,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 	)
@@ -4843,7 +6173,7 @@ func (q *sqlQuerier) GetPreviousTemplateVersion(ctx context.Context, arg GetPrev
 
 const getTemplateVersionByID = `-- name: GetTemplateVersionByID :one
 SELECT
-	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, created_by_avatar_url, created_by_username
+	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, git_url, git_commit_sha, archived, created_by_avatar_url, created_by_username
 FROM
 	template_version_with_user AS template_versions
 WHERE
@@ -4865,6 +6195,9 @@ func (q *sqlQuerier) GetTemplateVersionByID(ctx context.Context, id uuid.UUID) (
 		&i.CreatedBy,
 		pq.Array(&i.GitAuthProviders),
 		&i.Message,
+		&i.GitURL,
+		&i.GitCommitSHA,
+		&i.Archived,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 	)
@@ -4873,7 +6206,7 @@ func (q *sqlQuerier) GetTemplateVersionByID(ctx context.Context, id uuid.UUID) (
 
 const getTemplateVersionByJobID = `-- name: GetTemplateVersionByJobID :one
 SELECT
-	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, created_by_avatar_url, created_by_username
+	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, git_url, git_commit_sha, archived, created_by_avatar_url, created_by_username
 FROM
 	template_version_with_user AS template_versions
 WHERE
@@ -4895,6 +6228,9 @@ func (q *sqlQuerier) GetTemplateVersionByJobID(ctx context.Context, jobID uuid.U
 		&i.CreatedBy,
 		pq.Array(&i.GitAuthProviders),
 		&i.Message,
+		&i.GitURL,
+		&i.GitCommitSHA,
+		&i.Archived,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 	)
@@ -4903,7 +6239,7 @@ func (q *sqlQuerier) GetTemplateVersionByJobID(ctx context.Context, jobID uuid.U
 
 const getTemplateVersionByTemplateIDAndName = `-- name: GetTemplateVersionByTemplateIDAndName :one
 SELECT
-	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, created_by_avatar_url, created_by_username
+	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, git_url, git_commit_sha, archived, created_by_avatar_url, created_by_username
 FROM
 	template_version_with_user AS template_versions
 WHERE
@@ -4931,6 +6267,9 @@ func (q *sqlQuerier) GetTemplateVersionByTemplateIDAndName(ctx context.Context,
 		&i.CreatedBy,
 		pq.Array(&i.GitAuthProviders),
 		&i.Message,
+		&i.GitURL,
+		&i.GitCommitSHA,
+		&i.Archived,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 	)
@@ -4939,7 +6278,7 @@ func (q *sqlQuerier) GetTemplateVersionByTemplateIDAndName(ctx context.Context,
 
 const getTemplateVersionsByIDs = `-- name: GetTemplateVersionsByIDs :many
 SELECT
-	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, created_by_avatar_url, created_by_username
+	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, git_url, git_commit_sha, archived, created_by_avatar_url, created_by_username
 FROM
 	template_version_with_user AS template_versions
 WHERE
@@ -4967,6 +6306,9 @@ func (q *sqlQuerier) GetTemplateVersionsByIDs(ctx context.Context, ids []uuid.UU
 			&i.CreatedBy,
 			pq.Array(&i.GitAuthProviders),
 			&i.Message,
+			&i.GitURL,
+			&i.GitCommitSHA,
+			&i.Archived,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 		); err != nil {
@@ -4985,7 +6327,7 @@ func (q *sqlQuerier) GetTemplateVersionsByIDs(ctx context.Context, ids []uuid.UU
 
 const getTemplateVersionsByTemplateID = `-- name: GetTemplateVersionsByTemplateID :many
 SELECT
-	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, created_by_avatar_url, created_by_username
+	id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, git_url, git_commit_sha, archived, created_by_avatar_url, created_by_username
 FROM
 	template_version_with_user AS template_versions
 WHERE
@@ -5009,26 +6351,34 @@ WHERE
 		)
 		ELSE true
 	END
+	-- Filter by archived state, all versions are returned if unset.
+	AND CASE
+		WHEN $3 :: boolean IS NOT NULL THEN
+			archived = $3 :: boolean
+		ELSE true
+	END
 ORDER BY
     -- Deterministic and consistent ordering of all rows, even if they share
     -- a timestamp. This is to ensure consistent pagination.
-	(created_at, id) ASC OFFSET $3
+	(created_at, id) ASC OFFSET $4
 LIMIT
 	-- A null limit means "no limit", so 0 means return all
-	NULLIF($4 :: int, 0)
+	NULLIF($5 :: int, 0)
 `
 
 type GetTemplateVersionsByTemplateIDParams struct {
-	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
-	AfterID    uuid.UUID `db:"after_id" json:"after_id"`
-	OffsetOpt  int32     `db:"offset_opt" json:"offset_opt"`
-	LimitOpt   int32     `db:"limit_opt" json:"limit_opt"`
+	TemplateID uuid.UUID    `db:"template_id" json:"template_id"`
+	AfterID    uuid.UUID    `db:"after_id" json:"after_id"`
+	Archived   sql.NullBool `db:"archived" json:"archived"`
+	OffsetOpt  int32        `db:"offset_opt" json:"offset_opt"`
+	LimitOpt   int32        `db:"limit_opt" json:"limit_opt"`
 }
 
 func (q *sqlQuerier) GetTemplateVersionsByTemplateID(ctx context.Context, arg GetTemplateVersionsByTemplateIDParams) ([]TemplateVersion, error) {
 	rows, err := q.db.QueryContext(ctx, getTemplateVersionsByTemplateID,
 		arg.TemplateID,
 		arg.AfterID,
+		arg.Archived,
 		arg.OffsetOpt,
 		arg.LimitOpt,
 	)
@@ -5051,6 +6401,9 @@ func (q *sqlQuerier) GetTemplateVersionsByTemplateID(ctx context.Context, arg Ge
 			&i.CreatedBy,
 			pq.Array(&i.GitAuthProviders),
 			&i.Message,
+			&i.GitURL,
+			&i.GitCommitSHA,
+			&i.Archived,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 		); err != nil {
@@ -5068,7 +6421,7 @@ func (q *sqlQuerier) GetTemplateVersionsByTemplateID(ctx context.Context, arg Ge
 }
 
 const getTemplateVersionsCreatedAfter = `-- name: GetTemplateVersionsCreatedAfter :many
-SELECT id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, created_by_avatar_url, created_by_username FROM template_version_with_user AS template_versions WHERE created_at > $1
+SELECT id, template_id, organization_id, created_at, updated_at, name, readme, job_id, created_by, git_auth_providers, message, git_url, git_commit_sha, archived, created_by_avatar_url, created_by_username FROM template_version_with_user AS template_versions WHERE created_at > $1
 `
 
 func (q *sqlQuerier) GetTemplateVersionsCreatedAfter(ctx context.Context, createdAt time.Time) ([]TemplateVersion, error) {
@@ -5092,6 +6445,9 @@ func (q *sqlQuerier) GetTemplateVersionsCreatedAfter(ctx context.Context, create
 			&i.CreatedBy,
 			pq.Array(&i.GitAuthProviders),
 			&i.Message,
+			&i.GitURL,
+			&i.GitCommitSHA,
+			&i.Archived,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 		); err != nil {
@@ -5120,10 +6476,12 @@ INSERT INTO
 		message,
 		readme,
 		job_id,
-		created_by
+		created_by,
+		git_url,
+		git_commit_sha
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 `
 
 type InsertTemplateVersionParams struct {
@@ -5137,6 +6495,8 @@ type InsertTemplateVersionParams struct {
 	Readme         string        `db:"readme" json:"readme"`
 	JobID          uuid.UUID     `db:"job_id" json:"job_id"`
 	CreatedBy      uuid.UUID     `db:"created_by" json:"created_by"`
+	GitURL         string        `db:"git_url" json:"git_url"`
+	GitCommitSHA   string        `db:"git_commit_sha" json:"git_commit_sha"`
 }
 
 func (q *sqlQuerier) InsertTemplateVersion(ctx context.Context, arg InsertTemplateVersionParams) error {
@@ -5151,6 +6511,8 @@ func (q *sqlQuerier) InsertTemplateVersion(ctx context.Context, arg InsertTempla
 		arg.Readme,
 		arg.JobID,
 		arg.CreatedBy,
+		arg.GitURL,
+		arg.GitCommitSHA,
 	)
 	return err
 }
@@ -5186,6 +6548,27 @@ func (q *sqlQuerier) UpdateTemplateVersionByID(ctx context.Context, arg UpdateTe
 	return err
 }
 
+const updateTemplateVersionArchivedByID = `-- name: UpdateTemplateVersionArchivedByID :exec
+UPDATE
+	template_versions
+SET
+	archived = $2,
+	updated_at = $3
+WHERE
+	id = $1
+`
+
+type UpdateTemplateVersionArchivedByIDParams struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	Archived  bool      `db:"archived" json:"archived"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpdateTemplateVersionArchivedByID(ctx context.Context, arg UpdateTemplateVersionArchivedByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateTemplateVersionArchivedByID, arg.ID, arg.Archived, arg.UpdatedAt)
+	return err
+}
+
 const updateTemplateVersionDescriptionByJobID = `-- name: UpdateTemplateVersionDescriptionByJobID :exec
 UPDATE
 	template_versions
@@ -7884,6 +9267,49 @@ func (q *sqlQuerier) InsertWorkspaceBuildParameters(ctx context.Context, arg Ins
 	return err
 }
 
+const deleteOldWorkspaceBuildProvisionerState = `-- name: DeleteOldWorkspaceBuildProvisionerState :execrows
+UPDATE
+	workspace_builds
+SET
+	provisioner_state = NULL
+WHERE
+	id IN (
+		SELECT
+			workspace_builds.id
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.provisioner_state IS NOT NULL
+			AND workspace_builds.build_number <= (
+				SELECT
+					MAX(latest.build_number)
+				FROM
+					workspace_builds AS latest
+				WHERE
+					latest.workspace_id = workspace_builds.workspace_id
+			) - $1 :: bigint
+		LIMIT
+			$2
+	)
+`
+
+type DeleteOldWorkspaceBuildProvisionerStateParams struct {
+	KeepBuilds int64 `db:"keep_builds" json:"keep_builds"`
+	LimitCount int32 `db:"limit_count" json:"limit_count"`
+}
+
+// Deletes the provisioner state of all but the most recent builds of each
+// workspace. New builds copy the state of the latest build, so the latest
+// build must always be kept. Build numbers of a workspace have no gaps, so the
+// newest builds are found with the latest build number alone.
+func (q *sqlQuerier) DeleteOldWorkspaceBuildProvisionerState(ctx context.Context, arg DeleteOldWorkspaceBuildProvisionerStateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteOldWorkspaceBuildProvisionerState, arg.KeepBuilds, arg.LimitCount)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getLatestWorkspaceBuildByWorkspaceID = `-- name: GetLatestWorkspaceBuildByWorkspaceID :one
 SELECT
 	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, initiator_by_avatar_url, initiator_by_username
@@ -8142,6 +9568,73 @@ func (q *sqlQuerier) GetWorkspaceBuildByWorkspaceIDAndBuildNumber(ctx context.Co
 	return i, err
 }
 
+const getWorkspaceBuildStateSummariesByWorkspaceID = `-- name: GetWorkspaceBuildStateSummariesByWorkspaceID :many
+SELECT
+	id,
+	build_number,
+	transition,
+	template_version_id,
+	created_at,
+	coalesce(octet_length(provisioner_state), 0) :: int AS provisioner_state_size
+FROM
+	workspace_builds
+WHERE
+	workspace_id = $1
+ORDER BY
+	build_number DESC
+OFFSET $2
+LIMIT
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF($3 :: int, 0)
+`
+
+type GetWorkspaceBuildStateSummariesByWorkspaceIDParams struct {
+	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
+	OffsetOpt   int32     `db:"offset_opt" json:"offset_opt"`
+	LimitOpt    int32     `db:"limit_opt" json:"limit_opt"`
+}
+
+type GetWorkspaceBuildStateSummariesByWorkspaceIDRow struct {
+	ID                   uuid.UUID           `db:"id" json:"id"`
+	BuildNumber          int32               `db:"build_number" json:"build_number"`
+	Transition           WorkspaceTransition `db:"transition" json:"transition"`
+	TemplateVersionID    uuid.UUID           `db:"template_version_id" json:"template_version_id"`
+	CreatedAt            time.Time           `db:"created_at" json:"created_at"`
+	ProvisionerStateSize int32               `db:"provisioner_state_size" json:"provisioner_state_size"`
+}
+
+// Returns the size of the provisioner state of each build instead of the
+// state itself, which may be large.
+func (q *sqlQuerier) GetWorkspaceBuildStateSummariesByWorkspaceID(ctx context.Context, arg GetWorkspaceBuildStateSummariesByWorkspaceIDParams) ([]GetWorkspaceBuildStateSummariesByWorkspaceIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceBuildStateSummariesByWorkspaceID, arg.WorkspaceID, arg.OffsetOpt, arg.LimitOpt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWorkspaceBuildStateSummariesByWorkspaceIDRow
+	for rows.Next() {
+		var i GetWorkspaceBuildStateSummariesByWorkspaceIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BuildNumber,
+			&i.Transition,
+			&i.TemplateVersionID,
+			&i.CreatedAt,
+			&i.ProvisionerStateSize,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWorkspaceBuildsByWorkspaceID = `-- name: GetWorkspaceBuildsByWorkspaceID :many
 SELECT
 	id, created_at, updated_at, workspace_id, template_version_id, build_number, transition, initiator_id, provisioner_state, job_id, deadline, reason, daily_cost, max_deadline, initiator_by_avatar_url, initiator_by_username
@@ -8247,19 +9740,364 @@ func (q *sqlQuerier) GetWorkspaceBuildsCreatedAfter(ctx context.Context, created
 			&i.ID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.WorkspaceID,
-			&i.TemplateVersionID,
-			&i.BuildNumber,
-			&i.Transition,
-			&i.InitiatorID,
-			&i.ProvisionerState,
-			&i.JobID,
-			&i.Deadline,
-			&i.Reason,
-			&i.DailyCost,
-			&i.MaxDeadline,
-			&i.InitiatorByAvatarUrl,
-			&i.InitiatorByUsername,
+			&i.WorkspaceID,
+			&i.TemplateVersionID,
+			&i.BuildNumber,
+			&i.Transition,
+			&i.InitiatorID,
+			&i.ProvisionerState,
+			&i.JobID,
+			&i.Deadline,
+			&i.Reason,
+			&i.DailyCost,
+			&i.MaxDeadline,
+			&i.InitiatorByAvatarUrl,
+			&i.InitiatorByUsername,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertWorkspaceBuild = `-- name: InsertWorkspaceBuild :exec
+INSERT INTO
+	workspace_builds (
+		id,
+		created_at,
+		updated_at,
+		workspace_id,
+		template_version_id,
+		"build_number",
+		transition,
+		initiator_id,
+		job_id,
+		provisioner_state,
+		deadline,
+		max_deadline,
+		reason
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+`
+
+type InsertWorkspaceBuildParams struct {
+	ID                uuid.UUID           `db:"id" json:"id"`
+	CreatedAt         time.Time           `db:"created_at" json:"created_at"`
+	UpdatedAt         time.Time           `db:"updated_at" json:"updated_at"`
+	WorkspaceID       uuid.UUID           `db:"workspace_id" json:"workspace_id"`
+	TemplateVersionID uuid.UUID           `db:"template_version_id" json:"template_version_id"`
+	BuildNumber       int32               `db:"build_number" json:"build_number"`
+	Transition        WorkspaceTransition `db:"transition" json:"transition"`
+	InitiatorID       uuid.UUID           `db:"initiator_id" json:"initiator_id"`
+	JobID             uuid.UUID           `db:"job_id" json:"job_id"`
+	ProvisionerState  []byte              `db:"provisioner_state" json:"provisioner_state"`
+	Deadline          time.Time           `db:"deadline" json:"deadline"`
+	MaxDeadline       time.Time           `db:"max_deadline" json:"max_deadline"`
+	Reason            BuildReason         `db:"reason" json:"reason"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceBuild(ctx context.Context, arg InsertWorkspaceBuildParams) error {
+	_, err := q.db.ExecContext(ctx, insertWorkspaceBuild,
+		arg.ID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.WorkspaceID,
+		arg.TemplateVersionID,
+		arg.BuildNumber,
+		arg.Transition,
+		arg.InitiatorID,
+		arg.JobID,
+		arg.ProvisionerState,
+		arg.Deadline,
+		arg.MaxDeadline,
+		arg.Reason,
+	)
+	return err
+}
+
+const updateWorkspaceBuildByID = `-- name: UpdateWorkspaceBuildByID :exec
+UPDATE
+	workspace_builds
+SET
+	updated_at = $2,
+	provisioner_state = $3,
+	deadline = $4,
+	max_deadline = $5
+WHERE
+	id = $1
+`
+
+type UpdateWorkspaceBuildByIDParams struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
+	ProvisionerState []byte    `db:"provisioner_state" json:"provisioner_state"`
+	Deadline         time.Time `db:"deadline" json:"deadline"`
+	MaxDeadline      time.Time `db:"max_deadline" json:"max_deadline"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceBuildByID(ctx context.Context, arg UpdateWorkspaceBuildByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildByID,
+		arg.ID,
+		arg.UpdatedAt,
+		arg.ProvisionerState,
+		arg.Deadline,
+		arg.MaxDeadline,
+	)
+	return err
+}
+
+const updateWorkspaceBuildCostByID = `-- name: UpdateWorkspaceBuildCostByID :exec
+UPDATE
+	workspace_builds
+SET
+	daily_cost = $2
+WHERE
+	id = $1
+`
+
+type UpdateWorkspaceBuildCostByIDParams struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	DailyCost int32     `db:"daily_cost" json:"daily_cost"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceBuildCostByID(ctx context.Context, arg UpdateWorkspaceBuildCostByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildCostByID, arg.ID, arg.DailyCost)
+	return err
+}
+
+const completeStaleWorkspaceBulkOperations = `-- name: CompleteStaleWorkspaceBulkOperations :many
+WITH stale AS (
+	UPDATE
+		workspace_bulk_operations
+	SET
+		completed_at = $1 :: timestamptz
+	WHERE
+		completed_at IS NULL
+		AND heartbeat_at < $2 :: timestamptz
+	RETURNING id
+), failed AS (
+	UPDATE
+		workspace_bulk_operation_workspaces
+	SET
+		status = 'failed',
+		error = $3 :: text,
+		updated_at = $1 :: timestamptz
+	FROM
+		stale
+	WHERE
+		workspace_bulk_operation_workspaces.operation_id = stale.id
+		AND workspace_bulk_operation_workspaces.status IN ('pending', 'building')
+)
+SELECT id FROM stale
+`
+
+type CompleteStaleWorkspaceBulkOperationsParams struct {
+	CompletedAt     time.Time `db:"completed_at" json:"completed_at"`
+	HeartbeatBefore time.Time `db:"heartbeat_before" json:"heartbeat_before"`
+	Error           string    `db:"error" json:"error"`
+}
+
+// CompleteStaleWorkspaceBulkOperations completes the operations that have not
+// had a heartbeat since heartbeat_before, because the replica running them
+// stopped, and fails their unfinished workspaces.
+func (q *sqlQuerier) CompleteStaleWorkspaceBulkOperations(ctx context.Context, arg CompleteStaleWorkspaceBulkOperationsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, completeStaleWorkspaceBulkOperations, arg.CompletedAt, arg.HeartbeatBefore, arg.Error)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceBulkOperationByID = `-- name: GetWorkspaceBulkOperationByID :one
+SELECT
+	id, created_at, initiator_id, transition, query, concurrency, completed_at, heartbeat_at
+FROM
+	workspace_bulk_operations
+WHERE
+	id = $1
+LIMIT
+	1
+`
+
+func (q *sqlQuerier) GetWorkspaceBulkOperationByID(ctx context.Context, id uuid.UUID) (WorkspaceBulkOperation, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceBulkOperationByID, id)
+	var i WorkspaceBulkOperation
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.InitiatorID,
+		&i.Transition,
+		&i.Query,
+		&i.Concurrency,
+		&i.CompletedAt,
+		&i.HeartbeatAt,
+	)
+	return i, err
+}
+
+const getWorkspaceBulkOperationWorkspacesByOperationID = `-- name: GetWorkspaceBulkOperationWorkspacesByOperationID :many
+SELECT
+	workspace_bulk_operation_workspaces.operation_id, workspace_bulk_operation_workspaces.workspace_id, workspace_bulk_operation_workspaces.status, workspace_bulk_operation_workspaces.workspace_build_id, workspace_bulk_operation_workspaces.error, workspace_bulk_operation_workspaces.updated_at,
+	workspaces.name AS workspace_name,
+	users.username AS workspace_owner_name
+FROM
+	workspace_bulk_operation_workspaces
+INNER JOIN
+	workspaces ON workspaces.id = workspace_bulk_operation_workspaces.workspace_id
+INNER JOIN
+	users ON users.id = workspaces.owner_id
+WHERE
+	workspace_bulk_operation_workspaces.operation_id = $1
+ORDER BY
+	users.username ASC,
+	workspaces.name ASC
+`
+
+type GetWorkspaceBulkOperationWorkspacesByOperationIDRow struct {
+	OperationID        uuid.UUID                             `db:"operation_id" json:"operation_id"`
+	WorkspaceID        uuid.UUID                             `db:"workspace_id" json:"workspace_id"`
+	Status             WorkspaceBulkOperationWorkspaceStatus `db:"status" json:"status"`
+	WorkspaceBuildID   uuid.NullUUID                         `db:"workspace_build_id" json:"workspace_build_id"`
+	Error              string                                `db:"error" json:"error"`
+	UpdatedAt          time.Time                             `db:"updated_at" json:"updated_at"`
+	WorkspaceName      string                                `db:"workspace_name" json:"workspace_name"`
+	WorkspaceOwnerName string                                `db:"workspace_owner_name" json:"workspace_owner_name"`
+}
+
+func (q *sqlQuerier) GetWorkspaceBulkOperationWorkspacesByOperationID(ctx context.Context, operationID uuid.UUID) ([]GetWorkspaceBulkOperationWorkspacesByOperationIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceBulkOperationWorkspacesByOperationID, operationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWorkspaceBulkOperationWorkspacesByOperationIDRow
+	for rows.Next() {
+		var i GetWorkspaceBulkOperationWorkspacesByOperationIDRow
+		if err := rows.Scan(
+			&i.OperationID,
+			&i.WorkspaceID,
+			&i.Status,
+			&i.WorkspaceBuildID,
+			&i.Error,
+			&i.UpdatedAt,
+			&i.WorkspaceName,
+			&i.WorkspaceOwnerName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertWorkspaceBulkOperation = `-- name: InsertWorkspaceBulkOperation :one
+INSERT INTO
+	workspace_bulk_operations (
+		id,
+		created_at,
+		initiator_id,
+		transition,
+		query,
+		concurrency,
+		heartbeat_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $2) RETURNING id, created_at, initiator_id, transition, query, concurrency, completed_at, heartbeat_at
+`
+
+type InsertWorkspaceBulkOperationParams struct {
+	ID          uuid.UUID                        `db:"id" json:"id"`
+	CreatedAt   time.Time                        `db:"created_at" json:"created_at"`
+	InitiatorID uuid.UUID                        `db:"initiator_id" json:"initiator_id"`
+	Transition  WorkspaceBulkOperationTransition `db:"transition" json:"transition"`
+	Query       string                           `db:"query" json:"query"`
+	Concurrency int32                            `db:"concurrency" json:"concurrency"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceBulkOperation(ctx context.Context, arg InsertWorkspaceBulkOperationParams) (WorkspaceBulkOperation, error) {
+	row := q.db.QueryRowContext(ctx, insertWorkspaceBulkOperation,
+		arg.ID,
+		arg.CreatedAt,
+		arg.InitiatorID,
+		arg.Transition,
+		arg.Query,
+		arg.Concurrency,
+	)
+	var i WorkspaceBulkOperation
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.InitiatorID,
+		&i.Transition,
+		&i.Query,
+		&i.Concurrency,
+		&i.CompletedAt,
+		&i.HeartbeatAt,
+	)
+	return i, err
+}
+
+const insertWorkspaceBulkOperationWorkspaces = `-- name: InsertWorkspaceBulkOperationWorkspaces :many
+INSERT INTO
+	workspace_bulk_operation_workspaces (operation_id, workspace_id, updated_at)
+SELECT
+	$1 :: uuid AS operation_id,
+	unnest($2 :: uuid [ ]) AS workspace_id,
+	$3 :: timestamptz AS updated_at
+RETURNING operation_id, workspace_id, status, workspace_build_id, error, updated_at
+`
+
+type InsertWorkspaceBulkOperationWorkspacesParams struct {
+	OperationID  uuid.UUID   `db:"operation_id" json:"operation_id"`
+	WorkspaceIDs []uuid.UUID `db:"workspace_ids" json:"workspace_ids"`
+	UpdatedAt    time.Time   `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceBulkOperationWorkspaces(ctx context.Context, arg InsertWorkspaceBulkOperationWorkspacesParams) ([]WorkspaceBulkOperationWorkspace, error) {
+	rows, err := q.db.QueryContext(ctx, insertWorkspaceBulkOperationWorkspaces, arg.OperationID, pq.Array(arg.WorkspaceIDs), arg.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceBulkOperationWorkspace
+	for rows.Next() {
+		var i WorkspaceBulkOperationWorkspace
+		if err := rows.Scan(
+			&i.OperationID,
+			&i.WorkspaceID,
+			&i.Status,
+			&i.WorkspaceBuildID,
+			&i.Error,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -8274,109 +10112,75 @@ func (q *sqlQuerier) GetWorkspaceBuildsCreatedAfter(ctx context.Context, created
 	return items, nil
 }
 
-const insertWorkspaceBuild = `-- name: InsertWorkspaceBuild :exec
-INSERT INTO
-	workspace_builds (
-		id,
-		created_at,
-		updated_at,
-		workspace_id,
-		template_version_id,
-		"build_number",
-		transition,
-		initiator_id,
-		job_id,
-		provisioner_state,
-		deadline,
-		max_deadline,
-		reason
-	)
-VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+const updateWorkspaceBulkOperationCompletedAt = `-- name: UpdateWorkspaceBulkOperationCompletedAt :exec
+UPDATE
+	workspace_bulk_operations
+SET
+	completed_at = $2
+WHERE
+	id = $1
 `
 
-type InsertWorkspaceBuildParams struct {
-	ID                uuid.UUID           `db:"id" json:"id"`
-	CreatedAt         time.Time           `db:"created_at" json:"created_at"`
-	UpdatedAt         time.Time           `db:"updated_at" json:"updated_at"`
-	WorkspaceID       uuid.UUID           `db:"workspace_id" json:"workspace_id"`
-	TemplateVersionID uuid.UUID           `db:"template_version_id" json:"template_version_id"`
-	BuildNumber       int32               `db:"build_number" json:"build_number"`
-	Transition        WorkspaceTransition `db:"transition" json:"transition"`
-	InitiatorID       uuid.UUID           `db:"initiator_id" json:"initiator_id"`
-	JobID             uuid.UUID           `db:"job_id" json:"job_id"`
-	ProvisionerState  []byte              `db:"provisioner_state" json:"provisioner_state"`
-	Deadline          time.Time           `db:"deadline" json:"deadline"`
-	MaxDeadline       time.Time           `db:"max_deadline" json:"max_deadline"`
-	Reason            BuildReason         `db:"reason" json:"reason"`
+type UpdateWorkspaceBulkOperationCompletedAtParams struct {
+	ID          uuid.UUID    `db:"id" json:"id"`
+	CompletedAt sql.NullTime `db:"completed_at" json:"completed_at"`
 }
 
-func (q *sqlQuerier) InsertWorkspaceBuild(ctx context.Context, arg InsertWorkspaceBuildParams) error {
-	_, err := q.db.ExecContext(ctx, insertWorkspaceBuild,
-		arg.ID,
-		arg.CreatedAt,
-		arg.UpdatedAt,
-		arg.WorkspaceID,
-		arg.TemplateVersionID,
-		arg.BuildNumber,
-		arg.Transition,
-		arg.InitiatorID,
-		arg.JobID,
-		arg.ProvisionerState,
-		arg.Deadline,
-		arg.MaxDeadline,
-		arg.Reason,
-	)
+func (q *sqlQuerier) UpdateWorkspaceBulkOperationCompletedAt(ctx context.Context, arg UpdateWorkspaceBulkOperationCompletedAtParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBulkOperationCompletedAt, arg.ID, arg.CompletedAt)
 	return err
 }
 
-const updateWorkspaceBuildByID = `-- name: UpdateWorkspaceBuildByID :exec
+const updateWorkspaceBulkOperationHeartbeat = `-- name: UpdateWorkspaceBulkOperationHeartbeat :exec
 UPDATE
-	workspace_builds
+	workspace_bulk_operations
 SET
-	updated_at = $2,
-	provisioner_state = $3,
-	deadline = $4,
-	max_deadline = $5
+	heartbeat_at = $2
 WHERE
 	id = $1
 `
 
-type UpdateWorkspaceBuildByIDParams struct {
-	ID               uuid.UUID `db:"id" json:"id"`
-	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
-	ProvisionerState []byte    `db:"provisioner_state" json:"provisioner_state"`
-	Deadline         time.Time `db:"deadline" json:"deadline"`
-	MaxDeadline      time.Time `db:"max_deadline" json:"max_deadline"`
+type UpdateWorkspaceBulkOperationHeartbeatParams struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	HeartbeatAt time.Time `db:"heartbeat_at" json:"heartbeat_at"`
 }
 
-func (q *sqlQuerier) UpdateWorkspaceBuildByID(ctx context.Context, arg UpdateWorkspaceBuildByIDParams) error {
-	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildByID,
-		arg.ID,
-		arg.UpdatedAt,
-		arg.ProvisionerState,
-		arg.Deadline,
-		arg.MaxDeadline,
-	)
+func (q *sqlQuerier) UpdateWorkspaceBulkOperationHeartbeat(ctx context.Context, arg UpdateWorkspaceBulkOperationHeartbeatParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBulkOperationHeartbeat, arg.ID, arg.HeartbeatAt)
 	return err
 }
 
-const updateWorkspaceBuildCostByID = `-- name: UpdateWorkspaceBuildCostByID :exec
+const updateWorkspaceBulkOperationWorkspace = `-- name: UpdateWorkspaceBulkOperationWorkspace :exec
 UPDATE
-	workspace_builds
+	workspace_bulk_operation_workspaces
 SET
-	daily_cost = $2
+	status = $3,
+	workspace_build_id = $4,
+	error = $5,
+	updated_at = $6
 WHERE
-	id = $1
+	operation_id = $1
+	AND workspace_id = $2
 `
 
-type UpdateWorkspaceBuildCostByIDParams struct {
-	ID        uuid.UUID `db:"id" json:"id"`
-	DailyCost int32     `db:"daily_cost" json:"daily_cost"`
+type UpdateWorkspaceBulkOperationWorkspaceParams struct {
+	OperationID      uuid.UUID                             `db:"operation_id" json:"operation_id"`
+	WorkspaceID      uuid.UUID                             `db:"workspace_id" json:"workspace_id"`
+	Status           WorkspaceBulkOperationWorkspaceStatus `db:"status" json:"status"`
+	WorkspaceBuildID uuid.NullUUID                         `db:"workspace_build_id" json:"workspace_build_id"`
+	Error            string                                `db:"error" json:"error"`
+	UpdatedAt        time.Time                             `db:"updated_at" json:"updated_at"`
 }
 
-func (q *sqlQuerier) UpdateWorkspaceBuildCostByID(ctx context.Context, arg UpdateWorkspaceBuildCostByIDParams) error {
-	_, err := q.db.ExecContext(ctx, updateWorkspaceBuildCostByID, arg.ID, arg.DailyCost)
+func (q *sqlQuerier) UpdateWorkspaceBulkOperationWorkspace(ctx context.Context, arg UpdateWorkspaceBulkOperationWorkspaceParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceBulkOperationWorkspace,
+		arg.OperationID,
+		arg.WorkspaceID,
+		arg.Status,
+		arg.WorkspaceBuildID,
+		arg.Error,
+		arg.UpdatedAt,
+	)
 	return err
 }
 
@@ -8790,7 +10594,7 @@ func (q *sqlQuerier) GetDeploymentWorkspaceStats(ctx context.Context) (GetDeploy
 
 const getWorkspaceByAgentID = `-- name: GetWorkspaceByAgentID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at, user_acl, group_acl, autostart_skip_until
 FROM
 	workspaces
 WHERE
@@ -8835,13 +10639,16 @@ func (q *sqlQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUI
 		&i.LastUsedAt,
 		&i.LockedAt,
 		&i.DeletingAt,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.AutostartSkipUntil,
 	)
 	return i, err
 }
 
 const getWorkspaceByID = `-- name: GetWorkspaceByID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at, user_acl, group_acl, autostart_skip_until
 FROM
 	workspaces
 WHERE
@@ -8867,13 +10674,52 @@ func (q *sqlQuerier) GetWorkspaceByID(ctx context.Context, id uuid.UUID) (Worksp
 		&i.LastUsedAt,
 		&i.LockedAt,
 		&i.DeletingAt,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.AutostartSkipUntil,
+	)
+	return i, err
+}
+
+const getWorkspaceByIDForUpdate = `-- name: GetWorkspaceByIDForUpdate :one
+SELECT
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at, user_acl, group_acl, autostart_skip_until
+FROM
+	workspaces
+WHERE
+	id = $1
+FOR UPDATE
+`
+
+// Locks the workspace row until the end of the transaction, so builds and
+// ownership transfers of the same workspace don't interleave.
+func (q *sqlQuerier) GetWorkspaceByIDForUpdate(ctx context.Context, id uuid.UUID) (Workspace, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceByIDForUpdate, id)
+	var i Workspace
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OwnerID,
+		&i.OrganizationID,
+		&i.TemplateID,
+		&i.Deleted,
+		&i.Name,
+		&i.AutostartSchedule,
+		&i.Ttl,
+		&i.LastUsedAt,
+		&i.LockedAt,
+		&i.DeletingAt,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.AutostartSkipUntil,
 	)
 	return i, err
 }
 
 const getWorkspaceByOwnerIDAndName = `-- name: GetWorkspaceByOwnerIDAndName :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at, user_acl, group_acl, autostart_skip_until
 FROM
 	workspaces
 WHERE
@@ -8906,13 +10752,16 @@ func (q *sqlQuerier) GetWorkspaceByOwnerIDAndName(ctx context.Context, arg GetWo
 		&i.LastUsedAt,
 		&i.LockedAt,
 		&i.DeletingAt,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.AutostartSkipUntil,
 	)
 	return i, err
 }
 
 const getWorkspaceByWorkspaceAppID = `-- name: GetWorkspaceByWorkspaceAppID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at, user_acl, group_acl, autostart_skip_until
 FROM
 	workspaces
 WHERE
@@ -8964,13 +10813,16 @@ func (q *sqlQuerier) GetWorkspaceByWorkspaceAppID(ctx context.Context, workspace
 		&i.LastUsedAt,
 		&i.LockedAt,
 		&i.DeletingAt,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.AutostartSkipUntil,
 	)
 	return i, err
 }
 
 const getWorkspaces = `-- name: GetWorkspaces :many
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.locked_at, workspaces.deleting_at,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.locked_at, workspaces.deleting_at, workspaces.user_acl, workspaces.group_acl, workspaces.autostart_skip_until,
 	COALESCE(template_name.template_name, 'unknown') as template_name,
 	latest_build.template_version_id,
 	latest_build.template_version_name,
@@ -9209,6 +11061,9 @@ type GetWorkspacesRow struct {
 	LastUsedAt          time.Time      `db:"last_used_at" json:"last_used_at"`
 	LockedAt            sql.NullTime   `db:"locked_at" json:"locked_at"`
 	DeletingAt          sql.NullTime   `db:"deleting_at" json:"deleting_at"`
+	UserACL             WorkspaceACL   `db:"user_acl" json:"user_acl"`
+	GroupACL            WorkspaceACL   `db:"group_acl" json:"group_acl"`
+	AutostartSkipUntil  sql.NullTime   `db:"autostart_skip_until" json:"autostart_skip_until"`
 	TemplateName        string         `db:"template_name" json:"template_name"`
 	TemplateVersionID   uuid.UUID      `db:"template_version_id" json:"template_version_id"`
 	TemplateVersionName sql.NullString `db:"template_version_name" json:"template_version_name"`
@@ -9251,6 +11106,9 @@ func (q *sqlQuerier) GetWorkspaces(ctx context.Context, arg GetWorkspacesParams)
 			&i.LastUsedAt,
 			&i.LockedAt,
 			&i.DeletingAt,
+			&i.UserACL,
+			&i.GroupACL,
+			&i.AutostartSkipUntil,
 			&i.TemplateName,
 			&i.TemplateVersionID,
 			&i.TemplateVersionName,
@@ -9269,9 +11127,83 @@ func (q *sqlQuerier) GetWorkspaces(ctx context.Context, arg GetWorkspacesParams)
 	return items, nil
 }
 
+const getWorkspacesAboutToAutostop = `-- name: GetWorkspacesAboutToAutostop :many
+SELECT
+	workspaces.id,
+	workspaces.owner_id,
+	workspaces.name,
+	workspace_builds.id AS build_id,
+	workspace_builds.deadline
+FROM
+	workspaces
+INNER JOIN
+	workspace_builds ON workspace_builds.workspace_id = workspaces.id
+INNER JOIN
+	provisioner_jobs ON workspace_builds.job_id = provisioner_jobs.id
+WHERE
+	workspace_builds.build_number = (
+		SELECT
+			MAX(build_number)
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.workspace_id = workspaces.id
+	)
+	AND workspace_builds.transition = 'start'::workspace_transition
+	AND provisioner_jobs.completed_at IS NOT NULL
+	AND (provisioner_jobs.error IS NULL OR provisioner_jobs.error = '')
+	AND workspace_builds.deadline > $1 :: timestamptz
+	AND workspace_builds.deadline <= $2 :: timestamptz
+	AND workspaces.deleted = false
+`
+
+type GetWorkspacesAboutToAutostopParams struct {
+	Now    time.Time `db:"now" json:"now"`
+	Before time.Time `db:"before" json:"before"`
+}
+
+type GetWorkspacesAboutToAutostopRow struct {
+	ID       uuid.UUID `db:"id" json:"id"`
+	OwnerID  uuid.UUID `db:"owner_id" json:"owner_id"`
+	Name     string    `db:"name" json:"name"`
+	BuildID  uuid.UUID `db:"build_id" json:"build_id"`
+	Deadline time.Time `db:"deadline" json:"deadline"`
+}
+
+// Returns the running workspaces whose latest build has a deadline in the
+// window (@now, @before].
+func (q *sqlQuerier) GetWorkspacesAboutToAutostop(ctx context.Context, arg GetWorkspacesAboutToAutostopParams) ([]GetWorkspacesAboutToAutostopRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspacesAboutToAutostop, arg.Now, arg.Before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWorkspacesAboutToAutostopRow
+	for rows.Next() {
+		var i GetWorkspacesAboutToAutostopRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.Name,
+			&i.BuildID,
+			&i.Deadline,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWorkspacesEligibleForTransition = `-- name: GetWorkspacesEligibleForTransition :many
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.locked_at, workspaces.deleting_at
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.locked_at, workspaces.deleting_at, workspaces.user_acl, workspaces.group_acl, workspaces.autostart_skip_until
 FROM
 	workspaces
 LEFT JOIN
@@ -9358,6 +11290,9 @@ func (q *sqlQuerier) GetWorkspacesEligibleForTransition(ctx context.Context, now
 			&i.LastUsedAt,
 			&i.LockedAt,
 			&i.DeletingAt,
+			&i.UserACL,
+			&i.GroupACL,
+			&i.AutostartSkipUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -9387,7 +11322,7 @@ INSERT INTO
 		last_used_at
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at, user_acl, group_acl, autostart_skip_until
 `
 
 type InsertWorkspaceParams struct {
@@ -9431,6 +11366,9 @@ func (q *sqlQuerier) InsertWorkspace(ctx context.Context, arg InsertWorkspacePar
 		&i.LastUsedAt,
 		&i.LockedAt,
 		&i.DeletingAt,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.AutostartSkipUntil,
 	)
 	return i, err
 }
@@ -9443,7 +11381,7 @@ SET
 WHERE
 	id = $1
 	AND deleted = false
-RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at
+RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at, user_acl, group_acl, autostart_skip_until
 `
 
 type UpdateWorkspaceParams struct {
@@ -9468,15 +11406,41 @@ func (q *sqlQuerier) UpdateWorkspace(ctx context.Context, arg UpdateWorkspacePar
 		&i.LastUsedAt,
 		&i.LockedAt,
 		&i.DeletingAt,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.AutostartSkipUntil,
 	)
 	return i, err
 }
 
+const updateWorkspaceACLByID = `-- name: UpdateWorkspaceACLByID :exec
+UPDATE
+	workspaces
+SET
+	group_acl = $1,
+	user_acl = $2
+WHERE
+	id = $3
+`
+
+type UpdateWorkspaceACLByIDParams struct {
+	GroupACL WorkspaceACL `db:"group_acl" json:"group_acl"`
+	UserACL  WorkspaceACL `db:"user_acl" json:"user_acl"`
+	ID       uuid.UUID    `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceACLByID(ctx context.Context, arg UpdateWorkspaceACLByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceACLByID, arg.GroupACL, arg.UserACL, arg.ID)
+	return err
+}
+
 const updateWorkspaceAutostart = `-- name: UpdateWorkspaceAutostart :exec
 UPDATE
 	workspaces
 SET
-	autostart_schedule = $2
+	autostart_schedule = $2,
+	-- A skipped autostart belongs to the previous schedule.
+	autostart_skip_until = NULL
 WHERE
 	id = $1
 `
@@ -9491,6 +11455,25 @@ func (q *sqlQuerier) UpdateWorkspaceAutostart(ctx context.Context, arg UpdateWor
 	return err
 }
 
+const updateWorkspaceAutostartSkipUntil = `-- name: UpdateWorkspaceAutostartSkipUntil :exec
+UPDATE
+	workspaces
+SET
+	autostart_skip_until = $2
+WHERE
+	id = $1
+`
+
+type UpdateWorkspaceAutostartSkipUntilParams struct {
+	ID                 uuid.UUID    `db:"id" json:"id"`
+	AutostartSkipUntil sql.NullTime `db:"autostart_skip_until" json:"autostart_skip_until"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceAutostartSkipUntil(ctx context.Context, arg UpdateWorkspaceAutostartSkipUntilParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceAutostartSkipUntil, arg.ID, arg.AutostartSkipUntil)
+	return err
+}
+
 const updateWorkspaceDeletedByID = `-- name: UpdateWorkspaceDeletedByID :exec
 UPDATE
 	workspaces
@@ -9546,7 +11529,7 @@ WHERE
 	workspaces.template_id = templates.id
 AND
 	workspaces.id = $1
-RETURNING workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.locked_at, workspaces.deleting_at
+RETURNING workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.locked_at, workspaces.deleting_at, workspaces.user_acl, workspaces.group_acl, workspaces.autostart_skip_until
 `
 
 type UpdateWorkspaceLockedDeletingAtParams struct {
@@ -9571,6 +11554,52 @@ func (q *sqlQuerier) UpdateWorkspaceLockedDeletingAt(ctx context.Context, arg Up
 		&i.LastUsedAt,
 		&i.LockedAt,
 		&i.DeletingAt,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.AutostartSkipUntil,
+	)
+	return i, err
+}
+
+const updateWorkspaceOwner = `-- name: UpdateWorkspaceOwner :one
+UPDATE
+	workspaces
+SET
+	owner_id = $2,
+	user_acl = '{}',
+	group_acl = '{}'
+WHERE
+	id = $1
+	AND deleted = false
+RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, locked_at, deleting_at, user_acl, group_acl, autostart_skip_until
+`
+
+type UpdateWorkspaceOwnerParams struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	OwnerID uuid.UUID `db:"owner_id" json:"owner_id"`
+}
+
+// The shares of the previous owner don't carry over to the new owner.
+func (q *sqlQuerier) UpdateWorkspaceOwner(ctx context.Context, arg UpdateWorkspaceOwnerParams) (Workspace, error) {
+	row := q.db.QueryRowContext(ctx, updateWorkspaceOwner, arg.ID, arg.OwnerID)
+	var i Workspace
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OwnerID,
+		&i.OrganizationID,
+		&i.TemplateID,
+		&i.Deleted,
+		&i.Name,
+		&i.AutostartSchedule,
+		&i.Ttl,
+		&i.LastUsedAt,
+		&i.LockedAt,
+		&i.DeletingAt,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.AutostartSkipUntil,
 	)
 	return i, err
 }
@@ -105,6 +105,117 @@ LIMIT
 OFFSET
     $2;
 
+-- GetAuditLogsForExport pages through audit logs newest first by ("time", id),
+-- starting after the given audit log. Unlike GetAuditLogsOffset, it doesn't
+-- count the matching audit logs.
+-- name: GetAuditLogsForExport :many
+SELECT
+    audit_logs.*,
+    users.username AS user_username,
+    users.email AS user_email,
+    users.created_at AS user_created_at,
+    users.status AS user_status,
+    users.rbac_roles AS user_roles,
+    users.avatar_url AS user_avatar_url
+FROM
+    audit_logs
+    LEFT JOIN users ON audit_logs.user_id = users.id
+    LEFT JOIN
+        -- First join on workspaces to get the initial workspace create
+        -- to workspace build 1 id. This is because the first create is
+        -- is a different audit log than subsequent starts.
+        workspaces ON
+		    audit_logs.resource_type = 'workspace' AND
+			audit_logs.resource_id = workspaces.id
+    LEFT JOIN
+	    workspace_builds ON
+            -- Get the reason from the build if the resource type
+            -- is a workspace_build
+            (
+			    audit_logs.resource_type = 'workspace_build'
+                AND audit_logs.resource_id = workspace_builds.id
+			)
+            OR
+            -- Get the reason from the build #1 if this is the first
+            -- workspace create.
+            (
+				audit_logs.resource_type = 'workspace' AND
+				audit_logs.action = 'create' AND
+				workspaces.id = workspace_builds.workspace_id AND
+				workspace_builds.build_number = 1
+			)
+WHERE
+    -- Filter resource_type
+	CASE
+		WHEN @resource_type :: text != '' THEN
+			resource_type = @resource_type :: resource_type
+		ELSE true
+	END
+	-- Filter resource_id
+	AND CASE
+		WHEN @resource_id :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			resource_id = @resource_id
+		ELSE true
+	END
+	-- Filter by resource_target
+	AND CASE
+		WHEN @resource_target :: text != '' THEN
+			resource_target = @resource_target
+		ELSE true
+	END
+	-- Filter action
+	AND CASE
+		WHEN @action :: text != '' THEN
+			action = @action :: audit_action
+		ELSE true
+	END
+	-- Filter by user_id
+	AND CASE
+		WHEN @user_id :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			user_id = @user_id
+		ELSE true
+	END
+	-- Filter by username
+	AND CASE
+		WHEN @username :: text != '' THEN
+			user_id = (SELECT id FROM users WHERE lower(username) = lower(@username) AND deleted = false)
+		ELSE true
+	END
+	-- Filter by user_email
+	AND CASE
+		WHEN @email :: text != '' THEN
+			users.email = @email
+		ELSE true
+	END
+	-- Filter by date_from
+	AND CASE
+		WHEN @date_from :: timestamp with time zone != '0001-01-01 00:00:00Z' THEN
+			"time" >= @date_from
+		ELSE true
+	END
+	-- Filter by date_to
+	AND CASE
+		WHEN @date_to :: timestamp with time zone != '0001-01-01 00:00:00Z' THEN
+			"time" <= @date_to
+		ELSE true
+	END
+    -- Filter by build_reason
+    AND CASE
+	    WHEN @build_reason::text != '' THEN
+            workspace_builds.reason::text = @build_reason
+        ELSE true
+    END
+	-- Continue after the last audit log of the previous page
+	AND CASE
+		WHEN @after_id :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			("time", audit_logs.id) < (@after_time :: timestamp with time zone, @after_id :: uuid)
+		ELSE true
+	END
+ORDER BY
+    "time" DESC, audit_logs.id DESC
+LIMIT
+    @limit_count;
+
 -- name: InsertAuditLog :one
 INSERT INTO
 	audit_logs (
@@ -122,7 +233,104 @@ INSERT INTO
         status_code,
         additional_fields,
         request_id,
-        resource_icon
+        resource_icon,
+        chain_sequence,
+        chain_hash
     )
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING *;
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING *;
+
+-- name: GetLatestChainedAuditLog :one
+SELECT
+	*
+FROM
+	audit_logs
+WHERE
+	chain_sequence IS NOT NULL
+ORDER BY
+	chain_sequence DESC
+LIMIT
+	1;
+
+-- GetChainedAuditLogs returns the audit logs in the hash chain after the
+-- given sequence, in chain order.
+-- name: GetChainedAuditLogs :many
+SELECT
+	*
+FROM
+	audit_logs
+WHERE
+	chain_sequence > @after_sequence :: bigint
+ORDER BY
+	chain_sequence ASC
+LIMIT
+	@limit_count;
+
+-- name: InsertAuditLogCheckpoint :exec
+INSERT INTO
+	audit_log_checkpoints (
+		chain_sequence,
+		chain_hash,
+		signature,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4)
+ON CONFLICT (chain_sequence) DO NOTHING;
+
+-- name: GetAuditLogCheckpoints :many
+SELECT
+	*
+FROM
+	audit_log_checkpoints
+ORDER BY
+	chain_sequence ASC;
+
+-- Unchained audit logs are deleted by age. Chained audit logs are deleted up
+-- to and including the newest checkpoint created before the cutoff, so that
+-- the remaining chain starts right after a signed anchor. That checkpoint must
+-- come before the head of the chain, so that new audit logs continue its
+-- sequence.
+-- name: DeleteOldAuditLogs :execrows
+DELETE FROM
+	audit_logs
+WHERE
+	id IN (
+		SELECT
+			id
+		FROM
+			audit_logs
+		WHERE
+			(chain_sequence IS NULL AND "time" < @before_time :: timestamptz)
+			OR chain_sequence <= (
+				SELECT
+					MAX(chain_sequence)
+				FROM
+					audit_log_checkpoints
+				WHERE
+					created_at < @before_time :: timestamptz
+					AND chain_sequence < (
+						SELECT
+							MAX(chain_sequence)
+						FROM
+							audit_logs
+					)
+			)
+		ORDER BY
+			chain_sequence ASC NULLS FIRST
+		LIMIT
+			@limit_count
+	);
+
+-- Deletes checkpoints of purged audit logs. The checkpoint right before the
+-- oldest remaining chained audit log is kept, since it anchors the chain.
+-- name: DeleteOldAuditLogCheckpoints :execrows
+DELETE FROM
+	audit_log_checkpoints
+WHERE
+	chain_sequence < (
+		SELECT
+			MIN(chain_sequence)
+		FROM
+			audit_logs
+	) - 1;
@@ -0,0 +1,55 @@
+-- name: GetDeploymentAutostartExceptions :many
+SELECT
+	*
+FROM
+	autostart_exceptions
+WHERE
+	template_id IS NULL
+ORDER BY
+	starts_at ASC, name ASC;
+
+-- name: GetTemplateAutostartExceptions :many
+SELECT
+	*
+FROM
+	autostart_exceptions
+WHERE
+	template_id = $1
+ORDER BY
+	starts_at ASC, name ASC;
+
+-- name: GetTemplateAutostartExceptionsByTemplateIDs :many
+SELECT
+	*
+FROM
+	autostart_exceptions
+WHERE
+	template_id = ANY(@template_ids :: uuid[])
+ORDER BY
+	starts_at ASC, name ASC;
+
+-- name: InsertAutostartException :one
+INSERT INTO
+	autostart_exceptions (
+		id,
+		template_id,
+		name,
+		starts_at,
+		ends_at,
+		all_day,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: DeleteDeploymentAutostartExceptions :exec
+DELETE FROM
+	autostart_exceptions
+WHERE
+	template_id IS NULL;
+
+-- name: DeleteTemplateAutostartExceptions :exec
+DELETE FROM
+	autostart_exceptions
+WHERE
+	template_id = $1;
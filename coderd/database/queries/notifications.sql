@@ -0,0 +1,164 @@
+-- Messages are deduplicated per user. If the user already received a message
+-- with the same dedupe key, nothing is inserted and no row is returned.
+-- name: InsertNotificationMessage :one
+INSERT INTO
+	notification_messages (
+		id,
+		user_id,
+		event,
+		title,
+		body,
+		dedupe_key,
+		inbox,
+		created_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (user_id, dedupe_key) DO NOTHING RETURNING *;
+
+-- name: GetNotificationMessageByID :one
+SELECT
+	*
+FROM
+	notification_messages
+WHERE
+	id = $1
+LIMIT
+	1;
+
+-- name: GetNotificationMessagesByUserID :many
+SELECT
+	*
+FROM
+	notification_messages
+WHERE
+	user_id = @user_id
+	AND inbox = true
+	AND CASE
+		WHEN @unread_only :: boolean THEN read_at IS NULL
+		ELSE true
+	END
+ORDER BY
+	created_at DESC, id ASC OFFSET @offset_opt
+LIMIT
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF(@limit_opt :: int, 0);
+
+-- name: UpdateNotificationMessageReadAt :one
+UPDATE
+	notification_messages
+SET
+	read_at = $2
+WHERE
+	id = $1 RETURNING *;
+
+-- name: UpdateNotificationMessagesReadAtByUserID :exec
+UPDATE
+	notification_messages
+SET
+	read_at = @read_at
+WHERE
+	user_id = @user_id
+	AND inbox = true
+	AND read_at IS NULL;
+
+-- name: GetNotificationPreferencesByUserID :many
+SELECT
+	*
+FROM
+	notification_preferences
+WHERE
+	user_id = $1
+ORDER BY
+	event ASC;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO
+	notification_preferences (
+		user_id,
+		event,
+		email,
+		inbox,
+		updated_at
+	)
+VALUES
+	($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, event) DO UPDATE SET
+	email = $3,
+	inbox = $4,
+	updated_at = $5
+RETURNING *;
+
+-- name: InsertNotificationDelivery :one
+INSERT INTO
+	notification_deliveries (
+		id,
+		message_id,
+		address,
+		next_attempt_at,
+		created_at,
+		updated_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $5) RETURNING *;
+
+-- Acquires pending deliveries that are due. The attempt time of the acquired
+-- deliveries is moved to @lease_until so that they are retried if the caller
+-- goes away before recording the result.
+--
+-- SKIP LOCKED prevents multiple replicas from acquiring the same deliveries.
+-- name: AcquireNotificationDeliveries :many
+UPDATE
+	notification_deliveries
+SET
+	next_attempt_at = @lease_until,
+	updated_at = @now
+WHERE
+	id IN (
+		SELECT
+			id
+		FROM
+			notification_deliveries AS nested
+		WHERE
+			nested.status = 'pending'::notification_delivery_status
+			AND nested.next_attempt_at <= @now
+		ORDER BY
+			nested.next_attempt_at
+		FOR UPDATE
+		SKIP LOCKED
+		LIMIT
+			@limit_opt
+	) RETURNING *;
+
+-- name: UpdateNotificationDelivery :exec
+UPDATE
+	notification_deliveries
+SET
+	status = $2,
+	attempts = $3,
+	last_error = $4,
+	next_attempt_at = $5,
+	updated_at = $6
+WHERE
+	id = $1;
+
+-- name: GetNotificationDeliveries :many
+SELECT
+	notification_deliveries.*,
+	notification_messages.user_id,
+	notification_messages.event,
+	notification_messages.title
+FROM
+	notification_deliveries
+INNER JOIN
+	notification_messages ON notification_messages.id = notification_deliveries.message_id
+WHERE
+	CASE
+		WHEN @status :: text != '' THEN notification_deliveries.status = @status :: notification_delivery_status
+		ELSE true
+	END
+ORDER BY
+	notification_deliveries.created_at DESC, notification_deliveries.id ASC OFFSET @offset_opt
+LIMIT
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF(@limit_opt :: int, 0);
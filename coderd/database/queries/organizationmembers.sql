@@ -50,3 +50,18 @@ WHERE
 	user_id = @user_id
 	AND organization_id = @org_id
 RETURNING *;
+
+-- name: GetOrganizationMembersByOrganizationID :many
+SELECT
+	*
+FROM
+	organization_members
+WHERE
+	organization_id = $1;
+
+-- name: DeleteOrganizationMember :exec
+DELETE FROM
+	organization_members
+WHERE
+	organization_id = @organization_id
+	AND user_id = @user_id;
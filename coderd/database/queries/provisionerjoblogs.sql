@@ -19,3 +19,22 @@ SELECT
 	unnest(@level :: log_level [ ]) AS LEVEL,
 	unnest(@stage :: VARCHAR(128) [ ]) AS stage,
 	unnest(@output :: VARCHAR(1024) [ ]) AS output RETURNING *;
+
+-- name: DeleteOldProvisionerJobLogs :execrows
+DELETE FROM
+	provisioner_job_logs
+WHERE
+	id IN (
+		SELECT
+			provisioner_job_logs.id
+		FROM
+			provisioner_job_logs
+		JOIN
+			provisioner_jobs ON provisioner_jobs.id = provisioner_job_logs.job_id
+		WHERE
+			provisioner_jobs.completed_at < @completed_before :: timestamptz
+		ORDER BY
+			provisioner_job_logs.id ASC
+		LIMIT
+			@limit_count
+	);
@@ -51,6 +51,21 @@ WHERE
 LIMIT
 	1;
 
+-- name: GetTemplateWorkspaceCounts :many
+-- Returns the number of non-deleted workspaces of each of the given templates.
+-- Templates without workspaces are omitted.
+SELECT
+	template_id,
+	COUNT(*) AS workspace_count
+FROM
+	workspaces
+WHERE
+	deleted = false
+	AND template_id = ANY(@template_ids :: uuid[])
+GROUP BY
+	template_id
+;
+
 -- name: GetTemplates :many
 SELECT * FROM template_with_users AS templates
 ORDER BY (name, id) ASC
@@ -104,7 +119,10 @@ SET
 	name = $4,
 	icon = $5,
 	display_name = $6,
-	allow_user_cancel_workspace_jobs = $7
+	allow_user_cancel_workspace_jobs = $7,
+	deprecated = $8,
+	replacement_template_id = $9,
+	require_active_version = $10
 WHERE
 	id = $1
 ;
@@ -24,6 +24,12 @@ WHERE
 		)
 		ELSE true
 	END
+	-- Filter by archived state, all versions are returned if unset.
+	AND CASE
+		WHEN sqlc.narg('archived') :: boolean IS NOT NULL THEN
+			archived = sqlc.narg('archived') :: boolean
+		ELSE true
+	END
 ORDER BY
     -- Deterministic and consistent ordering of all rows, even if they share
     -- a timestamp. This is to ensure consistent pagination.
@@ -80,10 +86,12 @@ INSERT INTO
 		message,
 		readme,
 		job_id,
-		created_by
+		created_by,
+		git_url,
+		git_commit_sha
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
 
 -- name: UpdateTemplateVersionByID :exec
 UPDATE
@@ -96,6 +104,15 @@ SET
 WHERE
 	id = $1;
 
+-- name: UpdateTemplateVersionArchivedByID :exec
+UPDATE
+	template_versions
+SET
+	archived = $2,
+	updated_at = $3
+WHERE
+	id = $1;
+
 -- name: UpdateTemplateVersionDescriptionByJobID :exec
 UPDATE
 	template_versions
@@ -30,6 +30,27 @@ WHERE
 	workspace_id = $1
 	AND build_number = $2;
 
+-- Returns the size of the provisioner state of each build instead of the
+-- state itself, which may be large.
+-- name: GetWorkspaceBuildStateSummariesByWorkspaceID :many
+SELECT
+	id,
+	build_number,
+	transition,
+	template_version_id,
+	created_at,
+	coalesce(octet_length(provisioner_state), 0) :: int AS provisioner_state_size
+FROM
+	workspace_builds
+WHERE
+	workspace_id = @workspace_id
+ORDER BY
+	build_number DESC
+OFFSET @offset_opt
+LIMIT
+	-- A null limit means "no limit", so 0 means return all
+	NULLIF(@limit_opt :: int, 0);
+
 -- name: GetWorkspaceBuildsByWorkspaceID :many
 SELECT
 	*
@@ -144,3 +165,32 @@ SET
 WHERE
 	id = $1;
 
+
+-- Deletes the provisioner state of all but the most recent builds of each
+-- workspace. New builds copy the state of the latest build, so the latest
+-- build must always be kept. Build numbers of a workspace have no gaps, so the
+-- newest builds are found with the latest build number alone.
+-- name: DeleteOldWorkspaceBuildProvisionerState :execrows
+UPDATE
+	workspace_builds
+SET
+	provisioner_state = NULL
+WHERE
+	id IN (
+		SELECT
+			workspace_builds.id
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.provisioner_state IS NOT NULL
+			AND workspace_builds.build_number <= (
+				SELECT
+					MAX(latest.build_number)
+				FROM
+					workspace_builds AS latest
+				WHERE
+					latest.workspace_id = workspace_builds.workspace_id
+			) - @keep_builds :: bigint
+		LIMIT
+			@limit_count
+	);
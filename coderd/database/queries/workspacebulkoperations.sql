@@ -0,0 +1,105 @@
+-- name: InsertWorkspaceBulkOperation :one
+INSERT INTO
+	workspace_bulk_operations (
+		id,
+		created_at,
+		initiator_id,
+		transition,
+		query,
+		concurrency,
+		heartbeat_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $2) RETURNING *;
+
+-- name: GetWorkspaceBulkOperationByID :one
+SELECT
+	*
+FROM
+	workspace_bulk_operations
+WHERE
+	id = $1
+LIMIT
+	1;
+
+-- name: UpdateWorkspaceBulkOperationCompletedAt :exec
+UPDATE
+	workspace_bulk_operations
+SET
+	completed_at = $2
+WHERE
+	id = $1;
+
+-- name: UpdateWorkspaceBulkOperationHeartbeat :exec
+UPDATE
+	workspace_bulk_operations
+SET
+	heartbeat_at = $2
+WHERE
+	id = $1;
+
+-- CompleteStaleWorkspaceBulkOperations completes the operations that have not
+-- had a heartbeat since heartbeat_before, because the replica running them
+-- stopped, and fails their unfinished workspaces.
+-- name: CompleteStaleWorkspaceBulkOperations :many
+WITH stale AS (
+	UPDATE
+		workspace_bulk_operations
+	SET
+		completed_at = @completed_at :: timestamptz
+	WHERE
+		completed_at IS NULL
+		AND heartbeat_at < @heartbeat_before :: timestamptz
+	RETURNING id
+), failed AS (
+	UPDATE
+		workspace_bulk_operation_workspaces
+	SET
+		status = 'failed',
+		error = @error :: text,
+		updated_at = @completed_at :: timestamptz
+	FROM
+		stale
+	WHERE
+		workspace_bulk_operation_workspaces.operation_id = stale.id
+		AND workspace_bulk_operation_workspaces.status IN ('pending', 'building')
+)
+SELECT id FROM stale;
+
+-- name: InsertWorkspaceBulkOperationWorkspaces :many
+INSERT INTO
+	workspace_bulk_operation_workspaces (operation_id, workspace_id, updated_at)
+SELECT
+	@operation_id :: uuid AS operation_id,
+	unnest(@workspace_ids :: uuid [ ]) AS workspace_id,
+	@updated_at :: timestamptz AS updated_at
+RETURNING *;
+
+-- name: GetWorkspaceBulkOperationWorkspacesByOperationID :many
+SELECT
+	workspace_bulk_operation_workspaces.*,
+	workspaces.name AS workspace_name,
+	users.username AS workspace_owner_name
+FROM
+	workspace_bulk_operation_workspaces
+INNER JOIN
+	workspaces ON workspaces.id = workspace_bulk_operation_workspaces.workspace_id
+INNER JOIN
+	users ON users.id = workspaces.owner_id
+WHERE
+	workspace_bulk_operation_workspaces.operation_id = $1
+ORDER BY
+	users.username ASC,
+	workspaces.name ASC;
+
+-- name: UpdateWorkspaceBulkOperationWorkspace :exec
+UPDATE
+	workspace_bulk_operation_workspaces
+SET
+	status = $3,
+	workspace_build_id = $4,
+	error = $5,
+	updated_at = $6
+WHERE
+	operation_id = $1
+	AND workspace_id = $2;
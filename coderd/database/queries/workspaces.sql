@@ -8,6 +8,17 @@ WHERE
 LIMIT
 	1;
 
+-- name: GetWorkspaceByIDForUpdate :one
+-- Locks the workspace row until the end of the transaction, so builds and
+-- ownership transfers of the same workspace don't interleave.
+SELECT
+	*
+FROM
+	workspaces
+WHERE
+	id = $1
+FOR UPDATE;
+
 -- name: GetWorkspaceByWorkspaceAppID :one
 SELECT
 	*
@@ -331,11 +342,43 @@ WHERE
 	AND deleted = false
 RETURNING *;
 
+-- The shares of the previous owner don't carry over to the new owner.
+-- name: UpdateWorkspaceOwner :one
+UPDATE
+	workspaces
+SET
+	owner_id = $2,
+	user_acl = '{}',
+	group_acl = '{}'
+WHERE
+	id = $1
+	AND deleted = false
+RETURNING *;
+
+-- name: UpdateWorkspaceACLByID :exec
+UPDATE
+	workspaces
+SET
+	group_acl = $1,
+	user_acl = $2
+WHERE
+	id = $3;
+
 -- name: UpdateWorkspaceAutostart :exec
 UPDATE
 	workspaces
 SET
-	autostart_schedule = $2
+	autostart_schedule = $2,
+	-- A skipped autostart belongs to the previous schedule.
+	autostart_skip_until = NULL
+WHERE
+	id = $1;
+
+-- name: UpdateWorkspaceAutostartSkipUntil :exec
+UPDATE
+	workspaces
+SET
+	autostart_skip_until = $2
 WHERE
 	id = $1;
 
@@ -482,6 +525,37 @@ WHERE
 		)
 	) AND workspaces.deleted = 'false';
 
+-- Returns the running workspaces whose latest build has a deadline in the
+-- window (@now, @before].
+-- name: GetWorkspacesAboutToAutostop :many
+SELECT
+	workspaces.id,
+	workspaces.owner_id,
+	workspaces.name,
+	workspace_builds.id AS build_id,
+	workspace_builds.deadline
+FROM
+	workspaces
+INNER JOIN
+	workspace_builds ON workspace_builds.workspace_id = workspaces.id
+INNER JOIN
+	provisioner_jobs ON workspace_builds.job_id = provisioner_jobs.id
+WHERE
+	workspace_builds.build_number = (
+		SELECT
+			MAX(build_number)
+		FROM
+			workspace_builds
+		WHERE
+			workspace_builds.workspace_id = workspaces.id
+	)
+	AND workspace_builds.transition = 'start'::workspace_transition
+	AND provisioner_jobs.completed_at IS NOT NULL
+	AND (provisioner_jobs.error IS NULL OR provisioner_jobs.error = '')
+	AND workspace_builds.deadline > @now :: timestamptz
+	AND workspace_builds.deadline <= @before :: timestamptz
+	AND workspaces.deleted = false;
+
 -- name: UpdateWorkspaceLockedDeletingAt :one
 UPDATE
 	workspaces
@@ -18,10 +18,14 @@ func Recover(log slog.Logger) func(h http.Handler) http.Handler {
 
 				// Reverse proxying (among other things) may panic with
 				// http.ErrAbortHandler when the request is aborted. It's not a
-				// real panic so we shouldn't log them.
+				// real panic so we shouldn't log them, but the server has to
+				// see it to abort the response instead of ending it cleanly.
 				//
 				//nolint:errorlint // this is how the stdlib does the check
-				if r != nil && r != http.ErrAbortHandler {
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+				if r != nil {
 					log.Warn(context.Background(),
 						"panic serving http request (recovered)",
 						slog.F("panic", r),
@@ -0,0 +1,97 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/coderd/rbac"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/testutil"
+)
+
+func TestOrganizationMembers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("List", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		_, user := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		members, err := client.OrganizationMembers(ctx, owner.OrganizationID)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+
+		usernames := []string{members[0].Username, members[1].Username}
+		require.Contains(t, usernames, user.Username)
+	})
+
+	t.Run("AddAndRemove", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		_, user := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		org, err := client.CreateOrganization(ctx, codersdk.CreateOrganizationRequest{
+			Name: "another",
+		})
+		require.NoError(t, err)
+
+		member, err := client.PostOrganizationMember(ctx, org.ID, user.Username)
+		require.NoError(t, err)
+		require.Equal(t, user.ID, member.UserID)
+		require.Len(t, member.Roles, 1)
+		require.Equal(t, rbac.RoleOrgMember(org.ID), member.Roles[0].Name)
+
+		_, err = client.PostOrganizationMember(ctx, org.ID, user.Username)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusConflict, apiErr.StatusCode())
+
+		err = client.DeleteOrganizationMember(ctx, org.ID, user.Username)
+		require.NoError(t, err)
+
+		members, err := client.OrganizationMembers(ctx, org.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		require.Equal(t, owner.UserID, members[0].UserID)
+	})
+
+	t.Run("RemoveSelf", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err := client.DeleteOrganizationMember(ctx, owner.OrganizationID, codersdk.Me)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("MemberCannotAdd", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		memberClient, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		_, other := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		org, err := client.CreateOrganization(ctx, codersdk.CreateOrganizationRequest{
+			Name: "another",
+		})
+		require.NoError(t, err)
+		_, err = client.PostOrganizationMember(ctx, org.ID, member.Username)
+		require.NoError(t, err)
+
+		_, err = memberClient.PostOrganizationMember(ctx, org.ID, other.Username)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+	})
+}
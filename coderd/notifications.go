@@ -0,0 +1,369 @@
+package coderd
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/httpapi"
+	"github.com/coder/coder/coderd/httpmw"
+	"github.com/coder/coder/coderd/rbac"
+	"github.com/coder/coder/codersdk"
+)
+
+// @Summary Get notifications of user
+// @ID get-notifications-of-user
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param unread query bool false "Only return unread notifications"
+// @Param limit query int false "Page limit"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} codersdk.Notification
+// @Router /users/{user}/notifications [get]
+func (api *API) notifications(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := httpmw.UserParam(r)
+
+	page, ok := parsePagination(rw, r)
+	if !ok {
+		return
+	}
+	var (
+		unreadStr = r.URL.Query().Get("unread")
+		unread    = false
+	)
+	if unreadStr != "" {
+		var err error
+		unread, err = strconv.ParseBool(unreadStr)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: fmt.Sprintf("Invalid boolean value %q for \"unread\" query param.", unreadStr),
+				Validations: []codersdk.ValidationError{
+					{Field: "unread", Detail: "Must be a valid boolean"},
+				},
+			})
+			return
+		}
+	}
+
+	messages, err := api.Database.GetNotificationMessagesByUserID(ctx, database.GetNotificationMessagesByUserIDParams{
+		UserID:     user.ID,
+		UnreadOnly: unread,
+		OffsetOpt:  int32(page.Offset),
+		LimitOpt:   int32(page.Limit),
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	notifications := make([]codersdk.Notification, 0, len(messages))
+	for _, message := range messages {
+		notifications = append(notifications, convertNotification(message))
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, notifications)
+}
+
+// @Summary Update notification of user
+// @ID update-notification-of-user
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param notification path string true "Notification ID" format(uuid)
+// @Param request body codersdk.UpdateNotificationRequest true "Update notification request"
+// @Success 200 {object} codersdk.Notification
+// @Router /users/{user}/notifications/{notification} [patch]
+func (api *API) patchNotification(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := httpmw.UserParam(r)
+
+	id, ok := httpmw.ParseUUIDParam(rw, r, "notification")
+	if !ok {
+		return
+	}
+	var req codersdk.UpdateNotificationRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	message, err := api.Database.GetNotificationMessageByID(ctx, id)
+	if httpapi.Is404Error(err) || (err == nil && message.UserID != user.ID) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching notification.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	readAt := sql.NullTime{}
+	if req.Read {
+		readAt = sql.NullTime{Time: database.Now(), Valid: true}
+		if message.ReadAt.Valid {
+			// Keep the time the notification was first read.
+			readAt = message.ReadAt
+		}
+	}
+	message, err = api.Database.UpdateNotificationMessageReadAt(ctx, database.UpdateNotificationMessageReadAtParams{
+		ID:     message.ID,
+		ReadAt: readAt,
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating notification.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertNotification(message))
+}
+
+// @Summary Mark all notifications of user as read
+// @ID mark-all-notifications-of-user-as-read
+// @Security CoderSessionToken
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 204
+// @Router /users/{user}/notifications/read [put]
+func (api *API) putNotificationsRead(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := httpmw.UserParam(r)
+
+	err := api.Database.UpdateNotificationMessagesReadAtByUserID(ctx, database.UpdateNotificationMessagesReadAtByUserIDParams{
+		ReadAt: sql.NullTime{Time: database.Now(), Valid: true},
+		UserID: user.ID,
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Get notification preferences of user
+// @ID get-notification-preferences-of-user
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {array} codersdk.NotificationPreference
+// @Router /users/{user}/notifications/preferences [get]
+func (api *API) notificationPreferences(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := httpmw.UserParam(r)
+
+	preferences, err := api.Database.GetNotificationPreferencesByUserID(ctx, user.ID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertNotificationPreferences(preferences))
+}
+
+// @Summary Update notification preferences of user
+// @ID update-notification-preferences-of-user
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param request body codersdk.UpdateNotificationPreferencesRequest true "Update notification preferences request"
+// @Success 200 {array} codersdk.NotificationPreference
+// @Router /users/{user}/notifications/preferences [put]
+func (api *API) putNotificationPreferences(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := httpmw.UserParam(r)
+
+	var req codersdk.UpdateNotificationPreferencesRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+	for i, preference := range req.Preferences {
+		if !database.NotificationEvent(preference.Event).Valid() {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Invalid notification preferences.",
+				Validations: []codersdk.ValidationError{{
+					Field:  "preferences",
+					Detail: fmt.Sprintf("Unknown event %q at index %d.", preference.Event, i),
+				}},
+			})
+			return
+		}
+	}
+
+	var preferences []database.NotificationPreference
+	err := api.Database.InTx(func(tx database.Store) error {
+		now := database.Now()
+		for _, preference := range req.Preferences {
+			_, err := tx.UpsertNotificationPreference(ctx, database.UpsertNotificationPreferenceParams{
+				UserID:    user.ID,
+				Event:     database.NotificationEvent(preference.Event),
+				Email:     preference.Email,
+				Inbox:     preference.Inbox,
+				UpdatedAt: now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		var err error
+		preferences, err = tx.GetNotificationPreferencesByUserID(ctx, user.ID)
+		return err
+	}, nil)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertNotificationPreferences(preferences))
+}
+
+// @Summary Get notification deliveries
+// @ID get-notification-deliveries
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param status query string false "Delivery status" Enums(pending,sent,failed)
+// @Param limit query int false "Page limit"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} codersdk.NotificationDelivery
+// @Router /notifications/deliveries [get]
+func (api *API) notificationDeliveries(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.Authorize(r, rbac.ActionRead, rbac.ResourceDeploymentValues) {
+		httpapi.Forbidden(rw)
+		return
+	}
+
+	page, ok := parsePagination(rw, r)
+	if !ok {
+		return
+	}
+	status := r.URL.Query().Get("status")
+	if status != "" && !database.NotificationDeliveryStatus(status).Valid() {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Query parameters have invalid values.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "status",
+				Detail: "Status must be one of pending, sent or failed.",
+			}},
+		})
+		return
+	}
+
+	rows, err := api.Database.GetNotificationDeliveries(ctx, database.GetNotificationDeliveriesParams{
+		Status:    status,
+		OffsetOpt: int32(page.Offset),
+		LimitOpt:  int32(page.Limit),
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching notification deliveries.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	deliveries := make([]codersdk.NotificationDelivery, 0, len(rows))
+	for _, row := range rows {
+		delivery := codersdk.NotificationDelivery{
+			ID:             row.ID,
+			NotificationID: row.MessageID,
+			UserID:         row.UserID,
+			Event:          codersdk.NotificationEvent(row.Event),
+			Title:          row.Title,
+			Address:        row.Address,
+			Status:         codersdk.NotificationDeliveryStatus(row.Status),
+			Attempts:       int(row.Attempts),
+			LastError:      row.LastError,
+			CreatedAt:      row.CreatedAt,
+			UpdatedAt:      row.UpdatedAt,
+		}
+		if row.Status == database.NotificationDeliveryStatusPending {
+			nextAttemptAt := row.NextAttemptAt
+			delivery.NextAttemptAt = &nextAttemptAt
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, deliveries)
+}
+
+func convertNotification(message database.NotificationMessage) codersdk.Notification {
+	notification := codersdk.Notification{
+		ID:        message.ID,
+		Event:     codersdk.NotificationEvent(message.Event),
+		Title:     message.Title,
+		Body:      message.Body,
+		CreatedAt: message.CreatedAt,
+	}
+	if message.ReadAt.Valid {
+		readAt := message.ReadAt.Time
+		notification.ReadAt = &readAt
+	}
+	return notification
+}
+
+// convertNotificationPreferences returns the preferences for every event.
+// Users are notified of events without a preference by email and in their
+// inbox.
+func convertNotificationPreferences(preferences []database.NotificationPreference) []codersdk.NotificationPreference {
+	converted := make([]codersdk.NotificationPreference, 0, len(codersdk.NotificationEvents))
+	for _, event := range codersdk.NotificationEvents {
+		preference := codersdk.NotificationPreference{
+			Event: event,
+			Email: true,
+			Inbox: true,
+		}
+		for _, p := range preferences {
+			if p.Event == database.NotificationEvent(event) {
+				preference.Email, preference.Inbox = p.Email, p.Inbox
+			}
+		}
+		converted = append(converted, preference)
+	}
+	return converted
+}
@@ -0,0 +1,207 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbauthz"
+)
+
+const (
+	// DispatchInterval is how often the server sends pending emails.
+	DispatchInterval = 15 * time.Second
+
+	// MaxDeliveriesPerRun is the maximum number of emails the dispatcher
+	// sends in a single run.
+	MaxDeliveriesPerRun = 50
+
+	// deliveryLease is how long an acquired delivery is hidden from other
+	// dispatchers. If the dispatcher goes away before recording the result,
+	// the delivery is retried after the lease.
+	deliveryLease = 5 * time.Minute
+
+	// sendTimeout bounds a single attempt to send an email.
+	sendTimeout = 30 * time.Second
+)
+
+// Dispatcher sends the pending notification emails on every tick and records
+// the result of each attempt in the delivery log. Failed attempts are retried
+// with exponential backoff until the maximum number of attempts is reached.
+type Dispatcher struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	db          database.Store
+	sender      Sender
+	log         slog.Logger
+	tick        <-chan time.Time
+	maxAttempts int
+	stats       chan<- Stats
+}
+
+// Stats contains statistics about the last run of the dispatcher.
+type Stats struct {
+	// Sent contains the IDs of the deliveries that were sent.
+	Sent []uuid.UUID
+	// Retried contains the IDs of the deliveries that failed and will be
+	// attempted again.
+	Retried []uuid.UUID
+	// Failed contains the IDs of the deliveries that failed for the last
+	// time.
+	Failed []uuid.UUID
+	// Error is the fatal error that occurred during the last run of the
+	// dispatcher, if any.
+	Error error
+}
+
+// NewDispatcher returns a new notification email dispatcher.
+func NewDispatcher(ctx context.Context, db database.Store, sender Sender, log slog.Logger, tick <-chan time.Time, maxAttempts int) *Dispatcher {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	//nolint:gocritic // Sending notifications is a system function.
+	ctx, cancel := context.WithCancel(dbauthz.AsSystemRestricted(ctx))
+	return &Dispatcher{
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		db:          db,
+		sender:      sender,
+		log:         log,
+		tick:        tick,
+		maxAttempts: maxAttempts,
+		stats:       nil,
+	}
+}
+
+// WithStatsChannel will cause Dispatcher to push a Stats to ch after every
+// tick. This push is blocking, so if ch is not read, the dispatcher will
+// hang. This should only be used in tests.
+func (d *Dispatcher) WithStatsChannel(ch chan<- Stats) *Dispatcher {
+	d.stats = ch
+	return d
+}
+
+// Start will cause the dispatcher to send pending emails on every tick from
+// its channel. It will stop when its context is Done, or when its channel is
+// closed.
+//
+// Start should only be called once.
+func (d *Dispatcher) Start() {
+	go func() {
+		defer close(d.done)
+		defer d.cancel()
+
+		for {
+			select {
+			case <-d.ctx.Done():
+				return
+			case t, ok := <-d.tick:
+				if !ok {
+					return
+				}
+				stats := d.run(t)
+				if stats.Error != nil {
+					d.log.Warn(d.ctx, "error dispatching notification emails", slog.Error(stats.Error))
+				}
+				if d.stats != nil {
+					select {
+					case <-d.ctx.Done():
+						return
+					case d.stats <- stats:
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Close will stop the dispatcher.
+func (d *Dispatcher) Close() {
+	d.cancel()
+	<-d.done
+}
+
+func (d *Dispatcher) run(t time.Time) Stats {
+	stats := Stats{
+		Sent:    []uuid.UUID{},
+		Retried: []uuid.UUID{},
+		Failed:  []uuid.UUID{},
+	}
+
+	deliveries, err := d.db.AcquireNotificationDeliveries(d.ctx, database.AcquireNotificationDeliveriesParams{
+		LeaseUntil: t.Add(deliveryLease),
+		Now:        t,
+		LimitOpt:   MaxDeliveriesPerRun,
+	})
+	if err != nil {
+		stats.Error = xerrors.Errorf("acquire notification deliveries: %w", err)
+		return stats
+	}
+
+	for _, delivery := range deliveries {
+		log := d.log.With(slog.F("delivery_id", delivery.ID), slog.F("message_id", delivery.MessageID))
+
+		sendErr := d.send(delivery)
+		update := database.UpdateNotificationDeliveryParams{
+			ID:            delivery.ID,
+			Status:        database.NotificationDeliveryStatusSent,
+			Attempts:      delivery.Attempts + 1,
+			LastError:     "",
+			NextAttemptAt: t,
+			UpdatedAt:     database.Now(),
+		}
+		switch {
+		case sendErr == nil:
+			stats.Sent = append(stats.Sent, delivery.ID)
+		case int(update.Attempts) >= d.maxAttempts:
+			update.Status = database.NotificationDeliveryStatusFailed
+			update.LastError = sendErr.Error()
+			stats.Failed = append(stats.Failed, delivery.ID)
+			log.Warn(d.ctx, "giving up on notification email", slog.F("attempts", update.Attempts), slog.Error(sendErr))
+		default:
+			update.Status = database.NotificationDeliveryStatusPending
+			update.LastError = sendErr.Error()
+			update.NextAttemptAt = t.Add(retryBackoff(int(update.Attempts)))
+			stats.Retried = append(stats.Retried, delivery.ID)
+			log.Debug(d.ctx, "notification email failed, will retry", slog.F("next_attempt_at", update.NextAttemptAt), slog.Error(sendErr))
+		}
+
+		err := d.db.UpdateNotificationDelivery(d.ctx, update)
+		if err != nil {
+			// The delivery is attempted again after the lease.
+			log.Error(d.ctx, "record notification delivery", slog.Error(err))
+		}
+	}
+	return stats
+}
+
+func (d *Dispatcher) send(delivery database.NotificationDelivery) error {
+	ctx, cancel := context.WithTimeout(d.ctx, sendTimeout)
+	defer cancel()
+
+	message, err := d.db.GetNotificationMessageByID(ctx, delivery.MessageID)
+	if err != nil {
+		return xerrors.Errorf("get notification message: %w", err)
+	}
+	return d.sender.Send(ctx, delivery.Address, message.Title, message.Body)
+}
+
+// retryBackoff returns the delay before the next attempt after the given
+// number of failed attempts: one minute, doubling up to an hour.
+func retryBackoff(attempts int) time.Duration {
+	backoff := time.Minute
+	for i := 1; i < attempts && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+	if backoff > time.Hour {
+		backoff = time.Hour
+	}
+	return backoff
+}
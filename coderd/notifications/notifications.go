@@ -0,0 +1,166 @@
+// Package notifications notifies users of events, such as failed workspace
+// builds, in their inbox and by email.
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"text/template"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbauthz"
+)
+
+// Enqueuer notifies users of events.
+type Enqueuer interface {
+	// Enqueue notifies a user of an event. The data is made available to the
+	// templates of the event. A user is notified at most once per dedupe key,
+	// so the key should identify the occurrence of the event, e.g. the ID of
+	// the failed build.
+	Enqueue(ctx context.Context, userID uuid.UUID, event database.NotificationEvent, data map[string]string, dedupeKey string) error
+}
+
+// NoopEnqueuer discards all notifications.
+type NoopEnqueuer struct{}
+
+func (NoopEnqueuer) Enqueue(context.Context, uuid.UUID, database.NotificationEvent, map[string]string, string) error {
+	return nil
+}
+
+type messageTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+func newMessageTemplate(event database.NotificationEvent, title, body string) messageTemplate {
+	return messageTemplate{
+		title: template.Must(template.New(string(event) + "_title").Option("missingkey=zero").Parse(title)),
+		body:  template.Must(template.New(string(event) + "_body").Option("missingkey=zero").Parse(body)),
+	}
+}
+
+var templates = map[database.NotificationEvent]messageTemplate{
+	database.NotificationEventWorkspaceBuildFailed: newMessageTemplate(database.NotificationEventWorkspaceBuildFailed,
+		`Workspace {{.Workspace}} failed to {{.Transition}}`,
+		`The {{.Transition}} build of your workspace {{.Workspace}} failed: {{.Error}}`,
+	),
+	database.NotificationEventWorkspaceAutostop: newMessageTemplate(database.NotificationEventWorkspaceAutostop,
+		`Workspace {{.Workspace}} will stop soon`,
+		`Your workspace {{.Workspace}} will be stopped automatically at {{.Deadline}}. Activity in the workspace postpones the stop.`,
+	),
+	database.NotificationEventWorkspaceLocked: newMessageTemplate(database.NotificationEventWorkspaceLocked,
+		`Workspace {{.Workspace}} was locked`,
+		`Your workspace {{.Workspace}} was locked because it was not used for {{.Inactivity}}.{{if .DeletingAt}} It will be deleted at {{.DeletingAt}} unless you unlock it.{{end}}`,
+	),
+	database.NotificationEventTemplateVersionPromoted: newMessageTemplate(database.NotificationEventTemplateVersionPromoted,
+		`Template {{.Template}} has a new version`,
+		`Version {{.Version}} of the template {{.Template}} was promoted by {{.Initiator}}. Update your workspaces {{.Workspaces}} to use it.`,
+	),
+	database.NotificationEventUserSuspended: newMessageTemplate(database.NotificationEventUserSuspended,
+		`Your account was suspended`,
+		`Your account {{.User}} was suspended. Contact an administrator to restore your access.`,
+	),
+}
+
+// StoreEnqueuer persists notifications in the database. Messages are added to
+// the inbox of the user, and emails are queued for the Dispatcher.
+type StoreEnqueuer struct {
+	db    database.Store
+	log   slog.Logger
+	email bool
+}
+
+// NewStoreEnqueuer returns an Enqueuer that persists notifications in db.
+// Emails are only queued if email is set, i.e. if a Dispatcher sends them.
+func NewStoreEnqueuer(db database.Store, log slog.Logger, email bool) *StoreEnqueuer {
+	return &StoreEnqueuer{
+		db:    db,
+		log:   log,
+		email: email,
+	}
+}
+
+func (e *StoreEnqueuer) Enqueue(ctx context.Context, userID uuid.UUID, event database.NotificationEvent, data map[string]string, dedupeKey string) error {
+	tmpl, ok := templates[event]
+	if !ok {
+		return xerrors.Errorf("unknown notification event %q", event)
+	}
+
+	// Events are caused by the system or by other users, so the caller is
+	// not the one allowed to notify the user.
+	//nolint:gocritic // Notifying users is a system function.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+
+	preferences, err := e.db.GetNotificationPreferencesByUserID(ctx, userID)
+	if err != nil {
+		return xerrors.Errorf("get notification preferences: %w", err)
+	}
+	inbox, email := true, true
+	for _, preference := range preferences {
+		if preference.Event == event {
+			inbox, email = preference.Inbox, preference.Email
+		}
+	}
+	email = email && e.email
+	if !inbox && !email {
+		return nil
+	}
+
+	var title, body bytes.Buffer
+	if err := tmpl.title.Execute(&title, data); err != nil {
+		return xerrors.Errorf("render title: %w", err)
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return xerrors.Errorf("render body: %w", err)
+	}
+
+	return e.db.InTx(func(tx database.Store) error {
+		now := database.Now()
+		message, err := tx.InsertNotificationMessage(ctx, database.InsertNotificationMessageParams{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Event:     event,
+			Title:     title.String(),
+			Body:      body.String(),
+			DedupeKey: dedupeKey,
+			Inbox:     inbox,
+			CreatedAt: now,
+		})
+		if xerrors.Is(err, sql.ErrNoRows) {
+			// The user was already notified of this occurrence.
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("insert notification message: %w", err)
+		}
+		if !email {
+			return nil
+		}
+
+		user, err := tx.GetUserByID(ctx, userID)
+		if err != nil {
+			return xerrors.Errorf("get user: %w", err)
+		}
+		_, err = tx.InsertNotificationDelivery(ctx, database.InsertNotificationDeliveryParams{
+			ID:            uuid.New(),
+			MessageID:     message.ID,
+			Address:       user.Email,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return xerrors.Errorf("insert notification delivery: %w", err)
+		}
+		e.log.Debug(ctx, "queued notification email",
+			slog.F("user_id", userID),
+			slog.F("event", event),
+			slog.F("message_id", message.ID),
+		)
+		return nil
+	}, nil)
+}
@@ -0,0 +1,358 @@
+package notifications_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbfake"
+	"github.com/coder/coder/coderd/database/dbgen"
+	"github.com/coder/coder/coderd/notifications"
+	"github.com/coder/coder/testutil"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("InboxAndEmail", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		db := dbfake.New()
+		user := dbgen.User(t, db, database.User{Email: "alice@coder.com"})
+		enq := notifications.NewStoreEnqueuer(db, slogtest.Make(t, nil), true)
+
+		err := enq.Enqueue(ctx, user.ID, database.NotificationEventWorkspaceBuildFailed, map[string]string{
+			"Workspace":  "dev",
+			"Transition": "start",
+			"Error":      "terraform apply failed",
+		}, "build:1")
+		require.NoError(t, err)
+
+		messages, err := db.GetNotificationMessagesByUserID(ctx, database.GetNotificationMessagesByUserIDParams{UserID: user.ID})
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "Workspace dev failed to start", messages[0].Title)
+		assert.Equal(t, "The start build of your workspace dev failed: terraform apply failed", messages[0].Body)
+
+		deliveries, err := db.GetNotificationDeliveries(ctx, database.GetNotificationDeliveriesParams{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, messages[0].ID, deliveries[0].MessageID)
+		assert.Equal(t, "alice@coder.com", deliveries[0].Address)
+		assert.Equal(t, database.NotificationDeliveryStatusPending, deliveries[0].Status)
+
+		// The same occurrence is only notified once.
+		err = enq.Enqueue(ctx, user.ID, database.NotificationEventWorkspaceBuildFailed, nil, "build:1")
+		require.NoError(t, err)
+		messages, err = db.GetNotificationMessagesByUserID(ctx, database.GetNotificationMessagesByUserIDParams{UserID: user.ID})
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+	})
+
+	t.Run("EmailDisabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		db := dbfake.New()
+		user := dbgen.User(t, db, database.User{})
+		enq := notifications.NewStoreEnqueuer(db, slogtest.Make(t, nil), false)
+
+		err := enq.Enqueue(ctx, user.ID, database.NotificationEventUserSuspended, map[string]string{"User": user.Username}, "suspended")
+		require.NoError(t, err)
+
+		messages, err := db.GetNotificationMessagesByUserID(ctx, database.GetNotificationMessagesByUserIDParams{UserID: user.ID})
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		deliveries, err := db.GetNotificationDeliveries(ctx, database.GetNotificationDeliveriesParams{})
+		require.NoError(t, err)
+		require.Empty(t, deliveries)
+	})
+
+	t.Run("Preferences", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		db := dbfake.New()
+		user := dbgen.User(t, db, database.User{})
+		enq := notifications.NewStoreEnqueuer(db, slogtest.Make(t, nil), true)
+
+		// Email only.
+		_, err := db.UpsertNotificationPreference(ctx, database.UpsertNotificationPreferenceParams{
+			UserID: user.ID,
+			Event:  database.NotificationEventWorkspaceAutostop,
+			Email:  true,
+			Inbox:  false,
+		})
+		require.NoError(t, err)
+		// Nothing at all.
+		_, err = db.UpsertNotificationPreference(ctx, database.UpsertNotificationPreferenceParams{
+			UserID: user.ID,
+			Event:  database.NotificationEventWorkspaceLocked,
+		})
+		require.NoError(t, err)
+
+		err = enq.Enqueue(ctx, user.ID, database.NotificationEventWorkspaceAutostop, map[string]string{"Workspace": "dev"}, "autostop")
+		require.NoError(t, err)
+		err = enq.Enqueue(ctx, user.ID, database.NotificationEventWorkspaceLocked, map[string]string{"Workspace": "dev"}, "locked")
+		require.NoError(t, err)
+
+		// The email is logged, but the inbox stays empty.
+		messages, err := db.GetNotificationMessagesByUserID(ctx, database.GetNotificationMessagesByUserIDParams{UserID: user.ID})
+		require.NoError(t, err)
+		require.Empty(t, messages)
+		deliveries, err := db.GetNotificationDeliveries(ctx, database.GetNotificationDeliveriesParams{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, database.NotificationEventWorkspaceAutostop, deliveries[0].Event)
+	})
+}
+
+func TestDispatcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SMTP", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx     = testutil.Context(t, testutil.WaitLong)
+			db      = dbfake.New()
+			log     = slogtest.Make(t, nil)
+			tickCh  = make(chan time.Time)
+			statsCh = make(chan notifications.Stats)
+			server  = newSMTPServer(t)
+		)
+		user := dbgen.User(t, db, database.User{Email: "alice@coder.com"})
+		err := notifications.NewStoreEnqueuer(db, log, true).Enqueue(ctx, user.ID, database.NotificationEventUserSuspended, map[string]string{"User": "alice"}, "suspended")
+		require.NoError(t, err)
+
+		sender := &notifications.SMTPSender{
+			From:      "coder@coder.com",
+			Smarthost: server.addr,
+			Username:  "coder",
+			Password:  "hunter2",
+		}
+		dispatcher := notifications.NewDispatcher(ctx, db, sender, log, tickCh, 3).WithStatsChannel(statsCh)
+		dispatcher.Start()
+		defer dispatcher.Close()
+
+		tickCh <- time.Now()
+		stats := <-statsCh
+		require.NoError(t, stats.Error)
+		require.Len(t, stats.Sent, 1)
+
+		mail := server.message(t)
+		assert.Equal(t, "\x00coder\x00hunter2", mail.auth)
+		assert.Equal(t, "coder@coder.com", mail.from)
+		assert.Equal(t, "alice@coder.com", mail.to)
+		assert.Contains(t, mail.data, "Subject: Your account was suspended\r\n")
+		assert.Contains(t, mail.data, "Your account alice was suspended.")
+
+		deliveries, err := db.GetNotificationDeliveries(ctx, database.GetNotificationDeliveriesParams{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, database.NotificationDeliveryStatusSent, deliveries[0].Status)
+		assert.EqualValues(t, 1, deliveries[0].Attempts)
+
+		// Sent emails are not sent again.
+		tickCh <- time.Now().Add(time.Hour)
+		stats = <-statsCh
+		require.NoError(t, stats.Error)
+		require.Empty(t, stats.Sent)
+	})
+
+	t.Run("Retries", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx     = testutil.Context(t, testutil.WaitLong)
+			db      = dbfake.New()
+			log     = slogtest.Make(t, nil)
+			tickCh  = make(chan time.Time)
+			statsCh = make(chan notifications.Stats)
+			sender  = &failingSender{}
+		)
+		user := dbgen.User(t, db, database.User{})
+		err := notifications.NewStoreEnqueuer(db, log, true).Enqueue(ctx, user.ID, database.NotificationEventUserSuspended, nil, "suspended")
+		require.NoError(t, err)
+
+		dispatcher := notifications.NewDispatcher(ctx, db, sender, log, tickCh, 2).WithStatsChannel(statsCh)
+		dispatcher.Start()
+		defer dispatcher.Close()
+
+		now := time.Now()
+		tickCh <- now
+		stats := <-statsCh
+		require.NoError(t, stats.Error)
+		require.Len(t, stats.Retried, 1)
+
+		deliveries, err := db.GetNotificationDeliveries(ctx, database.GetNotificationDeliveriesParams{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, database.NotificationDeliveryStatusPending, deliveries[0].Status)
+		assert.Equal(t, "connection refused", deliveries[0].LastError)
+		assert.WithinDuration(t, now.Add(time.Minute), deliveries[0].NextAttemptAt, time.Second)
+
+		// The delivery is not retried before the backoff.
+		tickCh <- now.Add(30 * time.Second)
+		stats = <-statsCh
+		require.NoError(t, stats.Error)
+		require.Empty(t, stats.Retried)
+		require.Empty(t, stats.Failed)
+
+		// The second attempt is the last one.
+		tickCh <- now.Add(time.Minute)
+		stats = <-statsCh
+		require.NoError(t, stats.Error)
+		require.Len(t, stats.Failed, 1)
+
+		deliveries, err = db.GetNotificationDeliveries(ctx, database.GetNotificationDeliveriesParams{
+			Status: string(database.NotificationDeliveryStatusFailed),
+		})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.EqualValues(t, 2, deliveries[0].Attempts)
+		assert.Equal(t, 2, sender.calls)
+	})
+}
+
+type failingSender struct {
+	calls int
+}
+
+func (s *failingSender) Send(context.Context, string, string, string) error {
+	s.calls++
+	return xerrors.New("connection refused")
+}
+
+type smtpMessage struct {
+	auth string
+	from string
+	to   string
+	data string
+}
+
+// smtpServer is a minimal SMTP server that accepts every message.
+type smtpServer struct {
+	addr     string
+	messages chan smtpMessage
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &smtpServer{
+		addr:     listener.Addr().String(),
+		messages: make(chan smtpMessage, 1),
+	}
+	var wg sync.WaitGroup
+	t.Cleanup(func() {
+		_ = listener.Close()
+		wg.Wait()
+	})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer conn.Close()
+				server.serve(conn)
+			}()
+		}
+	}()
+	return server
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+	reply("220 localhost ready")
+
+	var msg smtpMessage
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO", "HELO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			fields := strings.Fields(line)
+			if len(fields) == 3 {
+				auth, _ := base64.StdEncoding.DecodeString(fields[2])
+				msg.auth = string(auth)
+			}
+			reply("235 authenticated")
+		case "MAIL":
+			msg.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			reply("250 ok")
+		case "RCPT":
+			msg.to = strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>")
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				_, _ = data.WriteString(line)
+			}
+			msg.data = data.String()
+			s.messages <- msg
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func (s *smtpServer) message(t *testing.T) smtpMessage {
+	t.Helper()
+
+	select {
+	case msg := <-s.messages:
+		return msg
+	case <-time.After(testutil.WaitShort):
+		t.Fatal("timed out waiting for email")
+		return smtpMessage{}
+	}
+}
@@ -0,0 +1,103 @@
+package notifications
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// Sender delivers notification emails.
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// SMTPSender sends emails through an SMTP server. STARTTLS is used if the
+// server supports it.
+type SMTPSender struct {
+	From string
+	// Smarthost is the address of the SMTP server in host:port form.
+	Smarthost string
+	// Username and Password are used for PLAIN authentication. Authentication
+	// is skipped if Username is empty.
+	Username string
+	Password string
+}
+
+func (s *SMTPSender) Send(ctx context.Context, to, subject, body string) error {
+	host, _, err := net.SplitHostPort(s.Smarthost)
+	if err != nil {
+		return xerrors.Errorf("parse smarthost: %w", err)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.Smarthost)
+	if err != nil {
+		return xerrors.Errorf("dial smarthost: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return xerrors.Errorf("create smtp client: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{
+			ServerName: host,
+			MinVersion: tls.VersionTLS12,
+		})
+		if err != nil {
+			return xerrors.Errorf("starttls: %w", err)
+		}
+	}
+	if s.Username != "" {
+		err = client.Auth(smtp.PlainAuth("", s.Username, s.Password, host))
+		if err != nil {
+			return xerrors.Errorf("authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.From); err != nil {
+		return xerrors.Errorf("set sender: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return xerrors.Errorf("set recipient: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return xerrors.Errorf("start data: %w", err)
+	}
+	if _, err := w.Write(message(s.From, to, subject, body)); err != nil {
+		return xerrors.Errorf("write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return xerrors.Errorf("send message: %w", err)
+	}
+	return client.Quit()
+}
+
+// message formats a plain text email.
+func message(from, to, subject, body string) []byte {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "From: %s\r\n", from)
+	_, _ = fmt.Fprintf(&b, "To: %s\r\n", to)
+	_, _ = fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	_, _ = fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	_, _ = b.WriteString("MIME-Version: 1.0\r\n")
+	_, _ = b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	_, _ = b.WriteString("\r\n")
+	_, _ = b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	_, _ = b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,239 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/coderd/database/dbtestutil"
+	"github.com/coder/coder/coderd/notifications"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/provisioner/echo"
+	"github.com/coder/coder/testutil"
+)
+
+func TestNotifications(t *testing.T) {
+	t.Parallel()
+
+	t.Run("BuildFailed", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, &echo.Responses{
+			Parse:          echo.ParseComplete,
+			ProvisionPlan:  echo.ProvisionComplete,
+			ProvisionApply: echo.ProvisionFailed,
+		})
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+		build := coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+		require.Equal(t, codersdk.WorkspaceStatusFailed, build.Status)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		notifications, err := client.Notifications(ctx, codersdk.Me, codersdk.NotificationsRequest{})
+		require.NoError(t, err)
+		require.Len(t, notifications, 1)
+		require.Equal(t, codersdk.NotificationEventWorkspaceBuildFailed, notifications[0].Event)
+		require.Contains(t, notifications[0].Title, workspace.Name)
+		require.Nil(t, notifications[0].ReadAt)
+	})
+
+	t.Run("Read", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		_, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		_, other := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := client.UpdateUserStatus(ctx, member.ID.String(), codersdk.UserStatusSuspended)
+		require.NoError(t, err)
+		_, err = client.UpdateUserStatus(ctx, member.ID.String(), codersdk.UserStatusActive)
+		require.NoError(t, err)
+		_, err = client.UpdateUserStatus(ctx, member.ID.String(), codersdk.UserStatusSuspended)
+		require.NoError(t, err)
+
+		// Every suspension is a separate occurrence.
+		notifications, err := client.Notifications(ctx, member.ID.String(), codersdk.NotificationsRequest{})
+		require.NoError(t, err)
+		require.Len(t, notifications, 2)
+		for _, notification := range notifications {
+			require.Equal(t, codersdk.NotificationEventUserSuspended, notification.Event)
+			require.Contains(t, notification.Body, member.Username)
+		}
+
+		read, err := client.UpdateNotification(ctx, member.ID.String(), notifications[0].ID, codersdk.UpdateNotificationRequest{Read: true})
+		require.NoError(t, err)
+		require.NotNil(t, read.ReadAt)
+
+		unread, err := client.Notifications(ctx, member.ID.String(), codersdk.NotificationsRequest{Unread: true})
+		require.NoError(t, err)
+		require.Len(t, unread, 1)
+		require.Equal(t, notifications[1].ID, unread[0].ID)
+
+		// The notification does not belong to the other user.
+		_, err = client.UpdateNotification(ctx, other.ID.String(), notifications[1].ID, codersdk.UpdateNotificationRequest{Read: true})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		_, err = client.UpdateNotification(ctx, member.ID.String(), uuid.New(), codersdk.UpdateNotificationRequest{Read: true})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		err = client.MarkAllNotificationsRead(ctx, member.ID.String())
+		require.NoError(t, err)
+		unread, err = client.Notifications(ctx, member.ID.String(), codersdk.NotificationsRequest{Unread: true})
+		require.NoError(t, err)
+		require.Empty(t, unread)
+
+		unreadAgain, err := client.UpdateNotification(ctx, member.ID.String(), notifications[0].ID, codersdk.UpdateNotificationRequest{Read: false})
+		require.NoError(t, err)
+		require.Nil(t, unreadAgain.ReadAt)
+	})
+
+	t.Run("OtherUser", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		owner := coderdtest.CreateFirstUser(t, client)
+		memberClient, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := memberClient.Notifications(ctx, owner.UserID.String(), codersdk.NotificationsRequest{})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("TemplateVersionPromoted", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		memberClient, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+		coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+
+		newVersion := coderdtest.UpdateTemplateVersion(t, client, owner.OrganizationID, nil, template.ID)
+		coderdtest.AwaitTemplateVersionJob(t, client, newVersion.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err := client.UpdateActiveTemplateVersion(ctx, template.ID, codersdk.UpdateActiveTemplateVersion{ID: newVersion.ID})
+		require.NoError(t, err)
+
+		// Workspace owners are notified in the background.
+		var notifications []codersdk.Notification
+		require.Eventually(t, func() bool {
+			notifications, err = memberClient.Notifications(ctx, codersdk.Me, codersdk.NotificationsRequest{})
+			return assert.NoError(t, err) && len(notifications) > 0
+		}, testutil.WaitShort, testutil.IntervalFast)
+		require.Len(t, notifications, 1)
+		require.Equal(t, codersdk.NotificationEventTemplateVersionPromoted, notifications[0].Event)
+		require.Contains(t, notifications[0].Body, newVersion.Name)
+		require.Contains(t, notifications[0].Body, workspace.Name)
+
+		// The owner of the template does not own any workspaces.
+		notifications, err = client.Notifications(ctx, codersdk.Me, codersdk.NotificationsRequest{})
+		require.NoError(t, err)
+		require.Empty(t, notifications)
+	})
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, nil)
+	owner := coderdtest.CreateFirstUser(t, client)
+	memberClient, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	preferences, err := memberClient.NotificationPreferences(ctx, codersdk.Me)
+	require.NoError(t, err)
+	require.Len(t, preferences, len(codersdk.NotificationEvents))
+	for i, preference := range preferences {
+		assert.Equal(t, codersdk.NotificationEvents[i], preference.Event)
+		assert.True(t, preference.Email)
+		assert.True(t, preference.Inbox)
+	}
+
+	preferences, err = memberClient.UpdateNotificationPreferences(ctx, codersdk.Me, codersdk.UpdateNotificationPreferencesRequest{
+		Preferences: []codersdk.NotificationPreference{{
+			Event: codersdk.NotificationEventUserSuspended,
+			Email: false,
+			Inbox: false,
+		}},
+	})
+	require.NoError(t, err)
+	for _, preference := range preferences {
+		enabled := preference.Event != codersdk.NotificationEventUserSuspended
+		assert.Equal(t, enabled, preference.Email, preference.Event)
+		assert.Equal(t, enabled, preference.Inbox, preference.Event)
+	}
+
+	// Suspensions are no longer added to the inbox.
+	_, err = client.UpdateUserStatus(ctx, member.ID.String(), codersdk.UserStatusSuspended)
+	require.NoError(t, err)
+	notifications, err := client.Notifications(ctx, member.ID.String(), codersdk.NotificationsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+
+	_, err = client.UpdateNotificationPreferences(ctx, codersdk.Me, codersdk.UpdateNotificationPreferencesRequest{
+		Preferences: []codersdk.NotificationPreference{{Event: "unknown"}},
+	})
+	var apiErr *codersdk.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+}
+
+func TestNotificationDeliveries(t *testing.T) {
+	t.Parallel()
+
+	db, pubsub := dbtestutil.NewDB(t)
+	client := coderdtest.New(t, &coderdtest.Options{
+		Database:      db,
+		Pubsub:        pubsub,
+		Notifications: notifications.NewStoreEnqueuer(db, slogtest.Make(t, nil), true),
+	})
+	owner := coderdtest.CreateFirstUser(t, client)
+	memberClient, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	_, err := client.UpdateUserStatus(ctx, member.ID.String(), codersdk.UserStatusSuspended)
+	require.NoError(t, err)
+
+	deliveries, err := client.NotificationDeliveries(ctx, codersdk.NotificationDeliveriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, member.ID, deliveries[0].UserID)
+	require.Equal(t, member.Email, deliveries[0].Address)
+	require.Equal(t, codersdk.NotificationEventUserSuspended, deliveries[0].Event)
+	require.Equal(t, codersdk.NotificationDeliveryStatusPending, deliveries[0].Status)
+	require.NotNil(t, deliveries[0].NextAttemptAt)
+
+	deliveries, err = client.NotificationDeliveries(ctx, codersdk.NotificationDeliveriesRequest{
+		Status: codersdk.NotificationDeliveryStatusSent,
+	})
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	_, err = client.NotificationDeliveries(ctx, codersdk.NotificationDeliveriesRequest{Status: "unknown"})
+	var apiErr *codersdk.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+	// Only admins can read the delivery log.
+	_, err = client.UpdateUserStatus(ctx, member.ID.String(), codersdk.UserStatusActive)
+	require.NoError(t, err)
+	_, err = memberClient.NotificationDeliveries(ctx, codersdk.NotificationDeliveriesRequest{})
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/coder/coder/coderd/database/pubsub"
 	"github.com/coder/coder/coderd/gitauth"
 	"github.com/coder/coder/coderd/httpmw"
+	"github.com/coder/coder/coderd/notifications"
 	"github.com/coder/coder/coderd/schedule"
 	"github.com/coder/coder/coderd/telemetry"
 	"github.com/coder/coder/coderd/tracing"
@@ -61,6 +62,7 @@ type Server struct {
 	Tracer                      trace.Tracer
 	QuotaCommitter              *atomic.Pointer[proto.QuotaCommitter]
 	Auditor                     *atomic.Pointer[audit.Auditor]
+	Notifier                    notifications.Enqueuer
 	TemplateScheduleStore       *atomic.Pointer[schedule.TemplateScheduleStore]
 	UserQuietHoursScheduleStore *atomic.Pointer[schedule.UserQuietHoursScheduleStore]
 	DeploymentValues            *codersdk.DeploymentValues
@@ -727,6 +729,18 @@ func (server *Server) FailJob(ctx context.Context, failJob *proto.FailedJob) (*p
 					Status:           http.StatusInternalServerError,
 					AdditionalFields: wriBytes,
 				})
+
+				// Builds canceled by users are not worth a notification.
+				if server.Notifier != nil && !job.CanceledAt.Valid {
+					err = server.Notifier.Enqueue(ctx, workspace.OwnerID, database.NotificationEventWorkspaceBuildFailed, map[string]string{
+						"Workspace":  workspace.Name,
+						"Transition": string(build.Transition),
+						"Error":      failJob.Error,
+					}, "workspace_build_failed:"+build.ID.String())
+					if err != nil {
+						server.Logger.Error(ctx, "notify owner of failed build", slog.Error(err))
+					}
+				}
 			}
 		}
 	}
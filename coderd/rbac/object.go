@@ -37,6 +37,14 @@ var (
 		Type: "workspace_build",
 	}
 
+	// ResourceWorkspaceBulkOperation CRUD. User owner
+	//	create = start a bulk operation on workspaces
+	//	read = view the progress of a bulk operation
+	//	update = record the progress of a bulk operation
+	ResourceWorkspaceBulkOperation = Object{
+		Type: "workspace_bulk_operation",
+	}
+
 	// ResourceWorkspaceLocked is returned if a workspace is locked.
 	// It grants restricted permissions on workspace builds.
 	ResourceWorkspaceLocked = Object{
@@ -26,6 +26,7 @@ func AllResources() []Object {
 		ResourceWorkspace,
 		ResourceWorkspaceApplicationConnect,
 		ResourceWorkspaceBuild,
+		ResourceWorkspaceBulkOperation,
 		ResourceWorkspaceExecution,
 		ResourceWorkspaceLocked,
 		ResourceWorkspaceProxy,
@@ -336,6 +336,15 @@ func TestRolePermissions(t *testing.T) {
 				false: {userAdmin, otherOrgAdmin, otherOrgMember, templateAdmin, memberMe},
 			},
 		},
+		{
+			Name:     "WorkspaceBulkOperation",
+			Actions:  []rbac.Action{rbac.ActionCreate, rbac.ActionRead, rbac.ActionUpdate},
+			Resource: rbac.ResourceWorkspaceBulkOperation.WithID(uuid.New()).WithOwner(memberMe.Actor.ID),
+			AuthorizeMap: map[bool][]authSubject{
+				true:  {owner, memberMe, orgMemberMe},
+				false: {orgAdmin, userAdmin, otherOrgAdmin, otherOrgMember, templateAdmin},
+			},
+		},
 	}
 
 	for _, c := range testCases {
@@ -0,0 +1,227 @@
+package schedule
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/coderd/database"
+)
+
+// maxSkippedAutostarts bounds the search for the next autostart so a schedule
+// covered entirely by exceptions does not loop forever.
+const maxSkippedAutostarts = 1000
+
+// AutostartException is a period during which workspaces are not
+// automatically started, e.g. a public holiday or a team offsite.
+type AutostartException struct {
+	Name     string
+	StartsAt time.Time
+	EndsAt   time.Time
+	// AllDay exceptions cover the dates from StartsAt up to, but not
+	// including, EndsAt in the location of the autostart schedule. Only the
+	// UTC dates of StartsAt and EndsAt are used.
+	AllDay bool
+}
+
+// Covers returns true if no workspace should be autostarted at t.
+func (e AutostartException) Covers(t time.Time) bool {
+	if e.AllDay {
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return !date.Before(utcDate(e.StartsAt)) && date.Before(utcDate(e.EndsAt))
+	}
+	return !t.Before(e.StartsAt) && t.Before(e.EndsAt)
+}
+
+// ConvertAutostartExceptions converts autostart exceptions from the database.
+func ConvertAutostartExceptions(exceptions []database.AutostartException) []AutostartException {
+	converted := make([]AutostartException, 0, len(exceptions))
+	for _, exception := range exceptions {
+		converted = append(converted, AutostartException{
+			Name:     exception.Name,
+			StartsAt: exception.StartsAt,
+			EndsAt:   exception.EndsAt,
+			AllDay:   exception.AllDay,
+		})
+	}
+	return converted
+}
+
+// NextAutostart returns the first time after t at which the schedule starts
+// a workspace. Times at or before skipUntil and times covered by one of the
+// exceptions are skipped. The zero time is returned if no such time is found.
+func NextAutostart(sched *Schedule, t time.Time, skipUntil time.Time, exceptions []AutostartException) time.Time {
+	if t.Before(skipUntil) {
+		t = skipUntil
+	}
+	next := sched.Next(t)
+	for i := 0; i < maxSkippedAutostarts && !next.IsZero(); i++ {
+		covered := false
+		for _, exception := range exceptions {
+			if exception.Covers(next) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return next
+		}
+		next = sched.Next(next)
+	}
+	return time.Time{}
+}
+
+// ParseICalendar reads the events of an iCalendar (RFC 5545) file as
+// autostart exceptions. Events with a DTSTART date are all-day exceptions.
+// Date-times without a TZID or a UTC designator are read as UTC. Recurrence
+// rules are not expanded, so only the first occurrence of a recurring event
+// is returned.
+func ParseICalendar(r io.Reader) ([]AutostartException, error) {
+	lines, err := unfoldICalendar(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		exceptions = make([]AutostartException, 0)
+		event      *AutostartException
+		hasStart   bool
+		hasEnd     bool
+	)
+	for _, line := range lines {
+		name, params, value, ok := splitICalendarLine(line)
+		if !ok {
+			return nil, xerrors.Errorf("invalid content line %q", line)
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = &AutostartException{}
+			hasStart, hasEnd = false, false
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event == nil {
+				return nil, xerrors.New("END:VEVENT without BEGIN:VEVENT")
+			}
+			if !hasStart {
+				return nil, xerrors.Errorf("event %q has no DTSTART", event.Name)
+			}
+			if !hasEnd {
+				// RFC 5545 3.6.1: an event starting on a date lasts one
+				// day, an event starting at a date-time ends when it starts.
+				event.EndsAt = event.StartsAt
+				if event.AllDay {
+					event.EndsAt = event.StartsAt.AddDate(0, 0, 1)
+				}
+			}
+			if event.EndsAt.Before(event.StartsAt) {
+				return nil, xerrors.Errorf("event %q ends before it starts", event.Name)
+			}
+			exceptions = append(exceptions, *event)
+			event = nil
+		case event == nil:
+			// Only the properties of events are relevant.
+		case name == "SUMMARY":
+			event.Name = unescapeICalendarText(value)
+		case name == "DTSTART":
+			event.StartsAt, event.AllDay, err = parseICalendarTime(params, value)
+			if err != nil {
+				return nil, xerrors.Errorf("event %q: parse DTSTART: %w", event.Name, err)
+			}
+			hasStart = true
+		case name == "DTEND":
+			event.EndsAt, _, err = parseICalendarTime(params, value)
+			if err != nil {
+				return nil, xerrors.Errorf("event %q: parse DTEND: %w", event.Name, err)
+			}
+			hasEnd = true
+		}
+	}
+	if event != nil {
+		return nil, xerrors.New("missing END:VEVENT")
+	}
+	return exceptions, nil
+}
+
+// unfoldICalendar returns the content lines of an iCalendar file, joining
+// lines that were folded with a leading space or tab.
+func unfoldICalendar(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// splitICalendarLine splits a content line into its upper-cased name, its
+// parameters and its value. Colons in quoted parameter values are ignored.
+func splitICalendarLine(line string) (name string, params map[string]string, value string, ok bool) {
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// parseICalendarTime parses a DATE or DATE-TIME value. The returned bool is
+// true for dates.
+func parseICalendarTime(params map[string]string, value string) (time.Time, bool, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		var err error
+		loc, err = time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, xerrors.Errorf("load location %q: %w", tzid, err)
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// unescapeICalendarText reverses the escaping of TEXT values.
+func unescapeICalendarText(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n").Replace(value)
+}
+
+func utcDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,145 @@
+package schedule_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/coderd/schedule"
+)
+
+const holidays = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example//Holidays//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20231225\r\n" +
+	"DTEND;VALUE=DATE:20231227\r\n" +
+	"SUMMARY:Christmas\\, Boxing Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20240101\r\n" +
+	"SUMMARY:New Year's\r\n" +
+	"  Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=\"Europe/Dublin\":20230912T090000\r\n" +
+	"DTEND;TZID=\"Europe/Dublin\":20230914T180000\r\n" +
+	"SUMMARY:Offsite\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20230801T120000Z\r\n" +
+	"DTEND:20230801T130000Z\r\n" +
+	"SUMMARY:Maintenance\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICalendar(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		exceptions, err := schedule.ParseICalendar(strings.NewReader(holidays))
+		require.NoError(t, err)
+		require.Equal(t, []schedule.AutostartException{{
+			Name:     "Christmas, Boxing Day",
+			StartsAt: time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2023, 12, 27, 0, 0, 0, 0, time.UTC),
+			AllDay:   true,
+		}, {
+			Name:     "New Year's Day",
+			StartsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			AllDay:   true,
+		}, {
+			Name:     "Offsite",
+			StartsAt: time.Date(2023, 9, 12, 9, 0, 0, 0, mustLocation(t, "Europe/Dublin")),
+			EndsAt:   time.Date(2023, 9, 14, 18, 0, 0, 0, mustLocation(t, "Europe/Dublin")),
+		}, {
+			Name:     "Maintenance",
+			StartsAt: time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2023, 8, 1, 13, 0, 0, 0, time.UTC),
+		}}, exceptions)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, calendar := range []string{
+			"BEGIN:VEVENT\nSUMMARY:No start\nEND:VEVENT\n",
+			"BEGIN:VEVENT\nDTSTART:20230801T120000Z\nDTEND:20230801T110000Z\nEND:VEVENT\n",
+			"BEGIN:VEVENT\nDTSTART;TZID=Nowhere/Special:20230801T120000\nEND:VEVENT\n",
+			"BEGIN:VEVENT\nDTSTART:tomorrow\nEND:VEVENT\n",
+			"BEGIN:VEVENT\nDTSTART:20230801\n",
+			"not a calendar",
+		} {
+			_, err := schedule.ParseICalendar(strings.NewReader(calendar))
+			require.Error(t, err, calendar)
+		}
+	})
+}
+
+func TestNextAutostart(t *testing.T) {
+	t.Parallel()
+
+	// Every weekday at 9:00 in Dublin.
+	sched, err := schedule.Weekly("CRON_TZ=Europe/Dublin 0 9 * * 1-5")
+	require.NoError(t, err)
+	dublin := mustLocation(t, "Europe/Dublin")
+	// Friday evening.
+	friday := time.Date(2023, 12, 22, 18, 0, 0, 0, dublin)
+	christmas := schedule.AutostartException{
+		Name:     "Christmas",
+		StartsAt: time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2023, 12, 27, 0, 0, 0, 0, time.UTC),
+		AllDay:   true,
+	}
+
+	testCases := []struct {
+		name       string
+		skipUntil  time.Time
+		exceptions []schedule.AutostartException
+		expected   time.Time
+	}{
+		{
+			name:     "NoExceptions",
+			expected: time.Date(2023, 12, 25, 9, 0, 0, 0, dublin),
+		},
+		{
+			name:       "AllDay",
+			exceptions: []schedule.AutostartException{christmas},
+			expected:   time.Date(2023, 12, 27, 9, 0, 0, 0, dublin),
+		},
+		{
+			name: "Period",
+			exceptions: []schedule.AutostartException{{
+				StartsAt: time.Date(2023, 12, 25, 8, 0, 0, 0, time.UTC),
+				EndsAt:   time.Date(2023, 12, 25, 10, 0, 0, 0, time.UTC),
+			}},
+			expected: time.Date(2023, 12, 26, 9, 0, 0, 0, dublin),
+		},
+		{
+			name:       "SkipUntil",
+			skipUntil:  time.Date(2023, 12, 27, 9, 0, 0, 0, dublin),
+			exceptions: []schedule.AutostartException{christmas},
+			expected:   time.Date(2023, 12, 28, 9, 0, 0, 0, dublin),
+		},
+		{
+			name: "AlwaysCovered",
+			exceptions: []schedule.AutostartException{{
+				StartsAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndsAt:   time.Date(2033, 1, 1, 0, 0, 0, 0, time.UTC),
+				AllDay:   true,
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			next := schedule.NextAutostart(sched, friday, tc.skipUntil, tc.exceptions)
+			require.True(t, tc.expected.Equal(next), "expected %s, got %s", tc.expected, next)
+		})
+	}
+}
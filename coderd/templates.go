@@ -1,6 +1,7 @@
 package coderd
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
+	"cdr.dev/slog"
 	"github.com/coder/coder/coderd/audit"
 	"github.com/coder/coder/coderd/database"
 	"github.com/coder/coder/coderd/database/dbauthz"
@@ -39,7 +41,7 @@ func (api *API) template(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	template := httpmw.TemplateParam(r)
 
-	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplate(template))
+	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplate(r, template))
 }
 
 // @Summary Delete template by ID
@@ -370,7 +372,7 @@ func (api *API) postTemplateByOrganization(rw http.ResponseWriter, r *http.Reque
 		}
 		templateVersionAudit.New = newTemplateVersion
 
-		template = api.convertTemplate(dbTemplate)
+		template = api.convertTemplateWithWorkspaceCount(dbTemplate, 0)
 		return nil
 	}, nil)
 	if err != nil {
@@ -426,7 +428,7 @@ func (api *API) templatesByOrganization(rw http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplates(templates))
+	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplates(r, templates))
 }
 
 // @Summary Get templates by organization and template name
@@ -459,7 +461,7 @@ func (api *API) templateByOrganizationAndName(rw http.ResponseWriter, r *http.Re
 		return
 	}
 
-	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplate(template))
+	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplate(r, template))
 }
 
 // @Summary Update template metadata by ID
@@ -543,6 +545,28 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 		validErrs = append(validErrs, codersdk.ValidationError{Field: "locked_ttl_ms", Detail: "Must be a positive integer."})
 	}
 
+	deprecated := template.Deprecated
+	if req.DeprecationMessage != nil {
+		deprecated = *req.DeprecationMessage
+	}
+	replacementTemplateID := template.ReplacementTemplateID
+	if req.ReplacementTemplateID != nil {
+		replacementTemplateID = uuid.NullUUID{
+			UUID:  *req.ReplacementTemplateID,
+			Valid: *req.ReplacementTemplateID != uuid.Nil,
+		}
+	}
+	if deprecated == "" {
+		if req.ReplacementTemplateID != nil && replacementTemplateID.Valid {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "replacement_template_id", Detail: "Only deprecated templates can have a replacement."})
+		}
+		// Undeprecating a template also clears its replacement.
+		replacementTemplateID = uuid.NullUUID{}
+	}
+	if replacementTemplateID.Valid && replacementTemplateID != template.ReplacementTemplateID {
+		validErrs = append(validErrs, api.validateReplacementTemplate(ctx, template, replacementTemplateID.UUID)...)
+	}
+
 	if len(validErrs) > 0 {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message:     "Invalid request to update template metadata!",
@@ -560,13 +584,16 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 			req.AllowUserAutostart == template.AllowUserAutostart &&
 			req.AllowUserAutostop == template.AllowUserAutostop &&
 			req.AllowUserCancelWorkspaceJobs == template.AllowUserCancelWorkspaceJobs &&
+			req.RequireActiveVersion == template.RequireActiveVersion &&
 			req.DefaultTTLMillis == time.Duration(template.DefaultTTL).Milliseconds() &&
 			req.MaxTTLMillis == time.Duration(template.MaxTTL).Milliseconds() &&
 			restartRequirementDaysOfWeekParsed == scheduleOpts.RestartRequirement.DaysOfWeek &&
 			req.RestartRequirement.Weeks == scheduleOpts.RestartRequirement.Weeks &&
 			req.FailureTTLMillis == time.Duration(template.FailureTTL).Milliseconds() &&
 			req.InactivityTTLMillis == time.Duration(template.InactivityTTL).Milliseconds() &&
-			req.LockedTTLMillis == time.Duration(template.LockedTTL).Milliseconds() {
+			req.LockedTTLMillis == time.Duration(template.LockedTTL).Milliseconds() &&
+			deprecated == template.Deprecated &&
+			replacementTemplateID == template.ReplacementTemplateID {
 			return nil
 		}
 
@@ -585,6 +612,9 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 			Description:                  req.Description,
 			Icon:                         req.Icon,
 			AllowUserCancelWorkspaceJobs: req.AllowUserCancelWorkspaceJobs,
+			Deprecated:                   deprecated,
+			ReplacementTemplateID:        replacementTemplateID,
+			RequireActiveVersion:         req.RequireActiveVersion,
 		})
 		if err != nil {
 			return xerrors.Errorf("update template metadata: %w", err)
@@ -645,7 +675,27 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 	}
 	aReq.New = updated
 
-	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplate(updated))
+	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplate(r, updated))
+}
+
+// validateReplacementTemplate checks that the template with the given ID can
+// replace the deprecated template.
+func (api *API) validateReplacementTemplate(ctx context.Context, template database.Template, replacementID uuid.UUID) []codersdk.ValidationError {
+	const field = "replacement_template_id"
+	if replacementID == template.ID {
+		return []codersdk.ValidationError{{Field: field, Detail: "A template can't replace itself."}}
+	}
+	replacement, err := api.Database.GetTemplateByID(ctx, replacementID)
+	if err != nil || replacement.Deleted {
+		return []codersdk.ValidationError{{Field: field, Detail: fmt.Sprintf("Template %q doesn't exist.", replacementID)}}
+	}
+	if replacement.OrganizationID != template.OrganizationID {
+		return []codersdk.ValidationError{{Field: field, Detail: "The replacement template must be in the same organization."}}
+	}
+	if replacement.Deprecated != "" {
+		return []codersdk.ValidationError{{Field: field, Detail: fmt.Sprintf("Template %q is deprecated itself.", replacement.Name)}}
+	}
+	return nil
 }
 
 // @Summary Get template DAUs by ID
@@ -713,11 +763,13 @@ func (api *API) templateExamples(rw http.ResponseWriter, r *http.Request) {
 	httpapi.Write(ctx, rw, http.StatusOK, ex)
 }
 
-func (api *API) convertTemplates(templates []database.Template) []codersdk.Template {
+func (api *API) convertTemplates(r *http.Request, templates []database.Template) []codersdk.Template {
+	workspaceCounts := api.templateWorkspaceCounts(r, templates)
+
 	apiTemplates := make([]codersdk.Template, 0, len(templates))
 
 	for _, template := range templates {
-		apiTemplates = append(apiTemplates, api.convertTemplate(template))
+		apiTemplates = append(apiTemplates, api.convertTemplateWithWorkspaceCount(template, workspaceCounts[template.ID]))
 	}
 
 	// Sort templates by ActiveUserCount DESC
@@ -728,13 +780,54 @@ func (api *API) convertTemplates(templates []database.Template) []codersdk.Templ
 	return apiTemplates
 }
 
-func (api *API) convertTemplate(
-	template database.Template,
+func (api *API) convertTemplate(r *http.Request, template database.Template) codersdk.Template {
+	workspaceCounts := api.templateWorkspaceCounts(r, []database.Template{template})
+	return api.convertTemplateWithWorkspaceCount(template, workspaceCounts[template.ID])
+}
+
+// templateWorkspaceCounts returns the number of workspaces of each template
+// the user can update. The counts include workspaces the user may not be able
+// to read, so they're only shown to template admins. The counts are
+// informational, so failing to fetch them is only logged.
+func (api *API) templateWorkspaceCounts(r *http.Request, templates []database.Template) map[uuid.UUID]int64 {
+	ctx := r.Context()
+	counts := make(map[uuid.UUID]int64)
+	templates, err := AuthorizeFilter(api.HTTPAuth, r, rbac.ActionUpdate, templates)
+	if err != nil {
+		api.Logger.Warn(ctx, "filter templates to count workspaces of", slog.Error(err))
+		return counts
+	}
+	if len(templates) == 0 {
+		return counts
+	}
+	templateIDs := make([]uuid.UUID, 0, len(templates))
+	for _, template := range templates {
+		templateIDs = append(templateIDs, template.ID)
+	}
+	// nolint:gocritic // Template admins can count workspaces they can't read.
+	rows, err := api.Database.GetTemplateWorkspaceCounts(dbauthz.AsSystemRestricted(ctx), templateIDs)
+	if err != nil {
+		api.Logger.Warn(ctx, "get template workspace counts", slog.Error(err))
+		return counts
+	}
+	for _, row := range rows {
+		counts[row.TemplateID] = row.WorkspaceCount
+	}
+	return counts
+}
+
+func (api *API) convertTemplateWithWorkspaceCount(
+	template database.Template, workspaceCount int64,
 ) codersdk.Template {
 	activeCount, _ := api.metricsCache.TemplateUniqueUsers(template.ID)
 
 	buildTimeStats := api.metricsCache.TemplateBuildTimeStats(template.ID)
 
+	var replacementTemplateID *uuid.UUID
+	if template.ReplacementTemplateID.Valid {
+		replacementTemplateID = &template.ReplacementTemplateID.UUID
+	}
+
 	return codersdk.Template{
 		ID:                           template.ID,
 		CreatedAt:                    template.CreatedAt,
@@ -755,6 +848,7 @@ func (api *API) convertTemplate(
 		AllowUserAutostart:           template.AllowUserAutostart,
 		AllowUserAutostop:            template.AllowUserAutostop,
 		AllowUserCancelWorkspaceJobs: template.AllowUserCancelWorkspaceJobs,
+		RequireActiveVersion:         template.RequireActiveVersion,
 		FailureTTLMillis:             time.Duration(template.FailureTTL).Milliseconds(),
 		InactivityTTLMillis:          time.Duration(template.InactivityTTL).Milliseconds(),
 		LockedTTLMillis:              time.Duration(template.LockedTTL).Milliseconds(),
@@ -762,5 +856,9 @@ func (api *API) convertTemplate(
 			DaysOfWeek: codersdk.BitmapToWeekdays(uint8(template.RestartRequirementDaysOfWeek)),
 			Weeks:      template.RestartRequirementWeeks,
 		},
+		Deprecated:            template.Deprecated != "",
+		DeprecationMessage:    template.Deprecated,
+		ReplacementTemplateID: replacementTemplateID,
+		WorkspaceCount:        workspaceCount,
 	}
 }
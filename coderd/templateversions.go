@@ -9,6 +9,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -20,6 +23,7 @@ import (
 
 	"github.com/coder/coder/coderd/audit"
 	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbauthz"
 	"github.com/coder/coder/coderd/gitauth"
 	"github.com/coder/coder/coderd/httpapi"
 	"github.com/coder/coder/coderd/httpmw"
@@ -225,6 +229,101 @@ func (api *API) patchCancelTemplateVersion(rw http.ResponseWriter, r *http.Reque
 	})
 }
 
+// @Summary Archive template version
+// @ID archive-template-version
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param templateversion path string true "Template version ID" format(uuid)
+// @Success 200 {object} codersdk.Response
+// @Router /templateversions/{templateversion}/archive [post]
+func (api *API) postArchiveTemplateVersion(rw http.ResponseWriter, r *http.Request) {
+	api.setArchiveTemplateVersion(true)(rw, r)
+}
+
+// @Summary Unarchive template version
+// @ID unarchive-template-version
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param templateversion path string true "Template version ID" format(uuid)
+// @Success 200 {object} codersdk.Response
+// @Router /templateversions/{templateversion}/unarchive [post]
+func (api *API) postUnarchiveTemplateVersion(rw http.ResponseWriter, r *http.Request) {
+	api.setArchiveTemplateVersion(false)(rw, r)
+}
+
+// setArchiveTemplateVersion archives or unarchives a template version.
+// Archived versions are hidden from version lists by default and can't be
+// used to start workspaces.
+func (api *API) setArchiveTemplateVersion(archive bool) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		var (
+			ctx               = r.Context()
+			templateVersion   = httpmw.TemplateVersionParam(r)
+			auditor           = *api.Auditor.Load()
+			aReq, commitAudit = audit.InitRequest[database.TemplateVersion](rw, &audit.RequestParams{
+				Audit:   auditor,
+				Log:     api.Logger,
+				Request: r,
+				Action:  database.AuditActionWrite,
+			})
+		)
+		defer commitAudit()
+		aReq.Old = templateVersion
+
+		if !templateVersion.TemplateID.Valid {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Only template versions that belong to a template can be archived.",
+			})
+			return
+		}
+		template, err := api.Database.GetTemplateByID(ctx, templateVersion.TemplateID.UUID)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching template.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		if !api.Authorize(r, rbac.ActionUpdate, template) {
+			httpapi.Forbidden(rw)
+			return
+		}
+		if archive && template.ActiveVersionID == templateVersion.ID {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "The active version of a template can't be archived.",
+				Detail:  "Promote another version of the template first.",
+			})
+			return
+		}
+
+		err = api.Database.UpdateTemplateVersionArchivedByID(ctx, database.UpdateTemplateVersionArchivedByIDParams{
+			ID:        templateVersion.ID,
+			Archived:  archive,
+			UpdatedAt: database.Now(),
+		})
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error updating template version.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		newTemplateVersion := templateVersion
+		newTemplateVersion.Archived = archive
+		aReq.New = newTemplateVersion
+
+		message := "Template version has been archived."
+		if !archive {
+			message = "Template version has been unarchived."
+		}
+		httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+			Message: message,
+		})
+	}
+}
+
 // @Summary Get rich parameters by template version
 // @ID get-rich-parameters-by-template-version
 // @Security CoderSessionToken
@@ -697,6 +796,7 @@ func (api *API) fetchTemplateVersionDryRunJob(rw http.ResponseWriter, r *http.Re
 // @Param after_id query string false "After ID" format(uuid)
 // @Param limit query int false "Page limit"
 // @Param offset query int false "Page offset"
+// @Param include_archived query bool false "Include archived versions in the list"
 // @Success 200 {array} codersdk.TemplateVersion
 // @Router /templates/{template}/versions [get]
 func (api *API) templateVersionsByTemplate(rw http.ResponseWriter, r *http.Request) {
@@ -708,6 +808,24 @@ func (api *API) templateVersionsByTemplate(rw http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Archived versions are hidden unless they are explicitly requested.
+	archiveFilter := sql.NullBool{Bool: false, Valid: true}
+	if s := r.URL.Query().Get("include_archived"); s != "" {
+		includeArchived, err := strconv.ParseBool(s)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: fmt.Sprintf("Invalid boolean value %q for \"include_archived\" query param.", s),
+				Validations: []codersdk.ValidationError{
+					{Field: "include_archived", Detail: "Must be a valid boolean"},
+				},
+			})
+			return
+		}
+		if includeArchived {
+			archiveFilter = sql.NullBool{}
+		}
+	}
+
 	var err error
 	apiVersions := []codersdk.TemplateVersion{}
 	err = api.Database.InTx(func(store database.Store) error {
@@ -732,6 +850,7 @@ func (api *API) templateVersionsByTemplate(rw http.ResponseWriter, r *http.Reque
 		versions, err := store.GetTemplateVersionsByTemplateID(ctx, database.GetTemplateVersionsByTemplateIDParams{
 			TemplateID: template.ID,
 			AfterID:    paginationParams.AfterID,
+			Archived:   archiveFilter,
 			LimitOpt:   int32(paginationParams.Limit),
 			OffsetOpt:  int32(paginationParams.Offset),
 		})
@@ -996,6 +1115,7 @@ func (api *API) patchActiveTemplateVersion(rw http.ResponseWriter, r *http.Reque
 	var (
 		ctx               = r.Context()
 		template          = httpmw.TemplateParam(r)
+		apiKey            = httpmw.APIKey(r)
 		auditor           = *api.Auditor.Load()
 		aReq, commitAudit = audit.InitRequest[database.Template](rw, &audit.RequestParams{
 			Audit:   auditor,
@@ -1031,6 +1151,13 @@ func (api *API) patchActiveTemplateVersion(rw http.ResponseWriter, r *http.Reque
 		})
 		return
 	}
+	if version.Archived {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "The provided template version is archived.",
+			Detail:  "Unarchive the version before making it the active version.",
+		})
+		return
+	}
 
 	err = api.Database.InTx(func(store database.Store) error {
 		err = store.UpdateTemplateActiveVersionByID(ctx, database.UpdateTemplateActiveVersionByIDParams{
@@ -1055,12 +1182,69 @@ func (api *API) patchActiveTemplateVersion(rw http.ResponseWriter, r *http.Reque
 	aReq.New = newTemplate
 
 	api.publishTemplateUpdate(ctx, template.ID)
+	if template.ActiveVersionID != version.ID {
+		// Templates may have many workspaces, so owners are notified in the
+		// background instead of delaying the response. Notifications are
+		// skipped once the server is shutting down.
+		api.notificationsWaitMutex.Lock()
+		if api.ctx.Err() == nil {
+			api.notificationsWaitGroup.Add(1)
+			go func() {
+				defer api.notificationsWaitGroup.Done()
+				api.notifyTemplateVersionPromoted(api.ctx, template, version, apiKey.UserID)
+			}()
+		}
+		api.notificationsWaitMutex.Unlock()
+	}
 
 	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
 		Message: "Updated the active template version!",
 	})
 }
 
+// notifyTemplateVersionPromoted notifies the owners of the workspaces of a
+// template that a new version was promoted. Failures are logged since the
+// version was promoted regardless.
+func (api *API) notifyTemplateVersionPromoted(ctx context.Context, template database.Template, version database.TemplateVersion, initiatorID uuid.UUID) {
+	log := api.Logger.With(slog.F("template_id", template.ID), slog.F("template_version_id", version.ID))
+
+	// The initiator may not be able to read all workspaces of the template.
+	//nolint:gocritic // Notifying workspace owners is a system function.
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	initiator, err := api.Database.GetUserByID(sysCtx, initiatorID)
+	if err != nil {
+		log.Error(ctx, "get initiator of template version promotion", slog.Error(err))
+		return
+	}
+	workspaces, err := api.Database.GetWorkspaces(sysCtx, database.GetWorkspacesParams{
+		TemplateIDs: []uuid.UUID{template.ID},
+	})
+	if err != nil {
+		log.Error(ctx, "get workspaces of template", slog.Error(err))
+		return
+	}
+
+	workspacesByOwner := map[uuid.UUID][]string{}
+	owners := []uuid.UUID{}
+	for _, workspace := range workspaces {
+		if _, ok := workspacesByOwner[workspace.OwnerID]; !ok {
+			owners = append(owners, workspace.OwnerID)
+		}
+		workspacesByOwner[workspace.OwnerID] = append(workspacesByOwner[workspace.OwnerID], workspace.Name)
+	}
+	for _, owner := range owners {
+		err := api.Notifications.Enqueue(ctx, owner, database.NotificationEventTemplateVersionPromoted, map[string]string{
+			"Template":   template.Name,
+			"Version":    version.Name,
+			"Initiator":  initiator.Username,
+			"Workspaces": strings.Join(workspacesByOwner[owner], ", "),
+		}, "template_version_promoted:"+version.ID.String())
+		if err != nil {
+			log.Error(ctx, "notify workspace owner of template version promotion", slog.F("user_id", owner), slog.Error(err))
+		}
+	}
+}
+
 // postTemplateVersionsByOrganization creates a new version of a template. An import job is queued to parse the storage method provided.
 //
 // @Summary Create template version by organization
@@ -1111,6 +1295,17 @@ func (api *API) postTemplateVersionsByOrganization(rw http.ResponseWriter, r *ht
 		}
 	}
 
+	if req.GitCommitSHA != "" && !gitCommitSHARegex.MatchString(req.GitCommitSHA) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid Git commit SHA.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "git_commit_sha",
+				Detail: "Must be a full SHA-1 or SHA-256 hash in hexadecimal.",
+			}},
+		})
+		return
+	}
+
 	// Ensures the "owner" is properly applied.
 	tags := provisionerdserver.MutateTags(apiKey.UserID, req.ProvisionerTags)
 
@@ -1269,6 +1464,8 @@ func (api *API) postTemplateVersionsByOrganization(rw http.ResponseWriter, r *ht
 			Readme:         "",
 			JobID:          provisionerJob.ID,
 			CreatedBy:      apiKey.UserID,
+			GitURL:         codersdk.RedactGitURL(req.GitURL),
+			GitCommitSHA:   strings.ToLower(req.GitCommitSHA),
 		})
 		if err != nil {
 			return xerrors.Errorf("insert template version: %w", err)
@@ -1375,10 +1572,16 @@ func convertTemplateVersion(version database.TemplateVersion, job codersdk.Provi
 			Username:  version.CreatedByUsername,
 			AvatarURL: version.CreatedByAvatarURL.String,
 		},
-		Warnings: warnings,
+		GitURL:       version.GitURL,
+		GitCommitSHA: version.GitCommitSHA,
+		Archived:     version.Archived,
+		Warnings:     warnings,
 	}
 }
 
+// gitCommitSHARegex matches full SHA-1 and SHA-256 commit hashes.
+var gitCommitSHARegex = regexp.MustCompile(`^([0-9a-fA-F]{40}|[0-9a-fA-F]{64})$`)
+
 func convertTemplateVersionParameters(dbParams []database.TemplateVersionParameter) ([]codersdk.TemplateVersionParameter, error) {
 	params := make([]codersdk.TemplateVersionParameter, 0)
 	for _, dbParameter := range dbParams {
@@ -0,0 +1,154 @@
+// Package tfstate reads the Terraform state files stored with workspace builds
+// and compares them at the resource level.
+package tfstate
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"golang.org/x/xerrors"
+)
+
+// State is the subset of a Terraform state file needed to compare resources.
+type State struct {
+	Version   int        `json:"version"`
+	Resources []Resource `json:"resources"`
+}
+
+type Resource struct {
+	Module    string     `json:"module,omitempty"`
+	Mode      string     `json:"mode"`
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Instances []Instance `json:"instances"`
+}
+
+type Instance struct {
+	// IndexKey is set for resources using count or for_each.
+	IndexKey   json.RawMessage            `json:"index_key,omitempty"`
+	Attributes map[string]json.RawMessage `json:"attributes"`
+}
+
+// Parse reads a Terraform state file. An empty file is an empty state.
+func Parse(raw []byte) (State, error) {
+	var state State
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return state, nil
+	}
+	err := json.Unmarshal(raw, &state)
+	if err != nil {
+		return State{}, xerrors.Errorf("unmarshal state: %w", err)
+	}
+	return state, nil
+}
+
+// ManagedInstances returns the number of resource instances managed by the
+// state. Data sources are not counted.
+func (s State) ManagedInstances() int {
+	count := 0
+	for _, resource := range s.Resources {
+		if resource.Mode == "managed" {
+			count += len(resource.Instances)
+		}
+	}
+	return count
+}
+
+// Instances returns the resource instances of the state keyed by their
+// address, e.g. module.dev.docker_container.workspace[0].
+func (s State) Instances() map[string]Instance {
+	instances := map[string]Instance{}
+	for _, resource := range s.Resources {
+		address := resource.Type + "." + resource.Name
+		if resource.Mode == "data" {
+			address = "data." + address
+		}
+		if resource.Module != "" {
+			address = resource.Module + "." + address
+		}
+		for _, instance := range resource.Instances {
+			key := address
+			if len(instance.IndexKey) > 0 {
+				key += "[" + string(instance.IndexKey) + "]"
+			}
+			instances[key] = instance
+		}
+	}
+	return instances
+}
+
+type ChangeAction string
+
+const (
+	ChangeActionAdded   ChangeAction = "added"
+	ChangeActionRemoved ChangeAction = "removed"
+	ChangeActionChanged ChangeAction = "changed"
+)
+
+// Change is a resource instance that differs between two states.
+type Change struct {
+	Address string       `json:"address"`
+	Action  ChangeAction `json:"action"`
+	// Attributes are the names of the attributes with a different value. It's
+	// only set for changed instances.
+	Attributes []string `json:"attributes,omitempty"`
+}
+
+// Diff returns the resource instances that were added, removed or changed
+// going from one state to the other, sorted by address.
+func Diff(from, to State) []Change {
+	fromInstances := from.Instances()
+	toInstances := to.Instances()
+
+	changes := make([]Change, 0)
+	for address, before := range fromInstances {
+		after, ok := toInstances[address]
+		if !ok {
+			changes = append(changes, Change{Address: address, Action: ChangeActionRemoved})
+			continue
+		}
+		attributes := changedAttributes(before.Attributes, after.Attributes)
+		if len(attributes) > 0 {
+			changes = append(changes, Change{Address: address, Action: ChangeActionChanged, Attributes: attributes})
+		}
+	}
+	for address := range toInstances {
+		if _, ok := fromInstances[address]; !ok {
+			changes = append(changes, Change{Address: address, Action: ChangeActionAdded})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Address < changes[j].Address
+	})
+	return changes
+}
+
+// changedAttributes returns the sorted names of attributes that were added,
+// removed or set to a different value.
+func changedAttributes(before, after map[string]json.RawMessage) []string {
+	var names []string
+	for name, value := range before {
+		other, ok := after[name]
+		if !ok || !jsonEqual(value, other) {
+			names = append(names, name)
+		}
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonEqual compares two JSON values ignoring insignificant whitespace.
+func jsonEqual(a, b json.RawMessage) bool {
+	var compactA, compactB bytes.Buffer
+	if json.Compact(&compactA, a) != nil || json.Compact(&compactB, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes())
+}
@@ -0,0 +1,106 @@
+package tfstate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/coderd/tfstate"
+)
+
+const stateBefore = `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "data",
+      "type": "coder_workspace",
+      "name": "me",
+      "instances": [{"attributes": {"name": "dev"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "docker_volume",
+      "name": "home",
+      "instances": [{"attributes": {"name": "coder-home", "driver": "local"}}]
+    },
+    {
+      "module": "module.code",
+      "mode": "managed",
+      "type": "docker_container",
+      "name": "workspace",
+      "instances": [
+        {"index_key": 0, "attributes": {"image": "ubuntu:22.04", "name": "dev"}}
+      ]
+    }
+  ]
+}`
+
+const stateAfter = `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "data",
+      "type": "coder_workspace",
+      "name": "me",
+      "instances": [{"attributes": {"name":"dev"}}]
+    },
+    {
+      "module": "module.code",
+      "mode": "managed",
+      "type": "docker_container",
+      "name": "workspace",
+      "instances": [
+        {"index_key": 0, "attributes": {"image": "ubuntu:23.04", "name": "dev", "env": []}}
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "coder_agent",
+      "name": "main",
+      "instances": [{"index_key": "linux", "attributes": {"os": "linux"}}]
+    }
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		state, err := tfstate.Parse([]byte(stateBefore))
+		require.NoError(t, err)
+		require.Equal(t, 4, state.Version)
+		require.Len(t, state.Resources, 3)
+		require.Equal(t, 2, state.ManagedInstances())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+		state, err := tfstate.Parse([]byte("  \n"))
+		require.NoError(t, err)
+		require.Empty(t, state.Resources)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := tfstate.Parse([]byte("some state"))
+		require.Error(t, err)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	before, err := tfstate.Parse([]byte(stateBefore))
+	require.NoError(t, err)
+	after, err := tfstate.Parse([]byte(stateAfter))
+	require.NoError(t, err)
+
+	require.Equal(t, []tfstate.Change{
+		{Address: `coder_agent.main["linux"]`, Action: tfstate.ChangeActionAdded},
+		{Address: "docker_volume.home", Action: tfstate.ChangeActionRemoved},
+		{Address: "module.code.docker_container.workspace[0]", Action: tfstate.ChangeActionChanged, Attributes: []string{"env", "image"}},
+	}, tfstate.Diff(before, after))
+
+	require.Empty(t, tfstate.Diff(before, before))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
+	"cdr.dev/slog"
 	"github.com/coder/coder/coderd/audit"
 	"github.com/coder/coder/coderd/database"
 	"github.com/coder/coder/coderd/database/db2sdk"
@@ -709,6 +710,15 @@ func (api *API) putUserStatus(status database.UserStatus) func(rw http.ResponseW
 		}
 		aReq.New = suspendedUser
 
+		if status == database.UserStatusSuspended && user.Status != database.UserStatusSuspended {
+			err = api.Notifications.Enqueue(ctx, user.ID, database.NotificationEventUserSuspended, map[string]string{
+				"User": user.Username,
+			}, fmt.Sprintf("user_suspended:%d", suspendedUser.UpdatedAt.UnixNano()))
+			if err != nil {
+				api.Logger.Error(ctx, "notify user of suspension", slog.F("user_id", user.ID), slog.Error(err))
+			}
+		}
+
 		organizations, err := userOrganizationIDs(ctx, api, user)
 		if err != nil {
 			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
@@ -22,6 +22,7 @@ import (
 	"github.com/coder/coder/coderd/httpapi"
 	"github.com/coder/coder/coderd/httpmw"
 	"github.com/coder/coder/coderd/rbac"
+	"github.com/coder/coder/coderd/tfstate"
 	"github.com/coder/coder/coderd/wsbuilder"
 	"github.com/coder/coder/codersdk"
 )
@@ -374,7 +375,8 @@ func (api *API) postWorkspaceBuilds(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	users, err := api.Database.GetUsersByIDs(ctx, []uuid.UUID{
+	// nolint:gocritic // The owner is readable by anyone the workspace is shared with.
+	users, err := api.Database.GetUsersByIDs(dbauthz.AsSystemRestricted(ctx), []uuid.UUID{
 		workspace.OwnerID,
 		workspaceBuild.InitiatorID,
 	})
@@ -623,6 +625,93 @@ func (api *API) workspaceBuildState(rw http.ResponseWriter, r *http.Request) {
 	_, _ = rw.Write(workspaceBuild.ProvisionerState)
 }
 
+// workspaceBuildStatesDefaultLimit is the number of builds summarized when no
+// limit is given, since the state of every build is parsed.
+const workspaceBuildStatesDefaultLimit = 25
+
+// @Summary Get provisioner state history for workspace
+// @ID get-provisioner-state-history-for-workspace
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Builds
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param limit query int false "Page limit, defaults to 25"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} codersdk.WorkspaceBuildStateSummary
+// @Router /workspaces/{workspace}/builds/states [get]
+func (api *API) workspaceBuildStates(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspace := httpmw.WorkspaceParam(r)
+
+	paginationParams, ok := parsePagination(rw, r)
+	if !ok {
+		return
+	}
+
+	template, err := api.Database.GetTemplateByID(ctx, workspace.TemplateID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to get template",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// Reading the state requires the same permission as for a single build.
+	if !api.Authorize(r, rbac.ActionUpdate, template.RBACObject()) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	limit := paginationParams.Limit
+	if limit <= 0 {
+		limit = workspaceBuildStatesDefaultLimit
+	}
+	summaries, err := api.Database.GetWorkspaceBuildStateSummariesByWorkspaceID(ctx, database.GetWorkspaceBuildStateSummariesByWorkspaceIDParams{
+		WorkspaceID: workspace.ID,
+		OffsetOpt:   int32(paginationParams.Offset),
+		LimitOpt:    int32(limit),
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace builds.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	states := make([]codersdk.WorkspaceBuildStateSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		state := codersdk.WorkspaceBuildStateSummary{
+			WorkspaceBuildID:  summary.ID,
+			BuildNumber:       summary.BuildNumber,
+			Transition:        codersdk.WorkspaceTransition(summary.Transition),
+			TemplateVersionID: summary.TemplateVersionID,
+			CreatedAt:         summary.CreatedAt,
+			SizeBytes:         int(summary.ProvisionerStateSize),
+		}
+		if summary.ProvisionerStateSize > 0 {
+			// States are fetched one at a time, so that only one of them is
+			// held in memory at once.
+			build, err := api.Database.GetWorkspaceBuildByID(ctx, summary.ID)
+			if err != nil {
+				httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+					Message: "Internal error fetching workspace build.",
+					Detail:  err.Error(),
+				})
+				return
+			}
+			// Provisioners other than Terraform may store anything as state.
+			if parsed, err := tfstate.Parse(build.ProvisionerState); err == nil {
+				state.Resources = parsed.ManagedInstances()
+			}
+		}
+		states = append(states, state)
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, states)
+}
+
 type workspaceBuildsData struct {
 	users            []database.User
 	jobs             []database.GetProvisionerJobsByIDsWithQueuePositionRow
@@ -638,7 +727,10 @@ func (api *API) workspaceBuildsData(ctx context.Context, workspaces []database.W
 	for _, workspace := range workspaces {
 		userIDs = append(userIDs, workspace.OwnerID)
 	}
-	users, err := api.Database.GetUsersByIDs(ctx, userIDs)
+	// The caller can read the workspaces, so they may see who owns them even
+	// when the workspace was shared with them.
+	// nolint:gocritic
+	users, err := api.Database.GetUsersByIDs(dbauthz.AsSystemRestricted(ctx), userIDs)
 	if err != nil {
 		return workspaceBuildsData{}, xerrors.Errorf("get users: %w", err)
 	}
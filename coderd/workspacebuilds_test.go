@@ -17,6 +17,8 @@ import (
 	"github.com/coder/coder/coderd/audit"
 	"github.com/coder/coder/coderd/coderdtest"
 	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbgen"
+	"github.com/coder/coder/coderd/database/dbtestutil"
 	"github.com/coder/coder/coderd/rbac"
 	"github.com/coder/coder/codersdk"
 	"github.com/coder/coder/provisioner/echo"
@@ -570,6 +572,88 @@ func TestWorkspaceBuildState(t *testing.T) {
 	require.Equal(t, wantState, gotState)
 }
 
+func TestWorkspaceBuildStates(t *testing.T) {
+	t.Parallel()
+	client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+	user := coderdtest.CreateFirstUser(t, client)
+	wantState := []byte(`{"version": 4, "resources": [{"mode": "managed", "type": "docker_volume", "name": "home", "instances": [{"attributes": {}}]}]}`)
+	version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, &echo.Responses{
+		Parse:         echo.ParseComplete,
+		ProvisionPlan: echo.ProvisionComplete,
+		ProvisionApply: []*proto.Provision_Response{{
+			Type: &proto.Provision_Response_Complete{
+				Complete: &proto.Provision_Complete{
+					State: wantState,
+				},
+			},
+		}},
+	})
+	coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+	template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+	workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+	coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+	build := coderdtest.CreateWorkspaceBuild(t, client, workspace, database.WorkspaceTransitionStop)
+	coderdtest.AwaitWorkspaceBuildJob(t, client, build.ID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+	defer cancel()
+
+	states, err := client.WorkspaceBuildStates(ctx, workspace.ID, codersdk.Pagination{})
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	require.Equal(t, build.ID, states[0].WorkspaceBuildID)
+	require.Equal(t, codersdk.WorkspaceTransitionStop, states[0].Transition)
+	require.Equal(t, workspace.LatestBuild.ID, states[1].WorkspaceBuildID)
+	for _, state := range states {
+		require.Equal(t, len(wantState), state.SizeBytes)
+		require.Equal(t, 1, state.Resources)
+	}
+
+	states, err = client.WorkspaceBuildStates(ctx, workspace.ID, codersdk.Pagination{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+}
+
+func TestWorkspaceBuildStatesDefaultLimit(t *testing.T) {
+	t.Parallel()
+	db, pubsub := dbtestutil.NewDB(t)
+	client := coderdtest.New(t, &coderdtest.Options{
+		Database: db,
+		Pubsub:   pubsub,
+	})
+	user := coderdtest.CreateFirstUser(t, client)
+	template := dbgen.Template(t, db, database.Template{
+		OrganizationID: user.OrganizationID,
+		CreatedBy:      user.UserID,
+	})
+	workspace := dbgen.Workspace(t, db, database.Workspace{
+		OwnerID:        user.UserID,
+		OrganizationID: user.OrganizationID,
+		TemplateID:     template.ID,
+	})
+	for i := 1; i <= 30; i++ {
+		_ = dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+			WorkspaceID:      workspace.ID,
+			BuildNumber:      int32(i),
+			InitiatorID:      user.UserID,
+			ProvisionerState: []byte("state"),
+		})
+	}
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	states, err := client.WorkspaceBuildStates(ctx, workspace.ID, codersdk.Pagination{})
+	require.NoError(t, err)
+	require.Len(t, states, 25)
+	require.EqualValues(t, 30, states[0].BuildNumber)
+	require.Equal(t, len("state"), states[0].SizeBytes)
+	require.Zero(t, states[0].Resources)
+
+	states, err = client.WorkspaceBuildStates(ctx, workspace.ID, codersdk.Pagination{Offset: 25})
+	require.NoError(t, err)
+	require.Len(t, states, 5)
+	require.EqualValues(t, 5, states[0].BuildNumber)
+}
+
 func TestWorkspaceBuildStatus(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,507 @@
+package coderd
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/db2sdk"
+	"github.com/coder/coder/coderd/database/dbauthz"
+	"github.com/coder/coder/coderd/httpapi"
+	"github.com/coder/coder/coderd/httpmw"
+	"github.com/coder/coder/coderd/rbac"
+	"github.com/coder/coder/coderd/searchquery"
+	"github.com/coder/coder/coderd/wsbuilder"
+	"github.com/coder/coder/codersdk"
+)
+
+const (
+	defaultWorkspaceBulkOperationConcurrency = 5
+	// workspaceBulkOperationPollInterval is how often build jobs are checked
+	// in case a workspace update is missed.
+	workspaceBulkOperationPollInterval = 5 * time.Second
+	// workspaceBulkOperationHeartbeatInterval is how often the replica running
+	// an operation records that it is still running it. Operations without a
+	// heartbeat for a few intervals were left behind by a replica that
+	// stopped, and are completed by the others.
+	workspaceBulkOperationHeartbeatInterval = 30 * time.Second
+	workspaceBulkOperationStaleAfter        = 3 * workspaceBulkOperationHeartbeatInterval
+)
+
+// @Summary Create workspace bulk operation
+// @ID create-workspace-bulk-operation
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Workspaces
+// @Param request body codersdk.CreateWorkspaceBulkOperationRequest true "Create workspace bulk operation request"
+// @Success 201 {object} codersdk.WorkspaceBulkOperation
+// @Router /workspaces/bulk [post]
+func (api *API) postWorkspaceBulkOperation(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	apiKey := httpmw.APIKey(r)
+	actor := httpmw.UserAuthorization(r).Actor
+
+	var req codersdk.CreateWorkspaceBulkOperationRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+	if req.Concurrency == 0 {
+		req.Concurrency = defaultWorkspaceBulkOperationConcurrency
+	}
+
+	filter, postFilter, errs := searchquery.Workspaces(req.Query, codersdk.Pagination{}, api.AgentInactiveDisconnectTimeout)
+	if len(errs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid workspace search query.",
+			Validations: errs,
+		})
+		return
+	}
+	if filter.OwnerUsername == "me" {
+		filter.OwnerID = apiKey.UserID
+		filter.OwnerUsername = ""
+	}
+
+	// Only select the workspaces the user is allowed to build.
+	action := rbac.ActionUpdate
+	if req.Transition == codersdk.WorkspaceBulkTransitionDelete {
+		action = rbac.ActionDelete
+	}
+	prepared, err := api.HTTPAuth.AuthorizeSQLFilter(r, action, rbac.ResourceWorkspace.Type)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error preparing sql filter.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	workspaceRows, err := api.Database.GetAuthorizedWorkspaces(ctx, filter, prepared)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspaces.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	workspaces := make([]database.Workspace, 0, len(workspaceRows))
+	for _, workspace := range database.ConvertWorkspaceRows(workspaceRows) {
+		if postFilter.DeletingBy != nil {
+			if !workspace.DeletingAt.Valid {
+				continue
+			}
+			deletingAt := workspace.DeletingAt.Time
+			// get the beginning of the day on which deletion is scheduled
+			truncatedDeletionAt := time.Date(deletingAt.Year(), deletingAt.Month(), deletingAt.Day(), 0, 0, 0, 0, deletingAt.Location())
+			if truncatedDeletionAt.After(*postFilter.DeletingBy) {
+				continue
+			}
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	if len(workspaces) == 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "No workspaces match the search query.",
+		})
+		return
+	}
+
+	workspaceIDs := make([]uuid.UUID, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		workspaceIDs = append(workspaceIDs, workspace.ID)
+	}
+
+	if api.ctx.Err() != nil {
+		httpapi.Write(ctx, rw, http.StatusServiceUnavailable, codersdk.Response{
+			Message: "The server is shutting down.",
+		})
+		return
+	}
+
+	var operation database.WorkspaceBulkOperation
+	err = api.Database.InTx(func(tx database.Store) error {
+		now := database.Now()
+		operation, err = tx.InsertWorkspaceBulkOperation(ctx, database.InsertWorkspaceBulkOperationParams{
+			ID:          uuid.New(),
+			CreatedAt:   now,
+			InitiatorID: apiKey.UserID,
+			Transition:  database.WorkspaceBulkOperationTransition(req.Transition),
+			Query:       req.Query,
+			Concurrency: int32(req.Concurrency),
+		})
+		if err != nil {
+			return xerrors.Errorf("insert workspace bulk operation: %w", err)
+		}
+		_, err = tx.InsertWorkspaceBulkOperationWorkspaces(ctx, database.InsertWorkspaceBulkOperationWorkspacesParams{
+			OperationID:  operation.ID,
+			WorkspaceIDs: workspaceIDs,
+			UpdatedAt:    now,
+		})
+		if err != nil {
+			return xerrors.Errorf("insert workspace bulk operation workspaces: %w", err)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error creating workspace bulk operation.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// Read the workspaces back before the builds start, so the response
+	// lists them all as pending.
+	items, err := api.Database.GetWorkspaceBulkOperationWorkspacesByOperationID(ctx, operation.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace bulk operation workspaces.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	api.workspaceBulkOperationsWaitMutex.Lock()
+	if api.ctx.Err() != nil {
+		api.workspaceBulkOperationsWaitMutex.Unlock()
+		// The server started shutting down after the operation was created.
+		// It's completed as stale by the replicas that keep running.
+		httpapi.Write(ctx, rw, http.StatusServiceUnavailable, codersdk.Response{
+			Message: "The server is shutting down.",
+		})
+		return
+	}
+	api.workspaceBulkOperationsWaitGroup.Add(1)
+	api.workspaceBulkOperationsWaitMutex.Unlock()
+	go func() {
+		defer api.workspaceBulkOperationsWaitGroup.Done()
+		api.runWorkspaceBulkOperation(actor, operation, workspaces)
+	}()
+
+	httpapi.Write(ctx, rw, http.StatusCreated, convertWorkspaceBulkOperation(operation, items))
+}
+
+// @Summary Get workspace bulk operation
+// @ID get-workspace-bulk-operation
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param operation path string true "Operation ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceBulkOperation
+// @Router /workspaces/bulk/{operation} [get]
+func (api *API) workspaceBulkOperation(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	operationID, ok := httpmw.ParseUUIDParam(rw, r, "operation")
+	if !ok {
+		return
+	}
+
+	operation, err := api.Database.GetWorkspaceBulkOperationByID(ctx, operationID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace bulk operation.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	workspaces, err := api.Database.GetWorkspaceBulkOperationWorkspacesByOperationID(ctx, operation.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace bulk operation workspaces.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertWorkspaceBulkOperation(operation, workspaces))
+}
+
+// runWorkspaceBulkOperation builds the workspaces of a bulk operation, at most
+// operation.Concurrency at a time. Builds are authorized as the user that
+// created the operation.
+func (api *API) runWorkspaceBulkOperation(actor rbac.Subject, operation database.WorkspaceBulkOperation, workspaces []database.Workspace) {
+	logger := api.Logger.Named("workspace_bulk_operation").With(slog.F("operation_id", operation.ID))
+	ctx := dbauthz.As(api.ctx, actor)
+	// Progress is written with a context that outlives the API, so the
+	// operation is still completed when the server shuts down halfway.
+	writeCtx := dbauthz.As(context.Background(), actor)
+
+	heartbeatDone := make(chan struct{})
+	defer close(heartbeatDone)
+	go func() {
+		ticker := time.NewTicker(workspaceBulkOperationHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-heartbeatDone:
+				return
+			case <-ticker.C:
+			}
+			err := api.Database.UpdateWorkspaceBulkOperationHeartbeat(writeCtx, database.UpdateWorkspaceBulkOperationHeartbeatParams{
+				ID:          operation.ID,
+				HeartbeatAt: database.Now(),
+			})
+			if err != nil {
+				logger.Warn(writeCtx, "update workspace bulk operation heartbeat", slog.Error(err))
+			}
+		}
+	}()
+
+	var eg errgroup.Group
+	eg.SetLimit(int(operation.Concurrency))
+	for _, workspace := range workspaces {
+		workspace := workspace
+		eg.Go(func() error {
+			record := func(status database.WorkspaceBulkOperationWorkspaceStatus, buildID uuid.NullUUID, reason string) {
+				err := api.Database.UpdateWorkspaceBulkOperationWorkspace(writeCtx, database.UpdateWorkspaceBulkOperationWorkspaceParams{
+					OperationID:      operation.ID,
+					WorkspaceID:      workspace.ID,
+					Status:           status,
+					WorkspaceBuildID: buildID,
+					Error:            reason,
+					UpdatedAt:        database.Now(),
+				})
+				if err != nil {
+					logger.Error(writeCtx, "update workspace bulk operation workspace",
+						slog.F("workspace_id", workspace.ID), slog.Error(err))
+				}
+			}
+			api.buildWorkspaceInBulk(ctx, actor, operation, workspace, record)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	err := api.Database.UpdateWorkspaceBulkOperationCompletedAt(writeCtx, database.UpdateWorkspaceBulkOperationCompletedAtParams{
+		ID:          operation.ID,
+		CompletedAt: sql.NullTime{Time: database.Now(), Valid: true},
+	})
+	if err != nil {
+		logger.Error(writeCtx, "complete workspace bulk operation", slog.Error(err))
+	}
+}
+
+// completeStaleWorkspaceBulkOperations periodically completes the operations
+// of replicas that stopped while running them, including this one before it
+// restarted. Their unfinished workspaces are marked as failed, since the
+// builds they waited for are no longer tracked.
+func (api *API) completeStaleWorkspaceBulkOperations() {
+	logger := api.Logger.Named("workspace_bulk_operation")
+	//nolint:gocritic // Operations of any user may have been left behind.
+	ctx := dbauthz.AsSystemRestricted(api.ctx)
+
+	ticker := time.NewTicker(workspaceBulkOperationHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		now := database.Now()
+		completed, err := api.Database.CompleteStaleWorkspaceBulkOperations(ctx, database.CompleteStaleWorkspaceBulkOperationsParams{
+			CompletedAt:     now,
+			HeartbeatBefore: now.Add(-workspaceBulkOperationStaleAfter),
+			Error:           "The server running the operation stopped before the workspace was built.",
+		})
+		if err != nil && ctx.Err() == nil {
+			logger.Error(ctx, "complete stale workspace bulk operations", slog.Error(err))
+		}
+		for _, id := range completed {
+			logger.Info(ctx, "completed stale workspace bulk operation", slog.F("operation_id", id))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// buildWorkspaceInBulk applies the transition of a bulk operation to a single
+// workspace and waits for the build to finish. Every status change is passed
+// to record.
+func (api *API) buildWorkspaceInBulk(
+	ctx context.Context,
+	actor rbac.Subject,
+	operation database.WorkspaceBulkOperation,
+	workspace database.Workspace,
+	record func(status database.WorkspaceBulkOperationWorkspaceStatus, buildID uuid.NullUUID, reason string),
+) {
+	if ctx.Err() != nil {
+		record(database.WorkspaceBulkOperationWorkspaceStatusSkipped, uuid.NullUUID{}, "The server shut down before the workspace was built.")
+		return
+	}
+
+	reason, err := api.workspaceBulkSkipReason(ctx, operation.Transition, workspace)
+	if err != nil {
+		record(database.WorkspaceBulkOperationWorkspaceStatusFailed, uuid.NullUUID{}, err.Error())
+		return
+	}
+	if reason != "" {
+		record(database.WorkspaceBulkOperationWorkspaceStatusSkipped, uuid.NullUUID{}, reason)
+		return
+	}
+
+	transition := database.WorkspaceTransition(operation.Transition)
+	if operation.Transition == database.WorkspaceBulkOperationTransitionUpdate {
+		transition = database.WorkspaceTransitionStart
+	}
+	builder := wsbuilder.New(workspace, transition).
+		Initiator(operation.InitiatorID).
+		DeploymentValues(api.Options.DeploymentValues)
+	if operation.Transition == database.WorkspaceBulkOperationTransitionUpdate {
+		builder = builder.ActiveVersion()
+	}
+	build, job, err := builder.Build(ctx, api.Database, func(action rbac.Action, object rbac.Objecter) bool {
+		return api.HTTPAuth.Authorizer.Authorize(ctx, actor, action, object.RBACObject()) == nil
+	})
+	if err != nil {
+		reason := err.Error()
+		var buildErr wsbuilder.BuildError
+		if xerrors.As(err, &buildErr) {
+			reason = buildErr.Message
+		}
+		record(database.WorkspaceBulkOperationWorkspaceStatusFailed, uuid.NullUUID{}, reason)
+		return
+	}
+	buildID := uuid.NullUUID{UUID: build.ID, Valid: true}
+	record(database.WorkspaceBulkOperationWorkspaceStatusBuilding, buildID, "")
+	api.publishWorkspaceUpdate(ctx, workspace.ID)
+
+	completed, err := api.waitForWorkspaceBuildJob(ctx, workspace.ID, job.ID)
+	if err != nil {
+		record(database.WorkspaceBulkOperationWorkspaceStatusFailed, buildID, "Stopped waiting for the build: "+err.Error())
+		return
+	}
+	switch db2sdk.ProvisionerJobStatus(completed) {
+	case codersdk.ProvisionerJobSucceeded:
+		record(database.WorkspaceBulkOperationWorkspaceStatusSucceeded, buildID, "")
+	case codersdk.ProvisionerJobCanceled:
+		record(database.WorkspaceBulkOperationWorkspaceStatusFailed, buildID, "The build was canceled.")
+	default:
+		record(database.WorkspaceBulkOperationWorkspaceStatusFailed, buildID, completed.Error.String)
+	}
+}
+
+// workspaceBulkSkipReason returns why a workspace doesn't need the transition,
+// or an empty string if it must be built.
+func (api *API) workspaceBulkSkipReason(ctx context.Context, transition database.WorkspaceBulkOperationTransition, workspace database.Workspace) (string, error) {
+	if transition == database.WorkspaceBulkOperationTransitionDelete {
+		return "", nil
+	}
+
+	build, err := api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		return "", xerrors.Errorf("get latest workspace build: %w", err)
+	}
+	job, err := api.Database.GetProvisionerJobByID(ctx, build.JobID)
+	if err != nil {
+		return "", xerrors.Errorf("get provisioner job: %w", err)
+	}
+	// Failed and running builds are left to the builder, which retries or
+	// rejects them.
+	if db2sdk.ProvisionerJobStatus(job) != codersdk.ProvisionerJobSucceeded {
+		return "", nil
+	}
+
+	switch transition {
+	case database.WorkspaceBulkOperationTransitionStart:
+		if build.Transition == database.WorkspaceTransitionStart {
+			return "The workspace is already running.", nil
+		}
+	case database.WorkspaceBulkOperationTransitionStop:
+		if build.Transition == database.WorkspaceTransitionStop {
+			return "The workspace is already stopped.", nil
+		}
+	case database.WorkspaceBulkOperationTransitionUpdate:
+		template, err := api.Database.GetTemplateByID(ctx, workspace.TemplateID)
+		if err != nil {
+			return "", xerrors.Errorf("get template: %w", err)
+		}
+		if build.TemplateVersionID == template.ActiveVersionID {
+			return "The workspace is already on the active template version.", nil
+		}
+	}
+	return "", nil
+}
+
+// waitForWorkspaceBuildJob blocks until the provisioner job of a workspace
+// build completes.
+func (api *API) waitForWorkspaceBuildJob(ctx context.Context, workspaceID, jobID uuid.UUID) (database.ProvisionerJob, error) {
+	// A workspace update is published when the job completes. The ticker
+	// covers updates published by replicas that lost their pubsub connection.
+	updates := make(chan struct{}, 1)
+	cancelSubscribe, err := api.Pubsub.Subscribe(codersdk.WorkspaceNotifyChannel(workspaceID), func(_ context.Context, _ []byte) {
+		select {
+		case updates <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return database.ProvisionerJob{}, xerrors.Errorf("subscribe to workspace updates: %w", err)
+	}
+	defer cancelSubscribe()
+
+	ticker := time.NewTicker(workspaceBulkOperationPollInterval)
+	defer ticker.Stop()
+	for {
+		job, err := api.Database.GetProvisionerJobByID(ctx, jobID)
+		if err != nil {
+			return database.ProvisionerJob{}, xerrors.Errorf("get provisioner job: %w", err)
+		}
+		if job.CompletedAt.Valid {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return database.ProvisionerJob{}, ctx.Err()
+		case <-updates:
+		case <-ticker.C:
+		}
+	}
+}
+
+func convertWorkspaceBulkOperation(operation database.WorkspaceBulkOperation, workspaces []database.GetWorkspaceBulkOperationWorkspacesByOperationIDRow) codersdk.WorkspaceBulkOperation {
+	converted := codersdk.WorkspaceBulkOperation{
+		ID:          operation.ID,
+		CreatedAt:   operation.CreatedAt,
+		InitiatorID: operation.InitiatorID,
+		Transition:  codersdk.WorkspaceBulkTransition(operation.Transition),
+		Query:       operation.Query,
+		Concurrency: int(operation.Concurrency),
+		Status:      codersdk.WorkspaceBulkOperationStatusRunning,
+		Workspaces:  make([]codersdk.WorkspaceBulkOperationWorkspace, 0, len(workspaces)),
+	}
+	if operation.CompletedAt.Valid {
+		converted.CompletedAt = &operation.CompletedAt.Time
+		converted.Status = codersdk.WorkspaceBulkOperationStatusCompleted
+	}
+
+	for _, workspace := range workspaces {
+		item := codersdk.WorkspaceBulkOperationWorkspace{
+			WorkspaceID:   workspace.WorkspaceID,
+			WorkspaceName: workspace.WorkspaceName,
+			OwnerName:     workspace.WorkspaceOwnerName,
+			Status:        codersdk.WorkspaceBulkOperationWorkspaceStatus(workspace.Status),
+			Error:         workspace.Error,
+			UpdatedAt:     workspace.UpdatedAt,
+		}
+		if workspace.WorkspaceBuildID.Valid {
+			item.WorkspaceBuildID = &workspace.WorkspaceBuildID.UUID
+		}
+		converted.Workspaces = append(converted.Workspaces, item)
+	}
+	return converted
+}
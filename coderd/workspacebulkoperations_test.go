@@ -0,0 +1,223 @@
+package coderd_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbgen"
+	"github.com/coder/coder/coderd/database/dbtestutil"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/testutil"
+)
+
+func TestWorkspaceBulkOperation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stop", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		first := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+		coderdtest.AwaitWorkspaceBuildJob(t, client, first.LatestBuild.ID)
+		second := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+		coderdtest.AwaitWorkspaceBuildJob(t, client, second.LatestBuild.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		operation, err := client.CreateWorkspaceBulkOperation(ctx, codersdk.CreateWorkspaceBulkOperationRequest{
+			Query:       "owner:me",
+			Transition:  codersdk.WorkspaceBulkTransitionStop,
+			Concurrency: 1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, operation.Concurrency)
+		require.Len(t, operation.Workspaces, 2)
+
+		operation = awaitWorkspaceBulkOperation(ctx, t, client, operation.ID)
+		require.NotNil(t, operation.CompletedAt)
+		for _, workspace := range operation.Workspaces {
+			require.Equal(t, codersdk.WorkspaceBulkOperationWorkspaceStatusSucceeded, workspace.Status, workspace.Error)
+			require.NotNil(t, workspace.WorkspaceBuildID)
+		}
+
+		for _, id := range []uuid.UUID{first.ID, second.ID} {
+			workspace, err := client.Workspace(ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, codersdk.WorkspaceTransitionStop, workspace.LatestBuild.Transition)
+		}
+	})
+
+	t.Run("SkipsReached", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+		coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		for _, transition := range []codersdk.WorkspaceBulkTransition{
+			codersdk.WorkspaceBulkTransitionStart,
+			codersdk.WorkspaceBulkTransitionUpdate,
+		} {
+			operation, err := client.CreateWorkspaceBulkOperation(ctx, codersdk.CreateWorkspaceBulkOperationRequest{
+				Query:      "owner:me",
+				Transition: transition,
+			})
+			require.NoError(t, err)
+			require.Equal(t, 5, operation.Concurrency)
+
+			operation = awaitWorkspaceBulkOperation(ctx, t, client, operation.ID)
+			require.Len(t, operation.Workspaces, 1)
+			require.Equal(t, codersdk.WorkspaceBulkOperationWorkspaceStatusSkipped, operation.Workspaces[0].Status)
+			require.NotEmpty(t, operation.Workspaces[0].Error)
+			require.Nil(t, operation.Workspaces[0].WorkspaceBuildID)
+		}
+	})
+
+	t.Run("OnlyAuthorized", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+		coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		// The member can't build the owner's workspace, so nothing matches.
+		_, err := member.CreateWorkspaceBulkOperation(ctx, codersdk.CreateWorkspaceBulkOperationRequest{
+			Transition: codersdk.WorkspaceBulkTransitionStop,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+		operation, err := client.CreateWorkspaceBulkOperation(ctx, codersdk.CreateWorkspaceBulkOperationRequest{
+			Transition: codersdk.WorkspaceBulkTransitionStop,
+		})
+		require.NoError(t, err)
+		awaitWorkspaceBulkOperation(ctx, t, client, operation.ID)
+
+		// Other users can't see the operation.
+		_, err = member.WorkspaceBulkOperation(ctx, operation.ID)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("CompletesStale", func(t *testing.T) {
+		t.Parallel()
+		db, pubsub := dbtestutil.NewDB(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		// An operation left behind by a replica that stopped, and one still
+		// running on another replica.
+		org := dbgen.Organization(t, db, database.Organization{})
+		user := dbgen.User(t, db, database.User{})
+		template := dbgen.Template(t, db, database.Template{OrganizationID: org.ID, CreatedBy: user.ID})
+		workspace := dbgen.Workspace(t, db, database.Workspace{OwnerID: user.ID, OrganizationID: org.ID, TemplateID: template.ID})
+		stale := dbgen.WorkspaceBulkOperation(t, db, database.WorkspaceBulkOperation{
+			InitiatorID: user.ID,
+			CreatedAt:   database.Now().Add(-time.Hour),
+		})
+		_, err := db.InsertWorkspaceBulkOperationWorkspaces(ctx, database.InsertWorkspaceBulkOperationWorkspacesParams{
+			OperationID:  stale.ID,
+			WorkspaceIDs: []uuid.UUID{workspace.ID},
+			UpdatedAt:    stale.CreatedAt,
+		})
+		require.NoError(t, err)
+		running := dbgen.WorkspaceBulkOperation(t, db, database.WorkspaceBulkOperation{InitiatorID: user.ID})
+
+		// The template has no versions, which the metrics cache logs as an
+		// error.
+		logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true})
+		_ = coderdtest.New(t, &coderdtest.Options{
+			Database: db,
+			Pubsub:   pubsub,
+			Logger:   &logger,
+		})
+
+		require.Eventually(t, func() bool {
+			stale, err = db.GetWorkspaceBulkOperationByID(ctx, stale.ID)
+			return err == nil && stale.CompletedAt.Valid
+		}, testutil.WaitShort, testutil.IntervalFast)
+		items, err := db.GetWorkspaceBulkOperationWorkspacesByOperationID(ctx, stale.ID)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Equal(t, database.WorkspaceBulkOperationWorkspaceStatusFailed, items[0].Status)
+		require.NotEmpty(t, items[0].Error)
+
+		running, err = db.GetWorkspaceBulkOperationByID(ctx, running.ID)
+		require.NoError(t, err)
+		require.False(t, running.CompletedAt.Valid)
+	})
+
+	t.Run("InvalidConcurrency", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		_, err := client.CreateWorkspaceBulkOperation(ctx, codersdk.CreateWorkspaceBulkOperationRequest{
+			Transition:  codersdk.WorkspaceBulkTransitionStart,
+			Concurrency: 1000,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		_, err := client.CreateWorkspaceBulkOperation(ctx, codersdk.CreateWorkspaceBulkOperationRequest{
+			Query:      "unknown:filter",
+			Transition: codersdk.WorkspaceBulkTransitionStart,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+}
+
+func awaitWorkspaceBulkOperation(ctx context.Context, t *testing.T, client *codersdk.Client, id uuid.UUID) codersdk.WorkspaceBulkOperation {
+	t.Helper()
+
+	var operation codersdk.WorkspaceBulkOperation
+	require.Eventually(t, func() bool {
+		var err error
+		operation, err = client.WorkspaceBulkOperation(ctx, id)
+		return err == nil && operation.Status == codersdk.WorkspaceBulkOperationStatusCompleted
+	}, testutil.WaitLong, testutil.IntervalFast)
+	return operation
+}
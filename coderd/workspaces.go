@@ -328,6 +328,50 @@ func (api *API) postWorkspacesByOrganization(rw http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Cloned workspaces default to the template, schedule and TTL of the
+	// source workspace.
+	var sourceWorkspace *database.Workspace
+	if createWorkspace.SourceWorkspaceID != nil {
+		source, err := api.Database.GetWorkspaceByID(ctx, *createWorkspace.SourceWorkspaceID)
+		if httpapi.Is404Error(err) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: fmt.Sprintf("Source workspace %q doesn't exist.", createWorkspace.SourceWorkspaceID.String()),
+				Validations: []codersdk.ValidationError{{
+					Field:  "source_workspace_id",
+					Detail: "workspace not found",
+				}},
+			})
+			return
+		}
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching source workspace.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		if createWorkspace.TemplateID == uuid.Nil {
+			createWorkspace.TemplateID = source.TemplateID
+		}
+		if createWorkspace.TemplateID != source.TemplateID {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "A cloned workspace must use the template of the source workspace.",
+				Validations: []codersdk.ValidationError{{
+					Field:  "template_id",
+					Detail: "does not match the template of the source workspace",
+				}},
+			})
+			return
+		}
+		if createWorkspace.AutostartSchedule == nil && source.AutostartSchedule.Valid {
+			createWorkspace.AutostartSchedule = ptr.Ref(source.AutostartSchedule.String)
+		}
+		if createWorkspace.TTLMillis == nil {
+			createWorkspace.TTLMillis = convertWorkspaceTTLMillis(source.Ttl)
+		}
+		sourceWorkspace = &source
+	}
+
 	template, err := api.Database.GetTemplateByID(ctx, createWorkspace.TemplateID)
 	if errors.Is(err, sql.ErrNoRows) {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
@@ -360,6 +404,22 @@ func (api *API) postWorkspacesByOrganization(rw http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if template.Deprecated != "" {
+		detail := template.Deprecated
+		if template.ReplacementTemplateID.Valid {
+			// The replacement is only named if the user is allowed to see it.
+			replacement, err := api.Database.GetTemplateByID(ctx, template.ReplacementTemplateID.UUID)
+			if err == nil && !replacement.Deleted {
+				detail += fmt.Sprintf(" Use the %q template instead.", replacement.Name)
+			}
+		}
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: fmt.Sprintf("Template %q has been deprecated, and cannot be used to create a new workspace.", template.Name),
+			Detail:  detail,
+		})
+		return
+	}
+
 	dbAutostartSchedule, err := validWorkspaceSchedule(createWorkspace.AutostartSchedule)
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
@@ -420,9 +480,22 @@ func (api *API) postWorkspacesByOrganization(rw http.ResponseWriter, r *http.Req
 	}
 
 	var (
-		provisionerJob *database.ProvisionerJob
-		workspaceBuild *database.WorkspaceBuild
+		sourceVersionID     uuid.UUID
+		richParameterValues = createWorkspace.RichParameterValues
+		provisionerJob      *database.ProvisionerJob
+		workspaceBuild      *database.WorkspaceBuild
 	)
+	if sourceWorkspace != nil {
+		sourceVersionID, richParameterValues, err = api.sourceWorkspaceParameters(ctx, *sourceWorkspace, template, createWorkspace.RichParameterValues)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching source workspace parameters.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+	}
+
 	err = api.Database.InTx(func(db database.Store) error {
 		now := database.Now()
 		// Workspaces are created without any versions.
@@ -448,7 +521,10 @@ func (api *API) postWorkspacesByOrganization(rw http.ResponseWriter, r *http.Req
 			Reason(database.BuildReasonInitiator).
 			Initiator(apiKey.UserID).
 			ActiveVersion().
-			RichParameterValues(createWorkspace.RichParameterValues)
+			RichParameterValues(richParameterValues)
+		if sourceVersionID != uuid.Nil {
+			builder = builder.VersionID(sourceVersionID)
+		}
 		workspaceBuild, provisionerJob, err = builder.Build(
 			ctx, db, func(action rbac.Action, object rbac.Objecter) bool {
 				return api.Authorize(r, action, object)
@@ -670,6 +746,7 @@ func (api *API) putWorkspaceAutostart(rw http.ResponseWriter, r *http.Request) {
 
 	newWorkspace := workspace
 	newWorkspace.AutostartSchedule = dbSched
+	newWorkspace.AutostartSkipUntil = sql.NullTime{}
 	aReq.New = newWorkspace
 
 	rw.WriteHeader(http.StatusNoContent)
@@ -823,6 +900,188 @@ func (api *API) putWorkspaceLock(rw http.ResponseWriter, r *http.Request) {
 	))
 }
 
+// @Summary Transfer workspace to another user
+// @ID transfer-workspace-to-another-user
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.TransferWorkspaceRequest true "Transfer workspace request"
+// @Success 200 {object} codersdk.Workspace
+// @Router /workspaces/{workspace}/owner [put]
+func (api *API) putWorkspaceOwner(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		workspace         = httpmw.WorkspaceParam(r)
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.Workspace](rw, &audit.RequestParams{
+			Audit:   *auditor,
+			Log:     api.Logger,
+			Request: r,
+			Action:  database.AuditActionWrite,
+		})
+	)
+	defer commitAudit()
+	aReq.Old = workspace
+
+	var req codersdk.TransferWorkspaceRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	if req.OwnerID == workspace.OwnerID {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "The workspace is already owned by this user.",
+			Validations: []codersdk.ValidationError{{Field: "owner_id", Detail: "Must be a different user."}},
+		})
+		return
+	}
+
+	// Giving a workspace away is the same as updating it and creating it
+	// for the new owner. Users the workspace is shared with can update it,
+	// but never give it away, so the ACL is ignored.
+	if !api.Authorize(r, rbac.ActionUpdate, workspace.RBACObject().WithACLUserList(nil).WithGroupACL(nil)) ||
+		!api.Authorize(r, rbac.ActionCreate, rbac.ResourceWorkspace.InOrg(workspace.OrganizationID).WithOwner(req.OwnerID.String())) {
+		httpapi.Forbidden(rw)
+		return
+	}
+
+	newOwner, err := api.Database.GetUserByID(ctx, req.OwnerID)
+	if httpapi.Is404Error(err) || (err == nil && newOwner.Deleted) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     fmt.Sprintf("User %q doesn't exist.", req.OwnerID.String()),
+			Validations: []codersdk.ValidationError{{Field: "owner_id", Detail: "user not found"}},
+		})
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching user.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	_, err = api.Database.GetOrganizationMemberByUserID(ctx, database.GetOrganizationMemberByUserIDParams{
+		OrganizationID: workspace.OrganizationID,
+		UserID:         newOwner.ID,
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     fmt.Sprintf("User %q is not a member of the workspace organization.", newOwner.Username),
+			Validations: []codersdk.ValidationError{{Field: "owner_id", Detail: "not an organization member"}},
+		})
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching organization member.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	code := http.StatusInternalServerError
+	resp := codersdk.Response{}
+	err = api.Database.InTx(func(s database.Store) error {
+		// Builds lock the workspace too, so none can be inserted between
+		// the stopped check and the transfer. This runs at read committed,
+		// so the check sees builds committed while waiting for the lock.
+		_, err := s.GetWorkspaceByIDForUpdate(ctx, workspace.ID)
+		if err != nil {
+			resp.Message = "Internal error locking workspace."
+			return xerrors.Errorf("lock workspace: %w", err)
+		}
+
+		// Only stopped workspaces can be transferred, so no agent is
+		// connected with a token the previous owner could have read. The
+		// agents of the next build get new tokens.
+		build, err := s.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+		if err != nil {
+			resp.Message = "Internal error fetching workspace build."
+			return xerrors.Errorf("get latest workspace build: %w", err)
+		}
+		job, err := s.GetProvisionerJobByID(ctx, build.JobID)
+		if err != nil {
+			resp.Message = "Internal error fetching workspace provisioner job."
+			return xerrors.Errorf("get provisioner job: %w", err)
+		}
+		if build.Transition != database.WorkspaceTransitionStop || !job.CompletedAt.Valid || job.Error.Valid {
+			code = http.StatusConflict
+			resp.Message = "Workspace must be stopped before it can be transferred."
+			return xerrors.New("workspace is not stopped")
+		}
+
+		if check := api.WorkspaceTransferQuotaCheck.Load(); check != nil && *check != nil {
+			// The user transferring the workspace can't necessarily see the
+			// quota of the new owner.
+			// nolint:gocritic
+			ok, err := (*check)(dbauthz.AsSystemRestricted(ctx), s, workspace, newOwner.ID)
+			if err != nil {
+				resp.Message = "Internal error checking workspace quota."
+				return xerrors.Errorf("check quota: %w", err)
+			}
+			if !ok {
+				code = http.StatusForbidden
+				resp.Message = fmt.Sprintf("User %q doesn't have enough quota for this workspace.", newOwner.Username)
+				return xerrors.New("insufficient quota")
+			}
+		}
+
+		updated, err := s.UpdateWorkspaceOwner(ctx, database.UpdateWorkspaceOwnerParams{
+			ID:      workspace.ID,
+			OwnerID: newOwner.ID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			code = http.StatusMethodNotAllowed
+			resp.Message = fmt.Sprintf("Workspace %q is deleted and cannot be transferred.", workspace.Name)
+			return err
+		}
+		if database.IsUniqueViolation(err) {
+			code = http.StatusConflict
+			resp.Message = fmt.Sprintf("User %q already has a workspace named %q.", newOwner.Username, workspace.Name)
+			resp.Validations = []codersdk.ValidationError{{
+				Field:  "owner_id",
+				Detail: "The new owner already has a workspace with this name.",
+			}}
+			return err
+		}
+		if err != nil {
+			resp.Message = "Internal error transferring workspace."
+			return xerrors.Errorf("update workspace owner: %w", err)
+		}
+		workspace = updated
+		return nil
+	}, nil)
+	if err != nil {
+		if code == http.StatusInternalServerError {
+			resp.Detail = err.Error()
+		}
+		httpapi.Write(ctx, rw, code, resp)
+		return
+	}
+	aReq.New = workspace
+
+	api.publishWorkspaceUpdate(ctx, workspace.ID)
+
+	data, err := api.workspaceData(ctx, []database.Workspace{workspace})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching workspace resources.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertWorkspace(
+		workspace,
+		data.builds[0],
+		data.templates[0],
+		findUser(workspace.OwnerID, data.users),
+	))
+}
+
 // @Summary Extend workspace deadline by ID
 // @ID extend-workspace-deadline-by-id
 // @Security CoderSessionToken
@@ -1124,6 +1383,11 @@ func convertWorkspace(
 		autostartSchedule = &workspace.AutostartSchedule.String
 	}
 
+	var autostartSkipUntil *time.Time
+	if workspace.AutostartSkipUntil.Valid {
+		autostartSkipUntil = &workspace.AutostartSkipUntil.Time
+	}
+
 	var lockedAt *time.Time
 	if workspace.LockedAt.Valid {
 		lockedAt = &workspace.LockedAt.Time
@@ -1163,6 +1427,7 @@ func convertWorkspace(
 		AutostartSchedule:                    autostartSchedule,
 		TTLMillis:                            ttlMillis,
 		LastUsedAt:                           workspace.LastUsedAt,
+		AutostartSkipUntil:                   autostartSkipUntil,
 		DeletingAt:                           deletedAt,
 		LockedAt:                             lockedAt,
 		Health: codersdk.WorkspaceHealth{
@@ -1172,6 +1437,50 @@ func convertWorkspace(
 	}
 }
 
+// sourceWorkspaceParameters returns the template version and rich parameter
+// values a workspace cloned from source is built with. Values from the
+// latest build of the source are used unless they're ephemeral, immutable or
+// overridden. Immutable parameters are chosen once per workspace, so the
+// clone must be given its own values for them.
+func (api *API) sourceWorkspaceParameters(ctx context.Context, source database.Workspace, template database.Template, overrides []codersdk.WorkspaceBuildParameter) (uuid.UUID, []codersdk.WorkspaceBuildParameter, error) {
+	build, err := api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, source.ID)
+	if err != nil {
+		return uuid.Nil, nil, xerrors.Errorf("get latest build: %w", err)
+	}
+	versionID := build.TemplateVersionID
+	if template.RequireActiveVersion {
+		versionID = template.ActiveVersionID
+	}
+	templateVersionParameters, err := api.Database.GetTemplateVersionParameters(ctx, versionID)
+	if err != nil {
+		return uuid.Nil, nil, xerrors.Errorf("get template version parameters: %w", err)
+	}
+	skip := make(map[string]bool, len(templateVersionParameters))
+	for _, tvp := range templateVersionParameters {
+		skip[tvp.Name] = tvp.Ephemeral || !tvp.Mutable
+	}
+	sourceParameters, err := api.Database.GetWorkspaceBuildParameters(ctx, build.ID)
+	if err != nil {
+		return uuid.Nil, nil, xerrors.Errorf("get build parameters: %w", err)
+	}
+
+	overridden := make(map[string]bool, len(overrides))
+	for _, o := range overrides {
+		overridden[o.Name] = true
+	}
+	values := overrides
+	for _, p := range sourceParameters {
+		if skip[p.Name] || overridden[p.Name] {
+			continue
+		}
+		values = append(values, codersdk.WorkspaceBuildParameter{
+			Name:  p.Name,
+			Value: p.Value,
+		})
+	}
+	return versionID, values, nil
+}
+
 func convertWorkspaceTTLMillis(i sql.NullInt64) *int64 {
 	if !i.Valid {
 		return nil
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -510,6 +511,95 @@ func TestPostWorkspacesByOrganization(t *testing.T) {
 		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
 	})
 
+	t.Run("Clone", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, &echo.Responses{
+			Parse: echo.ParseComplete,
+			ProvisionPlan: []*proto.Provision_Response{{
+				Type: &proto.Provision_Response_Complete{
+					Complete: &proto.Provision_Complete{
+						Parameters: []*proto.RichParameter{
+							{Name: "region", Type: "string", Mutable: true, Required: true},
+							{Name: "disk", Type: "string", Required: true},
+							{Name: "reset", Type: "bool", DefaultValue: "false", Mutable: true, Ephemeral: true},
+						},
+					},
+				},
+			}},
+			ProvisionApply: echo.ProvisionComplete,
+		})
+		coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		source := coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.AutostartSchedule = ptr.Ref("CRON_TZ=US/Central 30 9 * * 1-5")
+			cwr.TTLMillis = ptr.Ref((3 * time.Hour).Milliseconds())
+			cwr.RichParameterValues = []codersdk.WorkspaceBuildParameter{
+				{Name: "region", Value: "eu"},
+				{Name: "disk", Value: "50"},
+				{Name: "reset", Value: "true"},
+			}
+		})
+		coderdtest.AwaitWorkspaceBuildJob(t, client, source.LatestBuild.ID)
+
+		// Clones use the version of the source, not the active one.
+		newVersion := coderdtest.UpdateTemplateVersion(t, client, user.OrganizationID, nil, template.ID)
+		coderdtest.AwaitTemplateVersionJob(t, client, newVersion.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		err := client.UpdateActiveTemplateVersion(ctx, template.ID, codersdk.UpdateActiveTemplateVersion{
+			ID: newVersion.ID,
+		})
+		require.NoError(t, err)
+
+		// Immutable parameters aren't copied, so the required disk must be
+		// given again.
+		var apiErr *codersdk.Error
+		_, err = client.CreateWorkspace(ctx, user.OrganizationID, codersdk.Me, codersdk.CreateWorkspaceRequest{
+			Name:              "nodisk",
+			SourceWorkspaceID: &source.ID,
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+		clone, err := client.CreateWorkspace(ctx, user.OrganizationID, codersdk.Me, codersdk.CreateWorkspaceRequest{
+			Name:              "clone",
+			SourceWorkspaceID: &source.ID,
+			RichParameterValues: []codersdk.WorkspaceBuildParameter{
+				{Name: "region", Value: "us"},
+				{Name: "disk", Value: "100"},
+			},
+		})
+		require.NoError(t, err)
+		coderdtest.AwaitWorkspaceBuildJob(t, client, clone.LatestBuild.ID)
+
+		assert.Equal(t, template.ID, clone.TemplateID)
+		assert.Equal(t, version.ID, clone.LatestBuild.TemplateVersionID)
+		assert.Equal(t, source.AutostartSchedule, clone.AutostartSchedule)
+		assert.Equal(t, source.TTLMillis, clone.TTLMillis)
+
+		parameters, err := client.WorkspaceBuildParameters(ctx, clone.LatestBuild.ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []codersdk.WorkspaceBuildParameter{
+			{Name: "region", Value: "us"},
+			{Name: "disk", Value: "100"},
+			{Name: "reset", Value: "false"},
+		}, parameters)
+
+		// The template must match the source workspace.
+		otherVersion := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		coderdtest.AwaitTemplateVersionJob(t, client, otherVersion.ID)
+		otherTemplate := coderdtest.CreateTemplate(t, client, user.OrganizationID, otherVersion.ID)
+		_, err = client.CreateWorkspace(ctx, user.OrganizationID, codersdk.Me, codersdk.CreateWorkspaceRequest{
+			Name:              "other",
+			TemplateID:        otherTemplate.ID,
+			SourceWorkspaceID: &source.ID,
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+
 	t.Run("TemplateNoTTL", func(t *testing.T) {
 		t.Parallel()
 		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
@@ -2815,3 +2905,165 @@ func TestWorkspaceLock(t *testing.T) {
 		coderdtest.MustTransitionWorkspace(t, client, workspace.ID, database.WorkspaceTransitionStop, database.WorkspaceTransitionStart)
 	})
 }
+
+func TestWorkspaceTransfer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		var (
+			auditor   = audit.NewMock()
+			client    = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true, Auditor: auditor})
+			user      = coderdtest.CreateFirstUser(t, client)
+			_, other  = coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+			version   = coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+			_         = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+			template  = coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+			workspace = coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+			_         = coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+		)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// Running workspaces can't be transferred.
+		_, err := client.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: other.ID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusConflict, apiErr.StatusCode())
+
+		coderdtest.MustTransitionWorkspace(t, client, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+		transferred, err := client.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: other.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, other.ID, transferred.OwnerID)
+		require.Equal(t, other.Username, transferred.OwnerName)
+		require.Eventually(t, func() bool {
+			for _, alog := range auditor.AuditLogs() {
+				if alog.ResourceID == workspace.ID && alog.Action == database.AuditActionWrite {
+					return true
+				}
+			}
+			return false
+		}, testutil.WaitShort, testutil.IntervalFast)
+
+		// The workspace can be started by the new owner.
+		coderdtest.MustTransitionWorkspace(t, client, workspace.ID, database.WorkspaceTransitionStop, database.WorkspaceTransitionStart)
+	})
+
+	t.Run("ConcurrentStart", func(t *testing.T) {
+		t.Parallel()
+		var (
+			client    = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			user      = coderdtest.CreateFirstUser(t, client)
+			_, other  = coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+			version   = coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+			_         = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+			template  = coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+			workspace = coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+			_         = coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+		)
+		coderdtest.MustTransitionWorkspace(t, client, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		var (
+			start       = make(chan struct{})
+			wg          sync.WaitGroup
+			build       codersdk.WorkspaceBuild
+			buildErr    error
+			transferErr error
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			<-start
+			build, buildErr = client.CreateWorkspaceBuild(ctx, workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+				Transition: codersdk.WorkspaceTransitionStart,
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+			_, transferErr = client.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+				OwnerID: other.ID,
+			})
+		}()
+		close(start)
+		wg.Wait()
+
+		// Whichever goes first wins, and the other one sees it.
+		require.True(t, buildErr == nil || transferErr == nil, "build: %v, transfer: %v", buildErr, transferErr)
+		var apiErr *codersdk.Error
+		if buildErr != nil {
+			require.ErrorAs(t, buildErr, &apiErr)
+			require.Equal(t, http.StatusConflict, apiErr.StatusCode())
+		}
+		if transferErr != nil {
+			require.ErrorAs(t, transferErr, &apiErr)
+			require.Equal(t, http.StatusConflict, apiErr.StatusCode())
+		}
+		// A build that succeeded alongside the transfer came after it.
+		if buildErr == nil && transferErr == nil {
+			require.Equal(t, other.ID, build.WorkspaceOwnerID)
+		}
+		if buildErr == nil {
+			coderdtest.AwaitWorkspaceBuildJob(t, client, build.ID)
+		}
+	})
+
+	t.Run("NameConflict", func(t *testing.T) {
+		t.Parallel()
+		var (
+			client             = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			user               = coderdtest.CreateFirstUser(t, client)
+			otherClient, other = coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+			version            = coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+			_                  = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+			template           = coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+			workspace          = coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID)
+			_                  = coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+			existing           = coderdtest.CreateWorkspace(t, otherClient, user.OrganizationID, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+				cwr.Name = workspace.Name
+			})
+			_ = coderdtest.AwaitWorkspaceBuildJob(t, client, existing.LatestBuild.ID)
+		)
+		coderdtest.MustTransitionWorkspace(t, client, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := client.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: other.ID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusConflict, apiErr.StatusCode())
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+		var (
+			client          = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+			user            = coderdtest.CreateFirstUser(t, client)
+			memberClient, _ = coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+			_, other        = coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+			version         = coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+			_               = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+			template        = coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+			workspace       = coderdtest.CreateWorkspace(t, memberClient, user.OrganizationID, template.ID)
+			_               = coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+		)
+		coderdtest.MustTransitionWorkspace(t, memberClient, workspace.ID, database.WorkspaceTransitionStart, database.WorkspaceTransitionStop)
+
+		// Members can't create workspaces for other users, so they can't
+		// give theirs away either.
+		ctx := testutil.Context(t, testutil.WaitLong)
+		_, err := memberClient.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: other.ID,
+		})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+	})
+}
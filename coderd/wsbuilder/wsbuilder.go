@@ -67,7 +67,8 @@ type Option func(Builder) Builder
 // versionTarget expresses how to determine the template version for the build.
 //
 // The zero value of this struct means to use the version from the last build.  If there is no last build,
-// the build will fail.
+// the build will fail.  Start builds of workspaces whose template requires the active version use the active
+// version instead.
 //
 // setting active: true means to use the active version from the template.
 //
@@ -248,7 +249,11 @@ func (b *Builder) buildTx(authFunc func(action rbac.Action, object rbac.Objecter
 			return nil, nil, err
 		}
 	}
-	err := b.checkTemplateVersionMatchesTemplate()
+	err := b.lockWorkspace()
+	if err != nil {
+		return nil, nil, err
+	}
+	err = b.checkTemplateVersionMatchesTemplate()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -256,6 +261,10 @@ func (b *Builder) buildTx(authFunc func(action rbac.Action, object rbac.Objecter
 	if err != nil {
 		return nil, nil, err
 	}
+	err = b.checkTemplateVersionArchived()
+	if err != nil {
+		return nil, nil, err
+	}
 	err = b.checkRunningBuild()
 	if err != nil {
 		return nil, nil, err
@@ -430,12 +439,14 @@ func (b *Builder) getTemplateVersionID() (uuid.UUID, error) {
 	if b.version.specific != nil {
 		return *b.version.specific, nil
 	}
-	if b.version.active {
+	if b.version.active || b.trans == database.WorkspaceTransitionStart {
 		t, err := b.getTemplate()
 		if err != nil {
 			return uuid.Nil, xerrors.Errorf("get template so we can get active version: %w", err)
 		}
-		return t.ActiveVersionID, nil
+		if b.version.active || t.RequireActiveVersion {
+			return t.ActiveVersionID, nil
+		}
 	}
 	// default is prior version
 	bld, err := b.getLastBuild()
@@ -627,6 +638,22 @@ func (b *Builder) getLastBuildJob() (*database.ProvisionerJob, error) {
 	return b.lastBuildJob, nil
 }
 
+// lockWorkspace locks the workspace row for the rest of the transaction, so
+// the workspace can't be transferred to another user while the build is
+// inserted. A transfer committed since the transaction's snapshot was taken
+// fails the lock with a serialization error, and the build is retried.
+func (b *Builder) lockWorkspace() error {
+	workspace, err := b.store.GetWorkspaceByIDForUpdate(b.ctx, b.workspace.ID)
+	if err != nil {
+		return BuildError{http.StatusInternalServerError, "failed to lock workspace", err}
+	}
+	if workspace.OwnerID != b.workspace.OwnerID {
+		msg := "The workspace was transferred to another user."
+		return BuildError{http.StatusConflict, msg, xerrors.New(msg)}
+	}
+	return nil
+}
+
 // authorize performs build authorization pre-checks using the provided authFunc
 func (b *Builder) authorize(authFunc func(action rbac.Action, object rbac.Objecter) bool) error {
 	// Doing this up front saves a lot of work if the user doesn't have permission.
@@ -660,6 +687,15 @@ func (b *Builder) authorize(authFunc func(action rbac.Action, object rbac.Object
 		}
 	}
 
+	// Only template managers may start a workspace with an inactive version if
+	// the template requires the active version.
+	if b.trans == database.WorkspaceTransitionStart && template.RequireActiveVersion &&
+		b.version.specific != nil && *b.version.specific != template.ActiveVersionID &&
+		!authFunc(rbac.ActionUpdate, template.RBACObject()) {
+		msg := "The template requires workspaces to be started with the active version."
+		return BuildError{http.StatusForbidden, msg, xerrors.New(msg)}
+	}
+
 	if b.logLevel != "" && !authFunc(rbac.ActionRead, rbac.ResourceDeploymentValues) {
 		return BuildError{
 			http.StatusBadRequest,
@@ -742,6 +778,27 @@ func (b *Builder) checkTemplateJobStatus() error {
 	return nil
 }
 
+// checkTemplateVersionArchived prevents archived template versions from being
+// started. Workspaces on an archived version can still be stopped and deleted.
+func (b *Builder) checkTemplateVersionArchived() error {
+	if b.trans != database.WorkspaceTransitionStart {
+		return nil
+	}
+	templateVersion, err := b.getTemplateVersion()
+	if err != nil {
+		return BuildError{http.StatusInternalServerError, "failed to fetch template version", err}
+	}
+	if templateVersion.Archived {
+		msg := fmt.Sprintf("The template version %q is archived and can't be used to start workspaces. Update the workspace to the active version instead.", templateVersion.Name)
+		return BuildError{
+			http.StatusBadRequest,
+			msg,
+			xerrors.New(msg),
+		}
+	}
+	return nil
+}
+
 func (b *Builder) checkRunningBuild() error {
 	job, err := b.getLastBuildJob()
 	if xerrors.Is(err, sql.ErrNoRows) {
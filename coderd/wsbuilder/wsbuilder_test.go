@@ -16,6 +16,7 @@ import (
 	"github.com/coder/coder/coderd/database"
 	"github.com/coder/coder/coderd/database/dbmock"
 	"github.com/coder/coder/coderd/provisionerdserver"
+	"github.com/coder/coder/coderd/rbac"
 	"github.com/coder/coder/coderd/wsbuilder"
 	"github.com/coder/coder/codersdk"
 )
@@ -199,6 +200,70 @@ func TestBuilder_ActiveVersion(t *testing.T) {
 	req.NoError(err)
 }
 
+func TestBuilder_RequireActiveVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DefaultVersion", func(t *testing.T) {
+		t.Parallel()
+		req := require.New(t)
+		asrt := assert.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mDB := expectDB(t,
+			// Inputs
+			withTemplateRequireActiveVersion,
+			withActiveVersion(nil),
+			withLastBuildFound,
+			withRichParameters(nil),
+			withParameterSchemas(activeJobID, nil),
+
+			// Outputs
+			expectProvisionerJob(func(job database.InsertProvisionerJobParams) {
+				asrt.Equal(activeFileID, job.FileID)
+			}),
+
+			withInTx,
+			expectBuild(func(bld database.InsertWorkspaceBuildParams) {
+				// the last build used the inactive version, but the template
+				// requires the active one.
+				asrt.Equal(activeVersionID, bld.TemplateVersionID)
+				asrt.Equal(int32(2), bld.BuildNumber)
+			}),
+			withBuild,
+			expectBuildParameters(func(params database.InsertWorkspaceBuildParametersParams) {
+			}),
+		)
+
+		ws := database.Workspace{ID: workspaceID, TemplateID: templateID, OwnerID: userID}
+		uut := wsbuilder.New(ws, database.WorkspaceTransitionStart)
+		_, _, err := uut.Build(ctx, mDB, nil)
+		req.NoError(err)
+	})
+
+	t.Run("SpecificVersionForbidden", func(t *testing.T) {
+		t.Parallel()
+		req := require.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mDB := expectDB(t, withTemplateRequireActiveVersion)
+
+		ws := database.Workspace{ID: workspaceID, TemplateID: templateID, OwnerID: userID}
+		uut := wsbuilder.New(ws, database.WorkspaceTransitionStart).VersionID(inactiveVersionID)
+		// The caller may update the workspace, but not the template.
+		authFunc := func(action rbac.Action, object rbac.Objecter) bool {
+			return object.RBACObject().Type == rbac.ResourceWorkspace.Type
+		}
+		_, _, err := uut.Build(ctx, mDB, authFunc)
+		var buildErr wsbuilder.BuildError
+		req.ErrorAs(err, &buildErr)
+		req.Equal(http.StatusForbidden, buildErr.Status)
+	})
+}
+
 func TestWorkspaceBuildWithRichParameters(t *testing.T) {
 	t.Parallel()
 
@@ -596,6 +661,11 @@ func expectDB(t *testing.T, opts ...txExpect) *dbmock.MockStore {
 			return err
 		})
 
+	// every build locks the workspace first.
+	mTx.EXPECT().GetWorkspaceByIDForUpdate(gomock.Any(), workspaceID).
+		Times(1).
+		Return(database.Workspace{ID: workspaceID, TemplateID: templateID, OwnerID: userID}, nil)
+
 	// txExpect args set up the expectations for what happens in the transaction.
 	for _, o := range opts {
 		o(mTx)
@@ -614,6 +684,18 @@ func withTemplate(mTx *dbmock.MockStore) {
 		}, nil)
 }
 
+func withTemplateRequireActiveVersion(mTx *dbmock.MockStore) {
+	mTx.EXPECT().GetTemplateByID(gomock.Any(), templateID).
+		Times(1).
+		Return(database.Template{
+			ID:                   templateID,
+			OrganizationID:       orgID,
+			Provisioner:          database.ProvisionerTypeTerraform,
+			ActiveVersionID:      activeVersionID,
+			RequireActiveVersion: true,
+		}, nil)
+}
+
 // withInTx runs the given functions on the same db mock.
 func withInTx(mTx *dbmock.MockStore) {
 	mTx.EXPECT().InTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
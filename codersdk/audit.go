@@ -3,6 +3,7 @@ package codersdk
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/netip"
 	"strings"
@@ -132,6 +133,22 @@ type AuditLogResponse struct {
 	Count     int64      `json:"count"`
 }
 
+type AuditLogExportFormat string
+
+const (
+	AuditLogExportFormatCSV   AuditLogExportFormat = "csv"
+	AuditLogExportFormatJSONL AuditLogExportFormat = "jsonl"
+)
+
+// AuditLogExportRequest filters the audit logs to export. Since and Until
+// narrow the date range of the search query.
+type AuditLogExportRequest struct {
+	SearchQuery string               `json:"q,omitempty"`
+	Since       time.Time            `json:"since,omitempty" format:"date-time"`
+	Until       time.Time            `json:"until,omitempty" format:"date-time"`
+	Format      AuditLogExportFormat `json:"format,omitempty" enums:"csv,jsonl"`
+}
+
 type CreateTestAuditLogRequest struct {
 	Action           AuditAction     `json:"action,omitempty" enums:"create,write,delete,start,stop"`
 	ResourceType     ResourceType    `json:"resource_type,omitempty" enums:"template,template_version,user,workspace,workspace_build,git_ssh_key,auditable_group"`
@@ -170,6 +187,35 @@ func (c *Client) AuditLogs(ctx context.Context, req AuditLogsRequest) (AuditLogR
 	return logRes, nil
 }
 
+// ExportAuditLogs streams all audit logs matching the request, newest
+// first. The caller must close the returned reader.
+func (c *Client) ExportAuditLogs(ctx context.Context, req AuditLogExportRequest) (io.ReadCloser, error) {
+	res, err := c.Request(ctx, http.MethodGet, "/api/v2/audit/export", nil, func(r *http.Request) {
+		q := r.URL.Query()
+		if req.SearchQuery != "" {
+			q.Set("q", req.SearchQuery)
+		}
+		if !req.Since.IsZero() {
+			q.Set("since", req.Since.Format(time.RFC3339Nano))
+		}
+		if !req.Until.IsZero() {
+			q.Set("until", req.Until.Format(time.RFC3339Nano))
+		}
+		if req.Format != "" {
+			q.Set("format", string(req.Format))
+		}
+		r.URL.RawQuery = q.Encode()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		return nil, ReadBodyAsError(res)
+	}
+	return res.Body, nil
+}
+
 // CreateTestAuditLog creates a fake audit log. Only owners of the organization
 // can perform this action. It's used for testing purposes.
 func (c *Client) CreateTestAuditLog(ctx context.Context, req CreateTestAuditLogRequest) error {
@@ -0,0 +1,77 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+)
+
+// AutostartException is a period during which workspaces are not
+// automatically started, e.g. a public holiday.
+type AutostartException struct {
+	ID uuid.UUID `json:"id" format:"uuid"`
+	// TemplateID is empty for exceptions that apply to every workspace in
+	// the deployment.
+	TemplateID *uuid.UUID `json:"template_id,omitempty" format:"uuid"`
+	Name       string     `json:"name"`
+	StartsAt   time.Time  `json:"starts_at" format:"date-time"`
+	EndsAt     time.Time  `json:"ends_at" format:"date-time"`
+	// AllDay exceptions cover the dates from starts_at up to, but not
+	// including, ends_at in the time zone of each autostart schedule.
+	AllDay bool `json:"all_day"`
+}
+
+// CreateAutostartExceptionRequest is a single autostart exception.
+type CreateAutostartExceptionRequest struct {
+	Name     string    `json:"name"`
+	StartsAt time.Time `json:"starts_at" validate:"required" format:"date-time"`
+	EndsAt   time.Time `json:"ends_at" validate:"required" format:"date-time"`
+	AllDay   bool      `json:"all_day"`
+}
+
+// UpdateAutostartExceptionsRequest replaces all autostart exceptions of the
+// deployment or of a template.
+type UpdateAutostartExceptionsRequest struct {
+	Exceptions []CreateAutostartExceptionRequest `json:"exceptions"`
+}
+
+// DeploymentAutostartExceptions returns the autostart exceptions that apply
+// to every workspace in the deployment.
+func (c *Client) DeploymentAutostartExceptions(ctx context.Context) ([]AutostartException, error) {
+	return c.autostartExceptions(ctx, http.MethodGet, "/api/v2/deployment/autostart-exceptions", nil)
+}
+
+// UpdateDeploymentAutostartExceptions replaces the autostart exceptions that
+// apply to every workspace in the deployment.
+func (c *Client) UpdateDeploymentAutostartExceptions(ctx context.Context, req UpdateAutostartExceptionsRequest) ([]AutostartException, error) {
+	return c.autostartExceptions(ctx, http.MethodPut, "/api/v2/deployment/autostart-exceptions", req)
+}
+
+// TemplateAutostartExceptions returns the autostart exceptions of a template.
+func (c *Client) TemplateAutostartExceptions(ctx context.Context, templateID uuid.UUID) ([]AutostartException, error) {
+	return c.autostartExceptions(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/autostart-exceptions", templateID), nil)
+}
+
+// UpdateTemplateAutostartExceptions replaces the autostart exceptions of a
+// template.
+func (c *Client) UpdateTemplateAutostartExceptions(ctx context.Context, templateID uuid.UUID, req UpdateAutostartExceptionsRequest) ([]AutostartException, error) {
+	return c.autostartExceptions(ctx, http.MethodPut, fmt.Sprintf("/api/v2/templates/%s/autostart-exceptions", templateID), req)
+}
+
+func (c *Client) autostartExceptions(ctx context.Context, method, path string, body interface{}) ([]AutostartException, error) {
+	res, err := c.Request(ctx, method, path, body)
+	if err != nil {
+		return nil, xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var exceptions []AutostartException
+	return exceptions, json.NewDecoder(res.Body).Decode(&exceptions)
+}
@@ -171,6 +171,10 @@ type DeploymentValues struct {
 	ProxyHealthStatusInterval       clibase.Duration                `json:"proxy_health_status_interval,omitempty" typescript:",notnull"`
 	EnableTerraformDebugMode        clibase.Bool                    `json:"enable_terraform_debug_mode,omitempty" typescript:",notnull"`
 	UserQuietHoursSchedule          UserQuietHoursScheduleConfig    `json:"user_quiet_hours_schedule,omitempty" typescript:",notnull"`
+	Notifications                   NotificationsConfig             `json:"notifications,omitempty" typescript:",notnull"`
+	AuditLogExport                  AuditLogExportConfig            `json:"audit_log_export,omitempty" typescript:",notnull"`
+	AuditLogHashChain               AuditLogHashChainConfig         `json:"audit_log_hash_chain,omitempty" typescript:",notnull"`
+	Retention                       RetentionConfig                 `json:"retention,omitempty" typescript:",notnull"`
 
 	Config      clibase.YAMLConfigPath `json:"config,omitempty" typescript:",notnull"`
 	WriteConfig clibase.Bool           `json:"write_config,omitempty" typescript:",notnull"`
@@ -490,6 +494,26 @@ when required by your organization's security policy.`,
 			Description: "Allow users to set quiet hours schedules each day for workspaces to avoid workspaces stopping during the day due to template max TTL.",
 			YAML:        "userQuietHoursSchedule",
 		}
+		deploymentGroupNotifications = clibase.Group{
+			Name:        "Notifications",
+			Description: "Notify users of events such as failed builds or workspaces that are about to stop. Users read notifications in their inbox and, if an SMTP server is configured, by email.",
+			YAML:        "notifications",
+		}
+		deploymentGroupAuditLogExport = clibase.Group{
+			Name:        "Audit Log Export",
+			Description: "Send audit logs to external security tooling as they happen. Each configured destination receives every audit log. Requires the audit log feature.",
+			YAML:        "auditLogExport",
+		}
+		deploymentGroupAuditLogHashChain = clibase.Group{
+			Name:        "Audit Log Hash Chain",
+			Description: "Make audit logs tamper-evident. Every audit log stores a hash chained over the previous audit log, and the head of the chain is periodically signed. Verify the chain with \"coder server audit verify\". Requires the audit log feature.",
+			YAML:        "auditLogHashChain",
+		}
+		deploymentGroupRetention = clibase.Group{
+			Name:        "Retention",
+			Description: "Delete old data that would otherwise grow the database without bound. Old data is purged once a day.",
+			YAML:        "retention",
+		}
 		deploymentGroupDangerous = clibase.Group{
 			Name: "⚠️ Dangerous",
 			YAML: "dangerous",
@@ -1695,10 +1719,255 @@ Write out the current server config as YAML to stdout.`,
 			Group:       &deploymentGroupUserQuietHoursSchedule,
 			YAML:        "defaultQuietHoursSchedule",
 		},
+		{
+			Name:        "Notifications Email From",
+			Description: "The sender address of notification emails. Emails are only sent if both this and the smarthost are set.",
+			Flag:        "notifications-email-from",
+			Env:         "CODER_NOTIFICATIONS_EMAIL_FROM",
+			Value:       &c.Notifications.EmailFrom,
+			Group:       &deploymentGroupNotifications,
+			YAML:        "emailFrom",
+		},
+		{
+			Name:        "Notifications Email Smarthost",
+			Description: "The SMTP server to send notification emails through, in host:port form. STARTTLS is used if the server supports it.",
+			Flag:        "notifications-email-smarthost",
+			Env:         "CODER_NOTIFICATIONS_EMAIL_SMARTHOST",
+			Value:       &c.Notifications.EmailSmarthost,
+			Group:       &deploymentGroupNotifications,
+			YAML:        "emailSmarthost",
+		},
+		{
+			Name:        "Notifications Email Username",
+			Description: "The username to authenticate to the SMTP server with. Authentication is skipped if unset.",
+			Flag:        "notifications-email-username",
+			Env:         "CODER_NOTIFICATIONS_EMAIL_USERNAME",
+			Value:       &c.Notifications.EmailUsername,
+			Group:       &deploymentGroupNotifications,
+			YAML:        "emailUsername",
+		},
+		{
+			Name:        "Notifications Email Password",
+			Description: "The password to authenticate to the SMTP server with.",
+			Flag:        "notifications-email-password",
+			Env:         "CODER_NOTIFICATIONS_EMAIL_PASSWORD",
+			Annotations: clibase.Annotations{}.Mark(annotationSecretKey, "true"),
+			Value:       &c.Notifications.EmailPassword,
+			Group:       &deploymentGroupNotifications,
+		},
+		{
+			Name:        "Notifications Max Send Attempts",
+			Description: "The number of times sending a notification email is attempted before it is marked as failed.",
+			Flag:        "notifications-max-send-attempts",
+			Env:         "CODER_NOTIFICATIONS_MAX_SEND_ATTEMPTS",
+			Default:     "5",
+			Value:       &c.Notifications.MaxSendAttempts,
+			Group:       &deploymentGroupNotifications,
+			YAML:        "maxSendAttempts",
+		},
+		{
+			Name:        "Audit Log Webhook URL",
+			Description: "An HTTPS URL to send batches of audit logs to as JSON arrays. Requests are signed with the webhook secret.",
+			Flag:        "audit-log-webhook-url",
+			Env:         "CODER_AUDIT_LOG_WEBHOOK_URL",
+			Value:       &c.AuditLogExport.WebhookURL,
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "webhookURL",
+		},
+		{
+			Name:        "Audit Log Webhook Secret",
+			Description: "The secret to sign audit log webhook requests with. The X-Coder-Signature header contains the hex encoded HMAC-SHA256 of the X-Coder-Timestamp header, a period and the request body.",
+			Flag:        "audit-log-webhook-secret",
+			Env:         "CODER_AUDIT_LOG_WEBHOOK_SECRET",
+			Annotations: clibase.Annotations{}.Mark(annotationSecretKey, "true"),
+			Value:       &c.AuditLogExport.WebhookSecret,
+			Group:       &deploymentGroupAuditLogExport,
+		},
+		{
+			Name:        "Audit Log Webhook Batch Size",
+			Description: "The maximum number of audit logs sent per webhook request.",
+			Flag:        "audit-log-webhook-batch-size",
+			Env:         "CODER_AUDIT_LOG_WEBHOOK_BATCH_SIZE",
+			Default:     "100",
+			Value:       &c.AuditLogExport.WebhookBatchSize,
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "webhookBatchSize",
+		},
+		{
+			Name:        "Audit Log Webhook Flush Interval",
+			Description: "How often audit logs are sent to the webhook if fewer than a batch are queued.",
+			Flag:        "audit-log-webhook-flush-interval",
+			Env:         "CODER_AUDIT_LOG_WEBHOOK_FLUSH_INTERVAL",
+			Default:     (5 * time.Second).String(),
+			Value:       &c.AuditLogExport.WebhookFlushInterval,
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "webhookFlushInterval",
+		},
+		{
+			Name:        "Audit Log Webhook Max Queue Size",
+			Description: "The number of audit logs kept in memory while the webhook is unavailable. Failed requests are retried with exponential backoff, and the oldest audit logs are dropped once the queue is full.",
+			Flag:        "audit-log-webhook-max-queue-size",
+			Env:         "CODER_AUDIT_LOG_WEBHOOK_MAX_QUEUE_SIZE",
+			Default:     "10000",
+			Value:       &c.AuditLogExport.WebhookMaxQueueSize,
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "webhookMaxQueueSize",
+		},
+		{
+			Name:        "Audit Log Syslog Address",
+			Description: "The syslog server to send audit logs to as RFC 5424 messages, in host:port form.",
+			Flag:        "audit-log-syslog-address",
+			Env:         "CODER_AUDIT_LOG_SYSLOG_ADDRESS",
+			Value:       &c.AuditLogExport.SyslogAddress,
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "syslogAddress",
+		},
+		{
+			Name:        "Audit Log Syslog Transport",
+			Description: "The transport to send syslog messages over. Messages sent over udp are truncated to 2048 bytes, and messages sent over tcp and tls are framed by octet counting.",
+			Flag:        "audit-log-syslog-transport",
+			Env:         "CODER_AUDIT_LOG_SYSLOG_TRANSPORT",
+			Default:     "udp",
+			Value:       clibase.EnumOf((*string)(&c.AuditLogExport.SyslogTransport), "udp", "tcp", "tls"),
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "syslogTransport",
+		},
+		{
+			Name:        "Audit Log Syslog TLS CA File",
+			Description: "A PEM encoded file of the certificate authorities to verify the syslog server with when the transport is tls. Defaults to the system certificate pool.",
+			Flag:        "audit-log-syslog-tls-ca-file",
+			Env:         "CODER_AUDIT_LOG_SYSLOG_TLS_CA_FILE",
+			Value:       &c.AuditLogExport.SyslogTLSCAFile,
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "syslogTLSCAFile",
+		},
+		{
+			Name:        "Audit Log File Path",
+			Description: "A file to write audit logs to as JSON lines.",
+			Flag:        "audit-log-file-path",
+			Env:         "CODER_AUDIT_LOG_FILE_PATH",
+			Value:       &c.AuditLogExport.FilePath,
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "filePath",
+		},
+		{
+			Name:        "Audit Log File Max Size",
+			Description: "The size in megabytes the audit log file is rotated at.",
+			Flag:        "audit-log-file-max-size",
+			Env:         "CODER_AUDIT_LOG_FILE_MAX_SIZE",
+			Default:     "100",
+			Value:       &c.AuditLogExport.FileMaxSize,
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "fileMaxSize",
+		},
+		{
+			Name:        "Audit Log File Max Backups",
+			Description: "The number of rotated audit log files to keep. Set to 0 to keep all of them.",
+			Flag:        "audit-log-file-max-backups",
+			Env:         "CODER_AUDIT_LOG_FILE_MAX_BACKUPS",
+			Default:     "10",
+			Value:       &c.AuditLogExport.FileMaxBackups,
+			Group:       &deploymentGroupAuditLogExport,
+			YAML:        "fileMaxBackups",
+		},
+		{
+			Name:        "Audit Log Hash Chain",
+			Description: "Append audit logs to a hash chain. Audit logs are inserted one at a time across all replicas while this is enabled.",
+			Flag:        "audit-log-hash-chain",
+			Env:         "CODER_AUDIT_LOG_HASH_CHAIN",
+			Value:       &c.AuditLogHashChain.Enable,
+			Group:       &deploymentGroupAuditLogHashChain,
+			YAML:        "enable",
+		},
+		{
+			Name:        "Audit Log Hash Chain Secret",
+			Description: "The secret to sign hash chain checkpoints with. Required if the hash chain is enabled. Keep it out of the database, since anyone who knows it can forge checkpoints.",
+			Flag:        "audit-log-hash-chain-secret",
+			Env:         "CODER_AUDIT_LOG_HASH_CHAIN_SECRET",
+			Annotations: clibase.Annotations{}.Mark(annotationSecretKey, "true"),
+			Value:       &c.AuditLogHashChain.Secret,
+			Group:       &deploymentGroupAuditLogHashChain,
+		},
+		{
+			Name:        "Audit Log Hash Chain Checkpoint Interval",
+			Description: "How often the head of the hash chain is signed. Audit logs deleted from the end of the chain are only detected up to the last checkpoint.",
+			Flag:        "audit-log-hash-chain-checkpoint-interval",
+			Env:         "CODER_AUDIT_LOG_HASH_CHAIN_CHECKPOINT_INTERVAL",
+			Default:     time.Hour.String(),
+			Value:       &c.AuditLogHashChain.CheckpointInterval,
+			Group:       &deploymentGroupAuditLogHashChain,
+			YAML:        "checkpointInterval",
+		},
+		{
+			Name:        "Audit Log Retention",
+			Description: "How long audit logs are kept. Older audit logs are deleted. Audit logs in the hash chain are only deleted up to the newest signed checkpoint older than this, so the remaining chain still verifies. Set to 0 to keep audit logs forever.",
+			Flag:        "audit-log-retention",
+			Env:         "CODER_AUDIT_LOG_RETENTION",
+			Default:     "0",
+			Value:       &c.Retention.AuditLogs,
+			Group:       &deploymentGroupRetention,
+			YAML:        "auditLogs",
+		},
+		{
+			Name:        "Provisioner Job Log Retention",
+			Description: "How long the logs of completed provisioner jobs are kept. Logs of jobs that completed earlier are deleted. Set to 0 to keep job logs forever.",
+			Flag:        "provisioner-job-log-retention",
+			Env:         "CODER_PROVISIONER_JOB_LOG_RETENTION",
+			Default:     "0",
+			Value:       &c.Retention.ProvisionerJobLogs,
+			Group:       &deploymentGroupRetention,
+			YAML:        "provisionerJobLogs",
+		},
+		{
+			Name:        "Workspace Build State Retention",
+			Description: "The number of most recent builds of each workspace that keep their Terraform state. The state of older builds is deleted, so it can no longer be pulled with \"coder state pull\". Set to 0 to keep the state of every build.",
+			Flag:        "workspace-build-state-retention",
+			Env:         "CODER_WORKSPACE_BUILD_STATE_RETENTION",
+			Default:     "0",
+			Value:       &c.Retention.WorkspaceBuildState,
+			Group:       &deploymentGroupRetention,
+			YAML:        "workspaceBuildState",
+		},
 	}
 	return opts
 }
 
+type NotificationsConfig struct {
+	EmailFrom       clibase.String `json:"email_from" typescript:",notnull"`
+	EmailSmarthost  clibase.String `json:"email_smarthost" typescript:",notnull"`
+	EmailUsername   clibase.String `json:"email_username" typescript:",notnull"`
+	EmailPassword   clibase.String `json:"email_password" typescript:",notnull"`
+	MaxSendAttempts clibase.Int64  `json:"max_send_attempts" typescript:",notnull"`
+}
+
+type AuditLogExportConfig struct {
+	WebhookURL           clibase.URL      `json:"webhook_url" typescript:",notnull"`
+	WebhookSecret        clibase.String   `json:"webhook_secret" typescript:",notnull"`
+	WebhookBatchSize     clibase.Int64    `json:"webhook_batch_size" typescript:",notnull"`
+	WebhookFlushInterval clibase.Duration `json:"webhook_flush_interval" typescript:",notnull"`
+	WebhookMaxQueueSize  clibase.Int64    `json:"webhook_max_queue_size" typescript:",notnull"`
+	SyslogAddress        clibase.String   `json:"syslog_address" typescript:",notnull"`
+	SyslogTransport      clibase.String   `json:"syslog_transport" typescript:",notnull"`
+	SyslogTLSCAFile      clibase.String   `json:"syslog_tls_ca_file" typescript:",notnull"`
+	FilePath             clibase.String   `json:"file_path" typescript:",notnull"`
+	FileMaxSize          clibase.Int64    `json:"file_max_size" typescript:",notnull"`
+	FileMaxBackups       clibase.Int64    `json:"file_max_backups" typescript:",notnull"`
+}
+
+type AuditLogHashChainConfig struct {
+	Enable             clibase.Bool     `json:"enable" typescript:",notnull"`
+	Secret             clibase.String   `json:"secret" typescript:",notnull"`
+	CheckpointInterval clibase.Duration `json:"checkpoint_interval" typescript:",notnull"`
+}
+
+type RetentionConfig struct {
+	AuditLogs          clibase.Duration `json:"audit_logs" typescript:",notnull"`
+	ProvisionerJobLogs clibase.Duration `json:"provisioner_job_logs" typescript:",notnull"`
+	// WorkspaceBuildState is the number of builds per workspace that keep
+	// their state, not a duration.
+	WorkspaceBuildState clibase.Int64 `json:"workspace_build_state" typescript:",notnull"`
+}
+
 type SupportConfig struct {
 	Links clibase.Struct[[]LinkConfig] `json:"links" typescript:",notnull"`
 }
@@ -57,6 +57,15 @@ func TestDeploymentValues_HighlyConfigurable(t *testing.T) {
 		"SCIM API Key": {
 			yaml: true,
 		},
+		"Notifications Email Password": {
+			yaml: true,
+		},
+		"Audit Log Webhook Secret": {
+			yaml: true,
+		},
+		"Audit Log Hash Chain Secret": {
+			yaml: true,
+		},
 		// These complex objects should be configured through YAML.
 		"Support Links": {
 			flag: true,
@@ -0,0 +1,188 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+)
+
+// NotificationEvent is an event that users are notified of.
+type NotificationEvent string
+
+const (
+	NotificationEventWorkspaceBuildFailed    NotificationEvent = "workspace_build_failed"
+	NotificationEventWorkspaceAutostop       NotificationEvent = "workspace_autostop"
+	NotificationEventWorkspaceLocked         NotificationEvent = "workspace_locked"
+	NotificationEventTemplateVersionPromoted NotificationEvent = "template_version_promoted"
+	NotificationEventUserSuspended           NotificationEvent = "user_suspended"
+)
+
+// NotificationEvents lists all events users can be notified of.
+var NotificationEvents = []NotificationEvent{
+	NotificationEventWorkspaceBuildFailed,
+	NotificationEventWorkspaceAutostop,
+	NotificationEventWorkspaceLocked,
+	NotificationEventTemplateVersionPromoted,
+	NotificationEventUserSuspended,
+}
+
+// Notification is a message in the inbox of a user.
+type Notification struct {
+	ID        uuid.UUID         `json:"id" format:"uuid"`
+	Event     NotificationEvent `json:"event" enums:"workspace_build_failed,workspace_autostop,workspace_locked,template_version_promoted,user_suspended"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	CreatedAt time.Time         `json:"created_at" format:"date-time"`
+	// ReadAt is empty for unread notifications.
+	ReadAt *time.Time `json:"read_at,omitempty" format:"date-time"`
+}
+
+// NotificationsRequest filters the notifications in the inbox of a user.
+type NotificationsRequest struct {
+	// Unread only returns the notifications that were not read yet.
+	Unread bool `json:"unread,omitempty"`
+	Pagination
+}
+
+// UpdateNotificationRequest marks a notification as read or unread.
+type UpdateNotificationRequest struct {
+	Read bool `json:"read"`
+}
+
+// NotificationPreference controls how a user is notified of an event. Users
+// are notified of events without a preference by email and in their inbox.
+type NotificationPreference struct {
+	Event NotificationEvent `json:"event" validate:"required" enums:"workspace_build_failed,workspace_autostop,workspace_locked,template_version_promoted,user_suspended"`
+	Email bool              `json:"email"`
+	Inbox bool              `json:"inbox"`
+}
+
+// UpdateNotificationPreferencesRequest updates the preferences for the given
+// events. Preferences for other events are left unchanged.
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryStatusPending NotificationDeliveryStatus = "pending"
+	NotificationDeliveryStatusSent    NotificationDeliveryStatus = "sent"
+	NotificationDeliveryStatusFailed  NotificationDeliveryStatus = "failed"
+)
+
+// NotificationDelivery is an entry of the log of notification emails.
+type NotificationDelivery struct {
+	ID             uuid.UUID                  `json:"id" format:"uuid"`
+	NotificationID uuid.UUID                  `json:"notification_id" format:"uuid"`
+	UserID         uuid.UUID                  `json:"user_id" format:"uuid"`
+	Event          NotificationEvent          `json:"event" enums:"workspace_build_failed,workspace_autostop,workspace_locked,template_version_promoted,user_suspended"`
+	Title          string                     `json:"title"`
+	Address        string                     `json:"address"`
+	Status         NotificationDeliveryStatus `json:"status" enums:"pending,sent,failed"`
+	Attempts       int                        `json:"attempts"`
+	LastError      string                     `json:"last_error,omitempty"`
+	// NextAttemptAt is only set for pending deliveries.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" format:"date-time"`
+	CreatedAt     time.Time  `json:"created_at" format:"date-time"`
+	UpdatedAt     time.Time  `json:"updated_at" format:"date-time"`
+}
+
+// NotificationDeliveriesRequest filters the notification delivery log.
+type NotificationDeliveriesRequest struct {
+	Status NotificationDeliveryStatus `json:"status,omitempty"`
+	Pagination
+}
+
+// Notifications returns the inbox of a user, newest first.
+func (c *Client) Notifications(ctx context.Context, user string, req NotificationsRequest) ([]Notification, error) {
+	opts := []RequestOption{req.Pagination.asRequestOption()}
+	if req.Unread {
+		opts = append(opts, WithQueryParam("unread", "true"))
+	}
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications", user), nil, opts...)
+	if err != nil {
+		return nil, xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var notifications []Notification
+	return notifications, json.NewDecoder(res.Body).Decode(&notifications)
+}
+
+// UpdateNotification marks a notification of a user as read or unread.
+func (c *Client) UpdateNotification(ctx context.Context, user string, id uuid.UUID, req UpdateNotificationRequest) (Notification, error) {
+	res, err := c.Request(ctx, http.MethodPatch, fmt.Sprintf("/api/v2/users/%s/notifications/%s", user, id), req)
+	if err != nil {
+		return Notification{}, xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return Notification{}, ReadBodyAsError(res)
+	}
+	var notification Notification
+	return notification, json.NewDecoder(res.Body).Decode(&notification)
+}
+
+// MarkAllNotificationsRead marks all notifications of a user as read.
+func (c *Client) MarkAllNotificationsRead(ctx context.Context, user string) error {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/read", user), nil)
+	if err != nil {
+		return xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// NotificationPreferences returns the preferences of a user for every event.
+func (c *Client) NotificationPreferences(ctx context.Context, user string) ([]NotificationPreference, error) {
+	return c.notificationPreferences(ctx, http.MethodGet, user, nil)
+}
+
+// UpdateNotificationPreferences updates the preferences of a user and returns
+// the preferences for every event.
+func (c *Client) UpdateNotificationPreferences(ctx context.Context, user string, req UpdateNotificationPreferencesRequest) ([]NotificationPreference, error) {
+	return c.notificationPreferences(ctx, http.MethodPut, user, req)
+}
+
+func (c *Client) notificationPreferences(ctx context.Context, method, user string, body interface{}) ([]NotificationPreference, error) {
+	res, err := c.Request(ctx, method, fmt.Sprintf("/api/v2/users/%s/notifications/preferences", user), body)
+	if err != nil {
+		return nil, xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var preferences []NotificationPreference
+	return preferences, json.NewDecoder(res.Body).Decode(&preferences)
+}
+
+// NotificationDeliveries returns the log of notification emails, newest
+// first.
+func (c *Client) NotificationDeliveries(ctx context.Context, req NotificationDeliveriesRequest) ([]NotificationDelivery, error) {
+	opts := []RequestOption{req.Pagination.asRequestOption()}
+	if req.Status != "" {
+		opts = append(opts, WithQueryParam("status", string(req.Status)))
+	}
+	res, err := c.Request(ctx, http.MethodGet, "/api/v2/notifications/deliveries", nil, opts...)
+	if err != nil {
+		return nil, xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var deliveries []NotificationDelivery
+	return deliveries, json.NewDecoder(res.Body).Decode(&deliveries)
+}
@@ -40,6 +40,14 @@ type OrganizationMember struct {
 	Roles          []Role    `db:"roles" json:"roles"`
 }
 
+// OrganizationMemberWithUserData is an organization member along with the
+// identifying details of the user it belongs to.
+type OrganizationMemberWithUserData struct {
+	Username string `json:"username"`
+	Email    string `json:"email" format:"email"`
+	OrganizationMember
+}
+
 // CreateTemplateVersionRequest enables callers to create a new Template Version.
 type CreateTemplateVersionRequest struct {
 	Name    string `json:"name,omitempty" validate:"omitempty,template_version_name"`
@@ -53,6 +61,13 @@ type CreateTemplateVersionRequest struct {
 	ProvisionerTags map[string]string        `json:"tags"`
 
 	UserVariableValues []VariableValue `json:"user_variable_values,omitempty"`
+
+	// GitURL is the URL of the Git repository the template files were
+	// taken from. Credentials are removed before it is stored.
+	GitURL string `json:"git_url,omitempty"`
+	// GitCommitSHA is the SHA of the Git commit the template files were
+	// taken from.
+	GitCommitSHA string `json:"git_commit_sha,omitempty"`
 }
 
 type VariableValue struct {
@@ -125,13 +140,21 @@ type CreateTemplateRequest struct {
 
 // CreateWorkspaceRequest provides options for creating a new workspace.
 type CreateWorkspaceRequest struct {
-	TemplateID        uuid.UUID `json:"template_id" validate:"required" format:"uuid"`
+	// TemplateID is required unless the workspace is cloned from a source
+	// workspace, in which case it defaults to the template of the source.
+	TemplateID        uuid.UUID `json:"template_id" validate:"required_without=SourceWorkspaceID" format:"uuid"`
 	Name              string    `json:"name" validate:"workspace_name,required"`
 	AutostartSchedule *string   `json:"autostart_schedule"`
 	TTLMillis         *int64    `json:"ttl_ms,omitempty"`
 	// ParameterValues allows for additional parameters to be provided
 	// during the initial provision.
 	RichParameterValues []WorkspaceBuildParameter `json:"rich_parameter_values,omitempty"`
+	// SourceWorkspaceID clones an existing workspace. The new workspace is
+	// built with the template version and rich parameter values of the
+	// latest build of the source, and copies its autostart schedule and TTL.
+	// Ephemeral and immutable parameters aren't copied, and any values set in
+	// the request take precedence.
+	SourceWorkspaceID *uuid.UUID `json:"source_workspace_id,omitempty" format:"uuid"`
 }
 
 func (c *Client) Organization(ctx context.Context, id uuid.UUID) (Organization, error) {
@@ -149,6 +172,52 @@ func (c *Client) Organization(ctx context.Context, id uuid.UUID) (Organization,
 	return organization, json.NewDecoder(res.Body).Decode(&organization)
 }
 
+// OrganizationMembers lists all members of an organization.
+func (c *Client) OrganizationMembers(ctx context.Context, organizationID uuid.UUID) ([]OrganizationMemberWithUserData, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/organizations/%s/members", organizationID.String()), nil)
+	if err != nil {
+		return nil, xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+
+	var members []OrganizationMemberWithUserData
+	return members, json.NewDecoder(res.Body).Decode(&members)
+}
+
+// PostOrganizationMember adds a user to an organization as a regular member.
+func (c *Client) PostOrganizationMember(ctx context.Context, organizationID uuid.UUID, user string) (OrganizationMember, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/organizations/%s/members/%s", organizationID.String(), user), nil)
+	if err != nil {
+		return OrganizationMember{}, xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return OrganizationMember{}, ReadBodyAsError(res)
+	}
+
+	var member OrganizationMember
+	return member, json.NewDecoder(res.Body).Decode(&member)
+}
+
+// DeleteOrganizationMember removes a user from an organization.
+func (c *Client) DeleteOrganizationMember(ctx context.Context, organizationID uuid.UUID, user string) error {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/organizations/%s/members/%s", organizationID.String(), user), nil)
+	if err != nil {
+		return xerrors.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
 // ProvisionerDaemons returns provisioner daemons available.
 func (c *Client) ProvisionerDaemons(ctx context.Context) ([]ProvisionerDaemon, error) {
 	res, err := c.Request(ctx, http.MethodGet,
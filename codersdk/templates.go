@@ -43,6 +43,9 @@ type Template struct {
 	AllowUserAutostart           bool `json:"allow_user_autostart"`
 	AllowUserAutostop            bool `json:"allow_user_autostop"`
 	AllowUserCancelWorkspaceJobs bool `json:"allow_user_cancel_workspace_jobs"`
+	// RequireActiveVersion makes start builds of workspaces always use the
+	// active version of the template.
+	RequireActiveVersion bool `json:"require_active_version"`
 
 	// FailureTTLMillis, InactivityTTLMillis, and LockedTTLMillis are enterprise-only. Their
 	// values are used if your license is entitled to use the advanced
@@ -50,6 +53,17 @@ type Template struct {
 	FailureTTLMillis    int64 `json:"failure_ttl_ms"`
 	InactivityTTLMillis int64 `json:"inactivity_ttl_ms"`
 	LockedTTLMillis     int64 `json:"locked_ttl_ms"`
+
+	// Deprecated templates can't be used to create new workspaces.
+	// DeprecationMessage explains why and is shown to the owners of existing
+	// workspaces, who should move to the ReplacementTemplateID if it's set.
+	Deprecated            bool       `json:"deprecated"`
+	DeprecationMessage    string     `json:"deprecation_message"`
+	ReplacementTemplateID *uuid.UUID `json:"replacement_template_id,omitempty" format:"uuid"`
+	// WorkspaceCount is the number of workspaces that use the template. It
+	// allows tracking the migration away from a deprecated template, and is
+	// only set for users that can update the template.
+	WorkspaceCount int64 `json:"workspace_count"`
 }
 
 // WeekdaysToBitmap converts a list of weekdays to a bitmap in accordance with
@@ -189,9 +203,17 @@ type UpdateTemplateMeta struct {
 	AllowUserAutostart           bool                        `json:"allow_user_autostart,omitempty"`
 	AllowUserAutostop            bool                        `json:"allow_user_autostop,omitempty"`
 	AllowUserCancelWorkspaceJobs bool                        `json:"allow_user_cancel_workspace_jobs,omitempty"`
+	RequireActiveVersion         bool                        `json:"require_active_version,omitempty"`
 	FailureTTLMillis             int64                       `json:"failure_ttl_ms,omitempty"`
 	InactivityTTLMillis          int64                       `json:"inactivity_ttl_ms,omitempty"`
 	LockedTTLMillis              int64                       `json:"locked_ttl_ms,omitempty"`
+	// DeprecationMessage deprecates the template if it's set to a non-empty
+	// string and undeprecates it if it's set to an empty string. The template
+	// is left unchanged if it's omitted.
+	DeprecationMessage *string `json:"deprecation_message,omitempty"`
+	// ReplacementTemplateID is the template users should move to from a
+	// deprecated template. Set it to the nil UUID to clear it.
+	ReplacementTemplateID *uuid.UUID `json:"replacement_template_id,omitempty" format:"uuid"`
 }
 
 type TemplateExample struct {
@@ -303,12 +325,21 @@ func (c *Client) UpdateActiveTemplateVersion(ctx context.Context, template uuid.
 // TemplateVersionsByTemplate.
 type TemplateVersionsByTemplateRequest struct {
 	TemplateID uuid.UUID `json:"template_id" validate:"required" format:"uuid"`
+	// IncludeArchived includes archived versions, which are omitted by
+	// default.
+	IncludeArchived bool `json:"include_archived"`
 	Pagination
 }
 
 // TemplateVersionsByTemplate lists versions associated with a template.
 func (c *Client) TemplateVersionsByTemplate(ctx context.Context, req TemplateVersionsByTemplateRequest) ([]TemplateVersion, error) {
-	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/versions", req.TemplateID), nil, req.Pagination.asRequestOption())
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templates/%s/versions", req.TemplateID), nil, req.Pagination.asRequestOption(), func(r *http.Request) {
+		if req.IncludeArchived {
+			q := r.URL.Query()
+			q.Set("include_archived", "true")
+			r.URL.RawQuery = q.Encode()
+		}
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,10 +31,42 @@ type TemplateVersion struct {
 	Job            ProvisionerJob `json:"job"`
 	Readme         string         `json:"readme"`
 	CreatedBy      MinimalUser    `json:"created_by"`
+	// GitURL is the URL of the Git repository the version was pushed from,
+	// without credentials.
+	GitURL string `json:"git_url,omitempty"`
+	// GitCommitSHA is the SHA of the Git commit the version was pushed from.
+	GitCommitSHA string `json:"git_commit_sha,omitempty"`
+	// Archived versions are hidden from version lists by default and can't
+	// be used to start workspaces.
+	Archived bool `json:"archived"`
 
 	Warnings []TemplateVersionWarning `json:"warnings,omitempty" enums:"DEPRECATED_PARAMETERS"`
 }
 
+// RedactGitURL removes credentials from a Git repository URL. URLs in the
+// scp-like syntax, e.g. "git@github.com:coder/coder.git", can't contain a
+// password and are returned as-is.
+func RedactGitURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		scheme, rest, ok := strings.Cut(rawURL, "://")
+		if !ok {
+			return rawURL
+		}
+		// The host can't be told apart from the credentials, so everything
+		// up to the last "@" is removed, even if it's part of the path.
+		if i := strings.LastIndex(rest, "@"); i >= 0 {
+			rest = rest[i+1:]
+		}
+		return scheme + "://" + rest
+	}
+	if u.User == nil {
+		return rawURL
+	}
+	u.User = nil
+	return u.String()
+}
+
 type TemplateVersionGitAuth struct {
 	ID              string      `json:"id"`
 	Type            GitProvider `json:"type"`
@@ -118,6 +152,33 @@ func (c *Client) CancelTemplateVersion(ctx context.Context, version uuid.UUID) e
 	return nil
 }
 
+// ArchiveTemplateVersion hides a template version from version lists and
+// prevents it from being used to start workspaces.
+func (c *Client) ArchiveTemplateVersion(ctx context.Context, version uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/templateversions/%s/archive", version), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// UnarchiveTemplateVersion reverts ArchiveTemplateVersion.
+func (c *Client) UnarchiveTemplateVersion(ctx context.Context, version uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/templateversions/%s/unarchive", version), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
 // TemplateVersionParameters returns parameters a template version exposes.
 func (c *Client) TemplateVersionRichParameters(ctx context.Context, version uuid.UUID) ([]TemplateVersionParameter, error) {
 	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/templateversions/%s/rich-parameters", version), nil)
@@ -102,6 +102,20 @@ type WorkspaceBuildParameter struct {
 	Value string `json:"value"`
 }
 
+// WorkspaceBuildStateSummary describes the provisioner state stored with a
+// workspace build.
+type WorkspaceBuildStateSummary struct {
+	WorkspaceBuildID  uuid.UUID           `json:"workspace_build_id" format:"uuid"`
+	BuildNumber       int32               `json:"build_number"`
+	Transition        WorkspaceTransition `json:"transition" enums:"start,stop,delete"`
+	TemplateVersionID uuid.UUID           `json:"template_version_id" format:"uuid"`
+	CreatedAt         time.Time           `json:"created_at" format:"date-time"`
+	SizeBytes         int                 `json:"size_bytes"`
+	// Resources is the number of managed resource instances in the state. It
+	// is zero if the state is not a Terraform state.
+	Resources int `json:"resources"`
+}
+
 // WorkspaceBuild returns a single workspace build for a workspace.
 // If history is "", the latest version is returned.
 func (c *Client) WorkspaceBuild(ctx context.Context, id uuid.UUID) (WorkspaceBuild, error) {
@@ -135,6 +149,21 @@ func (c *Client) WorkspaceBuildLogsAfter(ctx context.Context, build uuid.UUID, a
 	return c.provisionerJobLogsAfter(ctx, fmt.Sprintf("/api/v2/workspacebuilds/%s/logs", build), after)
 }
 
+// WorkspaceBuildLogs returns the logs of a workspace build written so far,
+// without waiting for the build to complete.
+func (c *Client) WorkspaceBuildLogs(ctx context.Context, build uuid.UUID) ([]ProvisionerJobLog, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspacebuilds/%s/logs", build), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var logs []ProvisionerJobLog
+	return logs, json.NewDecoder(res.Body).Decode(&logs)
+}
+
 // WorkspaceBuildState returns the provisioner state of the build.
 func (c *Client) WorkspaceBuildState(ctx context.Context, build uuid.UUID) ([]byte, error) {
 	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspacebuilds/%s/state", build), nil)
@@ -148,6 +177,21 @@ func (c *Client) WorkspaceBuildState(ctx context.Context, build uuid.UUID) ([]by
 	return io.ReadAll(res.Body)
 }
 
+// WorkspaceBuildStates returns a page of summaries of the provisioner state
+// of the builds of a workspace, newest first.
+func (c *Client) WorkspaceBuildStates(ctx context.Context, workspace uuid.UUID, pagination Pagination) ([]WorkspaceBuildStateSummary, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/builds/states", workspace), nil, pagination.asRequestOption())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var states []WorkspaceBuildStateSummary
+	return states, json.NewDecoder(res.Body).Decode(&states)
+}
+
 func (c *Client) WorkspaceBuildByUsernameAndWorkspaceNameAndBuildNumber(ctx context.Context, username string, workspaceName string, buildNumber string) (WorkspaceBuild, error) {
 	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/workspace/%s/builds/%s", username, workspaceName, buildNumber), nil)
 	if err != nil {
@@ -0,0 +1,121 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceBulkTransition is the action a bulk operation applies to each of
+// its workspaces. "update" starts the workspace on the active version of its
+// template.
+type WorkspaceBulkTransition string
+
+const (
+	WorkspaceBulkTransitionStart  WorkspaceBulkTransition = "start"
+	WorkspaceBulkTransitionStop   WorkspaceBulkTransition = "stop"
+	WorkspaceBulkTransitionUpdate WorkspaceBulkTransition = "update"
+	WorkspaceBulkTransitionDelete WorkspaceBulkTransition = "delete"
+)
+
+type WorkspaceBulkOperationStatus string
+
+const (
+	WorkspaceBulkOperationStatusRunning   WorkspaceBulkOperationStatus = "running"
+	WorkspaceBulkOperationStatusCompleted WorkspaceBulkOperationStatus = "completed"
+)
+
+type WorkspaceBulkOperationWorkspaceStatus string
+
+const (
+	WorkspaceBulkOperationWorkspaceStatusPending   WorkspaceBulkOperationWorkspaceStatus = "pending"
+	WorkspaceBulkOperationWorkspaceStatusBuilding  WorkspaceBulkOperationWorkspaceStatus = "building"
+	WorkspaceBulkOperationWorkspaceStatusSucceeded WorkspaceBulkOperationWorkspaceStatus = "succeeded"
+	WorkspaceBulkOperationWorkspaceStatusFailed    WorkspaceBulkOperationWorkspaceStatus = "failed"
+	WorkspaceBulkOperationWorkspaceStatusSkipped   WorkspaceBulkOperationWorkspaceStatus = "skipped"
+)
+
+// Done returns true if the workspace won't change status anymore.
+func (s WorkspaceBulkOperationWorkspaceStatus) Done() bool {
+	switch s {
+	case WorkspaceBulkOperationWorkspaceStatusSucceeded,
+		WorkspaceBulkOperationWorkspaceStatusFailed,
+		WorkspaceBulkOperationWorkspaceStatusSkipped:
+		return true
+	}
+	return false
+}
+
+// CreateWorkspaceBulkOperationRequest builds every workspace matching a search
+// query.
+type CreateWorkspaceBulkOperationRequest struct {
+	// Query selects the workspaces with the same syntax as the workspaces
+	// search query. Only workspaces the user is allowed to build are selected.
+	Query      string                  `json:"q"`
+	Transition WorkspaceBulkTransition `json:"transition" validate:"oneof=start stop update delete,required" enums:"start,stop,update,delete"`
+	// Concurrency is the maximum number of builds that run at the same time.
+	// Defaults to 5, and can be at most 50.
+	Concurrency int `json:"concurrency,omitempty" validate:"omitempty,min=1,max=50"`
+}
+
+// WorkspaceBulkOperation is a transition applied to many workspaces at once.
+type WorkspaceBulkOperation struct {
+	ID          uuid.UUID                         `json:"id" format:"uuid"`
+	CreatedAt   time.Time                         `json:"created_at" format:"date-time"`
+	CompletedAt *time.Time                        `json:"completed_at,omitempty" format:"date-time"`
+	InitiatorID uuid.UUID                         `json:"initiator_id" format:"uuid"`
+	Transition  WorkspaceBulkTransition           `json:"transition" enums:"start,stop,update,delete"`
+	Query       string                            `json:"q"`
+	Concurrency int                               `json:"concurrency"`
+	Status      WorkspaceBulkOperationStatus      `json:"status" enums:"running,completed"`
+	Workspaces  []WorkspaceBulkOperationWorkspace `json:"workspaces"`
+}
+
+// WorkspaceBulkOperationWorkspace is the progress of a single workspace in a
+// bulk operation.
+type WorkspaceBulkOperationWorkspace struct {
+	WorkspaceID   uuid.UUID                             `json:"workspace_id" format:"uuid"`
+	WorkspaceName string                                `json:"workspace_name"`
+	OwnerName     string                                `json:"owner_name"`
+	Status        WorkspaceBulkOperationWorkspaceStatus `json:"status" enums:"pending,building,succeeded,failed,skipped"`
+	// WorkspaceBuildID is set once the build of the workspace is queued.
+	WorkspaceBuildID *uuid.UUID `json:"workspace_build_id,omitempty" format:"uuid"`
+	// Error explains why the workspace failed or was skipped.
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at" format:"date-time"`
+}
+
+// CreateWorkspaceBulkOperation queues builds for all workspaces matching the
+// request's search query. The builds run in the background, use
+// WorkspaceBulkOperation to follow their progress.
+func (c *Client) CreateWorkspaceBulkOperation(ctx context.Context, req CreateWorkspaceBulkOperationRequest) (WorkspaceBulkOperation, error) {
+	res, err := c.Request(ctx, http.MethodPost, "/api/v2/workspaces/bulk", req)
+	if err != nil {
+		return WorkspaceBulkOperation{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return WorkspaceBulkOperation{}, ReadBodyAsError(res)
+	}
+	var operation WorkspaceBulkOperation
+	return operation, json.NewDecoder(res.Body).Decode(&operation)
+}
+
+// WorkspaceBulkOperation returns a bulk operation and the status of each of
+// its workspaces.
+func (c *Client) WorkspaceBulkOperation(ctx context.Context, id uuid.UUID) (WorkspaceBulkOperation, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/bulk/%s", id), nil)
+	if err != nil {
+		return WorkspaceBulkOperation{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceBulkOperation{}, ReadBodyAsError(res)
+	}
+	var operation WorkspaceBulkOperation
+	return operation, json.NewDecoder(res.Body).Decode(&operation)
+}
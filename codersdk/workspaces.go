@@ -35,6 +35,9 @@ type Workspace struct {
 	TTLMillis                            *int64         `json:"ttl_ms,omitempty"`
 	LastUsedAt                           time.Time      `json:"last_used_at" format:"date-time"`
 
+	// AutostartSkipUntil is set when the owner skipped upcoming autostarts.
+	// Scheduled autostarts up to and including this time do not happen.
+	AutostartSkipUntil *time.Time `json:"autostart_skip_until" format:"date-time"`
 	// DeletingAt indicates the time of the upcoming workspace deletion, if applicable; otherwise it is nil.
 	// Workspaces may have impending deletions if Template.InactivityTTL feature is turned on and the workspace is inactive.
 	DeletingAt *time.Time `json:"deleting_at" format:"date-time"`
@@ -253,6 +256,28 @@ func (c *Client) UpdateWorkspaceAutostart(ctx context.Context, id uuid.UUID, req
 	return nil
 }
 
+// SkipWorkspaceAutostartRequest is a request to skip the next autostart of a
+// workspace.
+type SkipWorkspaceAutostartRequest struct {
+	// Skip skips the next scheduled autostart that is not already skipped.
+	// If false, all skipped autostarts happen again.
+	Skip bool `json:"skip"`
+}
+
+// SkipWorkspaceAutostart skips the next autostart of the workspace by id.
+func (c *Client) SkipWorkspaceAutostart(ctx context.Context, id uuid.UUID, req SkipWorkspaceAutostartRequest) error {
+	path := fmt.Sprintf("/api/v2/workspaces/%s/autostart/skip", id.String())
+	res, err := c.Request(ctx, http.MethodPut, path, req)
+	if err != nil {
+		return xerrors.Errorf("skip workspace autostart: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
 // UpdateWorkspaceTTLRequest is a request to update a workspace's TTL.
 type UpdateWorkspaceTTLRequest struct {
 	TTLMillis *int64 `json:"ttl_ms"`
@@ -312,6 +337,104 @@ func (c *Client) UpdateWorkspaceLock(ctx context.Context, id uuid.UUID, req Upda
 	return nil
 }
 
+// TransferWorkspaceRequest is a request to give a workspace to another user.
+type TransferWorkspaceRequest struct {
+	OwnerID uuid.UUID `json:"owner_id" validate:"required" format:"uuid"`
+}
+
+// TransferWorkspace makes another user the owner of a stopped workspace. Its
+// agents get new tokens on the next build.
+func (c *Client) TransferWorkspace(ctx context.Context, id uuid.UUID, req TransferWorkspaceRequest) (Workspace, error) {
+	path := fmt.Sprintf("/api/v2/workspaces/%s/owner", id.String())
+	res, err := c.Request(ctx, http.MethodPut, path, req)
+	if err != nil {
+		return Workspace{}, xerrors.Errorf("transfer workspace: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return Workspace{}, ReadBodyAsError(res)
+	}
+	var workspace Workspace
+	return workspace, json.NewDecoder(res.Body).Decode(&workspace)
+}
+
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleAdmin   WorkspaceRole = "admin"
+	WorkspaceRoleUse     WorkspaceRole = "use"
+	WorkspaceRoleDeleted WorkspaceRole = ""
+)
+
+// WorkspaceACL lists the users and groups a workspace is shared with. "use"
+// allows connecting to the workspace, "admin" additionally allows managing
+// it.
+type WorkspaceACL struct {
+	Users  []WorkspaceUser  `json:"users"`
+	Groups []WorkspaceGroup `json:"groups"`
+}
+
+type WorkspaceGroup struct {
+	Group
+	Role WorkspaceRole `json:"role" enums:"admin,use"`
+}
+
+type WorkspaceUser struct {
+	MinimalUser
+	Role WorkspaceRole `json:"role" enums:"admin,use"`
+}
+
+type UpdateWorkspaceACL struct {
+	// UserPerms should be a mapping of user id to role. The user id must be the
+	// uuid of the user, not a username or email address.
+	UserPerms map[string]WorkspaceRole `json:"user_perms,omitempty" example:"<user_id>:admin,4df59e74-c027-470b-ab4d-cbba8963a5e9:use"`
+	// GroupPerms should be a mapping of group id to role.
+	GroupPerms map[string]WorkspaceRole `json:"group_perms,omitempty" example:"<group_id>:admin,8bd26b20-f3e8-48be-a903-46bb920cf671:use"`
+}
+
+// WorkspaceACL returns the users and groups the workspace is shared with.
+func (c *Client) WorkspaceACL(ctx context.Context, id uuid.UUID) (WorkspaceACL, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/acl", id), nil)
+	if err != nil {
+		return WorkspaceACL{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceACL{}, ReadBodyAsError(res)
+	}
+	var acl WorkspaceACL
+	return acl, json.NewDecoder(res.Body).Decode(&acl)
+}
+
+// WorkspaceACLAvailable returns the users and groups the workspace can be
+// shared with.
+func (c *Client) WorkspaceACLAvailable(ctx context.Context, id uuid.UUID) (ACLAvailable, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/acl/available", id), nil)
+	if err != nil {
+		return ACLAvailable{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ACLAvailable{}, ReadBodyAsError(res)
+	}
+	var acl ACLAvailable
+	return acl, json.NewDecoder(res.Body).Decode(&acl)
+}
+
+// UpdateWorkspaceACL shares the workspace with, or unshares it from, users
+// and groups.
+func (c *Client) UpdateWorkspaceACL(ctx context.Context, id uuid.UUID, req UpdateWorkspaceACL) error {
+	res, err := c.Request(ctx, http.MethodPatch, fmt.Sprintf("/api/v2/workspaces/%s/acl", id), req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
 type WorkspaceFilter struct {
 	// Owner can be "me" or a username
 	Owner string `json:"owner,omitempty" typescript:"-"`
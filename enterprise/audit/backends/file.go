@@ -0,0 +1,69 @@
+package backends
+
+import (
+	"context"
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/enterprise/audit"
+)
+
+type FileOptions struct {
+	Path string
+	// MaxSizeMB is the size in megabytes a file is rotated at.
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files to keep. Zero keeps all of
+	// them.
+	MaxBackups int
+}
+
+// File writes audit logs to a file as JSON lines. The file is rotated once
+// it reaches the maximum size.
+type File struct {
+	logger *lumberjack.Logger
+}
+
+var _ audit.Backend = (*File)(nil)
+
+func NewFile(opts FileOptions) (*File, error) {
+	if opts.Path == "" {
+		return nil, xerrors.New("file path must be set")
+	}
+	if opts.MaxSizeMB <= 0 {
+		return nil, xerrors.New("file max size must be positive")
+	}
+	if opts.MaxBackups < 0 {
+		return nil, xerrors.New("file max backups must not be negative")
+	}
+	return &File{
+		logger: &lumberjack.Logger{
+			Filename:   opts.Path,
+			MaxSize:    opts.MaxSizeMB,
+			MaxBackups: opts.MaxBackups,
+		},
+	}, nil
+}
+
+func (*File) Decision() audit.FilterDecision {
+	return audit.FilterDecisionExport
+}
+
+func (f *File) Export(_ context.Context, alog database.AuditLog) error {
+	line, err := json.Marshal(newExportedLog(alog))
+	if err != nil {
+		return xerrors.Errorf("marshal audit log: %w", err)
+	}
+	// Lumberjack serializes writes, so lines are never interleaved.
+	_, err = f.logger.Write(append(line, '\n'))
+	if err != nil {
+		return xerrors.Errorf("write audit log: %w", err)
+	}
+	return nil
+}
+
+func (f *File) Close() error {
+	return f.logger.Close()
+}
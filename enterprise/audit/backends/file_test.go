@@ -0,0 +1,50 @@
+package backends_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/enterprise/audit/audittest"
+	"github.com/coder/coder/enterprise/audit/backends"
+)
+
+func TestFileBackend(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	backend, err := backends.NewFile(backends.FileOptions{
+		Path:      path,
+		MaxSizeMB: 1,
+	})
+	require.NoError(t, err)
+
+	logs := []string{}
+	for i := 0; i < 3; i++ {
+		alog := audittest.RandomLog()
+		logs = append(logs, alog.ID.String())
+		err = backend.Export(context.Background(), alog)
+		require.NoError(t, err)
+	}
+	require.NoError(t, backend.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	got := []string{}
+	for scanner.Scan() {
+		var exported struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &exported))
+		got = append(got, exported.ID)
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, logs, got)
+}
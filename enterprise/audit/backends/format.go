@@ -0,0 +1,61 @@
+package backends
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/coderd/database"
+)
+
+// exportedLog is the JSON representation of an audit log sent to external
+// backends. Unlike database.AuditLog, nullable columns are flattened so
+// that log pipelines don't have to unwrap them.
+type exportedLog struct {
+	ID               uuid.UUID       `json:"id"`
+	Time             time.Time       `json:"time"`
+	UserID           uuid.UUID       `json:"user_id"`
+	OrganizationID   uuid.UUID       `json:"organization_id"`
+	IP               string          `json:"ip"`
+	UserAgent        string          `json:"user_agent"`
+	ResourceType     string          `json:"resource_type"`
+	ResourceID       uuid.UUID       `json:"resource_id"`
+	ResourceTarget   string          `json:"resource_target"`
+	ResourceIcon     string          `json:"resource_icon"`
+	Action           string          `json:"action"`
+	Diff             json.RawMessage `json:"diff"`
+	StatusCode       int32           `json:"status_code"`
+	AdditionalFields json.RawMessage `json:"additional_fields"`
+	RequestID        uuid.UUID       `json:"request_id"`
+}
+
+func newExportedLog(alog database.AuditLog) exportedLog {
+	log := exportedLog{
+		ID:               alog.ID,
+		Time:             alog.Time.UTC(),
+		UserID:           alog.UserID,
+		OrganizationID:   alog.OrganizationID,
+		UserAgent:        alog.UserAgent.String,
+		ResourceType:     string(alog.ResourceType),
+		ResourceID:       alog.ResourceID,
+		ResourceTarget:   alog.ResourceTarget,
+		ResourceIcon:     alog.ResourceIcon,
+		Action:           string(alog.Action),
+		Diff:             alog.Diff,
+		StatusCode:       alog.StatusCode,
+		AdditionalFields: alog.AdditionalFields,
+		RequestID:        alog.RequestID,
+	}
+	if alog.Ip.Valid {
+		log.IP = alog.Ip.IPNet.IP.String()
+	}
+	// Empty raw messages are invalid JSON.
+	if len(log.Diff) == 0 {
+		log.Diff = json.RawMessage("{}")
+	}
+	if len(log.AdditionalFields) == 0 {
+		log.AdditionalFields = json.RawMessage("{}")
+	}
+	return log
+}
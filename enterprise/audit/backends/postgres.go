@@ -7,6 +7,7 @@ import (
 
 	"github.com/coder/coder/coderd/database"
 	"github.com/coder/coder/enterprise/audit"
+	"github.com/coder/coder/enterprise/audit/hashchain"
 )
 
 type postgresBackend struct {
@@ -15,13 +16,21 @@ type postgresBackend struct {
 	// we make different decisions to store the audit log based on if it's
 	// pointing to the Coderd database.
 	internal bool
-	db       database.Store
+	// hashChain appends audit logs to the tamper-evident hash chain.
+	hashChain bool
+	db        database.Store
 }
 
 func NewPostgres(db database.Store, internal bool) audit.Backend {
 	return &postgresBackend{db: db, internal: internal}
 }
 
+// NewPostgresHashChain is like NewPostgres, but every audit log is appended
+// to the hash chain. See the hashchain package.
+func NewPostgresHashChain(db database.Store, internal bool) audit.Backend {
+	return &postgresBackend{db: db, internal: internal, hashChain: true}
+}
+
 func (b *postgresBackend) Decision() audit.FilterDecision {
 	if b.internal {
 		return audit.FilterDecisionStore
@@ -31,6 +40,14 @@ func (b *postgresBackend) Decision() audit.FilterDecision {
 }
 
 func (b *postgresBackend) Export(ctx context.Context, alog database.AuditLog) error {
+	if b.hashChain {
+		err := hashchain.Append(ctx, b.db, alog)
+		if err != nil {
+			return xerrors.Errorf("append audit log to hash chain: %w", err)
+		}
+		return nil
+	}
+
 	_, err := b.db.InsertAuditLog(ctx, database.InsertAuditLogParams(alog))
 	if err != nil {
 		return xerrors.Errorf("insert audit log: %w", err)
@@ -36,4 +36,24 @@ func TestPostgresBackend(t *testing.T) {
 		require.Len(t, got, 1)
 		require.Equal(t, alog.ID, got[0].ID)
 	})
+	t.Run("HashChain", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			db          = dbfake.New()
+			pgb         = backends.NewPostgresHashChain(db, true)
+		)
+		defer cancel()
+
+		for i := 0; i < 2; i++ {
+			err := pgb.Export(ctx, audittest.RandomLog())
+			require.NoError(t, err)
+		}
+
+		got, err := db.GetLatestChainedAuditLog(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, got.ChainSequence.Int64)
+		require.Len(t, got.ChainHash, 32)
+	})
 }
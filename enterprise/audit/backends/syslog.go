@@ -0,0 +1,246 @@
+package backends
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/enterprise/audit"
+)
+
+const (
+	SyslogTransportUDP = "udp"
+	SyslogTransportTCP = "tcp"
+	SyslogTransportTLS = "tls"
+
+	// syslogPriority is the "log audit" facility (13) with the
+	// "informational" severity (6).
+	syslogPriority = 13*8 + 6
+	syslogTimeout  = 5 * time.Second
+	// syslogMaxUDPSize is the largest message receivers should accept over
+	// UDP (RFC 5426), so longer messages are truncated instead of being
+	// fragmented or dropped.
+	syslogMaxUDPSize = 2048
+	// syslogQueueSize is the default number of messages kept while the
+	// syslog server is slow or unavailable.
+	syslogQueueSize = 1000
+)
+
+type SyslogOptions struct {
+	// Address of the syslog server in host:port form.
+	Address string
+	// Transport is one of udp, tcp or tls.
+	Transport string
+	// TLSConfig is used by the tls transport.
+	TLSConfig *tls.Config
+	// MaxQueueSize is the number of messages waiting to be sent. New audit
+	// logs are dropped once it is reached. Defaults to 1000.
+	MaxQueueSize int
+}
+
+// Syslog sends audit logs to a syslog server as RFC 5424 messages. The
+// message of each entry is the audit log encoded as JSON. Messages sent
+// over TCP and TLS are framed by octet counting (RFC 6587, RFC 5425), and
+// messages sent over UDP are truncated to 2048 bytes.
+//
+// Messages are queued and sent in the background, so Export never blocks
+// on the network.
+type Syslog struct {
+	log      slog.Logger
+	opts     SyslogOptions
+	hostname string
+	procID   string
+
+	queue   chan []byte
+	dropped atomic.Int64
+	// conn is only used by the sender.
+	conn net.Conn
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ audit.Backend = (*Syslog)(nil)
+
+func NewSyslog(logger slog.Logger, opts SyslogOptions) (*Syslog, error) {
+	if opts.Address == "" {
+		return nil, xerrors.New("syslog address must be set")
+	}
+	switch opts.Transport {
+	case SyslogTransportUDP, SyslogTransportTCP, SyslogTransportTLS:
+	default:
+		return nil, xerrors.Errorf("unknown syslog transport %q, must be one of udp, tcp or tls", opts.Transport)
+	}
+	if opts.MaxQueueSize <= 0 {
+		opts.MaxQueueSize = syslogQueueSize
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Syslog{
+		log:      logger,
+		opts:     opts,
+		hostname: hostname,
+		procID:   fmt.Sprint(os.Getpid()),
+		queue:    make(chan []byte, opts.MaxQueueSize),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+func (*Syslog) Decision() audit.FilterDecision {
+	return audit.FilterDecisionExport
+}
+
+// Export queues the audit log to be sent to the syslog server.
+func (s *Syslog) Export(_ context.Context, alog database.AuditLog) error {
+	msg, err := s.format(alog)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case s.queue <- msg:
+	default:
+		s.log.Warn(s.ctx, "audit log syslog queue is full, dropping audit log",
+			slog.F("audit_log_id", alog.ID),
+			slog.F("queue_size", s.opts.MaxQueueSize),
+			slog.F("dropped", s.dropped.Add(1)),
+		)
+	}
+	return nil
+}
+
+// Dropped returns the number of audit logs dropped because the queue was
+// full or the syslog server could not be reached.
+func (s *Syslog) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close stops the syslog backend after a final attempt to send queued
+// audit logs.
+func (s *Syslog) Close() error {
+	s.cancel()
+	<-s.done
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var err error
+	for len(s.queue) > 0 && err == nil {
+		err = s.send(ctx, <-s.queue)
+	}
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	if err != nil {
+		return xerrors.Errorf("%d audit logs were not sent: %w", len(s.queue)+1, err)
+	}
+	return nil
+}
+
+func (s *Syslog) run() {
+	defer close(s.done)
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case msg := <-s.queue:
+			err := s.send(s.ctx, msg)
+			if err != nil && s.ctx.Err() == nil {
+				s.log.Warn(s.ctx, "send audit log to syslog",
+					slog.Error(err),
+					slog.F("queued", len(s.queue)),
+					slog.F("dropped", s.dropped.Add(1)),
+				)
+			}
+		}
+	}
+}
+
+// send writes a message to the syslog server. Connections may have been
+// closed by the server since the last audit log, so it retries once on a
+// new connection.
+func (s *Syslog) send(ctx context.Context, msg []byte) error {
+	for attempt := 0; ; attempt++ {
+		err := s.write(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		if s.conn != nil {
+			_ = s.conn.Close()
+			s.conn = nil
+		}
+		if attempt > 0 {
+			return xerrors.Errorf("write to syslog: %w", err)
+		}
+	}
+}
+
+// format returns the RFC 5424 message of an audit log:
+// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+func (s *Syslog) format(alog database.AuditLog) ([]byte, error) {
+	body, err := json.Marshal(newExportedLog(alog))
+	if err != nil {
+		return nil, xerrors.Errorf("marshal audit log: %w", err)
+	}
+	header := fmt.Sprintf("<%d>1 %s %s coder %s audit_log - ",
+		syslogPriority,
+		alog.Time.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		s.hostname,
+		s.procID,
+	)
+	msg := append([]byte(header), body...)
+	if s.opts.Transport == SyslogTransportUDP {
+		if len(msg) > syslogMaxUDPSize {
+			msg = msg[:syslogMaxUDPSize]
+		}
+		return msg, nil
+	}
+	return append([]byte(fmt.Sprintf("%d ", len(msg))), msg...), nil
+}
+
+func (s *Syslog) write(ctx context.Context, msg []byte) error {
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+	err := s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+	if err != nil {
+		return err
+	}
+	_, err = s.conn.Write(msg)
+	return err
+}
+
+func (s *Syslog) dial(ctx context.Context) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, syslogTimeout)
+	defer cancel()
+	switch s.opts.Transport {
+	case SyslogTransportTLS:
+		dialer := &tls.Dialer{Config: s.opts.TLSConfig}
+		return dialer.DialContext(ctx, "tcp", s.opts.Address)
+	default:
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, s.opts.Transport, s.opts.Address)
+	}
+}
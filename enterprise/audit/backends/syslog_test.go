@@ -0,0 +1,161 @@
+package backends_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/enterprise/audit/audittest"
+	"github.com/coder/coder/enterprise/audit/backends"
+)
+
+var syslogHeader = regexp.MustCompile(`^<110>1 \S+ \S+ coder \d+ audit_log - `)
+
+func TestSyslogBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("TCP", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		backend, err := backends.NewSyslog(slogtest.Make(t, nil), backends.SyslogOptions{
+			Address:   listener.Addr().String(),
+			Transport: backends.SyslogTransportTCP,
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		err = backend.Export(context.Background(), alog)
+		require.NoError(t, err)
+
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		// Messages are framed by their length.
+		length, err := reader.ReadString(' ')
+		require.NoError(t, err)
+		n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+		require.NoError(t, err)
+		msg := make([]byte, n)
+		_, err = io.ReadFull(reader, msg)
+		require.NoError(t, err)
+
+		requireSyslogMessage(t, string(msg), alog.ID.String())
+	})
+
+	t.Run("UDP", func(t *testing.T) {
+		t.Parallel()
+
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		backend, err := backends.NewSyslog(slogtest.Make(t, nil), backends.SyslogOptions{
+			Address:   conn.LocalAddr().String(),
+			Transport: backends.SyslogTransportUDP,
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		err = backend.Export(context.Background(), alog)
+		require.NoError(t, err)
+
+		buf := make([]byte, 64<<10)
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		requireSyslogMessage(t, string(buf[:n]), alog.ID.String())
+	})
+
+	t.Run("UDPTruncate", func(t *testing.T) {
+		t.Parallel()
+
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		backend, err := backends.NewSyslog(slogtest.Make(t, nil), backends.SyslogOptions{
+			Address:   conn.LocalAddr().String(),
+			Transport: backends.SyslogTransportUDP,
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		alog.Diff = []byte(fmt.Sprintf(`{"name":{"old":%q}}`, strings.Repeat("a", 64<<10)))
+		err = backend.Export(context.Background(), alog)
+		require.NoError(t, err)
+
+		buf := make([]byte, 128<<10)
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		require.Equal(t, 2048, n)
+		require.Regexp(t, syslogHeader, string(buf[:n]))
+	})
+
+	t.Run("QueueFull", func(t *testing.T) {
+		t.Parallel()
+
+		// The TLS handshake never completes because nothing reads from the
+		// accepted connections, so the sender is stuck on the first message.
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		backend, err := backends.NewSyslog(slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}), backends.SyslogOptions{
+			Address:      listener.Addr().String(),
+			Transport:    backends.SyslogTransportTLS,
+			TLSConfig:    &tls.Config{MinVersion: tls.VersionTLS12},
+			MaxQueueSize: 1,
+		})
+		require.NoError(t, err)
+
+		// At most one audit log is being sent and one is queued.
+		for i := 0; i < 5; i++ {
+			err = backend.Export(context.Background(), audittest.RandomLog())
+			require.NoError(t, err)
+		}
+		require.GreaterOrEqual(t, backend.Dropped(), int64(3))
+
+		// Closing tries to send the queued audit log, which fails fast once
+		// the listener is gone.
+		_ = listener.Close()
+		_ = backend.Close()
+	})
+
+	t.Run("UnknownTransport", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := backends.NewSyslog(slogtest.Make(t, nil), backends.SyslogOptions{
+			Address:   "127.0.0.1:514",
+			Transport: "http",
+		})
+		require.ErrorContains(t, err, "unknown syslog transport")
+	})
+}
+
+func requireSyslogMessage(t *testing.T, msg string, id string) {
+	t.Helper()
+
+	require.Regexp(t, syslogHeader, msg)
+	var exported map[string]any
+	err := json.Unmarshal([]byte(syslogHeader.ReplaceAllString(msg, "")), &exported)
+	require.NoError(t, err)
+	require.Equal(t, id, exported["id"])
+}
@@ -0,0 +1,261 @@
+package backends
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/enterprise/audit"
+)
+
+const (
+	// WebhookSignatureHeader contains the hex encoded HMAC-SHA256 of the
+	// timestamp and the request body, signed with the webhook secret.
+	WebhookSignatureHeader = "X-Coder-Signature"
+	// WebhookTimestampHeader contains the Unix time the request was signed
+	// at. Receivers should reject old timestamps to prevent replays.
+	WebhookTimestampHeader = "X-Coder-Timestamp"
+
+	webhookMaxBackoff = time.Minute
+)
+
+type WebhookOptions struct {
+	// URL must use the https scheme.
+	URL    *url.URL
+	Secret string
+	// BatchSize is the maximum number of audit logs sent per request.
+	BatchSize int
+	// FlushInterval is how often queued audit logs are sent.
+	FlushInterval time.Duration
+	// MaxQueueSize is the number of audit logs kept while the webhook
+	// is unavailable. The oldest audit logs are dropped once it is reached.
+	MaxQueueSize int
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Webhook sends batches of audit logs to an HTTPS endpoint. Audit logs are
+// queued in memory and retried with exponential backoff if the endpoint
+// fails, so Export never blocks on the network.
+type Webhook struct {
+	log    slog.Logger
+	opts   WebhookOptions
+	client *http.Client
+
+	mu         sync.Mutex
+	queue      []exportedLog
+	failures   int
+	retryAfter time.Time
+	// dropped counts the audit logs dropped while a batch is being sent.
+	dropped int
+
+	flush  chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ audit.Backend = (*Webhook)(nil)
+
+func NewWebhook(logger slog.Logger, opts WebhookOptions) (*Webhook, error) {
+	if opts.URL == nil || opts.URL.Scheme != "https" {
+		return nil, xerrors.New("webhook URL must use https")
+	}
+	if opts.Secret == "" {
+		return nil, xerrors.New("webhook secret must be set")
+	}
+	if opts.BatchSize <= 0 {
+		return nil, xerrors.New("webhook batch size must be positive")
+	}
+	if opts.FlushInterval <= 0 {
+		return nil, xerrors.New("webhook flush interval must be positive")
+	}
+	if opts.MaxQueueSize < opts.BatchSize {
+		return nil, xerrors.New("webhook queue size must be at least the batch size")
+	}
+	client := opts.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Webhook{
+		log:    logger,
+		opts:   opts,
+		client: client,
+		flush:  make(chan struct{}, 1),
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+func (*Webhook) Decision() audit.FilterDecision {
+	return audit.FilterDecisionExport
+}
+
+// Export queues the audit log to be sent with the next batch.
+func (w *Webhook) Export(_ context.Context, alog database.AuditLog) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.queue) >= w.opts.MaxQueueSize {
+		dropped := w.queue[0]
+		w.queue = w.queue[1:]
+		w.dropped++
+		w.log.Warn(w.ctx, "audit log webhook queue is full, dropping oldest audit log",
+			slog.F("audit_log_id", dropped.ID),
+			slog.F("queue_size", w.opts.MaxQueueSize),
+		)
+	}
+	w.queue = append(w.queue, newExportedLog(alog))
+	if len(w.queue) >= w.opts.BatchSize {
+		select {
+		case w.flush <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close stops the webhook after a final attempt to send queued audit logs.
+func (w *Webhook) Close() error {
+	w.cancel()
+	<-w.done
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	w.mu.Lock()
+	w.retryAfter = time.Time{}
+	w.mu.Unlock()
+	err := w.sendQueued(ctx)
+	if err != nil {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return xerrors.Errorf("%d audit logs were not sent: %w", len(w.queue), err)
+	}
+	return nil
+}
+
+func (w *Webhook) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.opts.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.flush:
+		}
+
+		err := w.sendQueued(w.ctx)
+		if err != nil && w.ctx.Err() == nil {
+			w.mu.Lock()
+			w.log.Warn(w.ctx, "send audit logs to webhook",
+				slog.Error(err),
+				slog.F("queued", len(w.queue)),
+				slog.F("retry_after", w.retryAfter),
+			)
+			w.mu.Unlock()
+		}
+	}
+}
+
+// sendQueued sends batches until the queue is empty or a request fails.
+// Failed batches stay at the front of the queue so that audit logs are
+// delivered in order.
+func (w *Webhook) sendQueued(ctx context.Context) error {
+	for {
+		w.mu.Lock()
+		if len(w.queue) == 0 || time.Now().Before(w.retryAfter) {
+			w.mu.Unlock()
+			return nil
+		}
+		size := len(w.queue)
+		if size > w.opts.BatchSize {
+			size = w.opts.BatchSize
+		}
+		batch := append([]exportedLog(nil), w.queue[:size]...)
+		w.dropped = 0
+		w.mu.Unlock()
+
+		err := w.send(ctx, batch)
+
+		w.mu.Lock()
+		if err != nil {
+			w.failures++
+			backoff := webhookMaxBackoff
+			if w.failures <= 6 {
+				backoff = time.Second << (w.failures - 1)
+			}
+			w.retryAfter = time.Now().Add(backoff)
+			w.mu.Unlock()
+			return err
+		}
+		w.failures = 0
+		// Export drops audit logs from the front of the queue when it is
+		// full, which may include audit logs of this batch.
+		sent := len(batch) - w.dropped
+		if sent > 0 {
+			w.queue = w.queue[sent:]
+		}
+		w.mu.Unlock()
+	}
+}
+
+func (w *Webhook) send(ctx context.Context, batch []exportedLog) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return xerrors.Errorf("marshal audit logs: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.URL.String(), bytes.NewReader(body))
+	if err != nil {
+		return xerrors.Errorf("create request: %w", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(w.opts.Secret, timestamp, body))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return xerrors.Errorf("send request: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return xerrors.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	return nil
+}
+
+// SignWebhook returns the hex encoded HMAC-SHA256 signature of a webhook
+// request. Receivers compute it from the timestamp header and the raw
+// request body to verify that the request was sent by Coder.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%s.", timestamp)
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
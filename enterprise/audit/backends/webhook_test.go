@@ -0,0 +1,146 @@
+package backends_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/enterprise/audit/audittest"
+	"github.com/coder/coder/enterprise/audit/backends"
+	"github.com/coder/coder/testutil"
+)
+
+func TestWebhookBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mu      sync.Mutex
+			batches [][]map[string]any
+		)
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if !assert.NoError(t, err) {
+				return
+			}
+			signature := backends.SignWebhook("secret", r.Header.Get(backends.WebhookTimestampHeader), body)
+			if r.Header.Get(backends.WebhookSignatureHeader) != "sha256="+signature {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var batch []map[string]any
+			if !assert.NoError(t, json.Unmarshal(body, &batch)) {
+				return
+			}
+			mu.Lock()
+			batches = append(batches, batch)
+			mu.Unlock()
+		}))
+		defer srv.Close()
+
+		backend := newWebhook(t, srv, "secret")
+		ids := make([]uuid.UUID, 0, 3)
+		for i := 0; i < 3; i++ {
+			alog := audittest.RandomLog()
+			ids = append(ids, alog.ID)
+			require.NoError(t, backend.Export(context.Background(), alog))
+		}
+
+		// A full batch is sent immediately, the rest once the flush
+		// interval passes.
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(batches) == 2
+		}, testutil.WaitShort, testutil.IntervalFast)
+		require.NoError(t, backend.Close())
+
+		require.Len(t, batches[0], 2)
+		require.Equal(t, ids[0].String(), batches[0][0]["id"])
+		require.Equal(t, ids[1].String(), batches[0][1]["id"])
+		require.Len(t, batches[1], 1)
+		require.Equal(t, ids[2].String(), batches[1][0]["id"])
+		require.Equal(t, "127.0.0.1", batches[1][0]["ip"])
+		require.Equal(t, "delete", batches[1][0]["action"])
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			attempts atomic.Int64
+			received = make(chan []map[string]any, 1)
+		)
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var batch []map[string]any
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch)) {
+				return
+			}
+			received <- batch
+		}))
+		defer srv.Close()
+
+		backend := newWebhook(t, srv, "secret")
+		defer backend.Close()
+		alog := audittest.RandomLog()
+		require.NoError(t, backend.Export(context.Background(), alog))
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		select {
+		case batch := <-received:
+			require.Len(t, batch, 1)
+			require.Equal(t, alog.ID.String(), batch[0]["id"])
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for retry")
+		}
+		require.EqualValues(t, 2, attempts.Load())
+	})
+
+	t.Run("RequireHTTPS", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := backends.NewWebhook(slogtest.Make(t, nil), backends.WebhookOptions{
+			URL:           &url.URL{Scheme: "http", Host: "example.com"},
+			Secret:        "secret",
+			BatchSize:     1,
+			FlushInterval: time.Second,
+			MaxQueueSize:  1,
+		})
+		require.ErrorContains(t, err, "https")
+	})
+}
+
+func newWebhook(t *testing.T, srv *httptest.Server, secret string) *backends.Webhook {
+	t.Helper()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	backend, err := backends.NewWebhook(slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}), backends.WebhookOptions{
+		URL:           u,
+		Secret:        secret,
+		BatchSize:     2,
+		FlushInterval: 100 * time.Millisecond,
+		MaxQueueSize:  10,
+		HTTPClient:    srv.Client(),
+	})
+	require.NoError(t, err)
+	return backend
+}
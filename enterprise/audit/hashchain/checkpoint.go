@@ -0,0 +1,57 @@
+package hashchain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbauthz"
+)
+
+// NewCheckpointer signs the head of the chain at every interval. It is the
+// caller's responsibility to call Close on the returned instance.
+func NewCheckpointer(ctx context.Context, logger slog.Logger, db database.Store, secret []byte, interval time.Duration) io.Closer {
+	closed := make(chan struct{})
+	ctx, cancelFunc := context.WithCancel(ctx)
+	//nolint:gocritic // The system signs checkpoints without user input.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	go func() {
+		defer close(closed)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			err := Checkpoint(ctx, db, secret)
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+				logger.Error(ctx, "failed to checkpoint audit log hash chain", slog.Error(err))
+			}
+		}
+	}()
+	return &checkpointer{
+		cancel: cancelFunc,
+		closed: closed,
+	}
+}
+
+type checkpointer struct {
+	cancel context.CancelFunc
+	closed chan struct{}
+}
+
+func (c *checkpointer) Close() error {
+	c.cancel()
+	<-c.closed
+	return nil
+}
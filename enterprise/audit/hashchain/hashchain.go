@@ -0,0 +1,194 @@
+// Package hashchain makes audit logs tamper-evident. Every audit log in the
+// chain stores a hash over the hash of the previous audit log and its own
+// contents, so editing or deleting a row breaks every link after it.
+// Checkpoints signed with a secret that is not stored in the database detect
+// rows that were deleted from the end of the chain.
+package hashchain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/coderd/database"
+)
+
+// canonicalAuditLog is the representation of an audit log that is hashed.
+// Fields must never be removed or reordered, otherwise existing chains no
+// longer verify.
+type canonicalAuditLog struct {
+	Sequence         int64           `json:"sequence"`
+	ID               uuid.UUID       `json:"id"`
+	Time             string          `json:"time"`
+	UserID           uuid.UUID       `json:"user_id"`
+	OrganizationID   uuid.UUID       `json:"organization_id"`
+	IP               *string         `json:"ip"`
+	UserAgent        *string         `json:"user_agent"`
+	ResourceType     string          `json:"resource_type"`
+	ResourceID       uuid.UUID       `json:"resource_id"`
+	ResourceTarget   string          `json:"resource_target"`
+	ResourceIcon     string          `json:"resource_icon"`
+	Action           string          `json:"action"`
+	Diff             json.RawMessage `json:"diff"`
+	StatusCode       int32           `json:"status_code"`
+	AdditionalFields json.RawMessage `json:"additional_fields"`
+	RequestID        uuid.UUID       `json:"request_id"`
+}
+
+// Hash returns the chain hash of an audit log at the given sequence. The
+// previous hash is empty for the first audit log in the chain.
+//
+// Values are normalized the way Postgres stores them, so the hash of an
+// audit log read back from the database matches the hash it was inserted
+// with.
+func Hash(prev []byte, sequence int64, alog database.AuditLog) ([]byte, error) {
+	c := canonicalAuditLog{
+		Sequence:       sequence,
+		ID:             alog.ID,
+		Time:           alog.Time.Round(time.Microsecond).UTC().Format(time.RFC3339Nano),
+		UserID:         alog.UserID,
+		OrganizationID: alog.OrganizationID,
+		ResourceType:   string(alog.ResourceType),
+		ResourceID:     alog.ResourceID,
+		ResourceTarget: alog.ResourceTarget,
+		ResourceIcon:   alog.ResourceIcon,
+		Action:         string(alog.Action),
+		StatusCode:     alog.StatusCode,
+		RequestID:      alog.RequestID,
+	}
+	if alog.Ip.Valid {
+		ip := alog.Ip.IPNet.String()
+		c.IP = &ip
+	}
+	if alog.UserAgent.Valid {
+		c.UserAgent = &alog.UserAgent.String
+	}
+	var err error
+	c.Diff, err = canonicalJSON(alog.Diff)
+	if err != nil {
+		return nil, xerrors.Errorf("diff: %w", err)
+	}
+	c.AdditionalFields, err = canonicalJSON(alog.AdditionalFields)
+	if err != nil {
+		return nil, xerrors.Errorf("additional fields: %w", err)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, xerrors.Errorf("marshal audit log: %w", err)
+	}
+
+	h := sha256.New()
+	_, _ = h.Write(prev)
+	_, _ = h.Write(data)
+	return h.Sum(nil), nil
+}
+
+// canonicalJSON re-encodes JSON so that the key order and whitespace
+// changes made by jsonb do not change the hash.
+func canonicalJSON(raw json.RawMessage) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return json.RawMessage("null"), nil
+	}
+	var v any
+	err := json.Unmarshal(raw, &v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// Sign returns the signature of a checkpoint at the given sequence and hash.
+func Sign(secret []byte, sequence int64, hash []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	_ = binary.Write(mac, binary.BigEndian, sequence)
+	_, _ = mac.Write(hash)
+	return mac.Sum(nil)
+}
+
+// Append inserts an audit log at the end of the chain. Appends are
+// serialized with a lock, so every replica extends the same chain.
+func Append(ctx context.Context, db database.Store, alog database.AuditLog) error {
+	// Postgres stores microseconds, and the hash has to match what is read
+	// back.
+	alog.Time = alog.Time.Round(time.Microsecond)
+	return db.InTx(func(tx database.Store) error {
+		err := tx.AcquireLock(ctx, database.LockIDAuditLogHashChain)
+		if err != nil {
+			return xerrors.Errorf("acquire lock: %w", err)
+		}
+		var (
+			prev     []byte
+			sequence int64 = 1
+		)
+		latest, err := tx.GetLatestChainedAuditLog(ctx)
+		if err == nil {
+			prev = latest.ChainHash
+			sequence = latest.ChainSequence.Int64 + 1
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return xerrors.Errorf("get latest chained audit log: %w", err)
+		}
+		hash, err := Hash(prev, sequence, alog)
+		if err != nil {
+			return xerrors.Errorf("hash audit log: %w", err)
+		}
+
+		_, err = tx.InsertAuditLog(ctx, database.InsertAuditLogParams{
+			ID:               alog.ID,
+			Time:             alog.Time,
+			UserID:           alog.UserID,
+			OrganizationID:   alog.OrganizationID,
+			Ip:               alog.Ip,
+			UserAgent:        alog.UserAgent,
+			ResourceType:     alog.ResourceType,
+			ResourceID:       alog.ResourceID,
+			ResourceTarget:   alog.ResourceTarget,
+			Action:           alog.Action,
+			Diff:             alog.Diff,
+			StatusCode:       alog.StatusCode,
+			AdditionalFields: alog.AdditionalFields,
+			RequestID:        alog.RequestID,
+			ResourceIcon:     alog.ResourceIcon,
+			ChainSequence:    sql.NullInt64{Int64: sequence, Valid: true},
+			ChainHash:        hash,
+		})
+		if err != nil {
+			return xerrors.Errorf("insert audit log: %w", err)
+		}
+		return nil
+	}, nil)
+}
+
+// Checkpoint signs the head of the chain. It is a no-op if the chain is
+// empty or the head is already signed.
+func Checkpoint(ctx context.Context, db database.Store, secret []byte) error {
+	latest, err := db.GetLatestChainedAuditLog(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("get latest chained audit log: %w", err)
+	}
+	err = db.InsertAuditLogCheckpoint(ctx, database.InsertAuditLogCheckpointParams{
+		ChainSequence: latest.ChainSequence.Int64,
+		ChainHash:     latest.ChainHash,
+		Signature:     Sign(secret, latest.ChainSequence.Int64, latest.ChainHash),
+		CreatedAt:     database.Now(),
+	})
+	if err != nil {
+		return xerrors.Errorf("insert checkpoint: %w", err)
+	}
+	return nil
+}
+
+// verifySignature reports whether the checkpoint was signed with the secret.
+func verifySignature(secret []byte, checkpoint database.AuditLogCheckpoint) bool {
+	return hmac.Equal(checkpoint.Signature, Sign(secret, checkpoint.ChainSequence, checkpoint.ChainHash))
+}
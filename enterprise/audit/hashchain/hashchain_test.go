@@ -0,0 +1,262 @@
+package hashchain_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbfake"
+	"github.com/coder/coder/coderd/database/dbgen"
+	"github.com/coder/coder/enterprise/audit/audittest"
+	"github.com/coder/coder/enterprise/audit/hashchain"
+)
+
+var secret = []byte("secret")
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	alog := audittest.RandomLog()
+	alog.Diff = json.RawMessage(`{"name": {"old": "a", "new": "b", "secret": false}}`)
+	a, err := hashchain.Hash(nil, 1, alog)
+	require.NoError(t, err)
+
+	// Postgres reorders the keys of jsonb and drops whitespace.
+	alog.Diff = json.RawMessage(`{"name":{"new":"b","old":"a","secret":false}}`)
+	b, err := hashchain.Hash(nil, 1, alog)
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+
+	c, err := hashchain.Hash(a, 2, alog)
+	require.NoError(t, err)
+	require.NotEqual(t, a, c)
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		db := dbfake.New()
+		appendLogs(t, db, 3)
+		require.NoError(t, hashchain.Checkpoint(ctx, db, secret))
+		appendLogs(t, db, 2)
+
+		res, err := hashchain.Verify(ctx, db, secret)
+		require.NoError(t, err)
+		require.Nil(t, res.Broken)
+		require.EqualValues(t, 5, res.AuditLogs)
+		require.EqualValues(t, 1, res.FirstSequence)
+		require.EqualValues(t, 5, res.LastSequence)
+		require.Equal(t, 1, res.Checkpoints)
+	})
+
+	t.Run("Edited", func(t *testing.T) {
+		t.Parallel()
+
+		alogs, _ := buildChain(t, 3)
+		alogs[1].ResourceTarget = "someone else"
+		db := copyChain(t, alogs, nil)
+
+		res, err := hashchain.Verify(context.Background(), db, secret)
+		require.NoError(t, err)
+		require.NotNil(t, res.Broken)
+		require.EqualValues(t, 2, res.Broken.Sequence)
+		require.Equal(t, alogs[1].ID, res.Broken.AuditLogID)
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		t.Parallel()
+
+		alogs, _ := buildChain(t, 3)
+		db := copyChain(t, []database.AuditLog{alogs[0], alogs[2]}, nil)
+
+		res, err := hashchain.Verify(context.Background(), db, secret)
+		require.NoError(t, err)
+		require.NotNil(t, res.Broken)
+		require.EqualValues(t, 2, res.Broken.Sequence)
+		require.Contains(t, res.Broken.Reason, "missing")
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		t.Parallel()
+
+		alogs, checkpoints := buildChain(t, 3)
+		db := copyChain(t, alogs[:2], checkpoints)
+
+		res, err := hashchain.Verify(context.Background(), db, secret)
+		require.NoError(t, err)
+		require.NotNil(t, res.Broken)
+		require.EqualValues(t, 3, res.Broken.Sequence)
+		require.Contains(t, res.Broken.Reason, "checkpoint")
+	})
+
+	t.Run("Purged", func(t *testing.T) {
+		t.Parallel()
+
+		alogs, checkpoints := buildAnchoredChain(t)
+		db := copyChain(t, alogs[1:], checkpoints)
+
+		res, err := hashchain.Verify(context.Background(), db, secret)
+		require.NoError(t, err)
+		require.Nil(t, res.Broken)
+		require.EqualValues(t, 2, res.FirstSequence)
+		require.EqualValues(t, 2, res.AuditLogs)
+	})
+
+	t.Run("PurgedAndEdited", func(t *testing.T) {
+		t.Parallel()
+
+		alogs, checkpoints := buildAnchoredChain(t)
+		alogs[1].ResourceTarget = "someone else"
+		alogs[1].ChainHash = mustHash(t, nil, 2, alogs[1])
+		db := copyChain(t, alogs[1:], checkpoints)
+
+		res, err := hashchain.Verify(context.Background(), db, secret)
+		require.NoError(t, err)
+		require.NotNil(t, res.Broken)
+		require.EqualValues(t, 2, res.Broken.Sequence)
+		require.Equal(t, alogs[1].ID, res.Broken.AuditLogID)
+	})
+
+	t.Run("Unanchored", func(t *testing.T) {
+		t.Parallel()
+
+		// Deleting the first audit log and editing the second, including its
+		// hash, leaves nothing to compare against but the missing checkpoint.
+		alogs, _ := buildChain(t, 3)
+		alogs[1].ResourceTarget = "someone else"
+		alogs[1].ChainHash = mustHash(t, nil, 2, alogs[1])
+		alogs[2].ChainHash = mustHash(t, alogs[1].ChainHash, 3, alogs[2])
+		db := copyChain(t, alogs[1:], nil)
+
+		res, err := hashchain.Verify(context.Background(), db, secret)
+		require.NoError(t, err)
+		require.NotNil(t, res.Broken)
+		require.EqualValues(t, 2, res.Broken.Sequence)
+		require.Contains(t, res.Broken.Reason, "no checkpoint")
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		db := dbfake.New()
+		appendLogs(t, db, 2)
+		require.NoError(t, hashchain.Checkpoint(ctx, db, secret))
+		appendLogs(t, db, 2)
+		require.NoError(t, hashchain.Checkpoint(ctx, db, secret))
+		appendLogs(t, db, 1)
+
+		// Retention purges up to the newest checkpoint before the head of
+		// the chain, and keeps that checkpoint to anchor what remains.
+		deleted, err := db.DeleteOldAuditLogs(ctx, database.DeleteOldAuditLogsParams{
+			BeforeTime: database.Now().Add(time.Minute),
+			LimitCount: 100,
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 4, deleted)
+		_, err = db.DeleteOldAuditLogCheckpoints(ctx)
+		require.NoError(t, err)
+
+		res, err := hashchain.Verify(ctx, db, secret)
+		require.NoError(t, err)
+		require.Nil(t, res.Broken)
+		require.EqualValues(t, 5, res.FirstSequence)
+		require.EqualValues(t, 1, res.AuditLogs)
+
+		appendLogs(t, db, 1)
+		res, err = hashchain.Verify(ctx, db, secret)
+		require.NoError(t, err)
+		require.Nil(t, res.Broken)
+		require.EqualValues(t, 6, res.LastSequence)
+	})
+
+	t.Run("ForgedCheckpoint", func(t *testing.T) {
+		t.Parallel()
+
+		alogs, _ := buildChain(t, 2)
+		db := copyChain(t, alogs, nil)
+		require.NoError(t, hashchain.Checkpoint(context.Background(), db, []byte("guessed")))
+
+		res, err := hashchain.Verify(context.Background(), db, secret)
+		require.NoError(t, err)
+		require.NotNil(t, res.Broken)
+		require.EqualValues(t, 2, res.Broken.Sequence)
+		require.Contains(t, res.Broken.Reason, "signature")
+	})
+}
+
+func appendLogs(t *testing.T, db database.Store, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		alog := audittest.RandomLog()
+		alog.AdditionalFields = json.RawMessage("{}")
+		alog.RequestID = uuid.New()
+		require.NoError(t, hashchain.Append(context.Background(), db, alog))
+	}
+}
+
+// buildChain returns a chain of n audit logs with a checkpoint at the end.
+func buildChain(t *testing.T, n int) ([]database.AuditLog, []database.AuditLogCheckpoint) {
+	t.Helper()
+
+	ctx := context.Background()
+	db := dbfake.New()
+	appendLogs(t, db, n)
+	require.NoError(t, hashchain.Checkpoint(ctx, db, secret))
+	alogs, err := db.GetChainedAuditLogs(ctx, database.GetChainedAuditLogsParams{LimitCount: int32(n)})
+	require.NoError(t, err)
+	checkpoints, err := db.GetAuditLogCheckpoints(ctx)
+	require.NoError(t, err)
+	return alogs, checkpoints
+}
+
+// buildAnchoredChain returns a chain of three audit logs with a checkpoint
+// after the first one.
+func buildAnchoredChain(t *testing.T) ([]database.AuditLog, []database.AuditLogCheckpoint) {
+	t.Helper()
+
+	ctx := context.Background()
+	db := dbfake.New()
+	appendLogs(t, db, 1)
+	require.NoError(t, hashchain.Checkpoint(ctx, db, secret))
+	appendLogs(t, db, 2)
+	alogs, err := db.GetChainedAuditLogs(ctx, database.GetChainedAuditLogsParams{LimitCount: 3})
+	require.NoError(t, err)
+	checkpoints, err := db.GetAuditLogCheckpoints(ctx)
+	require.NoError(t, err)
+	return alogs, checkpoints
+}
+
+func mustHash(t *testing.T, prev []byte, sequence int64, alog database.AuditLog) []byte {
+	t.Helper()
+
+	hash, err := hashchain.Hash(prev, sequence, alog)
+	require.NoError(t, err)
+	return hash
+}
+
+// copyChain inserts audit logs and checkpoints as is, the way someone with
+// access to the database could.
+func copyChain(t *testing.T, alogs []database.AuditLog, checkpoints []database.AuditLogCheckpoint) database.Store {
+	t.Helper()
+
+	db := dbfake.New()
+	for _, alog := range alogs {
+		_ = dbgen.AuditLog(t, db, alog)
+	}
+	for _, checkpoint := range checkpoints {
+		err := db.InsertAuditLogCheckpoint(context.Background(), database.InsertAuditLogCheckpointParams(checkpoint))
+		require.NoError(t, err)
+	}
+	return db
+}
@@ -0,0 +1,144 @@
+package hashchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/coderd/database"
+)
+
+const verifyBatchSize = 1000
+
+// BrokenLink is the first position at which the chain does not verify.
+type BrokenLink struct {
+	Sequence int64
+	// AuditLogID is uuid.Nil if the audit log at the sequence is missing.
+	AuditLogID uuid.UUID
+	Reason     string
+}
+
+func (b BrokenLink) String() string {
+	if b.AuditLogID == uuid.Nil {
+		return fmt.Sprintf("sequence %d: %s", b.Sequence, b.Reason)
+	}
+	return fmt.Sprintf("sequence %d (audit log %s): %s", b.Sequence, b.AuditLogID, b.Reason)
+}
+
+type Result struct {
+	// FirstSequence is greater than one if older audit logs were purged.
+	FirstSequence int64
+	LastSequence  int64
+	AuditLogs     int64
+	Checkpoints   int
+	// Broken is nil if the whole chain verified.
+	Broken *BrokenLink
+}
+
+// Verify walks the chain and reports the first broken link. Audit logs purged
+// from the start of the chain do not break it, as long as the checkpoint of
+// the audit log right before the oldest remaining one is kept to anchor it.
+func Verify(ctx context.Context, db database.Store, secret []byte) (Result, error) {
+	var res Result
+	checkpoints, err := db.GetAuditLogCheckpoints(ctx)
+	if err != nil {
+		return res, xerrors.Errorf("get checkpoints: %w", err)
+	}
+	res.Checkpoints = len(checkpoints)
+	checkpointBySequence := make(map[int64]database.AuditLogCheckpoint, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		if !verifySignature(secret, checkpoint) {
+			res.Broken = &BrokenLink{
+				Sequence: checkpoint.ChainSequence,
+				Reason:   "the checkpoint signature is invalid",
+			}
+			return res, nil
+		}
+		checkpointBySequence[checkpoint.ChainSequence] = checkpoint
+	}
+
+	var prev []byte
+	for {
+		alogs, err := db.GetChainedAuditLogs(ctx, database.GetChainedAuditLogsParams{
+			AfterSequence: res.LastSequence,
+			LimitCount:    verifyBatchSize,
+		})
+		if err != nil {
+			return res, xerrors.Errorf("get chained audit logs: %w", err)
+		}
+		for _, alog := range alogs {
+			sequence := alog.ChainSequence.Int64
+			switch {
+			case res.AuditLogs == 0:
+				res.FirstSequence = sequence
+				if sequence == 1 {
+					break
+				}
+				// If older audit logs were purged, the signed checkpoint of
+				// the previous one links the oldest remaining audit log.
+				// Without it the oldest remaining audit log could be forged
+				// along with everything before it.
+				checkpoint, ok := checkpointBySequence[sequence-1]
+				if !ok {
+					res.Broken = &BrokenLink{
+						Sequence:   sequence,
+						AuditLogID: alog.ID,
+						Reason:     fmt.Sprintf("the audit logs before it are missing and no checkpoint signed sequence %d", sequence-1),
+					}
+					return res, nil
+				}
+				prev = checkpoint.ChainHash
+			case sequence != res.LastSequence+1:
+				res.Broken = &BrokenLink{
+					Sequence: res.LastSequence + 1,
+					Reason:   "the audit log is missing",
+				}
+				return res, nil
+			}
+
+			hash, err := Hash(prev, sequence, alog)
+			if err != nil {
+				return res, xerrors.Errorf("hash audit log %s: %w", alog.ID, err)
+			}
+			if !bytes.Equal(hash, alog.ChainHash) {
+				res.Broken = &BrokenLink{
+					Sequence:   sequence,
+					AuditLogID: alog.ID,
+					Reason:     "the hash does not match the audit log and the previous hash",
+				}
+				return res, nil
+			}
+			if checkpoint, ok := checkpointBySequence[sequence]; ok && !bytes.Equal(checkpoint.ChainHash, alog.ChainHash) {
+				res.Broken = &BrokenLink{
+					Sequence:   sequence,
+					AuditLogID: alog.ID,
+					Reason:     "the hash does not match the signed checkpoint",
+				}
+				return res, nil
+			}
+
+			prev = hash
+			res.LastSequence = sequence
+			res.AuditLogs++
+		}
+		if len(alogs) < verifyBatchSize {
+			break
+		}
+	}
+
+	// Audit logs deleted from the end of the chain leave no broken link
+	// behind, but a checkpoint may have signed them.
+	if len(checkpoints) > 0 {
+		last := checkpoints[len(checkpoints)-1]
+		if last.ChainSequence > res.LastSequence {
+			res.Broken = &BrokenLink{
+				Sequence: res.LastSequence + 1,
+				Reason:   fmt.Sprintf("the audit log is missing, but the checkpoint at sequence %d signed it", last.ChainSequence),
+			}
+		}
+	}
+	return res, nil
+}
@@ -84,6 +84,9 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"failure_ttl":                      ActionTrack,
 		"inactivity_ttl":                   ActionTrack,
 		"locked_ttl":                       ActionTrack,
+		"deprecated":                       ActionTrack,
+		"replacement_template_id":          ActionTrack,
+		"require_active_version":           ActionTrack,
 	},
 	&database.TemplateVersion{}: {
 		"id":                    ActionTrack,
@@ -97,6 +100,9 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"job_id":                ActionIgnore, // Not helpful in a diff because jobs aren't tracked in audit logs.
 		"created_by":            ActionTrack,
 		"git_auth_providers":    ActionIgnore, // Not helpful because this can only change when new versions are added.
+		"git_url":               ActionTrack,
+		"git_commit_sha":        ActionTrack,
+		"archived":              ActionTrack,
 		"created_by_avatar_url": ActionIgnore,
 		"created_by_username":   ActionIgnore,
 	},
@@ -116,19 +122,22 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"quiet_hours_schedule": ActionTrack,
 	},
 	&database.Workspace{}: {
-		"id":                 ActionTrack,
-		"created_at":         ActionIgnore, // Never changes.
-		"updated_at":         ActionIgnore, // Changes, but is implicit and not helpful in a diff.
-		"owner_id":           ActionTrack,
-		"organization_id":    ActionIgnore, // Never changes.
-		"template_id":        ActionTrack,
-		"deleted":            ActionIgnore, // Changes, but is implicit when a delete event is fired.
-		"name":               ActionTrack,
-		"autostart_schedule": ActionTrack,
-		"ttl":                ActionTrack,
-		"last_used_at":       ActionIgnore,
-		"locked_at":          ActionTrack,
-		"deleting_at":        ActionTrack,
+		"id":                   ActionTrack,
+		"created_at":           ActionIgnore, // Never changes.
+		"updated_at":           ActionIgnore, // Changes, but is implicit and not helpful in a diff.
+		"owner_id":             ActionTrack,
+		"organization_id":      ActionIgnore, // Never changes.
+		"template_id":          ActionTrack,
+		"deleted":              ActionIgnore, // Changes, but is implicit when a delete event is fired.
+		"name":                 ActionTrack,
+		"autostart_schedule":   ActionTrack,
+		"autostart_skip_until": ActionTrack,
+		"ttl":                  ActionTrack,
+		"last_used_at":         ActionIgnore,
+		"locked_at":            ActionTrack,
+		"deleting_at":          ActionTrack,
+		"user_acl":             ActionTrack,
+		"group_acl":            ActionTrack,
 	},
 	&database.WorkspaceBuild{}: {
 		"id":                      ActionIgnore,
@@ -4,10 +4,13 @@ package cli
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"errors"
 	"io"
 	"net/url"
+	"os"
 
 	"golang.org/x/xerrors"
 	"tailscale.com/derp"
@@ -17,6 +20,7 @@ import (
 	"github.com/coder/coder/cryptorand"
 	"github.com/coder/coder/enterprise/audit"
 	"github.com/coder/coder/enterprise/audit/backends"
+	"github.com/coder/coder/enterprise/audit/hashchain"
 	"github.com/coder/coder/enterprise/coderd"
 	"github.com/coder/coder/enterprise/trialer"
 	"github.com/coder/coder/tailnet"
@@ -49,9 +53,32 @@ func (r *RootCmd) server() *clibase.Cmd {
 			}
 		}
 		options.DERPServer.SetMeshKey(meshKey)
+
+		postgresBackend := backends.NewPostgres(options.Database, true)
+		hashChain := options.DeploymentValues.AuditLogHashChain
+		if hashChain.Enable.Value() {
+			if hashChain.Secret.Value() == "" {
+				return nil, nil, xerrors.New("audit-log-hash-chain-secret must be set when the audit log hash chain is enabled")
+			}
+			if hashChain.CheckpointInterval.Value() <= 0 {
+				return nil, nil, xerrors.New("audit-log-hash-chain-checkpoint-interval must be positive")
+			}
+			postgresBackend = backends.NewPostgresHashChain(options.Database, true)
+		}
+		auditBackends, auditClosers, err := exportAuditBackends(options)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hashChain.Enable.Value() {
+			auditClosers = append(auditClosers, hashchain.NewCheckpointer(ctx,
+				options.Logger.Named("audit_hash_chain"), options.Database,
+				[]byte(hashChain.Secret.Value()), hashChain.CheckpointInterval.Value()))
+		}
 		options.Auditor = audit.NewAuditor(audit.DefaultFilter,
-			backends.NewPostgres(options.Database, true),
-			backends.NewSlog(options.Logger),
+			append([]audit.Backend{
+				postgresBackend,
+				backends.NewSlog(options.Logger),
+			}, auditBackends...)...,
 		)
 
 		options.TrialGenerator = trialer.New(options.Database, "https://v2-licensor.coder.com/trial", coderd.Keys)
@@ -71,9 +98,104 @@ func (r *RootCmd) server() *clibase.Cmd {
 
 		api, err := coderd.New(ctx, o)
 		if err != nil {
+			for _, closer := range auditClosers {
+				_ = closer.Close()
+			}
 			return nil, nil, err
 		}
-		return api.AGPL, api, nil
+		return api.AGPL, &apiCloser{api: api, auditBackends: auditClosers}, nil
 	})
+	cmd.AddSubcommands(r.serverAudit())
 	return cmd
 }
+
+// exportAuditBackends returns the backends that export audit logs to the
+// destinations configured by the deployment.
+func exportAuditBackends(options *agplcoderd.Options) ([]audit.Backend, []io.Closer, error) {
+	var (
+		cfg           = options.DeploymentValues.AuditLogExport
+		auditBackends []audit.Backend
+		auditClosers  []io.Closer
+	)
+	closeAll := func() {
+		for _, closer := range auditClosers {
+			_ = closer.Close()
+		}
+	}
+
+	if cfg.WebhookURL.String() != "" {
+		webhook, err := backends.NewWebhook(options.Logger.Named("audit_webhook"), backends.WebhookOptions{
+			URL:           cfg.WebhookURL.Value(),
+			Secret:        cfg.WebhookSecret.Value(),
+			BatchSize:     int(cfg.WebhookBatchSize.Value()),
+			FlushInterval: cfg.WebhookFlushInterval.Value(),
+			MaxQueueSize:  int(cfg.WebhookMaxQueueSize.Value()),
+		})
+		if err != nil {
+			return nil, nil, xerrors.Errorf("create audit log webhook: %w", err)
+		}
+		auditBackends = append(auditBackends, webhook)
+		auditClosers = append(auditClosers, webhook)
+	}
+
+	if cfg.SyslogAddress.Value() != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.SyslogTLSCAFile.Value() != "" {
+			pem, err := os.ReadFile(cfg.SyslogTLSCAFile.Value())
+			if err != nil {
+				closeAll()
+				return nil, nil, xerrors.Errorf("read audit log syslog CA file: %w", err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				closeAll()
+				return nil, nil, xerrors.Errorf("no certificates found in audit log syslog CA file %q", cfg.SyslogTLSCAFile.Value())
+			}
+		}
+		syslog, err := backends.NewSyslog(options.Logger.Named("audit_syslog"), backends.SyslogOptions{
+			Address:   cfg.SyslogAddress.Value(),
+			Transport: cfg.SyslogTransport.Value(),
+			TLSConfig: tlsConfig,
+		})
+		if err != nil {
+			closeAll()
+			return nil, nil, xerrors.Errorf("create audit log syslog: %w", err)
+		}
+		auditBackends = append(auditBackends, syslog)
+		auditClosers = append(auditClosers, syslog)
+	}
+
+	if cfg.FilePath.Value() != "" {
+		file, err := backends.NewFile(backends.FileOptions{
+			Path:       cfg.FilePath.Value(),
+			MaxSizeMB:  int(cfg.FileMaxSize.Value()),
+			MaxBackups: int(cfg.FileMaxBackups.Value()),
+		})
+		if err != nil {
+			closeAll()
+			return nil, nil, xerrors.Errorf("create audit log file: %w", err)
+		}
+		auditBackends = append(auditBackends, file)
+		auditClosers = append(auditClosers, file)
+	}
+
+	return auditBackends, auditClosers, nil
+}
+
+// apiCloser closes the API before the audit backends, so that audit logs
+// of requests during shutdown are still exported.
+type apiCloser struct {
+	api           io.Closer
+	auditBackends []io.Closer
+}
+
+func (c *apiCloser) Close() error {
+	err := c.api.Close()
+	for _, closer := range c.auditBackends {
+		cerr := closer.Close()
+		if cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
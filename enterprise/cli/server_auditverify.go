@@ -0,0 +1,89 @@
+//go:build !slim
+
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/enterprise/audit/hashchain"
+)
+
+func (r *RootCmd) serverAudit() *clibase.Cmd {
+	cmd := &clibase.Cmd{
+		Use:   "audit",
+		Short: "Manage the audit log hash chain.",
+		Handler: func(inv *clibase.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*clibase.Cmd{
+			r.serverAuditVerify(),
+		},
+	}
+	return cmd
+}
+
+func (*RootCmd) serverAuditVerify() *clibase.Cmd {
+	var (
+		postgresURL string
+		secret      string
+	)
+	cmd := &clibase.Cmd{
+		Use:   "verify",
+		Short: "Verify that audit logs in the hash chain were not edited or deleted.",
+		Long: "Audit logs purged from the start of the chain are not reported, as long as the checkpoint right before the oldest remaining audit log was kept to anchor it. " +
+			"Audit logs deleted from the end of the chain are detected up to the last signed checkpoint.",
+		Middleware: clibase.RequireNArgs(0),
+		Handler: func(inv *clibase.Invocation) error {
+			ctx := inv.Context()
+			if postgresURL == "" {
+				return xerrors.New("--postgres-url is required. Use \"coder server postgres-builtin-url --raw-url\" to get the URL of the built-in PostgreSQL deployment")
+			}
+			if secret == "" {
+				return xerrors.New("--audit-log-hash-chain-secret is required to verify checkpoint signatures")
+			}
+
+			sqlDB, err := sql.Open("postgres", postgresURL)
+			if err != nil {
+				return xerrors.Errorf("open postgres: %w", err)
+			}
+			defer sqlDB.Close()
+			db := database.New(sqlDB)
+
+			res, err := hashchain.Verify(ctx, db, []byte(secret))
+			if err != nil {
+				return xerrors.Errorf("verify hash chain: %w", err)
+			}
+			if res.Broken != nil {
+				return xerrors.Errorf("the audit log hash chain is broken at %s", res.Broken)
+			}
+			if res.AuditLogs == 0 {
+				cliui.Infof(inv.Stdout, "The audit log hash chain is empty.")
+				return nil
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Verified %d audit logs from sequence %d to %d and %d checkpoints.\n",
+				res.AuditLogs, res.FirstSequence, res.LastSequence, res.Checkpoints)
+			return nil
+		},
+	}
+	cmd.Options = clibase.OptionSet{
+		{
+			Env:         "CODER_PG_CONNECTION_URL",
+			Flag:        "postgres-url",
+			Description: "URL of the PostgreSQL database that holds the audit logs.",
+			Value:       clibase.StringOf(&postgresURL),
+		},
+		{
+			Env:         "CODER_AUDIT_LOG_HASH_CHAIN_SECRET",
+			Flag:        "audit-log-hash-chain-secret",
+			Description: "The secret the hash chain checkpoints were signed with.",
+			Value:       clibase.StringOf(&secret),
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,73 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbtestutil"
+	"github.com/coder/coder/coderd/database/postgres"
+	"github.com/coder/coder/enterprise/audit/audittest"
+	"github.com/coder/coder/enterprise/audit/hashchain"
+	"github.com/coder/coder/testutil"
+)
+
+func TestServerAuditVerify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RequiresPostgresURL", func(t *testing.T) {
+		t.Parallel()
+
+		inv, _ := newCLI(t, "server", "audit", "verify", "--audit-log-hash-chain-secret", "secret")
+		err := inv.Run()
+		require.ErrorContains(t, err, "--postgres-url")
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		if !dbtestutil.WillUsePostgres() {
+			t.Skip("This test requires PostgreSQL")
+		}
+
+		connectionURL, closeFunc, err := postgres.Open()
+		require.NoError(t, err)
+		defer closeFunc()
+		sqlDB, err := sql.Open("postgres", connectionURL)
+		require.NoError(t, err)
+		defer sqlDB.Close()
+		db := database.New(sqlDB)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		for i := 0; i < 3; i++ {
+			alog := audittest.RandomLog()
+			alog.AdditionalFields = []byte("{}")
+			alog.RequestID = uuid.New()
+			require.NoError(t, hashchain.Append(ctx, db, alog))
+		}
+		require.NoError(t, hashchain.Checkpoint(ctx, db, []byte("secret")))
+
+		verify := func() (string, error) {
+			inv, _ := newCLI(t, "server", "audit", "verify",
+				"--postgres-url", connectionURL,
+				"--audit-log-hash-chain-secret", "secret",
+			)
+			buf := new(bytes.Buffer)
+			inv.Stdout = buf
+			err := inv.WithContext(ctx).Run()
+			return buf.String(), err
+		}
+		out, err := verify()
+		require.NoError(t, err)
+		require.Contains(t, out, "Verified 3 audit logs")
+
+		_, err = sqlDB.ExecContext(context.Background(), "UPDATE audit_logs SET resource_target = 'someone else' WHERE chain_sequence = 2")
+		require.NoError(t, err)
+		_, err = verify()
+		require.ErrorContains(t, err, "broken at sequence 2")
+	})
+}
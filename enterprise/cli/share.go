@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	agpl "github.com/coder/coder/cli"
+	"github.com/coder/coder/cli/clibase"
+	"github.com/coder/coder/cli/cliui"
+	"github.com/coder/coder/codersdk"
+)
+
+func (r *RootCmd) share() *clibase.Cmd {
+	var (
+		users  []string
+		groups []string
+	)
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]shareTableRow{}, nil),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &clibase.Cmd{
+		Use:   "share <workspace>",
+		Short: "Share a workspace with other users and groups",
+		Long: "Users and groups with the \"use\" role can connect to the workspace over SSH, " +
+			"port-forward, open its terminal and use its apps. The \"admin\" role also lets " +
+			"them build, update and share the workspace, but not delete or transfer it. " +
+			"Without flags the current shares are listed.",
+		Middleware: clibase.Chain(
+			clibase.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *clibase.Invocation) error {
+			ctx := inv.Context()
+
+			workspace, err := agpl.NamedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+
+			var req codersdk.UpdateWorkspaceACL
+			if len(users) > 0 || len(groups) > 0 {
+				// Members can't look up other users and groups directly, so
+				// resolve the names from the ones the workspace can be shared
+				// with.
+				available, err := client.WorkspaceACLAvailable(ctx, workspace.ID)
+				if err != nil {
+					return xerrors.Errorf("get available users and groups: %w", err)
+				}
+
+				req.UserPerms, err = resolveShareEntries(users, available.Users, func(u codersdk.User) (string, string) {
+					return u.Username, u.ID.String()
+				})
+				if err != nil {
+					return xerrors.Errorf("parse --user: %w", err)
+				}
+				req.GroupPerms, err = resolveShareEntries(groups, available.Groups, func(g codersdk.Group) (string, string) {
+					return g.Name, g.ID.String()
+				})
+				if err != nil {
+					return xerrors.Errorf("parse --group: %w", err)
+				}
+			}
+
+			if len(req.UserPerms) > 0 || len(req.GroupPerms) > 0 {
+				err = client.UpdateWorkspaceACL(ctx, workspace.ID, req)
+				if err != nil {
+					return xerrors.Errorf("update workspace acl: %w", err)
+				}
+			}
+
+			acl, err := client.WorkspaceACL(ctx, workspace.ID)
+			if err != nil {
+				return xerrors.Errorf("get workspace acl: %w", err)
+			}
+			if len(acl.Users) == 0 && len(acl.Groups) == 0 {
+				_, _ = fmt.Fprintf(inv.Stderr, "%s Workspace %s is not shared with anyone.\n", agpl.Caret, cliui.DefaultStyles.Keyword.Render(workspace.Name))
+				return nil
+			}
+
+			out, err := formatter.Format(ctx, shareToRows(acl))
+			if err != nil {
+				return xerrors.Errorf("display workspace acl: %w", err)
+			}
+
+			_, _ = fmt.Fprintln(inv.Stdout, out)
+			return nil
+		},
+	}
+
+	cmd.Options = clibase.OptionSet{
+		{
+			Flag:          "user",
+			FlagShorthand: "u",
+			Description:   `Share the workspace with a user, given as "<username>[:<role>]". The role is one of "use" (default), "admin" or "none" to stop sharing.`,
+			Value:         clibase.StringArrayOf(&users),
+		},
+		{
+			Flag:          "group",
+			FlagShorthand: "g",
+			Description:   `Share the workspace with a group, given as "<group>[:<role>]". The role is one of "use" (default), "admin" or "none" to stop sharing.`,
+			Value:         clibase.StringArrayOf(&groups),
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+// parseShareEntry splits "<name>[:<role>]" into the name and the role to
+// assign. "none" maps to the deleted role, which removes the share.
+func parseShareEntry(entry string) (string, codersdk.WorkspaceRole, error) {
+	name, role, found := strings.Cut(entry, ":")
+	if name == "" {
+		return "", "", xerrors.Errorf("%q must be in the form <name>[:<role>]", entry)
+	}
+	if !found {
+		return name, codersdk.WorkspaceRoleUse, nil
+	}
+
+	switch codersdk.WorkspaceRole(role) {
+	case codersdk.WorkspaceRoleUse, codersdk.WorkspaceRoleAdmin:
+		return name, codersdk.WorkspaceRole(role), nil
+	}
+	if role == "none" {
+		return name, codersdk.WorkspaceRoleDeleted, nil
+	}
+	return "", "", xerrors.Errorf("invalid role %q, must be one of \"use\", \"admin\" or \"none\"", role)
+}
+
+// resolveShareEntries maps "<name>[:<role>]" entries to the IDs of the
+// matching candidates.
+func resolveShareEntries[T any](entries []string, candidates []T, key func(T) (name string, id string)) (map[string]codersdk.WorkspaceRole, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	ids := make(map[string]string, len(candidates))
+	for _, candidate := range candidates {
+		name, id := key(candidate)
+		ids[name] = id
+	}
+
+	perms := make(map[string]codersdk.WorkspaceRole, len(entries))
+	for _, entry := range entries {
+		name, role, err := parseShareEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		id, ok := ids[name]
+		if !ok {
+			return nil, xerrors.Errorf("%q not found", name)
+		}
+		perms[id] = role
+	}
+
+	return perms, nil
+}
+
+type shareTableRow struct {
+	Name string                 `json:"name" table:"name,default_sort"`
+	Type string                 `json:"type" table:"type"`
+	Role codersdk.WorkspaceRole `json:"role" table:"role"`
+}
+
+func shareToRows(acl codersdk.WorkspaceACL) []shareTableRow {
+	rows := make([]shareTableRow, 0, len(acl.Users)+len(acl.Groups))
+	for _, user := range acl.Users {
+		rows = append(rows, shareTableRow{
+			Name: user.Username,
+			Type: "user",
+			Role: user.Role,
+		})
+	}
+	for _, group := range acl.Groups {
+		rows = append(rows, shareTableRow{
+			Name: group.Name,
+			Type: "group",
+			Role: group.Role,
+		})
+	}
+
+	return rows
+}
@@ -0,0 +1,115 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/cli/clitest"
+	"github.com/coder/coder/coderd/coderdtest"
+	"github.com/coder/coder/codersdk"
+	"github.com/coder/coder/enterprise/coderd/coderdenttest"
+	"github.com/coder/coder/enterprise/coderd/license"
+	"github.com/coder/coder/pty/ptytest"
+	"github.com/coder/coder/testutil"
+)
+
+func TestShare(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*codersdk.Client, *codersdk.Client, codersdk.Workspace, codersdk.User) {
+		t.Helper()
+
+		client, admin := coderdenttest.New(t, &coderdenttest.Options{
+			Options: &coderdtest.Options{
+				IncludeProvisionerDaemon: true,
+			},
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				Features: license.Features{
+					codersdk.FeatureTemplateRBAC: 1,
+				},
+			},
+		})
+		version := coderdtest.CreateTemplateVersion(t, client, admin.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, admin.OrganizationID, version.ID)
+
+		ownerClient, _ := coderdtest.CreateAnotherUser(t, client, admin.OrganizationID)
+		_, other := coderdtest.CreateAnotherUser(t, client, admin.OrganizationID)
+		workspace := coderdtest.CreateWorkspace(t, ownerClient, admin.OrganizationID, template.ID)
+		_ = coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+		return client, ownerClient, workspace, other
+	}
+
+	t.Run("User", func(t *testing.T) {
+		t.Parallel()
+
+		_, ownerClient, workspace, other := setup(t)
+
+		inv, conf := newCLI(t, "share", workspace.Name, "--user", other.Username+":admin")
+		clitest.SetupConfig(t, ownerClient, conf)
+		pty := ptytest.New(t).Attach(inv)
+
+		err := inv.Run()
+		require.NoError(t, err)
+		pty.ExpectMatch(other.Username)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		acl, err := ownerClient.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Len(t, acl.Users, 1)
+		require.Equal(t, other.ID, acl.Users[0].ID)
+		require.Equal(t, codersdk.WorkspaceRoleAdmin, acl.Users[0].Role)
+
+		// Unshare again.
+		inv, conf = newCLI(t, "share", workspace.Name, "--user", other.Username+":none")
+		clitest.SetupConfig(t, ownerClient, conf)
+		err = inv.Run()
+		require.NoError(t, err)
+
+		acl, err = ownerClient.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Empty(t, acl.Users)
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		t.Parallel()
+
+		client, ownerClient, workspace, other := setup(t)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		group, err := client.CreateGroup(ctx, workspace.OrganizationID, codersdk.CreateGroupRequest{
+			Name: "pair",
+		})
+		require.NoError(t, err)
+		_, err = client.PatchGroup(ctx, group.ID, codersdk.PatchGroupRequest{
+			AddUsers: []string{other.ID.String()},
+		})
+		require.NoError(t, err)
+
+		inv, conf := newCLI(t, "share", workspace.Name, "--group", group.Name)
+		clitest.SetupConfig(t, ownerClient, conf)
+		pty := ptytest.New(t).Attach(inv)
+
+		err = inv.Run()
+		require.NoError(t, err)
+		pty.ExpectMatch(group.Name)
+
+		acl, err := ownerClient.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Len(t, acl.Groups, 1)
+		require.Equal(t, codersdk.WorkspaceRoleUse, acl.Groups[0].Role)
+	})
+
+	t.Run("InvalidRole", func(t *testing.T) {
+		t.Parallel()
+
+		_, ownerClient, workspace, other := setup(t)
+
+		inv, conf := newCLI(t, "share", workspace.Name, "--user", other.Username+":owner")
+		clitest.SetupConfig(t, ownerClient, conf)
+
+		err := inv.Run()
+		require.ErrorContains(t, err, "invalid role")
+	})
+}
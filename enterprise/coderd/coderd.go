@@ -223,6 +223,16 @@ func New(ctx context.Context, options *Options) (_ *API, err error) {
 			r.Get("/", api.templateACL)
 			r.Patch("/", api.patchTemplateACL)
 		})
+		r.Route("/workspaces/{workspace}/acl", func(r chi.Router) {
+			r.Use(
+				api.templateRBACEnabledMW,
+				apiKeyMiddleware,
+				httpmw.ExtractWorkspaceParam(api.Database),
+			)
+			r.Get("/available", api.workspaceAvailablePermissions)
+			r.Get("/", api.workspaceACL)
+			r.Patch("/", api.patchWorkspaceACL)
+		})
 		r.Route("/groups/{group}", func(r chi.Router) {
 			r.Use(
 				api.templateRBACEnabledMW,
@@ -500,8 +510,11 @@ func (api *API) updateEntitlements(ctx context.Context) error {
 			committer := committer{Database: api.Database}
 			ptr := proto.QuotaCommitter(&committer)
 			api.AGPL.QuotaCommitter.Store(&ptr)
+			checkTransfer := committer.CheckTransfer
+			api.AGPL.WorkspaceTransferQuotaCheck.Store(&checkTransfer)
 		} else {
 			api.AGPL.QuotaCommitter.Store(nil)
+			api.AGPL.WorkspaceTransferQuotaCheck.Store(nil)
 		}
 	}
 
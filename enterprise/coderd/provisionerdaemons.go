@@ -253,6 +253,7 @@ func (api *API) provisionerDaemonServe(rw http.ResponseWriter, r *http.Request)
 		Provisioners:                daemon.Provisioners,
 		Telemetry:                   api.Telemetry,
 		Auditor:                     &api.AGPL.Auditor,
+		Notifier:                    api.AGPL.Notifications,
 		TemplateScheduleStore:       api.AGPL.TemplateScheduleStore,
 		UserQuietHoursScheduleStore: api.AGPL.UserQuietHoursScheduleStore,
 		Logger:                      api.Logger.Named(fmt.Sprintf("provisionerd-%s", daemon.Name)),
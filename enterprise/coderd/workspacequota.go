@@ -99,6 +99,29 @@ func (c *committer) CommitQuota(
 	}, nil
 }
 
+// CheckTransfer reports whether newOwnerID can afford the workspace if it's
+// transferred to them. Workspaces that don't cost anything can always be
+// transferred.
+func (c *committer) CheckTransfer(ctx context.Context, store database.Store, workspace database.Workspace, newOwnerID uuid.UUID) (bool, error) {
+	build, err := store.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		return false, xerrors.Errorf("get latest build: %w", err)
+	}
+	if build.DailyCost == 0 {
+		return true, nil
+	}
+
+	consumed, err := store.GetQuotaConsumedForUser(ctx, newOwnerID)
+	if err != nil {
+		return false, xerrors.Errorf("get quota consumed: %w", err)
+	}
+	budget, err := store.GetQuotaAllowanceForUser(ctx, newOwnerID)
+	if err != nil {
+		return false, xerrors.Errorf("get quota allowance: %w", err)
+	}
+	return consumed+int64(build.DailyCost) <= budget, nil
+}
+
 // @Summary Get workspace quota by user
 // @ID get-workspace-quota-by-user
 // @Security CoderSessionToken
@@ -0,0 +1,362 @@
+package coderd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/coderd/audit"
+	"github.com/coder/coder/coderd/database"
+	"github.com/coder/coder/coderd/database/dbauthz"
+	"github.com/coder/coder/coderd/httpapi"
+	"github.com/coder/coder/coderd/httpmw"
+	"github.com/coder/coder/coderd/rbac"
+	"github.com/coder/coder/coderd/util/slice"
+	"github.com/coder/coder/codersdk"
+)
+
+// @Summary Get workspace available acl users/groups
+// @ID get-workspace-available-acl-usersgroups
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Enterprise
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Success 200 {object} codersdk.ACLAvailable
+// @Router /workspaces/{workspace}/acl/available [get]
+func (api *API) workspaceAvailablePermissions(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	// Requires update permission on the workspace to list all avail
+	// users/groups for assignment.
+	if !api.Authorize(r, rbac.ActionUpdate, workspace) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+
+	// We have to use the system restricted context here because the caller
+	// might not have permission to read all users.
+	// nolint:gocritic
+	users, _, ok := api.AGPL.GetUsers(rw, r.WithContext(dbauthz.AsSystemRestricted(ctx)))
+	if !ok {
+		return
+	}
+
+	// Perm check is the workspace update check.
+	// nolint:gocritic
+	groups, err := api.Database.GetGroupsByOrganizationID(dbauthz.AsSystemRestricted(ctx), workspace.OrganizationID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	sdkGroups := make([]codersdk.Group, 0, len(groups))
+	for _, group := range groups {
+		// nolint:gocritic
+		members, err := api.Database.GetGroupMembers(dbauthz.AsSystemRestricted(ctx), group.ID)
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+
+		sdkGroups = append(sdkGroups, convertGroup(group, members))
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.ACLAvailable{
+		Users:  convertUsers(users, map[uuid.UUID][]uuid.UUID{}),
+		Groups: sdkGroups,
+	})
+}
+
+// @Summary Get workspace ACLs
+// @ID get-workspace-acls
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Enterprise
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceACL
+// @Router /workspaces/{workspace}/acl [get]
+func (api *API) workspaceACL(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	userIDs := make([]uuid.UUID, 0, len(workspace.UserACL))
+	for id := range workspace.UserACL {
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	// The caller might not be able to read the users and groups, but they can
+	// read the workspace, so we let them see who it is shared with.
+	users := make([]codersdk.WorkspaceUser, 0, len(userIDs))
+	if len(userIDs) > 0 {
+		// nolint:gocritic
+		dbUsers, err := api.Database.GetUsersByIDs(dbauthz.AsSystemRestricted(ctx), userIDs)
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		for _, user := range dbUsers {
+			users = append(users, codersdk.WorkspaceUser{
+				MinimalUser: codersdk.MinimalUser{
+					ID:        user.ID,
+					Username:  user.Username,
+					AvatarURL: user.AvatarURL.String,
+				},
+				Role: convertToWorkspaceRole(workspace.UserACL[user.ID.String()]),
+			})
+		}
+	}
+
+	groups := make([]codersdk.WorkspaceGroup, 0, len(workspace.GroupACL))
+	for id, actions := range workspace.GroupACL {
+		groupID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+
+		// nolint:gocritic
+		group, err := api.Database.GetGroupByID(dbauthz.AsSystemRestricted(ctx), groupID)
+		if httpapi.Is404Error(err) {
+			// The group was deleted after the workspace was shared with it.
+			continue
+		}
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		// nolint:gocritic
+		members, err := api.Database.GetGroupMembers(dbauthz.AsSystemRestricted(ctx), group.ID)
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		groups = append(groups, codersdk.WorkspaceGroup{
+			Group: convertGroup(group, members),
+			Role:  convertToWorkspaceRole(actions),
+		})
+	}
+
+	slices.SortFunc(users, func(a, b codersdk.WorkspaceUser) int {
+		return slice.Ascending(a.Username, b.Username)
+	})
+	slices.SortFunc(groups, func(a, b codersdk.WorkspaceGroup) int {
+		return slice.Ascending(a.Name, b.Name)
+	})
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.WorkspaceACL{
+		Users:  users,
+		Groups: groups,
+	})
+}
+
+// @Summary Update workspace ACL
+// @ID update-workspace-acl
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Enterprise
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.UpdateWorkspaceACL true "Update workspace ACL request"
+// @Success 200 {object} codersdk.Response
+// @Router /workspaces/{workspace}/acl [patch]
+func (api *API) patchWorkspaceACL(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		workspace         = httpmw.WorkspaceParam(r)
+		auditor           = api.AGPL.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.Workspace](rw, &audit.RequestParams{
+			Audit:   *auditor,
+			Log:     api.Logger,
+			Request: r,
+			Action:  database.AuditActionWrite,
+		})
+	)
+	defer commitAudit()
+	aReq.Old = workspace
+
+	var req codersdk.UpdateWorkspaceACL
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	// Anyone who can read the workspace reaches this handler, including
+	// users it is shared with for use only.
+	if !api.Authorize(r, rbac.ActionUpdate, workspace) {
+		httpapi.Forbidden(rw)
+		return
+	}
+
+	validErrs := validateWorkspaceACLPerms(ctx, api.Database, workspace, req.UserPerms, "user_perms", true)
+	validErrs = append(validErrs,
+		validateWorkspaceACLPerms(ctx, api.Database, workspace, req.GroupPerms, "group_perms", false)...)
+
+	if len(validErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid request to update workspace ACL!",
+			Validations: validErrs,
+		})
+		return
+	}
+
+	err := api.Database.InTx(func(tx database.Store) error {
+		var err error
+		workspace, err = tx.GetWorkspaceByID(ctx, workspace.ID)
+		if err != nil {
+			return xerrors.Errorf("get workspace by ID: %w", err)
+		}
+
+		// Copy the lists so the workspace we fetched is left untouched.
+		userACL := maps.Clone(workspace.UserACL)
+		if userACL == nil {
+			userACL = database.WorkspaceACL{}
+		}
+		for id, role := range req.UserPerms {
+			// A user with an empty string implies
+			// deletion.
+			if role == "" {
+				delete(userACL, id)
+				continue
+			}
+			userACL[id] = convertSDKWorkspaceRole(role)
+		}
+
+		groupACL := maps.Clone(workspace.GroupACL)
+		if groupACL == nil {
+			groupACL = database.WorkspaceACL{}
+		}
+		for id, role := range req.GroupPerms {
+			// An id with an empty string implies
+			// deletion.
+			if role == "" {
+				delete(groupACL, id)
+				continue
+			}
+			groupACL[id] = convertSDKWorkspaceRole(role)
+		}
+
+		err = tx.UpdateWorkspaceACLByID(ctx, database.UpdateWorkspaceACLByIDParams{
+			ID:       workspace.ID,
+			UserACL:  userACL,
+			GroupACL: groupACL,
+		})
+		if err != nil {
+			return xerrors.Errorf("update workspace ACL by ID: %w", err)
+		}
+		workspace, err = tx.GetWorkspaceByID(ctx, workspace.ID)
+		if err != nil {
+			return xerrors.Errorf("get updated workspace by ID: %w", err)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	aReq.New = workspace
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+		Message: "Successfully updated workspace ACL list.",
+	})
+}
+
+// nolint:revive
+func validateWorkspaceACLPerms(ctx context.Context, db database.Store, workspace database.Workspace, perms map[string]codersdk.WorkspaceRole, field string, isUser bool) []codersdk.ValidationError {
+	// Validate requires full read access to users and groups
+	// nolint:gocritic
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	var validErrs []codersdk.ValidationError
+	for k, v := range perms {
+		if err := validateWorkspaceRole(v); err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: err.Error()})
+			continue
+		}
+		// Removing an entry doesn't require the user or group to still exist.
+		if v == codersdk.WorkspaceRoleDeleted {
+			continue
+		}
+
+		id, err := uuid.Parse(k)
+		if err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: "ID " + k + " must be a valid UUID."})
+			continue
+		}
+
+		if isUser {
+			if id == workspace.OwnerID {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: "A workspace cannot be shared with its owner."})
+				continue
+			}
+			// Users outside the organization could never see the workspace
+			// through their groups, so don't let them in directly either.
+			_, err = db.GetOrganizationMemberByUserID(ctx, database.GetOrganizationMemberByUserIDParams{
+				OrganizationID: workspace.OrganizationID,
+				UserID:         id,
+			})
+			if err != nil {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("Failed to find organization member with ID %q: %v", k, err.Error())})
+				continue
+			}
+		} else {
+			group, err := db.GetGroupByID(ctx, id)
+			if err != nil {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("Failed to find resource with ID %q: %v", k, err.Error())})
+				continue
+			}
+			if group.OrganizationID != workspace.OrganizationID {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("Group %q is not in the organization of the workspace.", group.Name)})
+				continue
+			}
+		}
+	}
+
+	return validErrs
+}
+
+func validateWorkspaceRole(role codersdk.WorkspaceRole) error {
+	actions := convertSDKWorkspaceRole(role)
+	if actions == nil && role != codersdk.WorkspaceRoleDeleted {
+		return xerrors.Errorf("role %q is not a valid Workspace role", role)
+	}
+
+	return nil
+}
+
+func convertToWorkspaceRole(actions []rbac.Action) codersdk.WorkspaceRole {
+	switch {
+	case len(actions) == 2 && slices.Contains(actions, rbac.ActionRead) && slices.Contains(actions, rbac.ActionCreate):
+		return codersdk.WorkspaceRoleUse
+	case len(actions) == 3 && slices.Contains(actions, rbac.ActionRead) && slices.Contains(actions, rbac.ActionCreate) && slices.Contains(actions, rbac.ActionUpdate):
+		return codersdk.WorkspaceRoleAdmin
+	}
+
+	return ""
+}
+
+// convertSDKWorkspaceRole returns the actions granted by a role. The same
+// actions apply to the workspace and to connecting to it, so "use" grants
+// "create" to allow opening terminals and apps. "admin" never grants "delete",
+// so only the owner can delete the workspace.
+func convertSDKWorkspaceRole(role codersdk.WorkspaceRole) []rbac.Action {
+	switch role {
+	case codersdk.WorkspaceRoleAdmin:
+		return []rbac.Action{rbac.ActionRead, rbac.ActionCreate, rbac.ActionUpdate}
+	case codersdk.WorkspaceRoleUse:
+		return []rbac.Action{rbac.ActionRead, rbac.ActionCreate}
+	}
+
+	return nil
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 
 	"cdr.dev/slog/sloggers/slogtest"
 
@@ -269,7 +274,14 @@ func TestWorkspaceAutobuild(t *testing.T) {
 		require.NotNil(t, ws.LockedAt)
 		lastUsedAt := ws.LastUsedAt
 
-		err := client.UpdateWorkspaceLock(ctx, ws.ID, codersdk.UpdateWorkspaceLock{Lock: false})
+		// The owner should be notified of the lock.
+		notifications, err := client.Notifications(ctx, codersdk.Me, codersdk.NotificationsRequest{})
+		require.NoError(t, err)
+		require.Len(t, notifications, 1)
+		require.Equal(t, codersdk.NotificationEventWorkspaceLocked, notifications[0].Event)
+		require.Contains(t, notifications[0].Body, ws.Name)
+
+		err = client.UpdateWorkspaceLock(ctx, ws.ID, codersdk.UpdateWorkspaceLock{Lock: false})
 		require.NoError(t, err)
 
 		// Assert that we updated our last_used_at so that we don't immediately
@@ -741,3 +753,344 @@ func TestWorkspaceLock(t *testing.T) {
 		require.True(t, workspace.LastUsedAt.After(lastUsedAt))
 	})
 }
+
+func TestWorkspaceACL(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (*codersdk.Client, codersdk.CreateFirstUserResponse, *codersdk.Client, codersdk.Workspace) {
+		t.Helper()
+
+		client, user := coderdenttest.New(t, &coderdenttest.Options{
+			Options: &coderdtest.Options{
+				IncludeProvisionerDaemon: true,
+			},
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				Features: license.Features{
+					codersdk.FeatureTemplateRBAC: 1,
+				},
+			},
+		})
+		version := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJob(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+
+		ownerClient, _ := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+		workspace := coderdtest.CreateWorkspace(t, ownerClient, user.OrganizationID, template.ID)
+		_ = coderdtest.AwaitWorkspaceBuildJob(t, client, workspace.LatestBuild.ID)
+		return client, user, ownerClient, workspace
+	}
+
+	canConnect := func(ctx context.Context, t *testing.T, client *codersdk.Client, workspace codersdk.Workspace) bool {
+		t.Helper()
+
+		resp, err := client.AuthCheck(ctx, codersdk.AuthorizationRequest{
+			Checks: map[string]codersdk.AuthorizationCheck{
+				"ssh": {
+					Object: codersdk.AuthorizationObject{
+						ResourceType: codersdk.ResourceWorkspaceExecution,
+						ResourceID:   workspace.ID.String(),
+					},
+					Action: "create",
+				},
+			},
+		})
+		require.NoError(t, err)
+		return resp["ssh"]
+	}
+
+	t.Run("UserPerms", func(t *testing.T) {
+		t.Parallel()
+
+		client, user, ownerClient, workspace := setup(t)
+		otherClient, other := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := otherClient.Workspace(ctx, workspace.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+		require.False(t, canConnect(ctx, t, otherClient, workspace))
+
+		err = ownerClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				other.ID.String(): codersdk.WorkspaceRoleUse,
+			},
+		})
+		require.NoError(t, err)
+
+		acl, err := ownerClient.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Len(t, acl.Users, 1)
+		require.Equal(t, other.ID, acl.Users[0].ID)
+		require.Equal(t, codersdk.WorkspaceRoleUse, acl.Users[0].Role)
+
+		// The shared user can see and connect to the workspace, but can't
+		// manage who it is shared with.
+		_, err = otherClient.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.True(t, canConnect(ctx, t, otherClient, workspace))
+		res, err := otherClient.Workspaces(ctx, codersdk.WorkspaceFilter{})
+		require.NoError(t, err)
+		require.Len(t, res.Workspaces, 1)
+		require.Equal(t, workspace.ID, res.Workspaces[0].ID)
+
+		err = otherClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				other.ID.String(): codersdk.WorkspaceRoleAdmin,
+			},
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+		err = ownerClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				other.ID.String(): codersdk.WorkspaceRoleDeleted,
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = otherClient.Workspace(ctx, workspace.ID)
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("GroupPerms", func(t *testing.T) {
+		t.Parallel()
+
+		client, user, ownerClient, workspace := setup(t)
+		otherClient, other := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		group, err := client.CreateGroup(ctx, user.OrganizationID, codersdk.CreateGroupRequest{
+			Name: "pair",
+		})
+		require.NoError(t, err)
+		_, err = client.PatchGroup(ctx, group.ID, codersdk.PatchGroupRequest{
+			AddUsers: []string{other.ID.String()},
+		})
+		require.NoError(t, err)
+
+		err = ownerClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			GroupPerms: map[string]codersdk.WorkspaceRole{
+				group.ID.String(): codersdk.WorkspaceRoleAdmin,
+			},
+		})
+		require.NoError(t, err)
+
+		acl, err := otherClient.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Len(t, acl.Groups, 1)
+		require.Equal(t, group.ID, acl.Groups[0].ID)
+		require.Equal(t, codersdk.WorkspaceRoleAdmin, acl.Groups[0].Role)
+		require.True(t, canConnect(ctx, t, otherClient, workspace))
+
+		// Admins of the workspace can share it further.
+		_, third := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+		err = otherClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				third.ID.String(): codersdk.WorkspaceRoleUse,
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Connect", func(t *testing.T) {
+		t.Parallel()
+
+		appServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(appServer.Close)
+		appURL, err := url.Parse(appServer.URL)
+		require.NoError(t, err)
+		appPort, err := strconv.ParseUint(appURL.Port(), 10, 16)
+		require.NoError(t, err)
+
+		// Path-based apps are blocked for anyone but the owner unless this is
+		// set, so that the workspace ACL is what decides access below.
+		dv := coderdtest.DeploymentValues(t)
+		dv.Dangerous.AllowPathAppSiteOwnerAccess = true
+		client, user := coderdenttest.New(t, &coderdenttest.Options{
+			Options: &coderdtest.Options{
+				DeploymentValues:         dv,
+				IncludeProvisionerDaemon: true,
+			},
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				Features: license.Features{
+					codersdk.FeatureTemplateRBAC: 1,
+				},
+			},
+		})
+		workspace, agnt := setupWorkspaceAgent(t, client, user, uint16(appPort))
+		otherClient, other := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		owner, err := client.User(ctx, codersdk.Me)
+		require.NoError(t, err)
+
+		connect := func(t *testing.T) (sshErr error, ptyErr error, appStatus int) {
+			t.Helper()
+
+			conn, sshErr := otherClient.DialWorkspaceAgent(ctx, agnt.ID, nil)
+			if sshErr == nil {
+				var sshClient *ssh.Client
+				sshClient, sshErr = conn.SSHClient(ctx)
+				if sshErr == nil {
+					_ = sshClient.Close()
+				}
+				_ = conn.Close()
+			}
+
+			pty, ptyErr := otherClient.WorkspaceAgentReconnectingPTY(ctx, codersdk.WorkspaceAgentReconnectingPTYOpts{
+				AgentID:   agnt.ID,
+				Reconnect: uuid.New(),
+				Width:     80,
+				Height:    80,
+				Command:   "echo test",
+			})
+			if ptyErr == nil {
+				_ = pty.Close()
+			}
+
+			res, err := otherClient.Request(ctx, http.MethodGet, fmt.Sprintf("/@%s/%s/apps/%s/", owner.Username, workspace.Name, testAppNameOwner), nil)
+			require.NoError(t, err)
+			_ = res.Body.Close()
+			return sshErr, ptyErr, res.StatusCode
+		}
+
+		sshErr, ptyErr, appStatus := connect(t)
+		require.Error(t, sshErr)
+		require.Error(t, ptyErr)
+		require.NotEqual(t, http.StatusOK, appStatus)
+
+		err = client.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				other.ID.String(): codersdk.WorkspaceRoleUse,
+			},
+		})
+		require.NoError(t, err)
+
+		sshErr, ptyErr, appStatus = connect(t)
+		require.NoError(t, sshErr)
+		require.NoError(t, ptyErr)
+		require.Equal(t, http.StatusOK, appStatus)
+	})
+
+	t.Run("AdminCannotDeleteOrTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		client, user, ownerClient, workspace := setup(t)
+		otherClient, other := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		err := ownerClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				other.ID.String(): codersdk.WorkspaceRoleAdmin,
+			},
+		})
+		require.NoError(t, err)
+
+		// Admins of the workspace can build it, but not delete it or give it
+		// away, including to themselves.
+		build, err := otherClient.CreateWorkspaceBuild(ctx, workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+			Transition: codersdk.WorkspaceTransitionStop,
+		})
+		require.NoError(t, err)
+		_ = coderdtest.AwaitWorkspaceBuildJob(t, client, build.ID)
+
+		var apiErr *codersdk.Error
+		_, err = otherClient.CreateWorkspaceBuild(ctx, workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+			Transition: codersdk.WorkspaceTransitionDelete,
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+		_, err = otherClient.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: other.ID,
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+		workspace, err = ownerClient.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.NotEqual(t, other.ID, workspace.OwnerID)
+	})
+
+	t.Run("TransferClearsShares", func(t *testing.T) {
+		t.Parallel()
+
+		client, user, ownerClient, workspace := setup(t)
+		otherClient, other := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+		newOwnerClient, newOwner := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		group, err := client.CreateGroup(ctx, user.OrganizationID, codersdk.CreateGroupRequest{
+			Name: "pair",
+		})
+		require.NoError(t, err)
+		err = ownerClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				other.ID.String():    codersdk.WorkspaceRoleAdmin,
+				newOwner.ID.String(): codersdk.WorkspaceRoleUse,
+			},
+			GroupPerms: map[string]codersdk.WorkspaceRole{
+				group.ID.String(): codersdk.WorkspaceRoleUse,
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, canConnect(ctx, t, otherClient, workspace))
+
+		build, err := ownerClient.CreateWorkspaceBuild(ctx, workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+			Transition: codersdk.WorkspaceTransitionStop,
+		})
+		require.NoError(t, err)
+		_ = coderdtest.AwaitWorkspaceBuildJob(t, client, build.ID)
+		workspace, err = client.TransferWorkspace(ctx, workspace.ID, codersdk.TransferWorkspaceRequest{
+			OwnerID: newOwner.ID,
+		})
+		require.NoError(t, err)
+
+		// The shares of the previous owner, including the one with the new
+		// owner, are gone.
+		acl, err := newOwnerClient.WorkspaceACL(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.Empty(t, acl.Users)
+		require.Empty(t, acl.Groups)
+		require.False(t, canConnect(ctx, t, otherClient, workspace))
+		_, err = otherClient.Workspace(ctx, workspace.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		client, user, ownerClient, workspace := setup(t)
+		_, other := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		var apiErr *codersdk.Error
+		err := ownerClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				workspace.OwnerID.String(): codersdk.WorkspaceRoleUse,
+			},
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+		err = ownerClient.UpdateWorkspaceACL(ctx, workspace.ID, codersdk.UpdateWorkspaceACL{
+			UserPerms: map[string]codersdk.WorkspaceRole{
+				other.ID.String(): "owner",
+			},
+		})
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
+}
@@ -94,6 +94,36 @@ export interface AuditLog {
   readonly user?: User
 }
 
+// From codersdk/deployment.go
+export interface AuditLogExportConfig {
+  readonly webhook_url: string
+  readonly webhook_secret: string
+  readonly webhook_batch_size: number
+  readonly webhook_flush_interval: number
+  readonly webhook_max_queue_size: number
+  readonly syslog_address: string
+  readonly syslog_transport: string
+  readonly syslog_tls_ca_file: string
+  readonly file_path: string
+  readonly file_max_size: number
+  readonly file_max_backups: number
+}
+
+// From codersdk/audit.go
+export interface AuditLogExportRequest {
+  readonly q?: string
+  readonly since?: string
+  readonly until?: string
+  readonly format?: AuditLogExportFormat
+}
+
+// From codersdk/deployment.go
+export interface AuditLogHashChainConfig {
+  readonly enable: boolean
+  readonly secret: string
+  readonly checkpoint_interval: number
+}
+
 // From codersdk/audit.go
 export interface AuditLogResponse {
   readonly audit_logs: AuditLog[]
@@ -139,6 +169,16 @@ export interface AuthorizationRequest {
 // From codersdk/authorization.go
 export type AuthorizationResponse = Record<string, boolean>
 
+// From codersdk/autostartexceptions.go
+export interface AutostartException {
+  readonly id: string
+  readonly template_id?: string
+  readonly name: string
+  readonly starts_at: string
+  readonly ends_at: string
+  readonly all_day: boolean
+}
+
 // From codersdk/deployment.go
 export interface BuildInfoResponse {
   readonly external_url: string
@@ -159,6 +199,14 @@ export interface ConvertLoginRequest {
   readonly password: string
 }
 
+// From codersdk/autostartexceptions.go
+export interface CreateAutostartExceptionRequest {
+  readonly name: string
+  readonly starts_at: string
+  readonly ends_at: string
+  readonly all_day: boolean
+}
+
 // From codersdk/users.go
 export interface CreateFirstUserRequest {
   readonly email: string
@@ -223,6 +271,8 @@ export interface CreateTemplateVersionRequest {
   readonly provisioner: ProvisionerType
   readonly tags: Record<string, string>
   readonly user_variable_values?: VariableValue[]
+  readonly git_url?: string
+  readonly git_commit_sha?: string
 }
 
 // From codersdk/audit.go
@@ -263,6 +313,13 @@ export interface CreateWorkspaceBuildRequest {
   readonly log_level?: ProvisionerLogLevel
 }
 
+// From codersdk/workspacebulkoperations.go
+export interface CreateWorkspaceBulkOperationRequest {
+  readonly q: string
+  readonly transition: WorkspaceBulkTransition
+  readonly concurrency?: number
+}
+
 // From codersdk/workspaceproxy.go
 export interface CreateWorkspaceProxyRequest {
   readonly name: string
@@ -277,6 +334,7 @@ export interface CreateWorkspaceRequest {
   readonly autostart_schedule?: string
   readonly ttl_ms?: number
   readonly rich_parameter_values?: WorkspaceBuildParameter[]
+  readonly source_workspace_id?: string
 }
 
 // From codersdk/deployment.go
@@ -400,6 +458,10 @@ export interface DeploymentValues {
   readonly proxy_health_status_interval?: number
   readonly enable_terraform_debug_mode?: boolean
   readonly user_quiet_hours_schedule?: UserQuietHoursScheduleConfig
+  readonly notifications?: NotificationsConfig
+  readonly audit_log_export?: AuditLogExportConfig
+  readonly audit_log_hash_chain?: AuditLogHashChainConfig
+  readonly retention?: RetentionConfig
   // This is likely an enum in an external package ("github.com/coder/coder/cli/clibase.YAMLConfigPath")
   readonly config?: string
   readonly write_config?: boolean
@@ -579,6 +641,58 @@ export interface MinimalUser {
   readonly avatar_url: string
 }
 
+// From codersdk/notifications.go
+export interface Notification {
+  readonly id: string
+  readonly event: NotificationEvent
+  readonly title: string
+  readonly body: string
+  readonly created_at: string
+  readonly read_at?: string
+}
+
+// From codersdk/notifications.go
+export interface NotificationDeliveriesRequest extends Pagination {
+  readonly status?: NotificationDeliveryStatus
+}
+
+// From codersdk/notifications.go
+export interface NotificationDelivery {
+  readonly id: string
+  readonly notification_id: string
+  readonly user_id: string
+  readonly event: NotificationEvent
+  readonly title: string
+  readonly address: string
+  readonly status: NotificationDeliveryStatus
+  readonly attempts: number
+  readonly last_error?: string
+  readonly next_attempt_at?: string
+  readonly created_at: string
+  readonly updated_at: string
+}
+
+// From codersdk/notifications.go
+export interface NotificationPreference {
+  readonly event: NotificationEvent
+  readonly email: boolean
+  readonly inbox: boolean
+}
+
+// From codersdk/deployment.go
+export interface NotificationsConfig {
+  readonly email_from: string
+  readonly email_smarthost: string
+  readonly email_username: string
+  readonly email_password: string
+  readonly max_send_attempts: number
+}
+
+// From codersdk/notifications.go
+export interface NotificationsRequest extends Pagination {
+  readonly unread?: boolean
+}
+
 // From codersdk/deployment.go
 export interface OAuth2Config {
   readonly github: OAuth2GithubConfig
@@ -663,6 +777,12 @@ export interface OrganizationMember {
   readonly roles: Role[]
 }
 
+// From codersdk/organizations.go
+export interface OrganizationMemberWithUserData extends OrganizationMember {
+  readonly username: string
+  readonly email: string
+}
+
 // From codersdk/pagination.go
 export interface Pagination {
   readonly after_id?: string
@@ -811,6 +931,13 @@ export interface Response {
   readonly validations?: ValidationError[]
 }
 
+// From codersdk/deployment.go
+export interface RetentionConfig {
+  readonly audit_logs: number
+  readonly provisioner_job_logs: number
+  readonly workspace_build_state: number
+}
+
 // From codersdk/roles.go
 export interface Role {
   readonly name: string
@@ -853,6 +980,11 @@ export interface SessionCountDeploymentStats {
   readonly reconnecting_pty: number
 }
 
+// From codersdk/workspaces.go
+export interface SkipWorkspaceAutostartRequest {
+  readonly skip: boolean
+}
+
 // From codersdk/deployment.go
 export interface SupportConfig {
   // Named type "github.com/coder/coder/cli/clibase.Struct[[]github.com/coder/coder/codersdk.LinkConfig]" unknown, using "any"
@@ -912,9 +1044,14 @@ export interface Template {
   readonly allow_user_autostart: boolean
   readonly allow_user_autostop: boolean
   readonly allow_user_cancel_workspace_jobs: boolean
+  readonly require_active_version: boolean
   readonly failure_ttl_ms: number
   readonly inactivity_ttl_ms: number
   readonly locked_ttl_ms: number
+  readonly deprecated: boolean
+  readonly deprecation_message: string
+  readonly replacement_template_id?: string
+  readonly workspace_count: number
 }
 
 // From codersdk/templates.go
@@ -1028,6 +1165,9 @@ export interface TemplateVersion {
   readonly job: ProvisionerJob
   readonly readme: string
   readonly created_by: MinimalUser
+  readonly git_url?: string
+  readonly git_commit_sha?: string
+  readonly archived: boolean
   readonly warnings?: TemplateVersionWarning[]
 }
 
@@ -1081,6 +1221,7 @@ export interface TemplateVersionVariable {
 // From codersdk/templates.go
 export interface TemplateVersionsByTemplateRequest extends Pagination {
   readonly template_id: string
+  readonly include_archived: boolean
 }
 
 // From codersdk/apikey.go
@@ -1100,6 +1241,11 @@ export interface TraceConfig {
   readonly capture_logs: boolean
 }
 
+// From codersdk/workspaces.go
+export interface TransferWorkspaceRequest {
+  readonly owner_id: string
+}
+
 // From codersdk/templates.go
 export interface TransitionStats {
   readonly P50?: number
@@ -1117,6 +1263,11 @@ export interface UpdateAppearanceConfig {
   readonly service_banner: ServiceBannerConfig
 }
 
+// From codersdk/autostartexceptions.go
+export interface UpdateAutostartExceptionsRequest {
+  readonly exceptions: CreateAutostartExceptionRequest[]
+}
+
 // From codersdk/updatecheck.go
 export interface UpdateCheckResponse {
   readonly current: boolean
@@ -1124,6 +1275,16 @@ export interface UpdateCheckResponse {
   readonly url: string
 }
 
+// From codersdk/notifications.go
+export interface UpdateNotificationPreferencesRequest {
+  readonly preferences: NotificationPreference[]
+}
+
+// From codersdk/notifications.go
+export interface UpdateNotificationRequest {
+  readonly read: boolean
+}
+
 // From codersdk/users.go
 export interface UpdateRoles {
   readonly roles: string[]
@@ -1147,9 +1308,12 @@ export interface UpdateTemplateMeta {
   readonly allow_user_autostart?: boolean
   readonly allow_user_autostop?: boolean
   readonly allow_user_cancel_workspace_jobs?: boolean
+  readonly require_active_version?: boolean
   readonly failure_ttl_ms?: number
   readonly inactivity_ttl_ms?: number
   readonly locked_ttl_ms?: number
+  readonly deprecation_message?: string
+  readonly replacement_template_id?: string
 }
 
 // From codersdk/users.go
@@ -1168,6 +1332,12 @@ export interface UpdateUserQuietHoursScheduleRequest {
   readonly schedule: string
 }
 
+// From codersdk/workspaces.go
+export interface UpdateWorkspaceACL {
+  readonly user_perms?: Record<string, WorkspaceRole>
+  readonly group_perms?: Record<string, WorkspaceRole>
+}
+
 // From codersdk/workspaces.go
 export interface UpdateWorkspaceAutostartRequest {
   readonly schedule?: string
@@ -1303,11 +1473,18 @@ export interface Workspace {
   readonly autostart_schedule?: string
   readonly ttl_ms?: number
   readonly last_used_at: string
+  readonly autostart_skip_until?: string
   readonly deleting_at?: string
   readonly locked_at?: string
   readonly health: WorkspaceHealth
 }
 
+// From codersdk/workspaces.go
+export interface WorkspaceACL {
+  readonly users: WorkspaceUser[]
+  readonly groups: WorkspaceGroup[]
+}
+
 // From codersdk/workspaceagents.go
 export interface WorkspaceAgent {
   readonly id: string
@@ -1439,12 +1616,47 @@ export interface WorkspaceBuildParameter {
   readonly value: string
 }
 
+// From codersdk/workspacebuilds.go
+export interface WorkspaceBuildStateSummary {
+  readonly workspace_build_id: string
+  readonly build_number: number
+  readonly transition: WorkspaceTransition
+  readonly template_version_id: string
+  readonly created_at: string
+  readonly size_bytes: number
+  readonly resources: number
+}
+
 // From codersdk/workspaces.go
 export interface WorkspaceBuildsRequest extends Pagination {
   readonly WorkspaceID: string
   readonly Since: string
 }
 
+// From codersdk/workspacebulkoperations.go
+export interface WorkspaceBulkOperation {
+  readonly id: string
+  readonly created_at: string
+  readonly completed_at?: string
+  readonly initiator_id: string
+  readonly transition: WorkspaceBulkTransition
+  readonly q: string
+  readonly concurrency: number
+  readonly status: WorkspaceBulkOperationStatus
+  readonly workspaces: WorkspaceBulkOperationWorkspace[]
+}
+
+// From codersdk/workspacebulkoperations.go
+export interface WorkspaceBulkOperationWorkspace {
+  readonly workspace_id: string
+  readonly workspace_name: string
+  readonly owner_name: string
+  readonly status: WorkspaceBulkOperationWorkspaceStatus
+  readonly workspace_build_id?: string
+  readonly error?: string
+  readonly updated_at: string
+}
+
 // From codersdk/deployment.go
 export interface WorkspaceConnectionLatencyMS {
   readonly P50: number
@@ -1468,6 +1680,11 @@ export interface WorkspaceFilter {
   readonly q?: string
 }
 
+// From codersdk/workspaces.go
+export interface WorkspaceGroup extends Group {
+  readonly role: WorkspaceRole
+}
+
 // From codersdk/workspaces.go
 export interface WorkspaceHealth {
   readonly healthy: boolean
@@ -1530,6 +1747,11 @@ export interface WorkspaceResourceMetadata {
   readonly sensitive: boolean
 }
 
+// From codersdk/workspaces.go
+export interface WorkspaceUser extends MinimalUser {
+  readonly role: WorkspaceRole
+}
+
 // From codersdk/workspaces.go
 export interface WorkspacesRequest extends Pagination {
   readonly q?: string
@@ -1574,6 +1796,10 @@ export const AuditActions: AuditAction[] = [
   "write",
 ]
 
+// From codersdk/audit.go
+export type AuditLogExportFormat = "csv" | "jsonl"
+export const AuditLogExportFormats: AuditLogExportFormat[] = ["csv", "jsonl"]
+
 // From codersdk/workspacebuilds.go
 export type BuildReason = "autostart" | "autostop" | "initiator"
 export const BuildReasons: BuildReason[] = [
@@ -1685,6 +1911,29 @@ export const LoginTypes: LoginType[] = [
   "token",
 ]
 
+// From codersdk/notifications.go
+export type NotificationDeliveryStatus = "failed" | "pending" | "sent"
+export const NotificationDeliveryStatuses: NotificationDeliveryStatus[] = [
+  "failed",
+  "pending",
+  "sent",
+]
+
+// From codersdk/notifications.go
+export type NotificationEvent =
+  | "template_version_promoted"
+  | "user_suspended"
+  | "workspace_autostop"
+  | "workspace_build_failed"
+  | "workspace_locked"
+export const NotificationEvents: NotificationEvent[] = [
+  "template_version_promoted",
+  "user_suspended",
+  "workspace_autostop",
+  "workspace_build_failed",
+  "workspace_locked",
+]
+
 // From codersdk/provisionerdaemons.go
 export type ProvisionerJobStatus =
   | "canceled"
@@ -1913,6 +2162,36 @@ export const WorkspaceAppSharingLevels: WorkspaceAppSharingLevel[] = [
   "public",
 ]
 
+// From codersdk/workspacebulkoperations.go
+export type WorkspaceBulkOperationStatus = "completed" | "running"
+export const WorkspaceBulkOperationStatuses: WorkspaceBulkOperationStatus[] = [
+  "completed",
+  "running",
+]
+
+// From codersdk/workspacebulkoperations.go
+export type WorkspaceBulkOperationWorkspaceStatus =
+  | "building"
+  | "failed"
+  | "pending"
+  | "skipped"
+  | "succeeded"
+export const WorkspaceBulkOperationWorkspaceStatuses: WorkspaceBulkOperationWorkspaceStatus[] =
+  ["building", "failed", "pending", "skipped", "succeeded"]
+
+// From codersdk/workspacebulkoperations.go
+export type WorkspaceBulkTransition = "delete" | "start" | "stop" | "update"
+export const WorkspaceBulkTransitions: WorkspaceBulkTransition[] = [
+  "delete",
+  "start",
+  "stop",
+  "update",
+]
+
+// From codersdk/workspaces.go
+export type WorkspaceRole = "" | "admin" | "use"
+export const WorkspaceRoles: WorkspaceRole[] = ["", "admin", "use"]
+
 // From codersdk/workspacebuilds.go
 export type WorkspaceStatus =
   | "canceled"
@@ -41,6 +41,7 @@ export const getValidationSchema = (): Yup.AnyObjectSchema =>
         .toString(),
     ),
     allow_user_cancel_workspace_jobs: Yup.boolean(),
+    require_active_version: Yup.boolean(),
     icon: iconValidator,
   })
 
@@ -72,6 +73,7 @@ export const TemplateSettingsForm: FC<TemplateSettingsForm> = ({
         icon: template.icon,
         allow_user_cancel_workspace_jobs:
           template.allow_user_cancel_workspace_jobs,
+        require_active_version: template.require_active_version,
       },
       validationSchema,
       onSubmit,
@@ -138,37 +140,60 @@ export const TemplateSettingsForm: FC<TemplateSettingsForm> = ({
         title={t("operations.title").toString()}
         description={t("operations.description").toString()}
       >
-        <label htmlFor="allow_user_cancel_workspace_jobs">
-          <Stack direction="row" spacing={1}>
-            <Checkbox
-              id="allow_user_cancel_workspace_jobs"
-              name="allow_user_cancel_workspace_jobs"
-              disabled={isSubmitting}
-              checked={form.values.allow_user_cancel_workspace_jobs}
-              onChange={form.handleChange}
-            />
-
-            <Stack direction="column" spacing={0.5}>
-              <Stack
-                direction="row"
-                alignItems="center"
-                spacing={0.5}
-                className={styles.optionText}
-              >
-                {t("allowUserCancelWorkspaceJobsLabel")}
-
-                <HelpTooltip>
-                  <HelpTooltipText>
-                    {t("allowUserCancelWorkspaceJobsNotice")}
-                  </HelpTooltipText>
-                </HelpTooltip>
+        <FormFields>
+          <label htmlFor="allow_user_cancel_workspace_jobs">
+            <Stack direction="row" spacing={1}>
+              <Checkbox
+                id="allow_user_cancel_workspace_jobs"
+                name="allow_user_cancel_workspace_jobs"
+                disabled={isSubmitting}
+                checked={form.values.allow_user_cancel_workspace_jobs}
+                onChange={form.handleChange}
+              />
+
+              <Stack direction="column" spacing={0.5}>
+                <Stack
+                  direction="row"
+                  alignItems="center"
+                  spacing={0.5}
+                  className={styles.optionText}
+                >
+                  {t("allowUserCancelWorkspaceJobsLabel")}
+
+                  <HelpTooltip>
+                    <HelpTooltipText>
+                      {t("allowUserCancelWorkspaceJobsNotice")}
+                    </HelpTooltipText>
+                  </HelpTooltip>
+                </Stack>
+                <span className={styles.optionHelperText}>
+                  {t("allowUsersCancelHelperText")}
+                </span>
+              </Stack>
+            </Stack>
+          </label>
+
+          <label htmlFor="require_active_version">
+            <Stack direction="row" spacing={1}>
+              <Checkbox
+                id="require_active_version"
+                name="require_active_version"
+                disabled={isSubmitting}
+                checked={form.values.require_active_version}
+                onChange={form.handleChange}
+              />
+
+              <Stack direction="column" spacing={0.5}>
+                <span className={styles.optionText}>
+                  {t("requireActiveVersionLabel")}
+                </span>
+                <span className={styles.optionHelperText}>
+                  {t("requireActiveVersionHelperText")}
+                </span>
               </Stack>
-              <span className={styles.optionHelperText}>
-                {t("allowUsersCancelHelperText")}
-              </span>
             </Stack>
-          </Stack>
-        </label>
+          </label>
+        </FormFields>
       </FormSection>
 
       <FormFooter onCancel={onCancel} isLoading={isSubmitting} />
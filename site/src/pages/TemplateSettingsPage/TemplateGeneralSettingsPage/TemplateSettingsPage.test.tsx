@@ -15,7 +15,13 @@ import i18next from "i18next"
 const { t } = i18next
 
 type FormValues = Required<
-  Omit<UpdateTemplateMeta, "default_ttl_ms" | "max_ttl_ms">
+  Omit<
+    UpdateTemplateMeta,
+    | "default_ttl_ms"
+    | "max_ttl_ms"
+    | "deprecation_message"
+    | "replacement_template_id"
+  >
 >
 
 const validFormValues: FormValues = {
@@ -33,6 +39,7 @@ const validFormValues: FormValues = {
   failure_ttl_ms: 0,
   inactivity_ttl_ms: 0,
   locked_ttl_ms: 0,
+  require_active_version: false,
 }
 
 const renderTemplateSettingsPage = async () => {
@@ -71,7 +78,12 @@ const fillAndSubmitForm = async ({
   await userEvent.clear(iconField)
   await userEvent.type(iconField, icon)
 
-  const allowCancelJobsField = screen.getByRole("checkbox")
+  const allowCancelJobsLabel = t("allowUserCancelWorkspaceJobsLabel", {
+    ns: "templateSettingsPage",
+  })
+  const allowCancelJobsField = screen.getByRole("checkbox", {
+    name: (name) => name.startsWith(allowCancelJobsLabel),
+  })
   // checkbox is checked by default, so it must be clicked to get unchecked
   if (!allow_user_cancel_workspace_jobs) {
     await userEvent.click(allowCancelJobsField)
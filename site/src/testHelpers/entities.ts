@@ -376,6 +376,7 @@ You can add instructions here
 
 [Some link info](https://coder.com)`,
   created_by: MockUser,
+  archived: false,
 }
 
 export const MockTemplateVersion2: TypesGen.TemplateVersion = {
@@ -394,6 +395,7 @@ You can add instructions here
 
 [Some link info](https://coder.com)`,
   created_by: MockUser,
+  archived: false,
 }
 
 export const MockTemplateVersion3: TypesGen.TemplateVersion = {
@@ -406,6 +408,7 @@ export const MockTemplateVersion3: TypesGen.TemplateVersion = {
   message: "first version",
   readme: "README",
   created_by: MockUser,
+  archived: false,
   warnings: ["UNSUPPORTED_WORKSPACES"],
 }
 
@@ -444,11 +447,15 @@ export const MockTemplate: TypesGen.Template = {
   created_by_name: "test_creator",
   icon: "/icon/code.svg",
   allow_user_cancel_workspace_jobs: true,
+  require_active_version: false,
   failure_ttl_ms: 0,
   inactivity_ttl_ms: 0,
   locked_ttl_ms: 0,
   allow_user_autostart: false,
   allow_user_autostop: false,
+  deprecated: false,
+  deprecation_message: "",
+  workspace_count: 1,
 }
 
 export const MockTemplateVersionFiles: TemplateVersionFiles = {